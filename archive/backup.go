@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
@@ -17,6 +19,14 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// BackupConfig is the configuration of the archive written by the backup
+type BackupConfig struct {
+	// SplitBlocks is the number of blocks in each archive file, the archive is a single file if zero
+	SplitBlocks uint64
+	// Append continues the existing archive from its last block instead of creating a new one
+	Append bool
+}
+
 // CreateBackup fetches blockchain data with the specific range via gRPC
 // and save this data as binary archive to given path
 func CreateBackup(
@@ -25,32 +35,15 @@ func CreateBackup(
 	from uint64,
 	to *uint64,
 	outPath string,
+	config BackupConfig,
 ) (uint64, uint64, error) {
-	// always create new file, throw error if the file exists
-	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	writer, err := newBackupWriter(outPath, config, logger)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	closeFile := func() error {
-		if err := fs.Close(); err != nil {
-			logger.Error("an error occurred while closing file", "err", err)
-
-			return err
-		}
-
-		return nil
-	}
-	removeFile := func() {
-		if err := os.Remove(outPath); err != nil {
-			logger.Error("an error occurred while removing file", "err", err)
-		}
-	}
-	// clean up function for the file when error occurs in the middle of function
-	closeAndRemoveFile := func() {
-		if err := closeFile(); err == nil {
-			removeFile()
-		}
+	if from, err = writer.startBlock(from); err != nil {
+		return 0, 0, err
 	}
 
 	signalCh := common.GetTerminationSignalCh()
@@ -68,38 +61,39 @@ func CreateBackup(
 
 	reqTo, reqToHash, err := determineTo(ctx, clt, to)
 	if err != nil {
-		closeAndRemoveFile()
-
 		return 0, 0, err
 	}
 
+	if from > reqTo {
+		return 0, 0, fmt.Errorf("%w: from (%d) is greater than the latest block (%d)", errNoNewBlocks, from, reqTo)
+	}
+
 	stream, err := clt.Export(ctx, &proto.ExportRequest{
 		From: from,
 		To:   reqTo,
 	})
 	if err != nil {
-		closeAndRemoveFile()
-
 		return 0, 0, err
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
-		closeAndRemoveFile()
+	logger.Info("Writing blocks to backup", "from", from, "to", reqTo, "hash", reqToHash)
+
+	resFrom, resTo, err := processExportStream(stream, logger, writer, from, reqTo)
+	if err != nil {
+		writer.abort()
 
 		return 0, 0, err
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
-	if err != nil {
-		closeAndRemoveFile()
-
+	if err := writer.close(); err != nil {
 		return 0, 0, err
 	}
 
-	if err := closeFile(); err != nil {
-		removeFile()
+	if *resTo == reqTo && writer.latestHash != reqToHash {
+		writer.abort()
 
-		return 0, 0, err
+		return 0, 0, fmt.Errorf("the hash of the last archived block (%s) does not match the expected one (%s)",
+			writer.latestHash, reqToHash)
 	}
 
 	return *resFrom, *resTo, nil
@@ -127,27 +121,10 @@ func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint6
 	return uint64(status.Current.Number), types.StringToHash(status.Current.Hash), nil
 }
 
-// writeMetadata writes the latest block height and the block hash to the writer
-func writeMetadata(writer io.Writer, logger hclog.Logger, to uint64, toHash types.Hash) error {
-	metadata := Metadata{
-		Latest:     to,
-		LatestHash: toHash,
-	}
-
-	_, err := writer.Write(metadata.MarshalRLP())
-	if err != nil {
-		return err
-	}
-
-	logger.Info("Wrote metadata to backup", "latest", to, "hash", toHash)
-
-	return err
-}
-
 func processExportStream(
 	stream proto.System_ExportClient,
 	logger hclog.Logger,
-	writer io.Writer,
+	writer blockWriter,
 	targetFrom, targetTo uint64,
 ) (*uint64, *uint64, error) {
 	var from, to *uint64
@@ -193,7 +170,7 @@ func processExportStream(
 			return nil, nil, err
 		}
 
-		if _, err := writer.Write(event.Data); err != nil {
+		if err := writeExportEvent(writer, event); err != nil {
 			return nil, nil, err
 		}
 
@@ -206,3 +183,369 @@ func processExportStream(
 		showProgress(event)
 	}
 }
+
+// blockReader is the blockchain interface required to export the blocks of the local chain
+type blockReader interface {
+	Header() *types.Header
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+}
+
+// ExportBackup reads the blocks with the specific range from the local chain
+// and saves them as binary archive to given path, in the same format as CreateBackup
+func ExportBackup(
+	chain blockReader,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outPath string,
+	config BackupConfig,
+) (uint64, uint64, error) {
+	latest := chain.Header()
+	if latest == nil {
+		return 0, 0, errors.New("couldn't get the latest header")
+	}
+
+	writer, err := newBackupWriter(outPath, config, logger)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from, err = writer.startBlock(from); err != nil {
+		return 0, 0, err
+	}
+
+	targetTo := latest.Number
+	if to != nil && *to < targetTo {
+		targetTo = *to
+	}
+
+	if from > targetTo {
+		return 0, 0, fmt.Errorf("%w: from (%d) must not be greater than to (%d)", errNoNewBlocks, from, targetTo)
+	}
+
+	for i := from; i <= targetTo; i++ {
+		block, ok := chain.GetBlockByNumber(i, true)
+		if !ok {
+			writer.abort()
+
+			return 0, 0, fmt.Errorf("block #%d not found", i)
+		}
+
+		if err := writer.writeBlock(i, block.MarshalRLP()); err != nil {
+			writer.abort()
+
+			return 0, 0, err
+		}
+	}
+
+	if err := writer.close(); err != nil {
+		return 0, 0, err
+	}
+
+	logger.Info("Exported blocks to backup", "from", from, "to", targetTo, "path", outPath)
+
+	return from, targetTo, nil
+}
+
+// blockWriter writes the RLP encoded blocks to the archive
+type blockWriter interface {
+	writeBlock(number uint64, data []byte) error
+}
+
+// writeExportEvent writes the blocks of the export event, which are concatenated in its data
+func writeExportEvent(writer blockWriter, event *proto.ExportEvent) error {
+	blocks, err := splitRLPBlocks(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to decode the exported blocks: %w", err)
+	}
+
+	if event.To < event.From || uint64(len(blocks)) != event.To-event.From+1 {
+		return fmt.Errorf("expected blocks #%d-#%d in the export event but got %d blocks",
+			event.From, event.To, len(blocks))
+	}
+
+	for i, data := range blocks {
+		if err := writer.writeBlock(event.From+uint64(i), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backupWriter writes the blocks to the archive files, splitting them by the block range if configured
+type backupWriter struct {
+	outPath string
+	config  BackupConfig
+	logger  hclog.Logger
+
+	// appendPath is the archive file holding the last archived block, if the archive is appended
+	appendPath string
+	// next is the number of the block following the last archived block
+	next *uint64
+
+	current     *archiveWriter
+	currentPath string
+	// currentLast is the number of the last block the current file may hold
+	currentLast uint64
+
+	// created lists the files created by the writer, which are removed on abort
+	created []string
+
+	// latestHash is the hash of the last archived block, set on close
+	latestHash types.Hash
+}
+
+func newBackupWriter(outPath string, config BackupConfig, logger hclog.Logger) (*backupWriter, error) {
+	w := &backupWriter{
+		outPath: outPath,
+		config:  config,
+		logger:  logger,
+	}
+
+	if config.SplitBlocks == 0 {
+		_, err := os.Stat(outPath)
+
+		switch {
+		case err == nil && !config.Append:
+			return nil, fmt.Errorf("%s: %w", outPath, os.ErrExist)
+		case err == nil:
+			if err := w.findLastArchive([]string{outPath}); err != nil {
+				return nil, err
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+
+		return w, nil
+	}
+
+	if config.Append {
+		paths, err := filepath.Glob(outPath + ".*-*")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := w.findLastArchive(paths); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// findLastArchive finds the archive holding the last archived block among the given files,
+// converting the legacy archive to the versioned format so it can be appended
+func (w *backupWriter) findLastArchive(paths []string) error {
+	for _, path := range paths {
+		legacy, err := isLegacyArchive(path)
+		if err != nil {
+			return err
+		}
+
+		if legacy {
+			if err := convertLegacyArchive(path, w.logger); err != nil {
+				return err
+			}
+		}
+
+		reader, err := openArchive(path)
+		if err != nil {
+			return err
+		}
+
+		index := reader.index
+
+		if err := reader.close(); err != nil {
+			return err
+		}
+
+		if w.appendPath == "" {
+			w.appendPath = path
+		}
+
+		if len(index.Chunks) == 0 || (w.next != nil && index.Latest < *w.next) {
+			continue
+		}
+
+		next := index.Latest + 1
+		w.next = &next
+		w.appendPath = path
+	}
+
+	return nil
+}
+
+// startBlock returns the first block to be archived. The appended archive continues from its last block,
+// in which case from must be either zero or the block following the last archived block
+func (w *backupWriter) startBlock(from uint64) (uint64, error) {
+	if w.next == nil {
+		return from, nil
+	}
+
+	if from != 0 && from != *w.next {
+		return 0, fmt.Errorf("%w: the archive ends at block #%d, appended blocks must start from #%d",
+			errNonContiguousArchive, *w.next-1, *w.next)
+	}
+
+	w.logger.Info("Appending blocks to the archive", "path", w.appendPath, "from", *w.next)
+
+	return *w.next, nil
+}
+
+// writeBlock writes the RLP encoded block to the archive file holding its range
+func (w *backupWriter) writeBlock(number uint64, data []byte) error {
+	if w.current == nil || number > w.currentLast {
+		if err := w.closeCurrent(); err != nil {
+			return err
+		}
+
+		if err := w.openArchive(number); err != nil {
+			return err
+		}
+	}
+
+	return w.current.writeBlock(number, data)
+}
+
+// openArchive opens the archive file for the range holding the given block
+func (w *backupWriter) openArchive(number uint64) error {
+	path, last := w.outPath, uint64(math.MaxUint64)
+
+	if w.config.SplitBlocks > 0 {
+		first := number - number%w.config.SplitBlocks
+		last = first + w.config.SplitBlocks - 1
+		path = fmt.Sprintf("%s.%d-%d", w.outPath, first, last)
+	}
+
+	var err error
+
+	if path == w.appendPath {
+		w.current, err = openArchiveForAppend(path)
+	} else {
+		w.current, err = createArchive(path)
+		if err == nil {
+			w.created = append(w.created, path)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.currentPath = path
+	w.currentLast = last
+
+	return nil
+}
+
+func (w *backupWriter) closeCurrent() error {
+	if w.current == nil {
+		return nil
+	}
+
+	current := w.current
+	w.current = nil
+
+	if err := current.close(); err != nil {
+		return err
+	}
+
+	w.latestHash = current.index.LatestHash
+
+	w.logger.Info("Wrote archive", "path", w.currentPath,
+		"from", current.index.First, "to", current.index.Latest)
+
+	return nil
+}
+
+// close finalizes the archive file being written
+func (w *backupWriter) close() error {
+	if err := w.closeCurrent(); err != nil {
+		w.abort()
+
+		return err
+	}
+
+	return nil
+}
+
+// abort removes the archive files created by the writer.
+// The blocks appended to the existing archive file are kept, as it remains a valid archive
+func (w *backupWriter) abort() {
+	if w.current != nil {
+		if err := w.current.close(); err != nil {
+			w.logger.Error("an error occurred while closing file", "err", err)
+		}
+
+		w.current = nil
+	}
+
+	for _, path := range w.created {
+		if err := os.Remove(path); err != nil {
+			w.logger.Error("an error occurred while removing file", "err", err)
+		}
+	}
+
+	w.created = nil
+}
+
+// convertLegacyArchive rewrites the legacy archive in the versioned format
+func convertLegacyArchive(path string, logger hclog.Logger) error {
+	tmpPath := path + ".tmp"
+
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer fp.Close()
+
+	stream := newBlockStream(fp)
+	if _, err := stream.getMetadata(); err != nil {
+		return err
+	}
+
+	writer, err := createArchive(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	abort := func(err error) error {
+		writer.file.Close()
+		writer.encoder.Close()
+
+		if removeErr := os.Remove(tmpPath); removeErr != nil {
+			logger.Error("an error occurred while removing file", "err", removeErr)
+		}
+
+		return fmt.Errorf("failed to convert the legacy archive %s: %w", path, err)
+	}
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return abort(err)
+		}
+
+		if block == nil {
+			break
+		}
+
+		if err := writer.writeBlock(block.Number(), block.MarshalRLP()); err != nil {
+			return abort(err)
+		}
+	}
+
+	if err := writer.close(); err != nil {
+		return abort(err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	logger.Info("Converted the legacy archive to the versioned format", "path", path)
+
+	return nil
+}
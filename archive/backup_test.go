@@ -37,6 +37,18 @@ func (m *mockSystemExportClient) Recv() (*proto.ExportEvent, error) {
 	return recv.event, recv.err
 }
 
+type mockBlockWriter struct {
+	data    bytes.Buffer
+	numbers []uint64
+}
+
+func (m *mockBlockWriter) writeBlock(number uint64, data []byte) error {
+	m.numbers = append(m.numbers, number)
+	m.data.Write(data)
+
+	return nil
+}
+
 var (
 	genesis = &types.Block{
 		Header: &types.Header{
@@ -251,8 +263,8 @@ func Test_processExportStream(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var buffer bytes.Buffer
-			from, to, err := processExportStream(tt.mockSystemExportClient, hclog.NewNullLogger(), &buffer, 0, 0)
+			writer := &mockBlockWriter{}
+			from, to, err := processExportStream(tt.mockSystemExportClient, hclog.NewNullLogger(), writer, 0, 0)
 
 			assert.Equal(t, tt.err, err)
 			if err != nil {
@@ -270,7 +282,11 @@ func Test_processExportStream(t *testing.T) {
 				}
 				expectedData = append(expectedData, rv.event.Data...)
 			}
-			assert.Equal(t, expectedData, buffer.Bytes())
+			assert.Equal(t, expectedData, writer.data.Bytes())
+
+			for i, number := range writer.numbers {
+				assert.Equal(t, tt.from+uint64(i), number)
+			}
 		})
 	}
 }
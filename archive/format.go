@@ -0,0 +1,542 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The versioned archive consists of:
+//   - header: archive magic and format version
+//   - chunks: zstd compressed RLP encoded blocks, each prefixed by the chunk header
+//     (chunk magic, first block, number of blocks, compressed size and its CRC32-C checksum)
+//   - index: RLP encoded archiveIndex, listing the chunks and their offsets
+//   - trailer: index offset, index size, index checksum and archive magic
+//
+// The legacy archive is the RLP encoded Metadata followed by the RLP encoded blocks
+const (
+	// archiveVersion is the version of the archive format written by the backups
+	archiveVersion byte = 1
+
+	// chunkMaxSize is the max size of the RLP encoded blocks compressed into a single chunk
+	chunkMaxSize = 4 * 1024 * 1024
+
+	archiveHeaderSize  = 9  // magic (8) + version (1)
+	chunkHeaderSize    = 24 // magic (4) + first block (8) + blocks (4) + size (4) + checksum (4)
+	archiveTrailerSize = 24 // index offset (8) + index size (4) + index checksum (4) + magic (8)
+)
+
+var (
+	archiveMagic = []byte("EDGEARCH")
+	chunkMagic   = []byte("CHNK")
+
+	castagnoli = crc32.MakeTable(crc32.Castagnoli)
+)
+
+var (
+	errLegacyArchive           = errors.New("archive is in the legacy format")
+	errArchiveTruncated        = errors.New("archive is truncated or was not completely written")
+	errArchiveCorrupted        = errors.New("archive is corrupted")
+	errUnsupportedVersion      = errors.New("unsupported archive version")
+	errBlockNotArchived        = errors.New("block is not in the archive")
+	errNonContiguousArchive    = errors.New("archived blocks are not contiguous")
+	errArchiveChecksumMismatch = errors.New("archive checksum mismatch")
+	errNoNewBlocks             = errors.New("no new blocks to archive")
+)
+
+// isLegacyArchive returns true if the archive in the given file is in the legacy format
+func isLegacyArchive(path string) (bool, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+
+	defer fp.Close()
+
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(fp, magic); err != nil {
+		// too short for the versioned archive
+		return true, nil //nolint:nilerr
+	}
+
+	return !bytes.Equal(magic, archiveMagic), nil
+}
+
+// archiveWriter writes the blocks to the versioned archive file
+type archiveWriter struct {
+	file    *os.File
+	encoder *zstd.Encoder
+
+	// chunkSize is the max size of the RLP encoded blocks compressed into a single chunk
+	chunkSize int
+
+	// index lists the chunks written so far
+	index *archiveIndex
+	// offset is the offset of the next chunk
+	offset uint64
+
+	// chunk holds the RLP encoded blocks of the pending chunk
+	chunk      bytes.Buffer
+	chunkFirst uint64
+	chunkCount uint64
+
+	// lastBlock is the RLP encoded last written block, whose hash is stored in the index
+	lastBlock []byte
+
+	// replacePath is the path of the archive replaced by the written file once it is closed
+	// (empty if the archive is written in place)
+	replacePath string
+}
+
+// createArchive creates the archive file, failing if the file exists
+func createArchive(path string) (*archiveWriter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append(append([]byte{}, archiveMagic...), archiveVersion)
+	if _, err := file.Write(header); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return newArchiveWriter(file, &archiveIndex{}, archiveHeaderSize)
+}
+
+// openArchiveForAppend opens the existing archive file to append the blocks following its last block.
+// The blocks are appended to the copy of the archive (without its index), which replaces the archive
+// only once the writer is closed, so the archive remains intact if appending is interrupted
+func openArchiveForAppend(path string) (*archiveWriter, error) {
+	reader, err := openArchive(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.close()
+
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.Copy(file, io.NewSectionReader(reader.file, 0, int64(reader.indexOffset))); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+
+		return nil, err
+	}
+
+	writer, err := newArchiveWriter(file, reader.index, reader.indexOffset)
+	if err != nil {
+		os.Remove(tmpPath)
+
+		return nil, err
+	}
+
+	writer.replacePath = path
+
+	return writer, nil
+}
+
+func newArchiveWriter(file *os.File, index *archiveIndex, offset uint64) (*archiveWriter, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return &archiveWriter{
+		file:      file,
+		encoder:   encoder,
+		chunkSize: chunkMaxSize,
+		index:     index,
+		offset:    offset,
+	}, nil
+}
+
+// blocks returns the number of the blocks in the archive
+func (w *archiveWriter) blocks() uint64 {
+	if len(w.index.Chunks) == 0 && w.chunkCount == 0 {
+		return 0
+	}
+
+	return w.index.Latest - w.index.First + 1
+}
+
+// writeBlock writes the RLP encoded block with the given number, following the last block of the archive
+func (w *archiveWriter) writeBlock(number uint64, data []byte) error {
+	if w.blocks() == 0 {
+		w.index.First = number
+	} else if number != w.index.Latest+1 {
+		return fmt.Errorf("%w: expected block #%d but got #%d", errNonContiguousArchive, w.index.Latest+1, number)
+	}
+
+	if w.chunkCount == 0 {
+		w.chunkFirst = number
+	}
+
+	w.chunk.Write(data)
+	w.chunkCount++
+	w.index.Latest = number
+	w.lastBlock = data
+
+	if w.chunk.Len() >= w.chunkSize {
+		return w.flushChunk()
+	}
+
+	return nil
+}
+
+// flushChunk compresses the pending blocks and writes them as the chunk
+func (w *archiveWriter) flushChunk() error {
+	if w.chunkCount == 0 {
+		return nil
+	}
+
+	payload := w.encoder.EncodeAll(w.chunk.Bytes(), nil)
+	entry := &chunkIndex{
+		First:    w.chunkFirst,
+		Count:    w.chunkCount,
+		Offset:   w.offset,
+		Size:     uint64(len(payload)),
+		Checksum: crc32.Checksum(payload, castagnoli),
+	}
+
+	if _, err := w.file.Write(append(entry.header(), payload...)); err != nil {
+		return err
+	}
+
+	w.index.Chunks = append(w.index.Chunks, entry)
+	w.offset += chunkHeaderSize + entry.Size
+
+	w.chunk.Reset()
+	w.chunkCount = 0
+
+	return nil
+}
+
+// close writes the pending blocks and the index, and closes the file.
+// If the writer appends to the copy of the archive, the copy replaces the archive
+func (w *archiveWriter) close() error {
+	if err := w.finalize(); err != nil {
+		if w.replacePath != "" {
+			os.Remove(w.file.Name())
+		}
+
+		return err
+	}
+
+	if w.replacePath != "" {
+		return os.Rename(w.file.Name(), w.replacePath)
+	}
+
+	return nil
+}
+
+// finalize writes the pending blocks and the index, and closes the file
+func (w *archiveWriter) finalize() error {
+	defer w.encoder.Close()
+
+	if err := w.flushChunk(); err != nil {
+		w.file.Close()
+
+		return err
+	}
+
+	if w.lastBlock != nil {
+		block := &types.Block{}
+		if err := block.UnmarshalRLP(w.lastBlock); err != nil {
+			w.file.Close()
+
+			return fmt.Errorf("failed to decode the last archived block: %w", err)
+		}
+
+		w.index.LatestHash = block.Hash()
+	}
+
+	index := w.index.MarshalRLP()
+
+	trailer := make([]byte, archiveTrailerSize)
+	binary.BigEndian.PutUint64(trailer[0:8], w.offset)
+	binary.BigEndian.PutUint32(trailer[8:12], uint32(len(index)))
+	binary.BigEndian.PutUint32(trailer[12:16], crc32.Checksum(index, castagnoli))
+	copy(trailer[16:], archiveMagic)
+
+	if _, err := w.file.Write(append(index, trailer...)); err != nil {
+		w.file.Close()
+
+		return err
+	}
+
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+
+		return err
+	}
+
+	return w.file.Close()
+}
+
+// archiveReader reads the blocks from the versioned archive file
+type archiveReader struct {
+	path        string
+	file        *os.File
+	decoder     *zstd.Decoder
+	index       *archiveIndex
+	indexOffset uint64
+}
+
+// openArchive opens the versioned archive and reads its index.
+// It fails if the archive is in the legacy format, or its index is missing or corrupted
+func openArchive(path string) (*archiveReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &archiveReader{path: path, file: file}
+
+	if err := reader.readIndex(); err != nil {
+		file.Close()
+
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if reader.decoder, err = zstd.NewReader(nil); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+func (r *archiveReader) readIndex() error {
+	header := make([]byte, archiveHeaderSize)
+	if _, err := io.ReadFull(r.file, header); err != nil || !bytes.Equal(header[:len(archiveMagic)], archiveMagic) {
+		return errLegacyArchive
+	}
+
+	if version := header[len(archiveMagic)]; version > archiveVersion {
+		return fmt.Errorf("%w: %d", errUnsupportedVersion, version)
+	}
+
+	info, err := r.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	size := uint64(info.Size())
+	if size < archiveHeaderSize+archiveTrailerSize {
+		return errArchiveTruncated
+	}
+
+	trailer := make([]byte, archiveTrailerSize)
+	if _, err := r.file.ReadAt(trailer, int64(size-archiveTrailerSize)); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(trailer[16:], archiveMagic) {
+		return errArchiveTruncated
+	}
+
+	r.indexOffset = binary.BigEndian.Uint64(trailer[0:8])
+	indexSize := uint64(binary.BigEndian.Uint32(trailer[8:12]))
+
+	if r.indexOffset+indexSize+archiveTrailerSize != size {
+		return errArchiveCorrupted
+	}
+
+	data := make([]byte, indexSize)
+	if _, err := r.file.ReadAt(data, int64(r.indexOffset)); err != nil {
+		return err
+	}
+
+	if crc32.Checksum(data, castagnoli) != binary.BigEndian.Uint32(trailer[12:16]) {
+		return fmt.Errorf("%w: index", errArchiveChecksumMismatch)
+	}
+
+	r.index = &archiveIndex{}
+	if err := r.index.UnmarshalRLP(data); err != nil {
+		return fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+	}
+
+	return nil
+}
+
+// verify checks the checksums of all the chunks, without decompressing them
+func (r *archiveReader) verify() error {
+	for i := range r.index.Chunks {
+		if _, err := r.readChunkPayload(i); err != nil {
+			return fmt.Errorf("%s: %w", r.path, err)
+		}
+	}
+
+	return nil
+}
+
+// readChunkPayload reads the compressed chunk and checks it against the index
+func (r *archiveReader) readChunkPayload(i int) ([]byte, error) {
+	entry := r.index.Chunks[i]
+
+	data := make([]byte, chunkHeaderSize+entry.Size)
+	if _, err := r.file.ReadAt(data, int64(entry.Offset)); err != nil {
+		return nil, fmt.Errorf("%w: failed to read chunk #%d: %v", errArchiveCorrupted, i, err) //nolint:errorlint
+	}
+
+	if !bytes.Equal(data[:chunkHeaderSize], entry.header()) {
+		return nil, fmt.Errorf("%w: chunk #%d header does not match the index", errArchiveCorrupted, i)
+	}
+
+	payload := data[chunkHeaderSize:]
+	if crc32.Checksum(payload, castagnoli) != entry.Checksum {
+		return nil, fmt.Errorf("%w: chunk #%d", errArchiveChecksumMismatch, i)
+	}
+
+	return payload, nil
+}
+
+// readChunk returns the RLP encoded blocks of the chunk
+func (r *archiveReader) readChunk(i int) ([][]byte, error) {
+	payload, err := r.readChunkPayload(i)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.decoder.DecodeAll(payload, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress chunk #%d: %v", errArchiveCorrupted, i, err) //nolint:errorlint
+	}
+
+	blocks, err := splitRLPBlocks(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: chunk #%d: %v", errArchiveCorrupted, i, err) //nolint:errorlint
+	}
+
+	if uint64(len(blocks)) != r.index.Chunks[i].Count {
+		return nil, fmt.Errorf("%w: chunk #%d has %d blocks, expected %d",
+			errArchiveCorrupted, i, len(blocks), r.index.Chunks[i].Count)
+	}
+
+	return blocks, nil
+}
+
+// findChunk returns the index of the chunk holding the given block
+func (r *archiveReader) findChunk(number uint64) (int, bool) {
+	chunks := r.index.Chunks
+
+	i := sort.Search(len(chunks), func(i int) bool {
+		return chunks[i].First+chunks[i].Count > number
+	})
+
+	if i == len(chunks) || chunks[i].First > number {
+		return 0, false
+	}
+
+	return i, true
+}
+
+// blockByNumber reads the block with the given number, decompressing only the chunk holding it
+func (r *archiveReader) blockByNumber(number uint64) (*types.Block, error) {
+	i, ok := r.findChunk(number)
+	if !ok {
+		return nil, fmt.Errorf("%w: #%d", errBlockNotArchived, number)
+	}
+
+	blocks, err := r.readChunk(i)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(blocks[number-r.index.Chunks[i].First]); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (r *archiveReader) close() error {
+	if r.decoder != nil {
+		r.decoder.Close()
+	}
+
+	return r.file.Close()
+}
+
+// header returns the chunk header
+func (c *chunkIndex) header() []byte {
+	header := make([]byte, chunkHeaderSize)
+
+	copy(header[0:4], chunkMagic)
+	binary.BigEndian.PutUint64(header[4:12], c.First)
+	binary.BigEndian.PutUint32(header[12:16], uint32(c.Count))
+	binary.BigEndian.PutUint32(header[16:20], uint32(c.Size))
+	binary.BigEndian.PutUint32(header[20:24], c.Checksum)
+
+	return header
+}
+
+// splitRLPBlocks splits the concatenated RLP encoded blocks
+func splitRLPBlocks(data []byte) ([][]byte, error) {
+	blocks := [][]byte{}
+
+	for len(data) > 0 {
+		size, err := rlpArraySize(data)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, data[:size])
+		data = data[size:]
+	}
+
+	return blocks, nil
+}
+
+// rlpArraySize returns the size of the RLP encoded array at the beginning of the data (including its header)
+func rlpArraySize(data []byte) (uint64, error) {
+	var size uint64
+
+	switch prefix := data[0]; {
+	case prefix >= 0xc0 && prefix <= 0xf7:
+		// an array whose size is less than 56
+		size = 1 + uint64(prefix-0xc0)
+	case prefix >= 0xf8:
+		// an array whose size is greater than or equal to 56
+		payloadSizeSize := uint64(prefix - 0xf7)
+		if uint64(len(data)) < 1+payloadSizeSize {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		payloadSize := new(big.Int).SetBytes(data[1 : 1+payloadSizeSize])
+		if !payloadSize.IsUint64() {
+			return 0, errors.New("array size overflow")
+		}
+
+		size = 1 + payloadSizeSize + payloadSize.Uint64()
+	default:
+		return 0, errors.New("expected array but got bytes")
+	}
+
+	if size > uint64(len(data)) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	return size, nil
+}
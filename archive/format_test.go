@@ -0,0 +1,390 @@
+package archive
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChain returns the genesis and the following blocks, linked by their parent hashes
+func newTestChain(count uint64) []*types.Block {
+	chain := make([]*types.Block, 0, count+1)
+	parent := types.ZeroHash
+
+	for i := uint64(0); i <= count; i++ {
+		header := &types.Header{
+			Number:     i,
+			ParentHash: parent,
+			GasLimit:   i * 1000,
+			ExtraData:  bytes.Repeat([]byte{byte(i)}, 32),
+		}
+		header.ComputeHash()
+
+		chain = append(chain, &types.Block{Header: header})
+		parent = header.Hash
+	}
+
+	return chain
+}
+
+// writeTestArchive writes the blocks to the archive, with the given number of blocks in each chunk
+func writeTestArchive(t *testing.T, path string, blocks []*types.Block, chunkBlocks int) {
+	t.Helper()
+
+	writer, err := createArchive(path)
+	require.NoError(t, err)
+
+	writer.chunkSize = len(blocks[0].MarshalRLP()) * chunkBlocks
+
+	for _, block := range blocks {
+		require.NoError(t, writer.writeBlock(block.Number(), block.MarshalRLP()))
+	}
+
+	require.NoError(t, writer.close())
+}
+
+// readTestArchives reads all the blocks from the archives
+func readTestArchives(t *testing.T, paths ...string) []*types.Block {
+	t.Helper()
+
+	source, err := openArchiveSource(paths)
+	require.NoError(t, err)
+
+	defer source.close()
+
+	blocks := []*types.Block{}
+
+	for {
+		block, err := source.nextBlock()
+		require.NoError(t, err)
+
+		if block == nil {
+			return blocks
+		}
+
+		blocks = append(blocks, block)
+	}
+}
+
+func TestArchive_WriteRead(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(20)
+	path := filepath.Join(t.TempDir(), "backup")
+
+	writeTestArchive(t, path, chain, 3)
+
+	legacy, err := isLegacyArchive(path)
+	require.NoError(t, err)
+	assert.False(t, legacy)
+
+	reader, err := openArchive(path)
+	require.NoError(t, err)
+
+	defer reader.close()
+
+	require.NoError(t, reader.verify())
+	assert.Equal(t, uint64(0), reader.index.First)
+	assert.Equal(t, uint64(20), reader.index.Latest)
+	assert.Equal(t, chain[20].Hash(), reader.index.LatestHash)
+	assert.Len(t, reader.index.Chunks, 7)
+
+	// random access
+	for _, number := range []uint64{20, 0, 7, 13} {
+		block, err := reader.blockByNumber(number)
+		require.NoError(t, err)
+		assert.Equal(t, chain[number].Hash(), block.Hash())
+	}
+
+	_, err = reader.blockByNumber(21)
+	assert.ErrorIs(t, err, errBlockNotArchived)
+
+	blocks := readTestArchives(t, path)
+	require.Len(t, blocks, len(chain))
+
+	for i, block := range blocks {
+		assert.Equal(t, chain[i].Hash(), block.Hash())
+	}
+}
+
+func TestArchive_NonContiguousBlocks(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(3)
+
+	writer, err := createArchive(filepath.Join(t.TempDir(), "backup"))
+	require.NoError(t, err)
+
+	require.NoError(t, writer.writeBlock(1, chain[1].MarshalRLP()))
+	assert.ErrorIs(t, writer.writeBlock(3, chain[3].MarshalRLP()), errNonContiguousArchive)
+	require.NoError(t, writer.close())
+}
+
+func TestArchive_DetectTruncation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "backup")
+	writeTestArchive(t, path, newTestChain(10), 2)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-10))
+
+	_, err = openArchive(path)
+	assert.ErrorIs(t, err, errArchiveTruncated)
+}
+
+func TestArchive_DetectCorruption(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "backup")
+	writeTestArchive(t, path, newTestChain(10), 2)
+
+	reader, err := openArchive(path)
+	require.NoError(t, err)
+
+	// flip a byte in the payload of the last chunk
+	offset := reader.index.Chunks[len(reader.index.Chunks)-1].Offset + chunkHeaderSize
+	require.NoError(t, reader.close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	data[offset] ^= 0xff
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	reader, err = openArchive(path)
+	require.NoError(t, err)
+
+	defer reader.close()
+
+	assert.ErrorIs(t, reader.verify(), errArchiveChecksumMismatch)
+
+	// the blocks of the other chunks are still readable
+	_, err = reader.blockByNumber(0)
+	assert.NoError(t, err)
+
+	_, err = reader.blockByNumber(10)
+	assert.ErrorIs(t, err, errArchiveChecksumMismatch)
+
+	_, err = openArchiveSource([]string{path})
+	assert.ErrorIs(t, err, errArchiveChecksumMismatch)
+}
+
+func TestArchive_Append(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(10)
+	path := filepath.Join(t.TempDir(), "backup")
+
+	writeTestArchive(t, path, chain[:6], 2)
+
+	writer, err := openArchiveForAppend(path)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, writer.writeBlock(7, chain[7].MarshalRLP()), errNonContiguousArchive)
+
+	for _, block := range chain[6:] {
+		require.NoError(t, writer.writeBlock(block.Number(), block.MarshalRLP()))
+	}
+
+	require.NoError(t, writer.close())
+
+	blocks := readTestArchives(t, path)
+	require.Len(t, blocks, len(chain))
+	assert.Equal(t, chain[10].Hash(), blocks[10].Hash())
+}
+
+func TestArchive_AppendInterrupted(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(10)
+	path := filepath.Join(t.TempDir(), "backup")
+
+	writeTestArchive(t, path, chain[:6], 2)
+
+	writer, err := openArchiveForAppend(path)
+	require.NoError(t, err)
+
+	writer.chunkSize = 1
+
+	for _, block := range chain[6:] {
+		require.NoError(t, writer.writeBlock(block.Number(), block.MarshalRLP()))
+	}
+
+	// the appended chunks are written, but the writer is not closed
+	require.NoError(t, writer.file.Close())
+	writer.encoder.Close()
+
+	blocks := readTestArchives(t, path)
+	require.Len(t, blocks, 6)
+	assert.Equal(t, chain[5].Hash(), blocks[5].Hash())
+
+	// appending again starts over from the intact archive
+	writer, err = openArchiveForAppend(path)
+	require.NoError(t, err)
+
+	for _, block := range chain[6:] {
+		require.NoError(t, writer.writeBlock(block.Number(), block.MarshalRLP()))
+	}
+
+	require.NoError(t, writer.close())
+
+	blocks = readTestArchives(t, path)
+	require.Len(t, blocks, len(chain))
+	assert.Equal(t, chain[10].Hash(), blocks[10].Hash())
+
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestArchive_OpenSource(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(9)
+	dir := t.TempDir()
+
+	first, second, third := filepath.Join(dir, "a"), filepath.Join(dir, "b"), filepath.Join(dir, "c")
+
+	writeTestArchive(t, first, chain[5:], 2)
+	writeTestArchive(t, second, chain[:5], 2)
+	writeTestArchive(t, third, chain[:3], 2)
+
+	// the archives are sorted by their first block
+	blocks := readTestArchives(t, first, second)
+	require.Len(t, blocks, len(chain))
+
+	for i, block := range blocks {
+		assert.Equal(t, chain[i].Hash(), block.Hash())
+	}
+
+	_, err := openArchiveSource([]string{first, third})
+	assert.ErrorIs(t, err, errNonContiguousArchive)
+
+	source, err := openArchiveSource([]string{first, second})
+	require.NoError(t, err)
+
+	defer source.close()
+
+	require.NoError(t, source.seek(6))
+
+	block, err := source.nextBlock()
+	require.NoError(t, err)
+	assert.Equal(t, chain[6].Hash(), block.Hash())
+
+	require.NoError(t, source.seek(10))
+
+	block, err = source.nextBlock()
+	require.NoError(t, err)
+	assert.Nil(t, block)
+}
+
+type mockBlockReader struct {
+	blocks []*types.Block
+}
+
+func (m *mockBlockReader) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockBlockReader) GetBlockByNumber(num uint64, _ bool) (*types.Block, bool) {
+	if num >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[num], true
+}
+
+func TestExportBackup_SplitAndAppend(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(25)
+	outPath := filepath.Join(t.TempDir(), "backup")
+	config := BackupConfig{SplitBlocks: 10, Append: true}
+
+	to := uint64(14)
+
+	from, resTo, err := ExportBackup(&mockBlockReader{blocks: chain}, hclog.NewNullLogger(), 0, &to, outPath, config)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), from)
+	assert.Equal(t, uint64(14), resTo)
+
+	// the appended blocks must follow the archived ones
+	_, _, err = ExportBackup(&mockBlockReader{blocks: chain}, hclog.NewNullLogger(), 3, nil, outPath, config)
+	assert.ErrorIs(t, err, errNonContiguousArchive)
+
+	from, resTo, err = ExportBackup(&mockBlockReader{blocks: chain}, hclog.NewNullLogger(), 0, nil, outPath, config)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), from)
+	assert.Equal(t, uint64(25), resTo)
+
+	_, _, err = ExportBackup(&mockBlockReader{blocks: chain}, hclog.NewNullLogger(), 0, nil, outPath, config)
+	assert.ErrorIs(t, err, errNoNewBlocks)
+
+	paths, err := filepath.Glob(outPath + ".*")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{outPath + ".0-9", outPath + ".10-19", outPath + ".20-29"}, paths)
+
+	blocks := readTestArchives(t, paths...)
+	require.Len(t, blocks, len(chain))
+
+	// restore the chain from the split archive files
+	restored := &mockChain{genesis: chain[0], blocks: []*types.Block{chain[0], chain[1], chain[2]}}
+	require.NoError(t, RestoreChain(restored, outPath+".*", progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+
+	require.Len(t, restored.blocks, len(chain))
+
+	for i, block := range restored.blocks {
+		assert.Equal(t, chain[i].Hash(), block.Hash())
+	}
+}
+
+func TestExportBackup_AppendLegacy(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(8)
+	outPath := filepath.Join(t.TempDir(), "backup")
+
+	// write the legacy archive
+	legacy := (&Metadata{Latest: 4, LatestHash: chain[4].Hash()}).MarshalRLP()
+	for _, block := range chain[:5] {
+		legacy = append(legacy, block.MarshalRLP()...)
+	}
+
+	require.NoError(t, os.WriteFile(outPath, legacy, 0600))
+
+	// the legacy archive is restored as before
+	restored := &mockChain{genesis: chain[0], blocks: []*types.Block{chain[0]}}
+	require.NoError(t, RestoreChain(restored, outPath, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, restored.blocks, 5)
+
+	// the legacy archive can't be overwritten
+	_, _, err := ExportBackup(&mockBlockReader{blocks: chain}, hclog.NewNullLogger(), 0, nil, outPath, BackupConfig{})
+	assert.ErrorIs(t, err, os.ErrExist)
+
+	// the legacy archive is converted when appended
+	from, to, err := ExportBackup(
+		&mockBlockReader{blocks: chain}, hclog.NewNullLogger(), 0, nil, outPath, BackupConfig{Append: true},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), from)
+	assert.Equal(t, uint64(8), to)
+
+	isLegacy, err := isLegacyArchive(outPath)
+	require.NoError(t, err)
+	assert.False(t, isLegacy)
+
+	blocks := readTestArchives(t, outPath)
+	require.Len(t, blocks, len(chain))
+
+	require.NoError(t, RestoreChain(restored, outPath, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, restored.blocks, len(chain))
+	assert.Equal(t, chain[8].Hash(), restored.blocks[8].Hash())
+}
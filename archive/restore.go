@@ -6,6 +6,8 @@ import (
 	"io"
 	"math/big"
 	"os"
+	"path/filepath"
+	"sort"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -20,26 +22,69 @@ const (
 type blockchainInterface interface {
 	SubscribeEvents() blockchain.Subscription
 	Genesis() types.Hash
+	Header() *types.Header
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
 	GetHashByNumber(uint64) types.Hash
 	WriteBlock(*types.Block, string) error
 	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+// blockSource provides the archived blocks in order
+type blockSource interface {
+	getMetadata() (*Metadata, error)
+	nextBlock() (*types.Block, error)
+}
+
+// RestoreChain reads blocks from the archive and write to the chain.
+// The file path may be a glob pattern matching the archive files split by the block range
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
-	fp, err := os.Open(filePath)
+	paths, err := filepath.Glob(filePath)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		// let the file open report the missing file
+		paths = []string{filePath}
+	}
+
+	if len(paths) == 1 {
+		legacy, err := isLegacyArchive(paths[0])
+		if err != nil {
+			return err
+		}
+
+		if legacy {
+			fp, err := os.Open(paths[0])
+			if err != nil {
+				return err
+			}
+
+			defer fp.Close()
+
+			return importBlocks(chain, newBlockStream(fp), progression)
+		}
+	}
+
+	source, err := openArchiveSource(paths)
 	if err != nil {
 		return err
 	}
 
-	blockStream := newBlockStream(fp)
+	defer source.close()
 
-	return importBlocks(chain, blockStream, progression)
+	// skip the archived blocks preceding the local head, its hash is still checked against the archive
+	if header := chain.Header(); header != nil {
+		if err := source.seek(header.Number); err != nil {
+			return err
+		}
+	}
+
+	return importBlocks(chain, source, progression)
 }
 
 // import blocks scans all blocks from stream and write them to chain
-func importBlocks(chain blockchainInterface, blockStream *blockStream, progression *progress.ProgressionWrapper) error {
+func importBlocks(chain blockchainInterface, blockStream blockSource, progression *progress.ProgressionWrapper) error {
 	shutdownCh := common.GetTerminationSignalCh()
 
 	metadata, err := blockStream.getMetadata()
@@ -111,7 +156,7 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 // returns the first block to be written into chain
 func consumeCommonBlocks(
 	chain blockchainInterface,
-	blockStream *blockStream,
+	blockStream blockSource,
 	shutdownCh <-chan os.Signal,
 ) (*types.Block, error) {
 	for {
@@ -148,6 +193,154 @@ func consumeCommonBlocks(
 	}
 }
 
+// archiveSource reads the blocks from the versioned archive files
+type archiveSource struct {
+	// readers are the archives sorted by their first block
+	readers []*archiveReader
+
+	reader int
+	chunk  int
+	// blocks are the RLP encoded blocks of the current chunk that haven't been read yet
+	blocks [][]byte
+}
+
+// openArchiveSource opens the archive files and verifies their checksums,
+// so that the corrupted archive is detected before any block is imported
+func openArchiveSource(paths []string) (*archiveSource, error) {
+	source := &archiveSource{}
+
+	for _, path := range paths {
+		reader, err := openArchive(path)
+		if err != nil {
+			source.close()
+
+			if errors.Is(err, errLegacyArchive) {
+				return nil, fmt.Errorf("%w: the legacy archive can't be combined with other archives", err)
+			}
+
+			return nil, err
+		}
+
+		if err := reader.verify(); err != nil {
+			reader.close()
+			source.close()
+
+			return nil, err
+		}
+
+		if len(reader.index.Chunks) == 0 {
+			reader.close()
+
+			continue
+		}
+
+		source.readers = append(source.readers, reader)
+	}
+
+	if len(source.readers) == 0 {
+		return nil, errors.New("archive doesn't contain any blocks")
+	}
+
+	sort.Slice(source.readers, func(i, j int) bool {
+		return source.readers[i].index.First < source.readers[j].index.First
+	})
+
+	for i := 1; i < len(source.readers); i++ {
+		prev, next := source.readers[i-1], source.readers[i]
+
+		if next.index.First != prev.index.Latest+1 {
+			source.close()
+
+			return nil, fmt.Errorf("%w: %s ends at block #%d but %s starts at block #%d",
+				errNonContiguousArchive, prev.path, prev.index.Latest, next.path, next.index.First)
+		}
+	}
+
+	return source, nil
+}
+
+// getMetadata returns the latest block of the archives
+func (a *archiveSource) getMetadata() (*Metadata, error) {
+	index := a.readers[len(a.readers)-1].index
+
+	return &Metadata{
+		Latest:     index.Latest,
+		LatestHash: index.LatestHash,
+	}, nil
+}
+
+// seek moves to the given block, so that it's the next block to be read
+func (a *archiveSource) seek(number uint64) error {
+	for i, reader := range a.readers {
+		if number > reader.index.Latest {
+			continue
+		}
+
+		chunk, ok := reader.findChunk(number)
+		if !ok {
+			// the block precedes the archives
+			return nil
+		}
+
+		blocks, err := reader.readChunk(chunk)
+		if err != nil {
+			return fmt.Errorf("%s: %w", reader.path, err)
+		}
+
+		a.reader = i
+		a.chunk = chunk + 1
+		a.blocks = blocks[number-reader.index.Chunks[chunk].First:]
+
+		return nil
+	}
+
+	// the block follows the archives
+	a.reader = len(a.readers)
+	a.blocks = nil
+
+	return nil
+}
+
+// nextBlock returns the next archived block, or nil if all the blocks have been read
+func (a *archiveSource) nextBlock() (*types.Block, error) {
+	for len(a.blocks) == 0 {
+		if a.reader == len(a.readers) {
+			return nil, nil
+		}
+
+		reader := a.readers[a.reader]
+		if a.chunk == len(reader.index.Chunks) {
+			a.reader++
+			a.chunk = 0
+
+			continue
+		}
+
+		blocks, err := reader.readChunk(a.chunk)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", reader.path, err)
+		}
+
+		a.chunk++
+		a.blocks = blocks
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(a.blocks[0]); err != nil {
+		return nil, err
+	}
+
+	a.blocks = a.blocks[1:]
+
+	return block, nil
+}
+
+func (a *archiveSource) close() {
+	for _, reader := range a.readers {
+		reader.close()
+	}
+}
+
 // blockStream parse RLP-encoded block from stream and consumed the used bytes
 type blockStream struct {
 	input  io.Reader
@@ -30,6 +30,14 @@ func (m *mockChain) Genesis() types.Hash {
 	return m.genesis.Hash()
 }
 
+func (m *mockChain) Header() *types.Header {
+	if latest := getLatestBlockFromMockChain(m); latest != nil {
+		return latest.Header
+	}
+
+	return m.genesis.Header
+}
+
 func (m *mockChain) GetBlockByNumber(num uint64, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Number() == num {
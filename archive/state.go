@@ -0,0 +1,664 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/klauspost/compress/zstd"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The state export consists of the header (state export magic and format version) followed by
+// the zstd compressed stream of records. Each record is the record kind, the payload size,
+// the RLP encoded payload and the CRC32-C checksum of the kind and the payload.
+// The records are the stateExportMeta, the genesis block, the headers of the blocks preceding
+// the exported blocks, the exported blocks with their receipts, the accounts each followed by
+// its storage slots, and the stateExportEnd closing the stream
+const (
+	// stateExportVersion is the version of the state export format
+	stateExportVersion byte = 2
+
+	// stateHeadersBatchSize is the number of the imported headers written to the chain storage at once
+	stateHeadersBatchSize = 10_000
+
+	// stateRecordMaxSize is the max size of the record payload accepted by the import
+	stateRecordMaxSize = 256 * 1024 * 1024
+
+	stateExportHeaderSize = 9 // magic (8) + version (1)
+	stateRecordHeaderSize = 5 // kind (1) + payload size (4)
+)
+
+const (
+	stateRecordMeta byte = iota + 1
+	stateRecordBlock
+	stateRecordAccount
+	stateRecordSlot
+	stateRecordEnd
+	stateRecordHeader
+)
+
+var stateExportMagic = []byte("EDGESTAT")
+
+var (
+	errNotStateExport       = errors.New("file is not a state export")
+	errChainStorageNotNew   = errors.New("the chain storage is not empty, the state is only imported to a new data dir")
+	errUnexpectedRecord     = errors.New("unexpected state export record")
+	errStateRootMismatch    = errors.New("imported state root does not match the exported block")
+	errExportedBlocksBroken = errors.New("exported blocks are not a chain")
+)
+
+// ExportState writes the world state at the given block (the head block if nil) to the state export file,
+// along with the genesis block and the given number of the blocks (with receipts) up to the exported one.
+// The headers of the blocks in between are exported as well, since the consensus (e.g. PolyBFT)
+// rebuilds its validator snapshots from the headers starting at the genesis.
+// The export is used to bootstrap a new node with ImportState
+func ExportState(
+	chainDB storage.Storage,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	number *uint64,
+	blocks uint64,
+	outPath string,
+) (*types.Header, error) {
+	if number == nil {
+		head, ok := chainDB.ReadHeadNumber()
+		if !ok {
+			return nil, errors.New("couldn't get the head block number")
+		}
+
+		number = &head
+	}
+
+	if blocks == 0 {
+		blocks = 1
+	}
+
+	exported, err := readExportedBlocks(chainDB, *number, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	header := exported[len(exported)-1].block.Header
+
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeStateExport(fs, chainDB, stateStorage, logger, header, exported); err != nil {
+		fs.Close()
+
+		if removeErr := os.Remove(outPath); removeErr != nil {
+			logger.Error("an error occurred while removing file", "err", removeErr)
+		}
+
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// readExportedBlocks reads the genesis block and the blocks preceding the given one,
+// along with their receipts, from the chain storage
+func readExportedBlocks(chainDB storage.Storage, number uint64, blocks uint64) ([]*exportedBlock, error) {
+	first := uint64(0)
+	if number >= blocks {
+		first = number - blocks + 1
+	}
+
+	numbers := make([]uint64, 0, number-first+2)
+	if first > 0 {
+		numbers = append(numbers, 0)
+	}
+
+	for i := first; i <= number; i++ {
+		numbers = append(numbers, i)
+	}
+
+	exported := make([]*exportedBlock, 0, len(numbers))
+
+	for _, i := range numbers {
+		hash, ok := chainDB.ReadCanonicalHash(i)
+		if !ok {
+			return nil, fmt.Errorf("block #%d not found", i)
+		}
+
+		header, err := chainDB.ReadHeader(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header #%d: %w", i, err)
+		}
+
+		body, err := chainDB.ReadBody(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body #%d: %w", i, err)
+		}
+
+		td, ok := chainDB.ReadTotalDifficulty(hash)
+		if !ok {
+			return nil, fmt.Errorf("total difficulty of block #%d not found", i)
+		}
+
+		receipts, err := chainDB.ReadReceipts(hash)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("failed to read receipts #%d: %w", i, err)
+		}
+
+		exported = append(exported, &exportedBlock{
+			block: &types.Block{
+				Header:       header,
+				Transactions: body.Transactions,
+				Uncles:       body.Uncles,
+			},
+			totalDifficulty: td,
+			receipts:        receipts,
+		})
+	}
+
+	return exported, nil
+}
+
+func writeStateExport(
+	fs *os.File,
+	chainDB storage.Storage,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	header *types.Header,
+	exported []*exportedBlock,
+) error {
+	if _, err := fs.Write(append(append([]byte{}, stateExportMagic...), stateExportVersion)); err != nil {
+		return err
+	}
+
+	encoder, err := zstd.NewWriter(fs)
+	if err != nil {
+		return err
+	}
+
+	end, err := writeStateRecords(&stateRecordWriter{output: encoder}, chainDB, stateStorage, logger, header, exported)
+	if err != nil {
+		encoder.Close()
+
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	if err := fs.Sync(); err != nil {
+		return err
+	}
+
+	logger.Info("Exported state", "block", header.Number, "root", header.StateRoot,
+		"accounts", end.Accounts, "slots", end.Slots, "blocks", len(exported))
+
+	return fs.Close()
+}
+
+// writeStateRecords writes the records of the exported block, blocks, headers and state.
+// It returns the end record closing the stream
+func writeStateRecords(
+	writer *stateRecordWriter,
+	chainDB storage.Storage,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	header *types.Header,
+	exported []*exportedBlock,
+) (*stateExportEnd, error) {
+	if err := writer.write(stateRecordMeta, (&stateExportMeta{
+		Number:    header.Number,
+		Hash:      header.Hash,
+		StateRoot: header.StateRoot,
+	}).MarshalRLPWith); err != nil {
+		return nil, err
+	}
+
+	for i, block := range exported {
+		if err := writer.write(stateRecordBlock, block.MarshalRLPWith); err != nil {
+			return nil, err
+		}
+
+		if i == 0 && len(exported) > 1 {
+			// the headers between the genesis and the exported blocks
+			if err := writeStateHeaders(writer, chainDB, 1, exported[1].block.Number()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var (
+		end   = &stateExportEnd{}
+		codes = map[types.Hash]struct{}{}
+	)
+
+	err := itrie.DumpState(stateStorage, header.StateRoot,
+		func(hash types.Hash, data []byte, code []byte) error {
+			if len(code) > 0 {
+				// the code shared by the accounts is exported only once
+				codeHash := types.BytesToHash(crypto.Keccak256(code))
+				if _, ok := codes[codeHash]; ok {
+					code = nil
+				} else {
+					codes[codeHash] = struct{}{}
+				}
+			}
+
+			if end.Accounts++; end.Accounts%100_000 == 0 {
+				logger.Info("Exporting state", "accounts", end.Accounts, "slots", end.Slots)
+			}
+
+			return writer.write(stateRecordAccount, (&stateExportAccount{Hash: hash, Data: data, Code: code}).MarshalRLPWith)
+		},
+		func(hash types.Hash, value []byte) error {
+			end.Slots++
+
+			return writer.write(stateRecordSlot, (&stateExportSlot{Hash: hash, Value: value}).MarshalRLPWith)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writer.write(stateRecordEnd, end.MarshalRLPWith); err != nil {
+		return nil, err
+	}
+
+	return end, nil
+}
+
+// writeStateHeaders writes the records of the headers in the given range (the end excluded)
+func writeStateHeaders(writer *stateRecordWriter, chainDB storage.Storage, from, to uint64) error {
+	for i := from; i < to; i++ {
+		hash, ok := chainDB.ReadCanonicalHash(i)
+		if !ok {
+			return fmt.Errorf("block #%d not found", i)
+		}
+
+		header, err := chainDB.ReadHeader(hash)
+		if err != nil {
+			return fmt.Errorf("failed to read header #%d: %w", i, err)
+		}
+
+		td, ok := chainDB.ReadTotalDifficulty(hash)
+		if !ok {
+			return fmt.Errorf("total difficulty of block #%d not found", i)
+		}
+
+		if err := writer.write(stateRecordHeader, (&exportedHeader{
+			header:          header,
+			totalDifficulty: td,
+		}).MarshalRLPWith); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportState writes the state and the blocks of the state export to the empty chain and state storages,
+// so that the node continues syncing from the block following the exported one.
+// The state root is checked against the exported block before the exported blocks are written
+func ImportState(
+	chainDB storage.Storage,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	path string,
+) (*types.Header, error) {
+	if _, ok := chainDB.ReadHeadHash(); ok {
+		return nil, errChainStorageNotNew
+	}
+
+	fs, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fs.Close()
+
+	header := make([]byte, stateExportHeaderSize)
+	if _, err := io.ReadFull(fs, header); err != nil || !bytes.Equal(header[:len(stateExportMagic)], stateExportMagic) {
+		return nil, errNotStateExport
+	}
+
+	if version := header[len(stateExportMagic)]; version != stateExportVersion {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, version)
+	}
+
+	decoder, err := zstd.NewReader(bufio.NewReader(fs))
+	if err != nil {
+		return nil, err
+	}
+
+	defer decoder.Close()
+
+	reader := &stateRecordReader{input: bufio.NewReader(decoder)}
+
+	meta := &stateExportMeta{}
+	if err := reader.expect(stateRecordMeta, meta.UnmarshalRLPFrom); err != nil {
+		return nil, err
+	}
+
+	exported, kind, err := readImportedBlocks(reader, chainDB, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := importState(reader, kind, stateStorage, logger, meta); err != nil {
+		return nil, err
+	}
+
+	if err := writeImportedBlocks(chainDB, exported); err != nil {
+		return nil, err
+	}
+
+	head := exported[len(exported)-1].block.Header
+
+	logger.Info("Imported state", "block", head.Number, "hash", head.Hash, "root", head.StateRoot)
+
+	return head, nil
+}
+
+// readImportedBlocks reads the exported blocks and checks they are linked up to the exported block.
+// The headers preceding the exported blocks are written to the chain storage as they are read,
+// since there can be too many of them to keep in memory. The head is not set until the import is completed,
+// so the failed import can be repeated. It returns the kind of the record following the blocks
+func readImportedBlocks(
+	reader *stateRecordReader,
+	chainDB storage.Storage,
+	meta *stateExportMeta,
+) ([]*exportedBlock, byte, error) {
+	var (
+		exported    []*exportedBlock
+		prev        *types.Header
+		batchWriter = storage.NewBatchWriter(chainDB)
+		batched     = 0
+	)
+
+	// follows checks that the header follows the previous one
+	follows := func(header *types.Header) error {
+		if prev == nil {
+			if header.Number != 0 {
+				return fmt.Errorf("%w: the genesis block is missing", errExportedBlocksBroken)
+			}
+		} else if header.Number != prev.Number+1 || header.ParentHash != prev.Hash {
+			return fmt.Errorf("%w: block #%d does not follow block #%d",
+				errExportedBlocksBroken, header.Number, prev.Number)
+		}
+
+		prev = header
+
+		return nil
+	}
+
+	for {
+		kind, payload, err := reader.next()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		switch kind {
+		case stateRecordHeader:
+			header := &exportedHeader{}
+			if err := types.UnmarshalRlp(header.UnmarshalRLPFrom, payload); err != nil {
+				return nil, 0, fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+			}
+
+			if len(exported) != 1 {
+				return nil, 0, fmt.Errorf("%w: header #%d is not preceding the exported blocks",
+					errExportedBlocksBroken, header.header.Number)
+			}
+
+			if err := follows(header.header); err != nil {
+				return nil, 0, err
+			}
+
+			batchWriter.PutHeader(header.header)
+			batchWriter.PutCanonicalHash(header.header.Number, header.header.Hash)
+			batchWriter.PutTotalDifficulty(header.header.Hash, header.totalDifficulty)
+
+			if batched++; batched == stateHeadersBatchSize {
+				if err := batchWriter.WriteBatch(); err != nil {
+					return nil, 0, err
+				}
+
+				batchWriter, batched = storage.NewBatchWriter(chainDB), 0
+			}
+
+		case stateRecordBlock:
+			block := &exportedBlock{}
+			if err := types.UnmarshalRlp(block.UnmarshalRLPFrom, payload); err != nil {
+				return nil, 0, fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+			}
+
+			if err := follows(block.block.Header); err != nil {
+				return nil, 0, err
+			}
+
+			exported = append(exported, block)
+
+		default:
+			if prev == nil {
+				return nil, 0, fmt.Errorf("%w: the genesis block is missing", errExportedBlocksBroken)
+			}
+
+			if prev.Number != meta.Number || prev.Hash != meta.Hash || prev.StateRoot != meta.StateRoot {
+				return nil, 0, fmt.Errorf("%w: expected block #%d (%s) but the last block is #%d (%s)",
+					errExportedBlocksBroken, meta.Number, meta.Hash, prev.Number, prev.Hash)
+			}
+
+			if err := batchWriter.WriteBatch(); err != nil {
+				return nil, 0, err
+			}
+
+			return exported, kind, nil
+		}
+	}
+}
+
+// importState reads the accounts and their storage slots, and writes them to the state storage
+func importState(
+	reader *stateRecordReader,
+	kind byte,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	meta *stateExportMeta,
+) error {
+	var (
+		loader   = itrie.NewStateLoader(stateStorage)
+		accounts uint64
+		slots    uint64
+	)
+
+	for ; kind != stateRecordEnd; kind = reader.kind {
+		switch kind {
+		case stateRecordAccount:
+			account := &stateExportAccount{}
+			if err := types.UnmarshalRlp(account.UnmarshalRLPFrom, reader.payload); err != nil {
+				return fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+			}
+
+			if err := loader.AddAccount(account.Hash, account.Data, account.Code); err != nil {
+				return err
+			}
+
+			if accounts++; accounts%100_000 == 0 {
+				logger.Info("Importing state", "accounts", accounts, "slots", slots)
+			}
+
+		case stateRecordSlot:
+			slot := &stateExportSlot{}
+			if err := types.UnmarshalRlp(slot.UnmarshalRLPFrom, reader.payload); err != nil {
+				return fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+			}
+
+			if err := loader.AddSlot(slot.Hash, slot.Value); err != nil {
+				return err
+			}
+
+			slots++
+
+		default:
+			return fmt.Errorf("%w: %d", errUnexpectedRecord, kind)
+		}
+
+		if _, _, err := reader.next(); err != nil {
+			return err
+		}
+	}
+
+	end := &stateExportEnd{}
+	if err := types.UnmarshalRlp(end.UnmarshalRLPFrom, reader.payload); err != nil {
+		return fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+	}
+
+	if end.Accounts != accounts || end.Slots != slots {
+		return fmt.Errorf("%w: expected %d accounts and %d slots, but got %d accounts and %d slots",
+			errArchiveCorrupted, end.Accounts, end.Slots, accounts, slots)
+	}
+
+	root, err := loader.Commit()
+	if err != nil {
+		return err
+	}
+
+	if root != meta.StateRoot {
+		return fmt.Errorf("%w: expected %s but got %s", errStateRootMismatch, meta.StateRoot, root)
+	}
+
+	return nil
+}
+
+// writeImportedBlocks writes the exported blocks and their receipts as the canonical ones,
+// the last one being the head
+func writeImportedBlocks(chainDB storage.Storage, exported []*exportedBlock) error {
+	batchWriter := storage.NewBatchWriter(chainDB)
+
+	for _, block := range exported {
+		header := block.block.Header
+
+		batchWriter.PutHeader(header)
+		batchWriter.PutBody(header.Hash, block.block.Body())
+		batchWriter.PutCanonicalHash(header.Number, header.Hash)
+		batchWriter.PutTotalDifficulty(header.Hash, block.totalDifficulty)
+
+		if header.Number > 0 {
+			batchWriter.PutReceipts(header.Hash, block.receipts)
+		}
+
+		for _, tx := range block.block.Transactions {
+			batchWriter.PutTxLookup(tx.Hash, header.Hash)
+		}
+	}
+
+	head := exported[len(exported)-1].block.Header
+
+	batchWriter.PutHeadHash(head.Hash)
+	batchWriter.PutHeadNumber(head.Number)
+
+	return batchWriter.WriteBatch()
+}
+
+// stateRecordWriter writes the records of the state export
+type stateRecordWriter struct {
+	output io.Writer
+	buf    []byte
+}
+
+func (w *stateRecordWriter) write(kind byte, marshal func(*fastrlp.Arena) *fastrlp.Value) error {
+	w.buf = append(w.buf[:0], kind, 0, 0, 0, 0)
+	w.buf = types.MarshalRLPTo(marshal, w.buf)
+
+	binary.BigEndian.PutUint32(w.buf[1:stateRecordHeaderSize], uint32(len(w.buf)-stateRecordHeaderSize))
+
+	checksum := crc32.Update(crc32.Checksum(w.buf[:1], castagnoli), castagnoli, w.buf[stateRecordHeaderSize:])
+	w.buf = binary.BigEndian.AppendUint32(w.buf, checksum)
+
+	_, err := w.output.Write(w.buf)
+
+	return err
+}
+
+// stateRecordReader reads the records of the state export, checking their checksums
+type stateRecordReader struct {
+	input io.Reader
+
+	// kind and payload of the last read record
+	kind    byte
+	payload []byte
+}
+
+// next reads the next record. The stream ending before the end record is reported as truncated
+func (r *stateRecordReader) next() (byte, []byte, error) {
+	header := make([]byte, stateRecordHeaderSize)
+	if _, err := io.ReadFull(r.input, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, errArchiveTruncated
+		}
+
+		return 0, nil, fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+	}
+
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > stateRecordMaxSize {
+		return 0, nil, fmt.Errorf("%w: record size %d exceeds the limit", errArchiveCorrupted, size)
+	}
+
+	data := make([]byte, size+4)
+	if _, err := io.ReadFull(r.input, data); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, errArchiveTruncated
+		}
+
+		return 0, nil, fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+	}
+
+	payload := data[:size]
+
+	checksum := crc32.Update(crc32.Checksum(header[:1], castagnoli), castagnoli, payload)
+	if checksum != binary.BigEndian.Uint32(data[size:]) {
+		return 0, nil, fmt.Errorf("%w: state export record", errArchiveChecksumMismatch)
+	}
+
+	r.kind, r.payload = header[0], payload
+
+	return r.kind, r.payload, nil
+}
+
+// expect reads the next record, which must be of the given kind, and decodes its payload
+func (r *stateRecordReader) expect(kind byte, unmarshal func(*fastrlp.Parser, *fastrlp.Value) error) error {
+	actual, payload, err := r.next()
+	if err != nil {
+		return err
+	}
+
+	if actual != kind {
+		return fmt.Errorf("%w: expected %d but got %d", errUnexpectedRecord, kind, actual)
+	}
+
+	if err := types.UnmarshalRlp(unmarshal, payload); err != nil {
+		return fmt.Errorf("%w: %v", errArchiveCorrupted, err) //nolint:errorlint
+	}
+
+	return nil
+}
+
+// exportedBlock is the block exported along with the state
+type exportedBlock struct {
+	block           *types.Block
+	totalDifficulty *big.Int
+	receipts        []*types.Receipt
+}
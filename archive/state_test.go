@@ -0,0 +1,205 @@
+package archive
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var successStatus = types.ReceiptSuccess
+
+// newTestStateChain writes the chain of the given number of blocks following the genesis,
+// whose last block has the state with the given number of accounts
+func newTestStateChain(t *testing.T, blocks uint64, accounts int) (storage.Storage, itrie.Storage, []*types.Block) {
+	t.Helper()
+
+	stateStorage := itrie.NewMemoryStorage()
+	snap := itrie.NewState(stateStorage).NewSnapshot()
+
+	txn := state.NewTxn(snap)
+
+	for i := 1; i <= accounts; i++ {
+		addr := types.BytesToAddress(big.NewInt(int64(i)).Bytes())
+
+		txn.SetBalance(addr, big.NewInt(int64(i)))
+		txn.SetState(addr, types.BytesToHash([]byte{byte(i)}), types.BytesToHash([]byte{1}))
+
+		if i%2 == 0 {
+			// the same code is shared by the accounts
+			txn.SetCode(addr, []byte{0x60, 0x01})
+		}
+	}
+
+	objs, err := txn.Commit(false)
+	require.NoError(t, err)
+
+	_, root := snap.Commit(objs)
+
+	chainDB, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	chain := make([]*types.Block, 0, blocks+1)
+	batchWriter := storage.NewBatchWriter(chainDB)
+
+	for i := uint64(0); i <= blocks; i++ {
+		header := &types.Header{
+			Number:     i,
+			Difficulty: 1,
+			StateRoot:  types.BytesToHash(root),
+		}
+
+		if i > 0 {
+			header.ParentHash = chain[i-1].Hash()
+		}
+
+		header.ComputeHash()
+
+		block := &types.Block{Header: header}
+		chain = append(chain, block)
+
+		batchWriter.PutBody(header.Hash, block.Body())
+		batchWriter.PutCanonicalHeader(header, new(big.Int).SetUint64(i+1))
+
+		if i > 0 {
+			batchWriter.PutReceipts(header.Hash, []*types.Receipt{
+				{Status: &successStatus, CumulativeGasUsed: i, GasUsed: i, TxHash: types.BytesToHash([]byte{byte(i)})},
+			})
+		}
+	}
+
+	require.NoError(t, batchWriter.WriteBatch())
+
+	return chainDB, stateStorage, chain
+}
+
+func TestStateExport_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	chainDB, stateStorage, chain := newTestStateChain(t, 20, 50)
+	path := filepath.Join(t.TempDir(), "state")
+
+	number := uint64(15)
+
+	header, err := ExportState(chainDB, stateStorage, hclog.NewNullLogger(), &number, 5, path)
+	require.NoError(t, err)
+	assert.Equal(t, chain[15].Hash(), header.Hash)
+
+	// the export file is never overwritten
+	_, err = ExportState(chainDB, stateStorage, hclog.NewNullLogger(), nil, 5, path)
+	assert.ErrorIs(t, err, os.ErrExist)
+
+	newChainDB, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	newStateStorage := itrie.NewMemoryStorage()
+
+	head, err := ImportState(newChainDB, newStateStorage, hclog.NewNullLogger(), path)
+	require.NoError(t, err)
+	assert.Equal(t, chain[15].Hash(), head.Hash)
+
+	// the chain continues from the exported block
+	headHash, ok := newChainDB.ReadHeadHash()
+	require.True(t, ok)
+	assert.Equal(t, chain[15].Hash(), headHash)
+
+	for _, i := range []uint64{0, 11, 15} {
+		hash, ok := newChainDB.ReadCanonicalHash(i)
+		require.True(t, ok)
+		assert.Equal(t, chain[i].Hash(), hash)
+
+		td, ok := newChainDB.ReadTotalDifficulty(hash)
+		require.True(t, ok)
+		assert.Equal(t, new(big.Int).SetUint64(i+1), td)
+	}
+
+	// the receipts of the exported blocks are imported
+	receipts, err := newChainDB.ReadReceipts(chain[15].Hash())
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	assert.Equal(t, uint64(15), receipts[0].GasUsed)
+
+	// only the headers of the blocks preceding the exported ones are imported
+	for i := uint64(1); i < 11; i++ {
+		hash, ok := newChainDB.ReadCanonicalHash(i)
+		require.True(t, ok)
+		assert.Equal(t, chain[i].Hash(), hash)
+
+		header, err := newChainDB.ReadHeader(hash)
+		require.NoError(t, err)
+		assert.Equal(t, chain[i].Header, header)
+	}
+
+	_, err = newChainDB.ReadBody(chain[10].Hash())
+	assert.Error(t, err)
+
+	// the imported state is readable
+	snap, err := itrie.NewState(newStateStorage).NewSnapshotAt(head.StateRoot)
+	require.NoError(t, err)
+
+	addr := types.BytesToAddress(big.NewInt(10).Bytes())
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(10), account.Balance)
+	assert.Equal(t, types.BytesToHash([]byte{1}), snap.GetStorage(addr, account.Root, types.BytesToHash([]byte{10})))
+
+	code, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+	require.True(t, ok)
+	assert.Equal(t, []byte{0x60, 0x01}, code)
+
+	// the state is imported only into the empty data dir
+	_, err = ImportState(newChainDB, newStateStorage, hclog.NewNullLogger(), path)
+	assert.ErrorIs(t, err, errChainStorageNotNew)
+}
+
+func TestStateExport_DetectTruncationAndCorruption(t *testing.T) {
+	t.Parallel()
+
+	chainDB, stateStorage, _ := newTestStateChain(t, 3, 200)
+	path := filepath.Join(t.TempDir(), "state")
+
+	_, err := ExportState(chainDB, stateStorage, hclog.NewNullLogger(), nil, 256, path)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	importData := func(data []byte) error {
+		modified := filepath.Join(t.TempDir(), "modified")
+		require.NoError(t, os.WriteFile(modified, data, 0600))
+
+		newChainDB, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+		require.NoError(t, err)
+
+		_, err = ImportState(newChainDB, itrie.NewMemoryStorage(), hclog.NewNullLogger(), modified)
+
+		if err != nil {
+			// nothing is written to the chain on failure
+			_, ok := newChainDB.ReadHeadHash()
+			assert.False(t, ok)
+		}
+
+		return err
+	}
+
+	require.NoError(t, importData(data))
+
+	assert.Error(t, importData(data[:len(data)/2]))
+
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	assert.Error(t, importData(corrupted))
+
+	assert.ErrorIs(t, importData([]byte("not a state export")), errNotStateExport)
+}
@@ -2,6 +2,7 @@ package archive
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
@@ -59,3 +60,391 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// chunkIndex is the entry of the archive index describing a single chunk
+type chunkIndex struct {
+	First    uint64
+	Count    uint64
+	Offset   uint64
+	Size     uint64
+	Checksum uint32
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (c *chunkIndex) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(c.First))
+	vv.Set(arena.NewUint(c.Count))
+	vv.Set(arena.NewUint(c.Offset))
+	vv.Set(arena.NewUint(c.Size))
+	vv.Set(arena.NewUint(uint64(c.Checksum)))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (c *chunkIndex) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 5 {
+		return fmt.Errorf("incorrect number of elements to decode chunk index, expected 5 but found %d", len(elems))
+	}
+
+	if c.First, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if c.Count, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if c.Offset, err = elems[2].GetUint64(); err != nil {
+		return err
+	}
+
+	if c.Size, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	checksum, err := elems[4].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	c.Checksum = uint32(checksum)
+
+	return nil
+}
+
+// archiveIndex is the index stored in the end of the versioned archive
+type archiveIndex struct {
+	First      uint64
+	Latest     uint64
+	LatestHash types.Hash
+	Chunks     []*chunkIndex
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (a *archiveIndex) MarshalRLP() []byte {
+	return types.MarshalRLPTo(a.MarshalRLPWith, nil)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (a *archiveIndex) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(a.First))
+	vv.Set(arena.NewUint(a.Latest))
+	vv.Set(arena.NewBytes(a.LatestHash.Bytes()))
+
+	if len(a.Chunks) == 0 {
+		vv.Set(arena.NewNullArray())
+	} else {
+		chunks := arena.NewArray()
+		for _, chunk := range a.Chunks {
+			chunks.Set(chunk.MarshalRLPWith(arena))
+		}
+
+		vv.Set(chunks)
+	}
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (a *archiveIndex) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(a.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (a *archiveIndex) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 4 {
+		return fmt.Errorf("incorrect number of elements to decode archive index, expected 4 but found %d", len(elems))
+	}
+
+	if a.First, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if a.Latest, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(a.LatestHash[:]); err != nil {
+		return err
+	}
+
+	chunkElems, err := elems[3].GetElems()
+	if err != nil {
+		return err
+	}
+
+	a.Chunks = make([]*chunkIndex, len(chunkElems))
+
+	for i, elem := range chunkElems {
+		a.Chunks[i] = &chunkIndex{}
+		if err := a.Chunks[i].UnmarshalRLPFrom(p, elem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stateExportMeta describes the block whose state is exported
+type stateExportMeta struct {
+	Number    uint64
+	Hash      types.Hash
+	StateRoot types.Hash
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (m *stateExportMeta) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(m.Number))
+	vv.Set(arena.NewBytes(m.Hash.Bytes()))
+	vv.Set(arena.NewBytes(m.StateRoot.Bytes()))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (m *stateExportMeta) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 3 {
+		return fmt.Errorf("incorrect number of elements to decode state export meta, expected 3 but found %d", len(elems))
+	}
+
+	if m.Number, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[1].GetHash(m.Hash[:]); err != nil {
+		return err
+	}
+
+	return elems[2].GetHash(m.StateRoot[:])
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (e *exportedBlock) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewCopyBytes(e.block.MarshalRLP()))
+	vv.Set(arena.NewBigInt(e.totalDifficulty))
+	vv.Set(arena.NewCopyBytes(types.Receipts(e.receipts).MarshalStoreRLPTo(nil)))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (e *exportedBlock) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 3 {
+		return fmt.Errorf("incorrect number of elements to decode exported block, expected 3 but found %d", len(elems))
+	}
+
+	data, err := elems[0].Bytes()
+	if err != nil {
+		return err
+	}
+
+	e.block = &types.Block{}
+	if err := e.block.UnmarshalRLP(data); err != nil {
+		return err
+	}
+
+	e.totalDifficulty = new(big.Int)
+	if err := elems[1].GetBigInt(e.totalDifficulty); err != nil {
+		return err
+	}
+
+	if data, err = elems[2].Bytes(); err != nil {
+		return err
+	}
+
+	receipts := types.Receipts{}
+	if err := receipts.UnmarshalStoreRLP(data); err != nil {
+		return err
+	}
+
+	e.receipts = receipts
+
+	return nil
+}
+
+// exportedHeader is the header of the block preceding the exported blocks.
+// The headers are exported from the genesis, so the consensus can rebuild its validator snapshots
+type exportedHeader struct {
+	header          *types.Header
+	totalDifficulty *big.Int
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (e *exportedHeader) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewCopyBytes(e.header.MarshalRLP()))
+	vv.Set(arena.NewBigInt(e.totalDifficulty))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (e *exportedHeader) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 2 {
+		return fmt.Errorf("incorrect number of elements to decode exported header, expected 2 but found %d", len(elems))
+	}
+
+	data, err := elems[0].Bytes()
+	if err != nil {
+		return err
+	}
+
+	e.header = &types.Header{}
+	if err := e.header.UnmarshalRLP(data); err != nil {
+		return err
+	}
+
+	e.totalDifficulty = new(big.Int)
+
+	return elems[1].GetBigInt(e.totalDifficulty)
+}
+
+// stateExportAccount is the exported account, identified by the hash of its address.
+// The code is omitted if it was exported with the preceding account
+type stateExportAccount struct {
+	Hash types.Hash
+	Data []byte
+	Code []byte
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (a *stateExportAccount) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(a.Hash.Bytes()))
+	vv.Set(arena.NewCopyBytes(a.Data))
+	vv.Set(arena.NewCopyBytes(a.Code))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (a *stateExportAccount) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 3 {
+		return fmt.Errorf("incorrect number of elements to decode exported account, expected 3 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(a.Hash[:]); err != nil {
+		return err
+	}
+
+	if a.Data, err = elems[1].GetBytes(nil); err != nil {
+		return err
+	}
+
+	a.Code, err = elems[2].GetBytes(nil)
+
+	return err
+}
+
+// stateExportSlot is the exported storage slot, identified by the hash of its key
+type stateExportSlot struct {
+	Hash  types.Hash
+	Value []byte
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (s *stateExportSlot) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(s.Hash.Bytes()))
+	vv.Set(arena.NewCopyBytes(s.Value))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (s *stateExportSlot) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 2 {
+		return fmt.Errorf("incorrect number of elements to decode exported slot, expected 2 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(s.Hash[:]); err != nil {
+		return err
+	}
+
+	s.Value, err = elems[1].GetBytes(nil)
+
+	return err
+}
+
+// stateExportEnd closes the state export with the number of the exported accounts and slots
+type stateExportEnd struct {
+	Accounts uint64
+	Slots    uint64
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (e *stateExportEnd) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(e.Accounts))
+	vv.Set(arena.NewUint(e.Slots))
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (e *stateExportEnd) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 2 {
+		return fmt.Errorf("incorrect number of elements to decode state export end, expected 2 but found %d", len(elems))
+	}
+
+	if e.Accounts, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	e.Slots, err = elems[1].GetUint64()
+
+	return err
+}
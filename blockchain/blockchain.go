@@ -73,6 +73,8 @@ type Blockchain struct {
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
+	prefetcher *statePrefetcher // Prefetches the state of the received blocks (nil if not supported by the executor)
+
 	writeLock sync.Mutex
 }
 
@@ -211,6 +213,10 @@ func NewBlockchain(
 		return nil, err
 	}
 
+	if prefetcher, ok := executor.(BlockPrefetcher); ok {
+		b.prefetcher = newStatePrefetcher(b.logger, b, prefetcher)
+	}
+
 	// Push the initial event to the stream
 	b.stream.push(&Event{})
 
@@ -1346,8 +1352,22 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 	return b.GetBlockByHash(blockHash, full)
 }
 
+// PrefetchBlock warms up the state caches for the block which is about to be verified,
+// by executing it in the background. The block has to be prefetched ahead of its verification
+// (e.g. by the syncer for the received blocks, or by PolyBFT for the validated proposals),
+// hence VerifyFinalizedBlock and WriteBlock do not prefetch it
+func (b *Blockchain) PrefetchBlock(block *types.Block) {
+	if b.prefetcher != nil {
+		b.prefetcher.enqueue(block)
+	}
+}
+
 // Close closes the DB connection
 func (b *Blockchain) Close() error {
+	if b.prefetcher != nil {
+		b.prefetcher.close()
+	}
+
 	return b.db.Close()
 }
 
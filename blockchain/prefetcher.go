@@ -0,0 +1,103 @@
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// prefetchQueueSize is the number of the blocks waiting to be prefetched
+const prefetchQueueSize = 32
+
+// BlockPrefetcher is the executor which warms up the state caches ahead of the block execution
+type BlockPrefetcher interface {
+	PrefetchBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address, abort func() bool) error
+}
+
+// statePrefetcher executes the received blocks in the background, ahead of their verification,
+// so the state reads of the actual execution do not wait for the disk
+type statePrefetcher struct {
+	logger     hclog.Logger
+	blockchain *Blockchain
+	executor   BlockPrefetcher
+
+	queue     chan *types.Block
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+func newStatePrefetcher(logger hclog.Logger, blockchain *Blockchain, executor BlockPrefetcher) *statePrefetcher {
+	p := &statePrefetcher{
+		logger:     logger.Named("prefetcher"),
+		blockchain: blockchain,
+		executor:   executor,
+		queue:      make(chan *types.Block, prefetchQueueSize),
+		closeCh:    make(chan struct{}),
+	}
+
+	go p.run()
+
+	return p
+}
+
+// enqueue schedules the prefetching of the block. The block is skipped if the queue is full
+func (p *statePrefetcher) enqueue(block *types.Block) {
+	select {
+	case p.queue <- block:
+	default:
+		p.logger.Debug("prefetch queue is full, skipping block", "number", block.Number())
+	}
+}
+
+func (p *statePrefetcher) run() {
+	for {
+		select {
+		case <-p.closeCh:
+			return
+		case block := <-p.queue:
+			p.prefetch(block)
+		}
+	}
+}
+
+func (p *statePrefetcher) prefetch(block *types.Block) {
+	// the block is stale once it (or any block on top of it) is written
+	isStale := func() bool {
+		select {
+		case <-p.closeCh:
+			return true
+		default:
+			return p.blockchain.Header().Number >= block.Number()
+		}
+	}
+
+	if isStale() {
+		return
+	}
+
+	// the blocks received ahead of their parent are executed on top of the latest state,
+	// which still has most of the state they read
+	parentRoot := p.blockchain.Header().StateRoot
+	if parent, ok := p.blockchain.readHeader(block.ParentHash()); ok {
+		parentRoot = parent.StateRoot
+	}
+
+	blockCreator, err := p.blockchain.consensus.GetBlockCreator(block.Header)
+	if err != nil {
+		p.logger.Debug("failed to get block creator", "number", block.Number(), "err", err)
+
+		return
+	}
+
+	if err := p.executor.PrefetchBlock(parentRoot, block, blockCreator, isStale); err != nil {
+		p.logger.Debug("failed to prefetch block", "number", block.Number(), "err", err)
+	}
+}
+
+func (p *statePrefetcher) close() {
+	p.closeOnce.Do(func() {
+		close(p.closeCh)
+	})
+}
@@ -0,0 +1,72 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type prefetchCall struct {
+	parentRoot types.Hash
+	number     uint64
+}
+
+type mockPrefetchingExecutor struct {
+	mockExecutor
+
+	calls chan prefetchCall
+}
+
+func (m *mockPrefetchingExecutor) PrefetchBlock(
+	parentRoot types.Hash,
+	block *types.Block,
+	_ types.Address,
+	_ func() bool,
+) error {
+	m.calls <- prefetchCall{parentRoot: parentRoot, number: block.Number()}
+
+	return nil
+}
+
+func TestBlockchain_PrefetchBlock(t *testing.T) {
+	t.Parallel()
+
+	genesisRoot := types.StringToHash("0x1")
+	executor := &mockPrefetchingExecutor{calls: make(chan prefetchCall, prefetchQueueSize)}
+
+	b, err := newBlockChain(&chain.Chain{
+		Genesis: &chain.Genesis{StateRoot: genesisRoot},
+		Params:  &chain.Params{BlockGasTarget: defaultBlockGasTarget},
+	}, executor)
+	require.NoError(t, err)
+
+	defer b.Close()
+
+	head := b.Header()
+
+	// already written block is skipped
+	b.PrefetchBlock(&types.Block{Header: head})
+
+	// child of the head is executed on top of its parent state
+	b.PrefetchBlock(&types.Block{Header: &types.Header{Number: 1, ParentHash: head.Hash}})
+
+	// block received ahead of its parent is executed on top of the latest state
+	b.PrefetchBlock(&types.Block{Header: &types.Header{Number: 5, ParentHash: types.StringToHash("0x5")}})
+
+	for _, expected := range []prefetchCall{
+		{parentRoot: genesisRoot, number: 1},
+		{parentRoot: genesisRoot, number: 5},
+	} {
+		select {
+		case call := <-executor.calls:
+			assert.Equal(t, expected, call)
+		case <-time.After(5 * time.Second):
+			t.Fatal("block is not prefetched")
+		}
+	}
+}
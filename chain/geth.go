@@ -0,0 +1,391 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrGethUnsupported is the error when the geth genesis uses a feature which can't be mapped onto the chain
+	ErrGethUnsupported = errors.New("unsupported geth genesis feature")
+)
+
+// gethIgnoredConfigFields are the geth chain config fields without an effect on the chain:
+// the difficulty bomb delays, the consensus engines (the engine is configured separately)
+// and the berlin fork, which is not implemented (the EIP-2929 gas costs are not applied)
+var gethIgnoredConfigFields = map[string]string{
+	"eip150Hash":         "the EIP-150 fork hash is not checked",
+	"muirGlacierBlock":   "the difficulty bomb is not implemented",
+	"arrowGlacierBlock":  "the difficulty bomb is not implemented",
+	"grayGlacierBlock":   "the difficulty bomb is not implemented",
+	"mergeNetsplitBlock": "the merge netsplit block has no effect",
+	"berlinBlock":        "the berlin fork is not implemented, its gas cost changes (EIP-2929) are not applied",
+	"clique":             "the consensus engine is configured separately",
+	"ethash":             "the consensus engine is configured separately",
+}
+
+// GethChainConfig is the chain config of the geth genesis. Only the fields, which are mapped onto the chain
+// or need to be checked, are listed
+type GethChainConfig struct {
+	ChainID *big.Int `json:"chainId"`
+
+	HomesteadBlock      *uint64 `json:"homesteadBlock,omitempty"`
+	DAOForkBlock        *uint64 `json:"daoForkBlock,omitempty"`
+	DAOForkSupport      bool    `json:"daoForkSupport,omitempty"`
+	EIP150Block         *uint64 `json:"eip150Block,omitempty"`
+	EIP155Block         *uint64 `json:"eip155Block,omitempty"`
+	EIP158Block         *uint64 `json:"eip158Block,omitempty"`
+	ByzantiumBlock      *uint64 `json:"byzantiumBlock,omitempty"`
+	ConstantinopleBlock *uint64 `json:"constantinopleBlock,omitempty"`
+	PetersburgBlock     *uint64 `json:"petersburgBlock,omitempty"`
+	IstanbulBlock       *uint64 `json:"istanbulBlock,omitempty"`
+	BerlinBlock         *uint64 `json:"berlinBlock,omitempty"`
+	LondonBlock         *uint64 `json:"londonBlock,omitempty"`
+
+	ShanghaiTime *uint64 `json:"shanghaiTime,omitempty"`
+	CancunTime   *uint64 `json:"cancunTime,omitempty"`
+	PragueTime   *uint64 `json:"pragueTime,omitempty"`
+	VerkleTime   *uint64 `json:"verkleTime,omitempty"`
+
+	TerminalTotalDifficulty       *big.Int `json:"terminalTotalDifficulty,omitempty"`
+	TerminalTotalDifficultyPassed bool     `json:"terminalTotalDifficultyPassed,omitempty"`
+}
+
+// gethForkBlock binds the block based geth fork to the chain fork
+type gethForkBlock struct {
+	block **uint64
+	fork  string
+}
+
+// forkBlocks returns the geth forks, which are mapped onto the chain forks, in their activation order
+func (c *GethChainConfig) forkBlocks() []gethForkBlock {
+	return []gethForkBlock{
+		{block: &c.HomesteadBlock, fork: Homestead},
+		{block: &c.EIP150Block, fork: EIP150},
+		{block: &c.EIP155Block, fork: EIP155},
+		{block: &c.EIP158Block, fork: EIP158},
+		{block: &c.ByzantiumBlock, fork: Byzantium},
+		{block: &c.ConstantinopleBlock, fork: Constantinople},
+		{block: &c.PetersburgBlock, fork: Petersburg},
+		{block: &c.IstanbulBlock, fork: Istanbul},
+		{block: &c.LondonBlock, fork: London},
+	}
+}
+
+// checkSupported returns the error listing the features of the config which can't be mapped onto the chain
+func (c *GethChainConfig) checkSupported() error {
+	var err error
+
+	unsupported := func(format string, args ...interface{}) {
+		err = multierror.Append(err, fmt.Errorf("%w: %s", ErrGethUnsupported, fmt.Sprintf(format, args...)))
+	}
+
+	if c.ChainID == nil {
+		unsupported("chainId is missing")
+	} else if !c.ChainID.IsInt64() || c.ChainID.Sign() <= 0 {
+		unsupported("chainId %s is out of range", c.ChainID)
+	}
+
+	if c.DAOForkSupport {
+		unsupported("the DAO fork state change is not implemented")
+	}
+
+	for field, time := range map[string]*uint64{
+		"shanghaiTime": c.ShanghaiTime,
+		"cancunTime":   c.CancunTime,
+		"pragueTime":   c.PragueTime,
+		"verkleTime":   c.VerkleTime,
+	} {
+		if time != nil {
+			unsupported("the time based forks are not implemented (%s)", field)
+		}
+	}
+
+	if c.TerminalTotalDifficulty != nil || c.TerminalTotalDifficultyPassed {
+		unsupported("the proof of stake transition (terminalTotalDifficulty) is not implemented")
+	}
+
+	return err
+}
+
+// GethGenesis is the genesis in the geth genesis.json layout, as written by geth and hardhat
+type GethGenesis struct {
+	Config *GethChainConfig
+	// Genesis holds the header fields and the alloc, which share the layout with the geth genesis.
+	// The base fee is read from the geth baseFeePerGas field
+	Genesis *Genesis
+
+	// ignoredFields are the config fields without an effect on the chain, with the reasons
+	ignoredFields map[string]string
+}
+
+// MarshalJSON implements the json interface
+func (g *GethGenesis) MarshalJSON() ([]byte, error) {
+	type GethGenesis struct {
+		Config        *GethChainConfig           `json:"config"`
+		Nonce         string                     `json:"nonce"`
+		Timestamp     *string                    `json:"timestamp"`
+		ExtraData     *string                    `json:"extraData"`
+		GasLimit      *string                    `json:"gasLimit"`
+		Difficulty    *string                    `json:"difficulty"`
+		Mixhash       types.Hash                 `json:"mixHash"`
+		Coinbase      types.Address              `json:"coinbase"`
+		Alloc         map[string]*GenesisAccount `json:"alloc"`
+		Number        *string                    `json:"number"`
+		GasUsed       *string                    `json:"gasUsed"`
+		ParentHash    types.Hash                 `json:"parentHash"`
+		BaseFeePerGas *string                    `json:"baseFeePerGas,omitempty"`
+	}
+
+	enc := GethGenesis{
+		Config:     g.Config,
+		Nonce:      hex.EncodeToHex(g.Genesis.Nonce[:]),
+		Timestamp:  common.EncodeUint64(g.Genesis.Timestamp),
+		ExtraData:  common.EncodeBytes(g.Genesis.ExtraData),
+		GasLimit:   common.EncodeUint64(g.Genesis.GasLimit),
+		Difficulty: common.EncodeUint64(g.Genesis.Difficulty),
+		Mixhash:    g.Genesis.Mixhash,
+		Coinbase:   g.Genesis.Coinbase,
+		Alloc:      make(map[string]*GenesisAccount, len(g.Genesis.Alloc)),
+		Number:     common.EncodeUint64(g.Genesis.Number),
+		GasUsed:    common.EncodeUint64(g.Genesis.GasUsed),
+		ParentHash: g.Genesis.ParentHash,
+	}
+
+	for addr, account := range g.Genesis.Alloc {
+		enc.Alloc[addr.String()] = account
+	}
+
+	if g.Genesis.BaseFee > 0 {
+		enc.BaseFeePerGas = common.EncodeUint64(g.Genesis.BaseFee)
+	}
+
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON implements the json interface
+func (g *GethGenesis) UnmarshalJSON(data []byte) error {
+	var dec struct {
+		Config        map[string]json.RawMessage `json:"config"`
+		Alloc         map[string]json.RawMessage `json:"alloc"`
+		BaseFeePerGas *string                    `json:"baseFeePerGas"`
+		ExcessBlobGas *string                    `json:"excessBlobGas"`
+		BlobGasUsed   *string                    `json:"blobGasUsed"`
+	}
+
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	if dec.Config == nil {
+		return fmt.Errorf("%w: config is missing", ErrGethUnsupported)
+	}
+
+	// the geth header fields and alloc are decoded as the chain genesis
+	genesis := &Genesis{}
+	if err := json.Unmarshal(data, genesis); err != nil {
+		return err
+	}
+
+	var err error
+
+	for addr := range dec.Alloc {
+		if subErr := types.IsValidAddress(addr); subErr != nil {
+			err = multierror.Append(err, fmt.Errorf("alloc: %w", subErr))
+		}
+	}
+
+	if dec.BaseFeePerGas != nil {
+		baseFee, subErr := common.ParseUint256orHex(dec.BaseFeePerGas)
+		if subErr != nil || !baseFee.IsUint64() {
+			err = multierror.Append(err, fmt.Errorf("baseFeePerGas: invalid value %s", *dec.BaseFeePerGas))
+		} else {
+			genesis.BaseFee = baseFee.Uint64()
+		}
+	}
+
+	for field, value := range map[string]*string{"excessBlobGas": dec.ExcessBlobGas, "blobGasUsed": dec.BlobGasUsed} {
+		if amount, subErr := common.ParseUint64orHex(value); subErr != nil || amount != 0 {
+			err = multierror.Append(err, fmt.Errorf("%w: blob transactions are not implemented (%s)",
+				ErrGethUnsupported, field))
+		}
+	}
+
+	config := &GethChainConfig{}
+	known := make(map[string]json.RawMessage, len(dec.Config))
+	ignored := make(map[string]string)
+
+	for field, value := range dec.Config {
+		if reason, ok := gethIgnoredConfigFields[field]; ok {
+			ignored[field] = reason
+
+			continue
+		}
+
+		known[field] = value
+	}
+
+	rawConfig, subErr := json.Marshal(known)
+	if subErr != nil {
+		return subErr
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawConfig))
+	decoder.DisallowUnknownFields()
+
+	if subErr := decoder.Decode(config); subErr != nil {
+		return fmt.Errorf("%w: config: %v", ErrGethUnsupported, subErr)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	g.Config = config
+	g.Genesis = genesis
+	g.ignoredFields = ignored
+
+	return nil
+}
+
+// ToChain maps the geth genesis onto the chain with the given name, with no consensus engine configured.
+// The base fee is burnt into the zero address as of the london fork and the polygon-edge specific forks
+// are enabled from the genesis. It returns the ignored geth config fields, with the reasons
+func (g *GethGenesis) ToChain(name string) (*Chain, map[string]string, error) {
+	if err := g.Config.checkSupported(); err != nil {
+		return nil, nil, err
+	}
+
+	genesis := *g.Genesis
+	forks := &Forks{
+		QuorumCalcAlignment: NewFork(0),
+		TxHashWithType:      NewFork(0),
+		ProposerSelection:   NewFork(0),
+		TxOrdering:          NewFork(0),
+		ParallelExecution:   NewFork(0),
+	}
+
+	params := &Params{
+		ChainID: g.Config.ChainID.Int64(),
+		Forks:   forks,
+		Engine:  map[string]interface{}{},
+	}
+
+	for _, forkBlock := range g.Config.forkBlocks() {
+		if block := *forkBlock.block; block != nil {
+			forks.SetFork(forkBlock.fork, NewFork(*block))
+		}
+	}
+
+	if london := g.Config.LondonBlock; london != nil {
+		forks.SetFork(LondonFix, NewFork(*london))
+
+		genesis.BaseFeeEM = GenesisBaseFeeEM
+		genesis.BaseFeeChangeDenom = BaseFeeChangeDenom
+
+		// the geth genesis has the base fee only if london is enabled from the genesis,
+		// the first london block uses the default base fee otherwise
+		if *london == 0 && genesis.BaseFee == 0 {
+			genesis.BaseFee = GenesisBaseFee
+		}
+
+		params.BurnContract = map[uint64]types.Address{*london: types.ZeroAddress}
+	} else {
+		genesis.BaseFee = 0
+	}
+
+	ignored := make(map[string]string, len(g.ignoredFields))
+	for field, reason := range g.ignoredFields {
+		ignored[field] = reason
+	}
+
+	if g.Config.DAOForkBlock != nil {
+		ignored["daoForkBlock"] = "the DAO fork block has no effect without the DAO fork support"
+	}
+
+	return &Chain{Name: name, Genesis: &genesis, Params: params}, ignored, nil
+}
+
+// NewGethGenesis maps the chain onto the geth genesis. The polygon-edge specific forks and the consensus
+// engine are not mapped, and the berlin fork is enabled along with london, as required by geth.
+// It returns the chain settings which can't be expressed in the geth genesis
+func NewGethGenesis(c *Chain) (*GethGenesis, []string, error) {
+	if c.Params.ChainID <= 0 {
+		return nil, nil, fmt.Errorf("chain id %d can't be exported", c.Params.ChainID)
+	}
+
+	config := &GethChainConfig{ChainID: big.NewInt(c.Params.ChainID)}
+
+	for _, forkBlock := range config.forkBlocks() {
+		if fork, ok := (*c.Params.Forks)[forkBlock.fork]; ok {
+			block := fork.Block
+			*forkBlock.block = &block
+		}
+	}
+
+	var omitted []string
+
+	if config.LondonBlock != nil {
+		block := *config.LondonBlock
+		config.BerlinBlock = &block
+
+		if em := c.Genesis.BaseFeeEM; em != 0 && em != GenesisBaseFeeEM {
+			omitted = append(omitted, fmt.Sprintf("base fee elasticity multiplier %d", em))
+		}
+
+		if denom := c.Genesis.BaseFeeChangeDenom; denom != 0 && denom != BaseFeeChangeDenom {
+			omitted = append(omitted, fmt.Sprintf("base fee change denominator %d", denom))
+		}
+
+		blocks := make([]uint64, 0, len(c.Params.BurnContract))
+		for block := range c.Params.BurnContract {
+			blocks = append(blocks, block)
+		}
+
+		sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+
+		for _, block := range blocks {
+			if addr := c.Params.BurnContract[block]; addr != types.ZeroAddress {
+				omitted = append(omitted, fmt.Sprintf("burn contract %s as of block %d", addr, block))
+			}
+		}
+	}
+
+	if engine := c.Params.GetEngine(); engine != "" {
+		omitted = append(omitted, fmt.Sprintf("%s consensus engine", engine))
+	}
+
+	genesis := *c.Genesis
+	if config.LondonBlock == nil || *config.LondonBlock > 0 {
+		genesis.BaseFee = 0
+	}
+
+	return &GethGenesis{Config: config, Genesis: &genesis}, omitted, nil
+}
+
+// ImportGethFromFile reads the geth genesis from the file
+func ImportGethFromFile(filename string) (*GethGenesis, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var genesis *GethGenesis
+
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return nil, err
+	}
+
+	return genesis, nil
+}
@@ -0,0 +1,172 @@
+package chain
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const testGethGenesis = `{
+	"config": {
+		"chainId": 12345,
+		"homesteadBlock": 0,
+		"eip150Block": 0,
+		"eip155Block": 0,
+		"eip158Block": 0,
+		"byzantiumBlock": 0,
+		"constantinopleBlock": 0,
+		"petersburgBlock": 0,
+		"istanbulBlock": 0,
+		"muirGlacierBlock": 0,
+		"berlinBlock": 0,
+		"londonBlock": 10,
+		"clique": {"period": 5, "epoch": 30000}
+	},
+	"difficulty": "1",
+	"gasLimit": "8000000",
+	"extraData": "0x1234",
+	"alloc": {
+		"f39fd6e51aad88f6f4ce6ab8827279cfffb92266": {"balance": "1000000000000000000000"},
+		"0x0000000000000000000000000000000000001234": {
+			"balance": "0x0",
+			"code": "0x6001",
+			"storage": {"0x00": "0x01"},
+			"nonce": "0x1"
+		}
+	}
+}`
+
+func TestGethGenesis_ToChain(t *testing.T) {
+	t.Parallel()
+
+	var gethGenesis *GethGenesis
+
+	require.NoError(t, json.Unmarshal([]byte(testGethGenesis), &gethGenesis))
+
+	chain, ignored, err := gethGenesis.ToChain("test")
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", chain.Name)
+	assert.Equal(t, int64(12345), chain.Params.ChainID)
+	assert.Equal(t, uint64(8000000), chain.Genesis.GasLimit)
+	assert.Equal(t, uint64(1), chain.Genesis.Difficulty)
+	assert.Equal(t, []byte{0x12, 0x34}, chain.Genesis.ExtraData)
+
+	// the base fee of the first london block is the default one
+	assert.Zero(t, chain.Genesis.BaseFee)
+	assert.Equal(t, uint64(GenesisBaseFeeEM), chain.Genesis.BaseFeeEM)
+	assert.Equal(t, map[uint64]types.Address{10: types.ZeroAddress}, chain.Params.BurnContract)
+
+	assert.True(t, chain.Params.Forks.IsActive(Istanbul, 0))
+	assert.False(t, chain.Params.Forks.IsActive(London, 9))
+	assert.True(t, chain.Params.Forks.IsActive(London, 10))
+	assert.True(t, chain.Params.Forks.IsActive(LondonFix, 10))
+	assert.True(t, chain.Params.Forks.IsActive(TxHashWithType, 0))
+
+	assert.Contains(t, ignored, "berlinBlock")
+	assert.Contains(t, ignored, "muirGlacierBlock")
+	assert.Contains(t, ignored, "clique")
+
+	balance, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	premined := chain.Genesis.Alloc[types.StringToAddress("f39fd6e51aad88f6f4ce6ab8827279cfffb92266")]
+	require.NotNil(t, premined)
+	assert.Equal(t, balance, premined.Balance)
+
+	contract := chain.Genesis.Alloc[types.StringToAddress("1234")]
+	require.NotNil(t, contract)
+	assert.Equal(t, []byte{0x60, 0x01}, contract.Code)
+	assert.Equal(t, uint64(1), contract.Nonce)
+	assert.Equal(t, types.BytesToHash([]byte{1}), contract.Storage[types.ZeroHash])
+
+	// the exported genesis is imported back into the same chain
+	exported, omitted, err := NewGethGenesis(chain)
+	require.NoError(t, err)
+	assert.Empty(t, omitted)
+	assert.Equal(t, exported.Config.LondonBlock, exported.Config.BerlinBlock)
+
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+
+	var reimported *GethGenesis
+
+	require.NoError(t, json.Unmarshal(data, &reimported))
+
+	reimportedChain, _, err := reimported.ToChain("test")
+	require.NoError(t, err)
+
+	assert.Equal(t, chain.Genesis.Hash(), reimportedChain.Genesis.Hash())
+	assert.Equal(t, chain.Params.Forks, reimportedChain.Params.Forks)
+	assert.Equal(t, chain.Params.ChainID, reimportedChain.Params.ChainID)
+}
+
+func TestGethGenesis_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	withConfig := func(old, new string) string {
+		return strings.Replace(testGethGenesis, old, new, 1)
+	}
+
+	cases := []struct {
+		name    string
+		genesis string
+	}{
+		{"time based fork", withConfig(`"londonBlock": 10,`, `"londonBlock": 10, "shanghaiTime": 0,`)},
+		{"merge", withConfig(`"londonBlock": 10,`, `"londonBlock": 10, "terminalTotalDifficulty": 0,`)},
+		{"DAO fork", withConfig(`"londonBlock": 10,`, `"londonBlock": 10, "daoForkBlock": 0, "daoForkSupport": true,`)},
+		{"missing chain id", withConfig(`"chainId": 12345,`, ``)},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gethGenesis *GethGenesis
+
+			require.NoError(t, json.Unmarshal([]byte(c.genesis), &gethGenesis))
+
+			_, _, err := gethGenesis.ToChain("test")
+			assert.ErrorIs(t, err, ErrGethUnsupported)
+		})
+	}
+
+	var gethGenesis *GethGenesis
+
+	// the unknown config fields are not dropped silently
+	err := json.Unmarshal([]byte(withConfig(`"londonBlock": 10,`, `"londonBlock": 10, "osakaTime": 0,`)), &gethGenesis)
+	assert.ErrorIs(t, err, ErrGethUnsupported)
+
+	err = json.Unmarshal([]byte(withConfig(`"0x0000000000000000000000000000000000001234"`, `"0x1234"`)), &gethGenesis)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(strings.Replace(testGethGenesis, `"difficulty"`, `"blobGasUsed": "0x1", "difficulty"`, 1)),
+		&gethGenesis)
+	assert.ErrorIs(t, err, ErrGethUnsupported)
+}
+
+func TestNewGethGenesis_Omitted(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Genesis: &Genesis{GasLimit: 1000, BaseFee: 10, BaseFeeEM: 4, BaseFeeChangeDenom: BaseFeeChangeDenom},
+		Params: &Params{
+			ChainID:      100,
+			Forks:        &Forks{London: NewFork(0), LondonFix: NewFork(0)},
+			Engine:       map[string]interface{}{"polybft": map[string]interface{}{}},
+			BurnContract: map[uint64]types.Address{0: types.StringToAddress("1")},
+		},
+	}
+
+	gethGenesis, omitted, err := NewGethGenesis(chain)
+	require.NoError(t, err)
+	assert.Len(t, omitted, 3)
+	assert.Equal(t, uint64(10), gethGenesis.Genesis.BaseFee)
+	assert.Nil(t, gethGenesis.Config.HomesteadBlock)
+}
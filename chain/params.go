@@ -2,9 +2,11 @@ package chain
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -27,6 +29,8 @@ type Params struct {
 	TransactionsBlockList     *AddressListConfig `json:"transactionsBlockList,omitempty"`
 	BridgeAllowList           *AddressListConfig `json:"bridgeAllowList,omitempty"`
 	BridgeBlockList           *AddressListConfig `json:"bridgeBlockList,omitempty"`
+	CallPolicy                *CallPolicyConfig  `json:"callPolicy,omitempty"`
+	SponsorAllowList          *AddressListConfig `json:"sponsorAllowList,omitempty"`
 
 	// Governance contract where the token will be sent to and burn in london fork
 	BurnContract map[uint64]types.Address `json:"burnContract"`
@@ -42,6 +46,64 @@ type AddressListConfig struct {
 	EnabledAddresses []types.Address `json:"enabledAddresses,omitempty"`
 }
 
+// CallPolicyConfig is the initial configuration of the call policy, which restricts
+// the calls to the given contract functions to the callers holding a permission for them
+type CallPolicyConfig struct {
+	// AdminAddresses is the list of the initial admin addresses
+	AdminAddresses []types.Address `json:"adminAddresses,omitempty"`
+
+	// ManagerAddresses is the list of the initial manager addresses,
+	// which are allowed to grant and revoke the permissions
+	ManagerAddresses []types.Address `json:"managerAddresses,omitempty"`
+
+	// Restrictions is the list of the initially restricted contract functions
+	Restrictions []*CallRestrictionConfig `json:"restrictions,omitempty"`
+
+	// Permissions is the list of the initial permissions to call the restricted functions
+	Permissions []*CallPermissionConfig `json:"permissions,omitempty"`
+}
+
+// CallRestrictionConfig restricts the calls to the function of the target contract.
+// The zero selector restricts all the calls to the target contract
+type CallRestrictionConfig struct {
+	Target   types.Address    `json:"target"`
+	Selector FunctionSelector `json:"selector"`
+}
+
+// CallPermissionConfig permits the caller to call the function of the target contract
+// within the given time range. The zero ValidUntil means the permission doesn't expire
+type CallPermissionConfig struct {
+	Caller     types.Address    `json:"caller"`
+	Target     types.Address    `json:"target"`
+	Selector   FunctionSelector `json:"selector"`
+	ValidFrom  uint64           `json:"validFrom,omitempty"`
+	ValidUntil uint64           `json:"validUntil,omitempty"`
+}
+
+// FunctionSelector is the 4 bytes identifier of the contract function
+type FunctionSelector [types.SignatureSize]byte
+
+// MarshalText implements encoding.TextMarshaler
+func (f FunctionSelector) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToHex(f[:])), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (f *FunctionSelector) UnmarshalText(input []byte) error {
+	buf, err := hex.DecodeHex(string(input))
+	if err != nil {
+		return err
+	}
+
+	if len(buf) != types.SignatureSize {
+		return fmt.Errorf("invalid function selector length %d, expected %d", len(buf), types.SignatureSize)
+	}
+
+	copy(f[:], buf)
+
+	return nil
+}
+
 // CalculateBurnContract calculates burn contract address for the given block number
 func (p *Params) CalculateBurnContract(block uint64) (types.Address, error) {
 	blocks := make([]uint64, 0, len(p.BurnContract))
@@ -90,6 +152,10 @@ const (
 	QuorumCalcAlignment = "quorumcalcalignment"
 	TxHashWithType      = "txHashWithType"
 	LondonFix           = "londonfix"
+	ProposerSelection   = "proposerselection"
+	TxOrdering          = "txordering"
+	ParallelExecution   = "parallelexecution"
+	Sponsorship         = "sponsorship"
 )
 
 // Forks is map which contains all forks and their starting blocks from genesis
@@ -126,6 +192,10 @@ func (f *Forks) At(block uint64) ForksInTime {
 		QuorumCalcAlignment: f.IsActive(QuorumCalcAlignment, block),
 		TxHashWithType:      f.IsActive(TxHashWithType, block),
 		LondonFix:           f.IsActive(LondonFix, block),
+		ProposerSelection:   f.IsActive(ProposerSelection, block),
+		TxOrdering:          f.IsActive(TxOrdering, block),
+		ParallelExecution:   f.IsActive(ParallelExecution, block),
+		Sponsorship:         f.IsActive(Sponsorship, block),
 	}
 }
 
@@ -155,7 +225,11 @@ type ForksInTime struct {
 	EIP155,
 	QuorumCalcAlignment,
 	TxHashWithType,
-	LondonFix bool
+	LondonFix,
+	ProposerSelection,
+	TxOrdering,
+	ParallelExecution,
+	Sponsorship bool
 }
 
 // AllForksEnabled should contain all supported forks by current edge version
@@ -172,4 +246,8 @@ var AllForksEnabled = &Forks{
 	QuorumCalcAlignment: NewFork(0),
 	TxHashWithType:      NewFork(0),
 	LondonFix:           NewFork(0),
+	ProposerSelection:   NewFork(0),
+	TxOrdering:          NewFork(0),
+	ParallelExecution:   NewFork(0),
+	Sponsorship:         NewFork(0),
 }
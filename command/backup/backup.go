@@ -44,6 +44,21 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().Uint64Var(
+		&params.split,
+		splitFlag,
+		0,
+		"the number of blocks in each backup file, the backup files are named <out>.<first>-<last> (not split if 0)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.append,
+		appendFlag,
+		false,
+		"append the blocks following the last block of the existing backup, "+
+			"the beginning height defaults to the block following it",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
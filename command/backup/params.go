@@ -11,9 +11,11 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag    = "out"
+	fromFlag   = "from"
+	toFlag     = "to"
+	splitFlag  = "split"
+	appendFlag = "append"
 )
 
 var (
@@ -34,6 +36,9 @@ type backupParams struct {
 	from uint64
 	to   *uint64
 
+	split  uint64
+	append bool
+
 	resFrom uint64
 	resTo   uint64
 }
@@ -86,6 +91,10 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		p.from,
 		p.to,
 		p.out,
+		archive.BackupConfig{
+			SplitBlocks: p.split,
+			Append:      p.append,
+		},
 	)
 	if err != nil {
 		return err
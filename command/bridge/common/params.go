@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/umbracle/ethgo"
 
 	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -35,6 +37,9 @@ const (
 	ChildTokenFlag         = "child-token"
 	JSONRPCFlag            = "json-rpc"
 	ChildChainMintableFlag = "child-chain-mintable"
+	ResubmitIntervalFlag   = "resubmit-interval"
+	FeeBumpFlag            = "fee-bump"
+	FeeCapFlag             = "fee-cap"
 
 	MinterKeyFlag     = "minter-key"
 	MinterKeyFlagDesc = "minter key is the account which is able to mint tokens to sender account " +
@@ -46,7 +51,72 @@ var (
 	errInconsistentTokenIds = errors.New("receivers and token ids must be equal length")
 )
 
+// ResubmitParams holds the parameters of the stuck transactions resubmission
+type ResubmitParams struct {
+	ResubmitInterval  time.Duration
+	FeeBumpPercentage uint64
+	FeeCapRaw         string
+
+	feeCap *big.Int
+}
+
+// RegisterResubmitFlags registers the flags of the stuck transactions resubmission to a given command
+func (p *ResubmitParams) RegisterResubmitFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(
+		&p.ResubmitInterval,
+		ResubmitIntervalFlag,
+		txrelayer.DefaultResubmitInterval,
+		"the time to wait for a transaction receipt before the transaction is resubmitted "+
+			"with bumped fees (0 disables the resubmission)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&p.FeeBumpPercentage,
+		FeeBumpFlag,
+		txrelayer.DefaultFeeBumpPercentage,
+		"the percentage by which the fees are increased on each transaction resubmission",
+	)
+
+	cmd.Flags().StringVar(
+		&p.FeeCapRaw,
+		FeeCapFlag,
+		"",
+		fmt.Sprintf("the maximum fee per gas (or gas price) of the resubmitted transactions in wei "+
+			"(%d times the initial fees if not set)", txrelayer.DefaultFeeCapMultiplier),
+	)
+}
+
+func (p *ResubmitParams) Validate() error {
+	if p.FeeCapRaw == "" {
+		return nil
+	}
+
+	feeCap, err := helperCommon.ParseUint256orHex(&p.FeeCapRaw)
+	if err != nil {
+		return fmt.Errorf("failed to parse fee cap %s: %w", p.FeeCapRaw, err)
+	}
+
+	p.feeCap = feeCap
+
+	return nil
+}
+
+// ResubmitOption returns the tx relayer option which sets the resubmit policy based on the provided flags
+func (p *ResubmitParams) ResubmitOption() txrelayer.TxRelayerOption {
+	if p.ResubmitInterval == 0 {
+		return txrelayer.WithResubmitPolicy(nil)
+	}
+
+	return txrelayer.WithResubmitPolicy(&txrelayer.ResubmitPolicy{
+		Interval:          p.ResubmitInterval,
+		FeeBumpPercentage: p.FeeBumpPercentage,
+		FeeCap:            p.feeCap,
+	})
+}
+
 type BridgeParams struct {
+	ResubmitParams
+
 	SenderKey          string
 	Receivers          []string
 	TokenAddr          string
@@ -84,6 +154,8 @@ func (p *BridgeParams) RegisterCommonFlags(cmd *cobra.Command) {
 		false,
 		"flag indicating whether tokens originate from child chain",
 	)
+
+	p.RegisterResubmitFlags(cmd)
 }
 
 func (p *BridgeParams) Validate() error {
@@ -96,7 +168,7 @@ func (p *BridgeParams) Validate() error {
 		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
 	}
 
-	return nil
+	return p.ResubmitParams.Validate()
 }
 
 type ERC20BridgeParams struct {
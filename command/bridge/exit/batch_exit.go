@@ -0,0 +1,209 @@
+package exit
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	"github.com/0xPolygon/polygon-edge/command/rootchain/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	exitEventIDsFlag = "exit-ids"
+)
+
+type batchExitParams struct {
+	common.ResubmitParams
+
+	senderKey         string
+	exitHelperAddrRaw string
+	exitIDs           []uint
+	rootJSONRPCAddr   string
+	childJSONRPCAddr  string
+	isTestMode        bool
+}
+
+var (
+	// bep represents batch exit command parameters
+	bep *batchExitParams = &batchExitParams{}
+)
+
+// GetBatchCommand returns the bridge batch exit command
+func GetBatchCommand() *cobra.Command {
+	batchExitCmd := &cobra.Command{
+		Use:     "batch-exit",
+		Short:   "Sends multiple exits to the Exit helper contract on the root chain in a single transaction",
+		PreRunE: preRunBatch,
+		Run:     runBatch,
+	}
+
+	batchExitCmd.Flags().StringVar(
+		&bep.senderKey,
+		common.SenderKeyFlag,
+		"",
+		"hex encoded private key of the account which sends batch exit transaction to the root chain",
+	)
+
+	batchExitCmd.Flags().StringVar(
+		&bep.exitHelperAddrRaw,
+		exitHelperFlag,
+		"",
+		"address of ExitHelper smart contract on root chain",
+	)
+
+	batchExitCmd.Flags().UintSliceVar(
+		&bep.exitIDs,
+		exitEventIDsFlag,
+		nil,
+		"child chain exit event IDs",
+	)
+
+	batchExitCmd.Flags().StringVar(
+		&bep.rootJSONRPCAddr,
+		rootJSONRPCFlag,
+		txrelayer.DefaultRPCAddress,
+		"the JSON RPC root chain endpoint",
+	)
+
+	batchExitCmd.Flags().StringVar(
+		&bep.childJSONRPCAddr,
+		childJSONRPCFlag,
+		"http://127.0.0.1:9545",
+		"the JSON RPC child chain endpoint",
+	)
+
+	batchExitCmd.Flags().BoolVar(
+		&bep.isTestMode,
+		helper.TestModeFlag,
+		false,
+		"test indicates whether batch exit transaction sender is hardcoded test account",
+	)
+
+	bep.RegisterResubmitFlags(batchExitCmd)
+
+	_ = batchExitCmd.MarkFlagRequired(exitHelperFlag)
+	_ = batchExitCmd.MarkFlagRequired(exitEventIDsFlag)
+	batchExitCmd.MarkFlagsMutuallyExclusive(helper.TestModeFlag, common.SenderKeyFlag)
+
+	return batchExitCmd
+}
+
+func preRunBatch(_ *cobra.Command, _ []string) error {
+	return bep.Validate()
+}
+
+func runBatch(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	senderKey, err := helper.DecodePrivateKey(bep.senderKey)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to create wallet from private key: %w", err))
+
+		return
+	}
+
+	rootTxRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(bep.rootJSONRPCAddr), bep.ResubmitOption())
+	if err != nil {
+		outputter.SetError(fmt.Errorf("could not create root chain tx relayer: %w", err))
+
+		return
+	}
+
+	childClient, err := jsonrpc.NewClient(bep.childJSONRPCAddr)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("could not create child chain JSON RPC client: %w", err))
+
+		return
+	}
+
+	exitInputs := make([]*contractsapi.BatchExitInput, len(bep.exitIDs))
+	result := &batchExitResult{Exits: make([]*exitResult, len(bep.exitIDs))}
+
+	for i, exitID := range bep.exitIDs {
+		// acquire proof for given exit event
+		var proof types.Proof
+
+		if err := childClient.Call(generateExitProofFn, &proof, fmt.Sprintf("0x%x", exitID)); err != nil {
+			outputter.SetError(fmt.Errorf("failed to get exit proof (exit id=%d): %w", exitID, err))
+
+			return
+		}
+
+		exitInput, exitEvent, err := decodeExitProof(proof)
+		if err != nil {
+			outputter.SetError(fmt.Errorf("failed to decode exit proof (exit id=%d): %w", exitID, err))
+
+			return
+		}
+
+		exitInputs[i] = exitInput
+		result.Exits[i] = &exitResult{
+			ID:       strconv.FormatUint(exitEvent.ID.Uint64(), 10),
+			Sender:   exitEvent.Sender.String(),
+			Receiver: exitEvent.Receiver.String(),
+		}
+	}
+
+	// create batch exit transaction
+	txn, err := createBatchExitTxn(senderKey.Address(), exitInputs)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to create tx input: %w", err))
+
+		return
+	}
+
+	// send batch exit transaction
+	receipt, err := rootTxRelayer.SendTransaction(txn, senderKey)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to send batch exit transaction (exit ids=%v): %w", bep.exitIDs, err))
+
+		return
+	}
+
+	if receipt.Status == uint64(types.ReceiptFailed) {
+		outputter.SetError(fmt.Errorf("failed to execute batch exit transaction (exit ids=%v)", bep.exitIDs))
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
+
+// createBatchExitTxn encodes parameters for batchExit function on root chain ExitHelper contract
+func createBatchExitTxn(sender ethgo.Address, exitInputs []*contractsapi.BatchExitInput) (*ethgo.Transaction, error) {
+	batchExitFn := &contractsapi.BatchExitExitHelperFn{Inputs: exitInputs}
+
+	input, err := batchExitFn.EncodeAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode provided parameters: %w", err)
+	}
+
+	exitHelperAddr := ethgo.Address(types.StringToAddress(bep.exitHelperAddrRaw))
+
+	// gas limit is estimated, since it depends on the number of exits
+	return helper.CreateTransaction(sender, &exitHelperAddr, input, nil, true), nil
+}
+
+type batchExitResult struct {
+	Exits []*exitResult `json:"exits"`
+}
+
+func (r *batchExitResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	for _, exit := range r.Exits {
+		buffer.WriteString(exit.GetOutput())
+	}
+
+	return buffer.String()
+}
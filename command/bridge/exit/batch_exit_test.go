@@ -0,0 +1,106 @@
+package exit
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestBatchExit_CreateBatchExitTxn(t *testing.T) {
+	exitHelperAddr := types.StringToAddress("0x100")
+	bep.exitHelperAddrRaw = exitHelperAddr.String()
+
+	proofs := make([]types.Proof, 3)
+	exitEvents := make([]*contractsapi.L2StateSyncedEvent, len(proofs))
+
+	for i := range proofs {
+		exitEvents[i] = &contractsapi.L2StateSyncedEvent{
+			ID:       big.NewInt(int64(i + 1)),
+			Sender:   types.StringToAddress("0x1"),
+			Receiver: types.StringToAddress("0x2"),
+			Data:     []byte{byte(i)},
+		}
+
+		exitEventEncoded, err := exitEvents[i].Encode()
+		require.NoError(t, err)
+
+		// metadata numbers are decoded from JSON as float64 values
+		proofs[i] = types.Proof{
+			Data: []types.Hash{types.StringToHash("0x10"), types.StringToHash("0x20")},
+			Metadata: map[string]interface{}{
+				"LeafIndex":       float64(i),
+				"CheckpointBlock": float64(10),
+				"ExitEvent":       hex.EncodeToString(exitEventEncoded),
+			},
+		}
+	}
+
+	exitInputs := make([]*contractsapi.BatchExitInput, len(proofs))
+
+	for i, proof := range proofs {
+		exitInput, exitEvent, err := decodeExitProof(proof)
+		require.NoError(t, err)
+		require.Equal(t, exitEvents[i].ID, exitEvent.ID)
+		require.Equal(t, exitEvents[i].Data, exitEvent.Data)
+		require.Equal(t, uint64(i), exitInput.LeafIndex.Uint64())
+		require.Equal(t, uint64(10), exitInput.BlockNumber.Uint64())
+
+		exitInputs[i] = exitInput
+	}
+
+	sender := ethgo.Address{0x1}
+
+	txn, err := createBatchExitTxn(sender, exitInputs)
+	require.NoError(t, err)
+	require.Equal(t, sender, txn.From)
+	require.Equal(t, ethgo.Address(exitHelperAddr), *txn.To)
+
+	var batchExitFn contractsapi.BatchExitExitHelperFn
+
+	require.NoError(t, batchExitFn.DecodeAbi(txn.Input))
+	require.Len(t, batchExitFn.Inputs, len(exitInputs))
+
+	for i, input := range batchExitFn.Inputs {
+		require.Equal(t, exitInputs[i].BlockNumber.Uint64(), input.BlockNumber.Uint64())
+		require.Equal(t, exitInputs[i].LeafIndex.Uint64(), input.LeafIndex.Uint64())
+		require.Equal(t, exitInputs[i].UnhashedLeaf, input.UnhashedLeaf)
+		require.Equal(t, exitInputs[i].Proof, input.Proof)
+	}
+}
+
+func TestBatchExit_DecodeExitProof_InvalidMetadata(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		metadata map[string]interface{}
+		err      string
+	}{
+		{
+			metadata: map[string]interface{}{},
+			err:      "failed to convert proof leaf index",
+		},
+		{
+			metadata: map[string]interface{}{"LeafIndex": float64(1)},
+			err:      "failed to convert proof checkpoint block",
+		},
+		{
+			metadata: map[string]interface{}{"LeafIndex": float64(1), "CheckpointBlock": float64(1)},
+			err:      "failed to convert exit event",
+		},
+		{
+			metadata: map[string]interface{}{"LeafIndex": float64(1), "CheckpointBlock": float64(1), "ExitEvent": "zz"},
+			err:      "failed to decode hex-encoded exit event",
+		},
+	}
+
+	for _, c := range cases {
+		_, _, err := decodeExitProof(types.Proof{Metadata: c.metadata})
+		require.ErrorContains(t, err, c.err)
+	}
+}
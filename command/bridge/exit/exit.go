@@ -33,6 +33,8 @@ const (
 )
 
 type exitParams struct {
+	common.ResubmitParams
+
 	senderKey         string
 	exitHelperAddrRaw string
 	exitID            uint64
@@ -49,9 +51,10 @@ var (
 // GetCommand returns the bridge exit command
 func GetCommand() *cobra.Command {
 	exitCmd := &cobra.Command{
-		Use:   "exit",
-		Short: "Sends exit transaction to the Exit helper contract on the root chain",
-		Run:   run,
+		Use:     "exit",
+		Short:   "Sends exit transaction to the Exit helper contract on the root chain",
+		PreRunE: preRun,
+		Run:     run,
 	}
 
 	exitCmd.Flags().StringVar(
@@ -96,12 +99,18 @@ func GetCommand() *cobra.Command {
 		"test indicates whether exit transaction sender is hardcoded test account",
 	)
 
+	ep.RegisterResubmitFlags(exitCmd)
+
 	_ = exitCmd.MarkFlagRequired(exitHelperFlag)
 	exitCmd.MarkFlagsMutuallyExclusive(helper.TestModeFlag, common.SenderKeyFlag)
 
 	return exitCmd
 }
 
+func preRun(_ *cobra.Command, _ []string) error {
+	return ep.Validate()
+}
+
 func run(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
@@ -113,7 +122,7 @@ func run(cmd *cobra.Command, _ []string) {
 		return
 	}
 
-	rootTxRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(ep.rootJSONRPCAddr))
+	rootTxRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(ep.rootJSONRPCAddr), ep.ResubmitOption())
 	if err != nil {
 		outputter.SetError(fmt.Errorf("could not create root chain tx relayer: %w", err))
 
@@ -169,6 +178,32 @@ func run(cmd *cobra.Command, _ []string) {
 // createExitTxn encodes parameters for exit function on root chain ExitHelper contract
 func createExitTxn(sender ethgo.Address, proof types.Proof) (*ethgo.Transaction,
 	*contractsapi.L2StateSyncedEvent, error) {
+	exitInput, exitEvent, err := decodeExitProof(proof)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exitFn := &contractsapi.ExitExitHelperFn{
+		BlockNumber:  exitInput.BlockNumber,
+		LeafIndex:    exitInput.LeafIndex,
+		UnhashedLeaf: exitInput.UnhashedLeaf,
+		Proof:        exitInput.Proof,
+	}
+
+	input, err := exitFn.EncodeAbi()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode provided parameters: %w", err)
+	}
+
+	exitHelperAddr := ethgo.Address(types.StringToAddress(ep.exitHelperAddrRaw))
+	txn := helper.CreateTransaction(sender, &exitHelperAddr, input, nil, true)
+	txn.Gas = txrelayer.DefaultGasLimit
+
+	return txn, exitEvent, err
+}
+
+// decodeExitProof decodes the exit function parameters and the exit event from the provided exit proof
+func decodeExitProof(proof types.Proof) (*contractsapi.BatchExitInput, *contractsapi.L2StateSyncedEvent, error) {
 	leafIndex, ok := proof.Metadata["LeafIndex"].(float64)
 	if !ok {
 		return nil, nil, errors.New("failed to convert proof leaf index")
@@ -194,23 +229,12 @@ func createExitTxn(sender ethgo.Address, proof types.Proof) (*ethgo.Transaction,
 		return nil, nil, fmt.Errorf("failed to decode exit event: %w", err)
 	}
 
-	exitFn := &contractsapi.ExitExitHelperFn{
+	return &contractsapi.BatchExitInput{
 		BlockNumber:  new(big.Int).SetUint64(uint64(checkpointBlock)),
 		LeafIndex:    new(big.Int).SetUint64(uint64(leafIndex)),
 		UnhashedLeaf: exitEventEncoded,
 		Proof:        proof.Data,
-	}
-
-	input, err := exitFn.EncodeAbi()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to encode provided parameters: %w", err)
-	}
-
-	exitHelperAddr := ethgo.Address(types.StringToAddress(ep.exitHelperAddrRaw))
-	txn := helper.CreateTransaction(sender, &exitHelperAddr, input, nil, true)
-	txn.Gas = txrelayer.DefaultGasLimit
-
-	return txn, exitEvent, err
+	}, exitEvent, nil
 }
 
 type exitResult struct {
@@ -0,0 +1,62 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	depositIDFlag = "deposit-id"
+	exitIDFlag    = "exit-id"
+	depositTxFlag = "deposit-tx"
+	exitTxFlag    = "exit-tx"
+)
+
+var (
+	errNoTransferProvided = errors.New("exactly one of the deposit-id, exit-id, deposit-tx or exit-tx flags must be set")
+)
+
+type statusParams struct {
+	depositID uint64
+	exitID    uint64
+	depositTx string
+	exitTx    string
+	jsonRPC   string
+}
+
+func (sp *statusParams) validateFlags(cmd *cobra.Command) error {
+	if _, err := helper.ParseJSONRPCAddress(sp.jsonRPC); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	setFlags := 0
+
+	for _, flag := range []string{depositIDFlag, exitIDFlag, depositTxFlag, exitTxFlag} {
+		if cmd.Flags().Changed(flag) {
+			setFlags++
+		}
+	}
+
+	if setFlags != 1 {
+		return errNoTransferProvided
+	}
+
+	for _, txHash := range []string{sp.depositTx, sp.exitTx} {
+		if txHash == "" {
+			continue
+		}
+
+		raw, err := hex.DecodeHex(txHash)
+		if err != nil || len(raw) != types.HashLength {
+			return fmt.Errorf("invalid transaction hash %s", txHash)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type statusResult struct {
+	Deposits []*types.DepositStatus `json:"deposits,omitempty"`
+	Exits    []*types.ExitStatus    `json:"exits,omitempty"`
+}
+
+func (r *statusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	if len(r.Deposits) == 0 && len(r.Exits) == 0 {
+		buffer.WriteString("\n[BRIDGE TRANSFER STATUS]\n")
+		buffer.WriteString("No bridge transfers found\n")
+
+		return buffer.String()
+	}
+
+	for _, deposit := range r.Deposits {
+		vals := []string{
+			fmt.Sprintf("State Sync ID|%d", deposit.StateSyncID),
+			fmt.Sprintf("Status|%s", deposit.Status),
+		}
+
+		vals = append(vals, formatParties(deposit.Sender, deposit.Receiver)...)
+
+		if deposit.CommitmentStartID != nil && deposit.CommitmentEndID != nil {
+			vals = append(vals, fmt.Sprintf("Commitment|%d - %d",
+				*deposit.CommitmentStartID, *deposit.CommitmentEndID))
+		}
+
+		buffer.WriteString("\n[DEPOSIT STATUS]\n")
+		buffer.WriteString(helper.FormatKV(vals))
+		buffer.WriteString("\n")
+	}
+
+	for _, exit := range r.Exits {
+		vals := []string{
+			fmt.Sprintf("Exit ID|%d", exit.ExitID),
+			fmt.Sprintf("Status|%s", exit.Status),
+		}
+
+		vals = append(vals, formatParties(exit.Sender, exit.Receiver)...)
+
+		if exit.Status != types.BridgeTransferUnknown {
+			vals = append(vals,
+				fmt.Sprintf("Epoch|%d", exit.EpochNumber),
+				fmt.Sprintf("Block|%d", exit.BlockNumber),
+				fmt.Sprintf("Checkpoint Block|%d", exit.CheckpointBlock))
+		}
+
+		buffer.WriteString("\n[EXIT STATUS]\n")
+		buffer.WriteString(helper.FormatKV(vals))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
+
+// formatParties formats sender and receiver of the bridge transfer (if known)
+func formatParties(sender, receiver *types.Address) []string {
+	var vals []string
+
+	if sender != nil {
+		vals = append(vals, fmt.Sprintf("Sender|%s", sender))
+	}
+
+	if receiver != nil {
+		vals = append(vals, fmt.Sprintf("Receiver|%s", receiver))
+	}
+
+	return vals
+}
@@ -0,0 +1,117 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// JSON RPC endpoints which provide the bridge transfer statuses
+	getDepositStatusFn         = "bridge_getDepositStatus"
+	getDepositStatusByTxHashFn = "bridge_getDepositStatusByTxHash"
+	getExitStatusFn            = "bridge_getExitStatus"
+	getExitStatusByTxHashFn    = "bridge_getExitStatusByTxHash"
+)
+
+var (
+	params = &statusParams{}
+)
+
+// GetCommand returns the bridge status command
+func GetCommand() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:     "status",
+		Short:   "Queries the child chain node for the status of the given deposit or exit",
+		PreRunE: preRunCommand,
+		Run:     runCommand,
+	}
+
+	statusCmd.Flags().Uint64Var(
+		&params.depositID,
+		depositIDFlag,
+		0,
+		"state sync id of the deposit",
+	)
+
+	statusCmd.Flags().Uint64Var(
+		&params.exitID,
+		exitIDFlag,
+		0,
+		"child chain exit event id",
+	)
+
+	statusCmd.Flags().StringVar(
+		&params.depositTx,
+		depositTxFlag,
+		"",
+		"hash of the root chain deposit transaction",
+	)
+
+	statusCmd.Flags().StringVar(
+		&params.exitTx,
+		exitTxFlag,
+		"",
+		"hash of the child chain withdraw transaction",
+	)
+
+	statusCmd.Flags().StringVar(
+		&params.jsonRPC,
+		common.JSONRPCFlag,
+		txrelayer.DefaultRPCAddress,
+		"the JSON RPC child chain endpoint",
+	)
+
+	statusCmd.MarkFlagsMutuallyExclusive(depositIDFlag, exitIDFlag, depositTxFlag, exitTxFlag)
+
+	return statusCmd
+}
+
+func preRunCommand(cmd *cobra.Command, _ []string) error {
+	return params.validateFlags(cmd)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	client, err := jsonrpc.NewClient(params.jsonRPC)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("could not create child chain JSON RPC client: %w", err))
+
+		return
+	}
+
+	result := &statusResult{}
+
+	switch {
+	case cmd.Flags().Changed(depositIDFlag):
+		var status *types.DepositStatus
+
+		err = client.Call(getDepositStatusFn, &status, fmt.Sprintf("0x%x", params.depositID))
+		result.Deposits = []*types.DepositStatus{status}
+	case cmd.Flags().Changed(depositTxFlag):
+		err = client.Call(getDepositStatusByTxHashFn, &result.Deposits, params.depositTx)
+	case cmd.Flags().Changed(exitIDFlag):
+		var status *types.ExitStatus
+
+		err = client.Call(getExitStatusFn, &status, fmt.Sprintf("0x%x", params.exitID))
+		result.Exits = []*types.ExitStatus{status}
+	default:
+		err = client.Call(getExitStatusByTxHashFn, &result.Exits, params.exitTx)
+	}
+
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to get bridge transfer status: %w", err))
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
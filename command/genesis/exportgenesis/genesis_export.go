@@ -0,0 +1,64 @@
+package exportgenesis
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	genesisExportCmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Exports the genesis configuration file in another client's format",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(genesisExportCmd)
+	helper.SetRequiredFlags(genesisExportCmd, params.getRequiredFlags())
+
+	return genesisExportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to export",
+	)
+
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		gethFormat,
+		fmt.Sprintf("the format of the exported genesis file. Supported formats: %s", gethFormat),
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the path of the exported genesis file",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.exportGenesis(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
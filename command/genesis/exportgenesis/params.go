@@ -0,0 +1,84 @@
+package exportgenesis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	chainFlag  = "chain"
+	formatFlag = "format"
+	outFlag    = "out"
+
+	gethFormat = "geth"
+)
+
+var (
+	params = &exportParams{}
+)
+
+type exportParams struct {
+	genesisPath string
+	format      string
+	out         string
+
+	omitted []string
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
+
+func (p *exportParams) validateFlags() error {
+	if p.format != gethFormat {
+		return fmt.Errorf("unsupported genesis format %s", p.format)
+	}
+
+	if _, err := os.Stat(p.out); err == nil {
+		return fmt.Errorf("genesis file at path (%s) already exists", p.out)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat (%s): %w", p.out, err)
+	}
+
+	return nil
+}
+
+func (p *exportParams) exportGenesis() error {
+	chainConfig, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chain config from %s: %w", p.genesisPath, err)
+	}
+
+	gethGenesis, omitted, err := chain.NewGethGenesis(chainConfig)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(gethGenesis, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to generate genesis: %w", err)
+	}
+
+	if err := common.SaveFileSafe(p.out, data, 0660); err != nil {
+		return fmt.Errorf("failed to write genesis: %w", err)
+	}
+
+	p.omitted = omitted
+
+	return nil
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &GenesisExportResult{
+		Path:    p.out,
+		Format:  p.format,
+		Omitted: p.omitted,
+	}
+}
@@ -0,0 +1,34 @@
+package exportgenesis
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type GenesisExportResult struct {
+	Path    string   `json:"path"`
+	Format  string   `json:"format"`
+	Omitted []string `json:"omitted"`
+}
+
+func (r *GenesisExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GENESIS EXPORT]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Genesis|%s", r.Path),
+		fmt.Sprintf("Format|%s", r.Format),
+	}
+
+	for _, omitted := range r.Omitted {
+		outputs = append(outputs, fmt.Sprintf("Omitted|%s", omitted))
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/genesis/exportgenesis"
+	"github.com/0xPolygon/polygon-edge/command/genesis/importgenesis"
 	"github.com/0xPolygon/polygon-edge/command/genesis/predeploy"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/spf13/cobra"
@@ -26,6 +29,10 @@ func GetCommand() *cobra.Command {
 	genesisCmd.AddCommand(
 		// genesis predeploy
 		predeploy.GetCommand(),
+		// genesis import
+		importgenesis.GetCommand(),
+		// genesis export
+		exportgenesis.GetCommand(),
 	)
 
 	return genesisCmd
@@ -254,6 +261,48 @@ func setFlags(cmd *cobra.Command) {
 			"configuration for block time drift value (in seconds)",
 		)
 
+		cmd.Flags().StringVar(
+			&params.proposerSelection,
+			proposerSelectionFlag,
+			string(polybft.WeightedRoundRobinProposerSelection),
+			fmt.Sprintf("block proposer selection strategy (%s, %s or %s)",
+				polybft.WeightedRoundRobinProposerSelection,
+				polybft.EqualRotationProposerSelection,
+				polybft.RandomizedProposerSelection),
+		)
+
+		cmd.Flags().StringVar(
+			&params.txOrdering,
+			txOrderingFlag,
+			string(polybft.PriceTxOrdering),
+			fmt.Sprintf("block transactions ordering policy (%s, %s or %s)",
+				polybft.PriceTxOrdering,
+				polybft.FIFOTxOrdering,
+				polybft.PriorityLanesTxOrdering),
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.priorityLaneSenders,
+			priorityLaneSenderFlag,
+			[]string{},
+			fmt.Sprintf("address of the sender whose transactions are prioritized by the %s ordering policy",
+				polybft.PriorityLanesTxOrdering),
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.priorityLaneGasShare,
+			priorityLaneGasShareFlag,
+			defaultPriorityLaneGasShare,
+			"share (percentage) of the block gas limit reserved for the priority lane senders",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.parallelExecution,
+			parallelExecutionFlag,
+			false,
+			"execute the block transactions in parallel while building the block",
+		)
+
 		cmd.Flags().DurationVar(
 			&params.blockTrackerPollInterval,
 			blockTrackerPollIntervalFlag,
@@ -347,6 +396,34 @@ func setFlags(cmd *cobra.Command) {
 			[]string{},
 			"list of addresses to enable by default in the bridge block list",
 		)
+
+		cmd.Flags().StringArrayVar(
+			&params.callPolicyAdmin,
+			callPolicyAdminFlag,
+			[]string{},
+			"list of addresses to use as admin accounts in the call policy",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.callPolicyManager,
+			callPolicyManagerFlag,
+			[]string{},
+			"list of addresses to use as manager accounts in the call policy, allowed to grant call permissions",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.sponsorAllowListAdmin,
+			sponsorAllowListAdminFlag,
+			[]string{},
+			"list of addresses to use as admin accounts in the sponsor allow list",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.sponsorAllowListEnabled,
+			sponsorAllowListEnabledFlag,
+			[]string{},
+			"list of addresses to enable by default in the sponsor allow list, allowed to pay for sponsored transactions",
+		)
 	}
 }
 
@@ -0,0 +1,83 @@
+package importgenesis
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server"
+)
+
+func GetCommand() *cobra.Command {
+	genesisImportCmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Generates the genesis configuration file out of the genesis in another client's format",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(genesisImportCmd)
+	helper.SetRequiredFlags(genesisImportCmd, params.getRequiredFlags())
+
+	return genesisImportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the genesis file to import",
+	)
+
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		gethFormat,
+		fmt.Sprintf("the format of the imported genesis file. Supported formats: %s", gethFormat),
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		dirFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the path of the generated Polygon Edge genesis file",
+	)
+
+	cmd.Flags().StringVar(
+		&params.name,
+		nameFlag,
+		command.DefaultChainName,
+		"the name for the chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.consensusRaw,
+		consensusFlag,
+		string(server.DevConsensus),
+		fmt.Sprintf(
+			"the consensus protocol to be used, which doesn't keep its validators in the genesis (%s, %s)",
+			server.DevConsensus,
+			server.DummyConsensus,
+		),
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.importGenesis(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,114 @@
+package importgenesis
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server"
+)
+
+const (
+	fileFlag      = "file"
+	formatFlag    = "format"
+	dirFlag       = "dir"
+	nameFlag      = "name"
+	consensusFlag = "consensus"
+
+	gethFormat = "geth"
+)
+
+var (
+	params = &importParams{}
+)
+
+type importParams struct {
+	file         string
+	format       string
+	genesisPath  string
+	name         string
+	consensusRaw string
+
+	genesisConfig *chain.Chain
+	ignored       map[string]string
+}
+
+func (p *importParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+func (p *importParams) validateFlags() error {
+	if p.format != gethFormat {
+		return fmt.Errorf("unsupported genesis format %s", p.format)
+	}
+
+	switch server.ConsensusType(p.consensusRaw) {
+	case server.DevConsensus, server.DummyConsensus:
+	default:
+		return fmt.Errorf(
+			"the %s consensus can't be imported, as its validators are set up by the genesis command",
+			p.consensusRaw,
+		)
+	}
+
+	if _, err := os.Stat(p.genesisPath); err == nil {
+		return fmt.Errorf("genesis file at path (%s) already exists", p.genesisPath)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat (%s): %w", p.genesisPath, err)
+	}
+
+	return nil
+}
+
+func (p *importParams) importGenesis() error {
+	gethGenesis, err := chain.ImportGethFromFile(p.file)
+	if err != nil {
+		return fmt.Errorf("failed to load geth genesis from %s: %w", p.file, err)
+	}
+
+	chainConfig, ignored, err := gethGenesis.ToChain(p.name)
+	if err != nil {
+		return err
+	}
+
+	chainConfig.Params.Engine = map[string]interface{}{
+		p.consensusRaw: map[string]interface{}{},
+	}
+
+	if err := helper.WriteGenesisConfigToDisk(chainConfig, p.genesisPath); err != nil {
+		return err
+	}
+
+	p.genesisConfig = chainConfig
+	p.ignored = ignored
+
+	return nil
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	forks := make([]string, 0, len(*p.genesisConfig.Params.Forks))
+	for name, fork := range *p.genesisConfig.Params.Forks {
+		forks = append(forks, fmt.Sprintf("%s: %d", name, fork.Block))
+	}
+
+	ignored := make([]string, 0, len(p.ignored))
+	for field, reason := range p.ignored {
+		ignored = append(ignored, fmt.Sprintf("%s: %s", field, reason))
+	}
+
+	sort.Strings(forks)
+	sort.Strings(ignored)
+
+	return &GenesisImportResult{
+		Path:     p.genesisPath,
+		ChainID:  p.genesisConfig.Params.ChainID,
+		Accounts: len(p.genesisConfig.Genesis.Alloc),
+		Forks:    forks,
+		Ignored:  ignored,
+	}
+}
@@ -0,0 +1,41 @@
+package importgenesis
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type GenesisImportResult struct {
+	Path     string   `json:"path"`
+	ChainID  int64    `json:"chainID"`
+	Accounts int      `json:"accounts"`
+	Forks    []string `json:"forks"`
+	Ignored  []string `json:"ignored"`
+}
+
+func (r *GenesisImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GENESIS IMPORT]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Genesis|%s", r.Path),
+		fmt.Sprintf("Chain ID|%d", r.ChainID),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+	}
+
+	for _, fork := range r.Forks {
+		outputs = append(outputs, fmt.Sprintf("Fork|%s", fork))
+	}
+
+	for _, ignored := range r.Ignored {
+		outputs = append(outputs, fmt.Sprintf("Ignored|%s", ignored))
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
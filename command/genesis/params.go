@@ -115,6 +115,11 @@ type genesisParams struct {
 	blockTime            time.Duration
 	epochReward          uint64
 	blockTimeDrift       uint64
+	proposerSelection    string
+	txOrdering           string
+	priorityLaneSenders  []string
+	priorityLaneGasShare uint64
+	parallelExecution    bool
 
 	initialStateRoot string
 
@@ -131,6 +136,10 @@ type genesisParams struct {
 	bridgeAllowListEnabled           []string
 	bridgeBlockListAdmin             []string
 	bridgeBlockListEnabled           []string
+	callPolicyAdmin                  []string
+	callPolicyManager                []string
+	sponsorAllowListAdmin            []string
+	sponsorAllowListEnabled          []string
 
 	nativeTokenConfigRaw string
 	nativeTokenConfig    *polybft.TokenConfig
@@ -187,6 +196,14 @@ func (p *genesisParams) validateFlags() error {
 		if err := p.validateProxyContractsAdmin(); err != nil {
 			return err
 		}
+
+		if err := polybft.ProposerSelectionStrategy(p.proposerSelection).Validate(); err != nil {
+			return err
+		}
+
+		if err := p.validatePriorityLanes(); err != nil {
+			return err
+		}
 	}
 
 	// Check if the genesis file already exists
@@ -612,6 +629,23 @@ func (p *genesisParams) validateProxyContractsAdmin() error {
 	return nil
 }
 
+// validatePriorityLanes validates the tx ordering strategy and the priority lanes configuration
+func (p *genesisParams) validatePriorityLanes() error {
+	if err := polybft.TxOrderingStrategy(p.txOrdering).Validate(); err != nil {
+		return err
+	}
+
+	for _, sender := range p.priorityLaneSenders {
+		if err := types.IsValidAddress(sender); err != nil {
+			return fmt.Errorf("invalid priority lane sender %s: %w", sender, err)
+		}
+	}
+
+	lanesConfig := &polybft.PriorityLanesConfig{ReservedGasShare: p.priorityLaneGasShare}
+
+	return lanesConfig.Validate()
+}
+
 // isBurnContractEnabled returns true in case burn contract info is provided
 func (p *genesisParams) isBurnContractEnabled() bool {
 	return p.burnContract != ""
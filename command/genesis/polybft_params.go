@@ -35,7 +35,12 @@ const (
 	blockTimeFlag  = "block-time"
 	trieRootFlag   = "trieroot"
 
-	blockTimeDriftFlag = "block-time-drift"
+	blockTimeDriftFlag       = "block-time-drift"
+	proposerSelectionFlag    = "proposer-selection"
+	txOrderingFlag           = "tx-ordering"
+	priorityLaneSenderFlag   = "priority-lane-sender"
+	priorityLaneGasShareFlag = "priority-lane-gas-share"
+	parallelExecutionFlag    = "parallel-execution"
 
 	defaultEpochSize                = uint64(10)
 	defaultSprintSize               = uint64(5)
@@ -44,6 +49,7 @@ const (
 	defaultEpochReward              = 1
 	defaultBlockTimeDrift           = uint64(10)
 	defaultBlockTrackerPollInterval = time.Second
+	defaultPriorityLaneGasShare     = uint64(20)
 
 	contractDeployerAllowListAdminFlag   = "contract-deployer-allow-list-admin"
 	contractDeployerAllowListEnabledFlag = "contract-deployer-allow-list-enabled"
@@ -57,6 +63,10 @@ const (
 	bridgeAllowListEnabledFlag           = "bridge-allow-list-enabled"
 	bridgeBlockListAdminFlag             = "bridge-block-list-admin"
 	bridgeBlockListEnabledFlag           = "bridge-block-list-enabled"
+	callPolicyAdminFlag                  = "call-policy-admin"
+	callPolicyManagerFlag                = "call-policy-manager"
+	sponsorAllowListAdminFlag            = "sponsor-allow-list-admin"
+	sponsorAllowListEnabledFlag          = "sponsor-allow-list-enabled"
 
 	bootnodePortStart = 30301
 
@@ -159,6 +169,21 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 		BlockTimeDrift:           p.blockTimeDrift,
 		BlockTrackerPollInterval: common.Duration{Duration: p.blockTrackerPollInterval},
 		ProxyContractsAdmin:      types.StringToAddress(p.proxyContractsAdmin),
+		ProposerSelection:        polybft.ProposerSelectionStrategy(p.proposerSelection),
+		TxOrdering:               polybft.TxOrderingStrategy(p.txOrdering),
+		ParallelExecution:        p.parallelExecution,
+	}
+
+	if len(p.priorityLaneSenders) > 0 {
+		senders := make([]types.Address, len(p.priorityLaneSenders))
+		for i, sender := range p.priorityLaneSenders {
+			senders[i] = types.StringToAddress(sender)
+		}
+
+		polyBftConfig.PriorityLanes = &polybft.PriorityLanesConfig{
+			Senders:          senders,
+			ReservedGasShare: p.priorityLaneGasShare,
+		}
 	}
 
 	// Disable london hardfork if burn contract address is not provided
@@ -304,6 +329,24 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 		}
 	}
 
+	if len(p.callPolicyAdmin) != 0 {
+		// only enable call policy if there is at least one address as **admin**, otherwise
+		// the call policy could never be updated
+		chainConfig.Params.CallPolicy = &chain.CallPolicyConfig{
+			AdminAddresses:   stringSliceToAddressSlice(p.callPolicyAdmin),
+			ManagerAddresses: stringSliceToAddressSlice(p.callPolicyManager),
+		}
+	}
+
+	if len(p.sponsorAllowListAdmin) != 0 {
+		// only enable allow list if there is at least one address as **admin**, otherwise
+		// the allow list could never be updated
+		chainConfig.Params.SponsorAllowList = &chain.AddressListConfig{
+			AdminAddresses:   stringSliceToAddressSlice(p.sponsorAllowListAdmin),
+			EnabledAddresses: stringSliceToAddressSlice(p.sponsorAllowListEnabled),
+		}
+	}
+
 	if p.isBurnContractEnabled() {
 		// only populate base fee and base fee multiplier values if burn contract(s)
 		// is provided
@@ -0,0 +1,70 @@
+package loglevel
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	logLevelCmd := &cobra.Command{
+		Use: "log-level",
+		Short: "Changes the log level of the running client, globally or for a single subsystem " +
+			"(e.g. network, syncer, txpool, polybft, jsonrpc). Without a level, it returns the current log levels",
+		Args:    cobra.NoArgs,
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(logLevelCmd)
+
+	setFlags(logLevelCmd)
+
+	return logLevelCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.module,
+		moduleFlag,
+		"",
+		"the subsystem whose log level is changed. If omitted, the global log level is changed",
+	)
+
+	cmd.Flags().StringVar(
+		&params.level,
+		levelFlag,
+		"",
+		"the new log level (TRACE, DEBUG, INFO, WARN, ERROR, OFF)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.reset,
+		resetFlag,
+		false,
+		"removes the log level of the subsystem, so the global log level applies to it again",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSystemClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.updateLogLevels(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
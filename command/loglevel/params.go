@@ -0,0 +1,100 @@
+package loglevel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	params = &logLevelParams{}
+)
+
+var (
+	errResetGlobalLevel = errors.New("the global log level can not be reset, the module is required")
+	errResetWithLevel   = errors.New("the level can not be set when resetting the module log level")
+)
+
+const (
+	moduleFlag = "module"
+	levelFlag  = "level"
+	resetFlag  = "reset"
+)
+
+type logLevelParams struct {
+	module string
+	level  string
+	reset  bool
+
+	systemClient proto.SystemClient
+
+	levels *proto.LogLevelsResponse
+}
+
+func (p *logLevelParams) validateFlags() error {
+	if !p.reset {
+		return nil
+	}
+
+	if p.module == "" {
+		return errResetGlobalLevel
+	}
+
+	if p.level != "" {
+		return errResetWithLevel
+	}
+
+	return nil
+}
+
+func (p *logLevelParams) initSystemClient(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.systemClient = systemClient
+
+	return nil
+}
+
+// updateLogLevels changes the log levels of the node if requested,
+// otherwise it only retrieves the current ones
+func (p *logLevelParams) updateLogLevels() error {
+	var (
+		levels *proto.LogLevelsResponse
+		err    error
+	)
+
+	if p.level == "" && !p.reset {
+		levels, err = p.systemClient.GetLogLevels(context.Background(), &empty.Empty{})
+	} else {
+		levels, err = p.systemClient.SetLogLevel(
+			context.Background(),
+			&proto.SetLogLevelRequest{
+				Module:      p.module,
+				Level:       p.level,
+				ResetModule: p.reset,
+			},
+		)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.levels = levels
+
+	return nil
+}
+
+func (p *logLevelParams) getResult() command.CommandResult {
+	return &LogLevelResult{
+		Global:  p.levels.Global,
+		Modules: p.levels.Modules,
+	}
+}
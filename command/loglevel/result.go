@@ -0,0 +1,44 @@
+package loglevel
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type LogLevelResult struct {
+	Global  string            `json:"global"`
+	Modules map[string]string `json:"modules"`
+}
+
+func (r *LogLevelResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[LOG LEVELS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Global|%s", r.Global),
+	}))
+
+	if len(r.Modules) > 0 {
+		modules := make([]string, 0, len(r.Modules))
+		for module := range r.Modules {
+			modules = append(modules, module)
+		}
+
+		sort.Strings(modules)
+
+		rows := make([]string, len(modules))
+		for i, module := range modules {
+			rows[i] = fmt.Sprintf("%s|%s", module, r.Modules[module])
+		}
+
+		buffer.WriteString("\n\n[MODULE LOG LEVELS]\n")
+		buffer.WriteString(helper.FormatKV(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,67 @@
+package checkpoint
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// getCheckpointStatusFn is the JSON RPC endpoint which provides the checkpoint submission status
+	getCheckpointStatusFn = "bridge_getCheckpointStatus"
+)
+
+var (
+	params = &checkpointStatusParams{}
+)
+
+// GetCommand returns the checkpoint status command
+func GetCommand() *cobra.Command {
+	checkpointStatusCmd := &cobra.Command{
+		Use:     "checkpoint-status",
+		Short:   "Queries the child chain node for the status of checkpoint submissions to the root chain",
+		PreRunE: preRunCommand,
+		Run:     runCommand,
+	}
+
+	checkpointStatusCmd.Flags().StringVar(
+		&params.jsonRPC,
+		common.JSONRPCFlag,
+		txrelayer.DefaultRPCAddress,
+		"the JSON RPC child chain endpoint",
+	)
+
+	return checkpointStatusCmd
+}
+
+func preRunCommand(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	client, err := jsonrpc.NewClient(params.jsonRPC)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("could not create child chain JSON RPC client: %w", err))
+
+		return
+	}
+
+	var status *types.CheckpointStatus
+
+	if err := client.Call(getCheckpointStatusFn, &status); err != nil {
+		outputter.SetError(fmt.Errorf("failed to get checkpoint status: %w", err))
+
+		return
+	}
+
+	outputter.SetCommandResult(&checkpointStatusResult{CheckpointStatus: status})
+}
@@ -0,0 +1,19 @@
+package checkpoint
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type checkpointStatusParams struct {
+	jsonRPC string
+}
+
+func (cp *checkpointStatusParams) validateFlags() error {
+	if _, err := helper.ParseJSONRPCAddress(cp.jsonRPC); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	return nil
+}
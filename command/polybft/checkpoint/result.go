@@ -0,0 +1,46 @@
+package checkpoint
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type checkpointStatusResult struct {
+	*types.CheckpointStatus
+}
+
+func (r *checkpointStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Child Chain Head|%d", r.ChildChainHead),
+		fmt.Sprintf("Last Checkpointed Block|%d", r.LastCheckpointedBlock),
+		fmt.Sprintf("Lag (blocks)|%d", r.Lag),
+		fmt.Sprintf("Catching Up|%t", r.CatchingUp),
+		fmt.Sprintf("Pending Checkpoints|%d", r.PendingCheckpoints),
+	}
+
+	buffer.WriteString("\n[CHECKPOINT STATUS]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	if r.LastSubmission != nil {
+		vals = []string{
+			fmt.Sprintf("Block|%d", r.LastSubmission.BlockNumber),
+			fmt.Sprintf("Epoch|%d", r.LastSubmission.EpochNumber),
+			fmt.Sprintf("Transaction Hash|%s", r.LastSubmission.TxHash),
+			fmt.Sprintf("Submitted At|%s",
+				time.Unix(int64(r.LastSubmission.Timestamp), 0).UTC().Format(time.RFC3339)),
+		}
+
+		buffer.WriteString("\n[LAST CHECKPOINT SUBMITTED BY THE NODE]\n")
+		buffer.WriteString(helper.FormatKV(vals))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
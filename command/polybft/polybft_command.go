@@ -1,6 +1,8 @@
 package polybft
 
 import (
+	"github.com/0xPolygon/polygon-edge/command/polybft/checkpoint"
+	"github.com/0xPolygon/polygon-edge/command/polybft/uptime"
 	"github.com/0xPolygon/polygon-edge/command/rootchain/registration"
 	"github.com/0xPolygon/polygon-edge/command/rootchain/staking"
 	"github.com/0xPolygon/polygon-edge/command/rootchain/supernet"
@@ -42,6 +44,10 @@ func GetCommand() *cobra.Command {
 		supernet.GetCommand(),
 		// rootchain command for deploying stake manager
 		stakemanager.GetCommand(),
+		// child chain command that queries the status of checkpoint submissions
+		checkpoint.GetCommand(),
+		// child chain command that queries the validators uptime
+		uptime.GetCommand(),
 	)
 
 	return polybftCmd
@@ -0,0 +1,24 @@
+package uptime
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+const (
+	epochFlag = "epoch"
+)
+
+type uptimeParams struct {
+	epoch   uint64
+	jsonRPC string
+}
+
+func (up *uptimeParams) validateFlags() error {
+	if _, err := helper.ParseJSONRPCAddress(up.jsonRPC); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	return nil
+}
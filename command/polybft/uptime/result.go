@@ -0,0 +1,37 @@
+package uptime
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type uptimeResult struct {
+	*types.EpochUptime
+}
+
+func (r *uptimeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR UPTIME]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Epoch|%d", r.Epoch),
+		fmt.Sprintf("Accounted Blocks|%d", r.TotalBlocks),
+		fmt.Sprintf("Last Accounted Block|%d", r.LastBlock),
+	}))
+	buffer.WriteString("\n\n")
+
+	rows := make([]string, 0, len(r.Validators)+1)
+	rows = append(rows, "Validator|Signed Blocks|Missed Blocks|Uptime")
+
+	for _, v := range r.Validators {
+		rows = append(rows, fmt.Sprintf("%s|%d|%d|%.2f%%", v.Address, v.SignedBlocks, v.MissedBlocks, v.Uptime))
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,81 @@
+package uptime
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/bridge/common"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// getValidatorUptimeFn is the JSON RPC endpoint which provides the validators uptime
+	getValidatorUptimeFn = "polybft_getValidatorUptime"
+)
+
+var (
+	params = &uptimeParams{}
+)
+
+// GetCommand returns the validator uptime command
+func GetCommand() *cobra.Command {
+	uptimeCmd := &cobra.Command{
+		Use:     "validator-uptime",
+		Short:   "Queries the child chain node for the number of blocks sealed by each validator during an epoch",
+		PreRunE: preRunCommand,
+		Run:     runCommand,
+	}
+
+	uptimeCmd.Flags().Uint64Var(
+		&params.epoch,
+		epochFlag,
+		0,
+		"epoch number (current epoch if not provided)",
+	)
+
+	uptimeCmd.Flags().StringVar(
+		&params.jsonRPC,
+		common.JSONRPCFlag,
+		txrelayer.DefaultRPCAddress,
+		"the JSON RPC child chain endpoint",
+	)
+
+	return uptimeCmd
+}
+
+func preRunCommand(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	client, err := jsonrpc.NewClient(params.jsonRPC)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("could not create child chain JSON RPC client: %w", err))
+
+		return
+	}
+
+	var (
+		uptime *types.EpochUptime
+		args   []interface{}
+	)
+
+	if cmd.Flags().Changed(epochFlag) {
+		args = append(args, fmt.Sprintf("0x%x", params.epoch))
+	}
+
+	if err := client.Call(getValidatorUptimeFn, &uptime, args...); err != nil {
+		outputter.SetError(fmt.Errorf("failed to get validator uptime: %w", err))
+
+		return
+	}
+
+	outputter.SetCommandResult(&uptimeResult{EpochUptime: uptime})
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/rootchain/helper"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/network"
+	edgeServer "github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// embeddedChainID is the chain id of the embedded rootchain (the same one geth uses in the dev mode)
+	embeddedChainID = 1337
+
+	// embeddedBlockTime is the block time (in seconds) of the embedded rootchain
+	embeddedBlockTime = uint64(2)
+
+	// embeddedBlockGasLimit is the block gas limit of the embedded rootchain
+	embeddedBlockGasLimit = uint64(30_000_000)
+)
+
+var (
+	// embeddedPremineAmount is the balance of the pre-funded rootchain account
+	embeddedPremineAmount = new(big.Int).Mul(ethgo.Ether(1), big.NewInt(1_000_000_000))
+)
+
+// runEmbeddedCommand runs the rootchain as an in-process polygon-edge chain, sealed by the dev consensus
+func runEmbeddedCommand(cmd *cobra.Command) {
+	outputter := command.InitializeOutputter(cmd)
+
+	closeCh := make(chan struct{})
+
+	srv, err := runEmbeddedRootchain()
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to run embedded rootchain: %w", err))
+		outputter.WriteOutput()
+
+		return
+	}
+
+	// Ping JSON-RPC server to make sure everything is up and running
+	if err := PingServer(closeCh); err != nil {
+		srv.Close()
+
+		outputter.SetError(fmt.Errorf("failed to ping embedded rootchain server: %w", err))
+		outputter.WriteOutput()
+
+		return
+	}
+
+	if err := cmdHelper.HandleSignals(srv.Close, outputter); err != nil {
+		outputter.SetError(fmt.Errorf("failed to handle signals: %w", err))
+		outputter.WriteOutput()
+	}
+}
+
+// runEmbeddedRootchain starts the embedded rootchain server.
+// The test account is pre-funded and unlocked, meaning that it is exposed through eth_accounts
+// and signs eth_sendTransaction calls (the same way the geth dev account does)
+func runEmbeddedRootchain() (*edgeServer.Server, error) {
+	// target directory for the chain
+	if err := common.CreateDirSafe(params.dataDir, 0700); err != nil {
+		return nil, err
+	}
+
+	rawKey, err := hex.DecodeString(helper.TestAccountPrivKey)
+	if err != nil {
+		return nil, err
+	}
+
+	devKey, err := crypto.ParseECDSAPrivateKey(rawKey)
+	if err != nil {
+		return nil, err
+	}
+
+	localhost := net.ParseIP(defaultHostIP)
+
+	genesis := newEmbeddedChain(crypto.PubKeyToAddress(&devKey.PublicKey))
+
+	networkConfig := network.DefaultConfig()
+	networkConfig.NoDiscover = true
+	// libp2p and gRPC listen on random ports, since the embedded rootchain has no peers
+	networkConfig.Addr = &net.TCPAddr{IP: localhost, Port: 0}
+
+	return edgeServer.NewServer(&edgeServer.Config{
+		Chain: genesis,
+		JSONRPC: &edgeServer.JSONRPC{
+			JSONRPCAddr:              &net.TCPAddr{IP: localhost, Port: edgeServer.DefaultJSONRPCPort},
+			AccessControlAllowOrigin: []string{"*"},
+			DevAccounts:              []*ecdsa.PrivateKey{devKey},
+		},
+		GRPCAddr:           &net.TCPAddr{IP: localhost, Port: 0},
+		LibP2PAddr:         networkConfig.Addr,
+		Telemetry:          &edgeServer.Telemetry{},
+		Network:            networkConfig,
+		DataDir:            params.dataDir,
+		Seal:               true,
+		PriceBump:          txpool.DefaultPriceBump,
+		MaxSlots:           4096,
+		MaxAccountEnqueued: 128,
+		LogLevel:           hclog.Info,
+	})
+}
+
+// newEmbeddedChain creates the embedded rootchain configuration, which pre-funds the given account
+func newEmbeddedChain(premineAddr types.Address) *chain.Chain {
+	return &chain.Chain{
+		Name: "rootchain",
+		Genesis: &chain.Genesis{
+			GasLimit:           embeddedBlockGasLimit,
+			Difficulty:         1,
+			BaseFee:            chain.GenesisBaseFee,
+			BaseFeeEM:          chain.GenesisBaseFeeEM,
+			BaseFeeChangeDenom: chain.BaseFeeChangeDenom,
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				premineAddr: {Balance: embeddedPremineAmount},
+			},
+		},
+		Params: &chain.Params{
+			ChainID: embeddedChainID,
+			Forks:   chain.AllForksEnabled,
+			Engine: map[string]interface{}{
+				string(edgeServer.DevConsensus): map[string]interface{}{
+					"interval": embeddedBlockTime,
+				},
+			},
+			BlockGasTarget: embeddedBlockGasLimit,
+			BurnContract:   map[uint64]types.Address{0: types.ZeroAddress},
+		},
+	}
+}
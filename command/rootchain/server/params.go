@@ -3,9 +3,11 @@ package server
 const (
 	dataDirFlag = "data-dir"
 	noConsole   = "no-console"
+	embedded    = "embedded"
 )
 
 type serverParams struct {
 	dataDir   string
 	noConsole bool
+	embedded  bool
 }
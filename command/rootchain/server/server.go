@@ -68,6 +68,15 @@ func setFlags(cmd *cobra.Command) {
 		false,
 		"use the official geth image instead of the console fork",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.embedded,
+		embedded,
+		false,
+		"run the rootchain as an in-process polygon-edge chain (with dev consensus) instead of the geth container",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(embedded, noConsole)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -75,6 +84,12 @@ func runPreRun(_ *cobra.Command, _ []string) error {
 }
 
 func runCommand(cmd *cobra.Command, _ []string) {
+	if params.embedded {
+		runEmbeddedCommand(cmd)
+
+		return
+	}
+
 	ctx := cmd.Context()
 
 	outputter := command.InitializeOutputter(cmd)
@@ -7,31 +7,34 @@ import (
 	"strings"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/hashicorp/hcl"
 	"gopkg.in/yaml.v3"
 )
 
 // Config defines the server configuration params
 type Config struct {
-	GenesisPath              string     `json:"chain_config" yaml:"chain_config"`
-	SecretsConfigPath        string     `json:"secrets_config" yaml:"secrets_config"`
-	DataDir                  string     `json:"data_dir" yaml:"data_dir"`
-	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
-	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
-	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
-	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
-	Network                  *Network   `json:"network" yaml:"network"`
-	ShouldSeal               bool       `json:"seal" yaml:"seal"`
-	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
-	LogLevel                 string     `json:"log_level" yaml:"log_level"`
-	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
-	Headers                  *Headers   `json:"headers" yaml:"headers"`
-	LogFilePath              string     `json:"log_to" yaml:"log_to"`
-	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
-	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
-	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
-	CorsAllowedOrigins       []string   `json:"cors_allowed_origins" yaml:"cors_allowed_origins"`
+	GenesisPath              string            `json:"chain_config" yaml:"chain_config"`
+	SecretsConfigPath        string            `json:"secrets_config" yaml:"secrets_config"`
+	DataDir                  string            `json:"data_dir" yaml:"data_dir"`
+	BlockGasTarget           string            `json:"block_gas_target" yaml:"block_gas_target"`
+	GRPCAddr                 string            `json:"grpc_addr" yaml:"grpc_addr"`
+	JSONRPCAddr              string            `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	Telemetry                *Telemetry        `json:"telemetry" yaml:"telemetry"`
+	Network                  *Network          `json:"network" yaml:"network"`
+	ShouldSeal               bool              `json:"seal" yaml:"seal"`
+	TxPool                   *TxPool           `json:"tx_pool" yaml:"tx_pool"`
+	LogLevel                 string            `json:"log_level" yaml:"log_level"`
+	LogLevels                map[string]string `json:"log_levels" yaml:"log_levels"`
+	RestoreFile              string            `json:"restore_file" yaml:"restore_file"`
+	Headers                  *Headers          `json:"headers" yaml:"headers"`
+	LogFilePath              string            `json:"log_to" yaml:"log_to"`
+	JSONRPCBatchRequestLimit uint64            `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
+	JSONRPCBlockRangeLimit   uint64            `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONLogFormat            bool              `json:"json_log_format" yaml:"json_log_format"`
+	CorsAllowedOrigins       []string          `json:"cors_allowed_origins" yaml:"cors_allowed_origins"`
 
 	Relayer                    bool          `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations      uint64        `json:"num_block_confirmations" yaml:"num_block_confirmations"`
@@ -40,7 +43,16 @@ type Config struct {
 	ConcurrentRequestsDebug uint64 `json:"concurrent_requests_debug" yaml:"concurrent_requests_debug"`
 	WebSocketReadLimit      uint64 `json:"web_socket_read_limit" yaml:"web_socket_read_limit"`
 
+	JSONRPCRateLimit  *RateLimit         `json:"json_rpc_rate_limit" yaml:"json_rpc_rate_limit"`
+	JSONRPCNamespaces *JSONRPCNamespaces `json:"json_rpc_namespaces" yaml:"json_rpc_namespaces"`
+
 	MetricsInterval time.Duration `json:"metrics_interval" yaml:"metrics_interval"`
+
+	DisableFlatState bool `json:"disable_flat_state" yaml:"disable_flat_state"`
+
+	ParallelExecution bool `json:"parallel_execution" yaml:"parallel_execution"`
+
+	GasPriceSuggestion bool `json:"gas_price_suggestion" yaml:"gas_price_suggestion"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -62,10 +74,38 @@ type Network struct {
 // TxPool defines the TxPool configuration params
 type TxPool struct {
 	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
+	PriceBump          uint64 `json:"price_bump" yaml:"price_bump"`
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
 }
 
+// RateLimit defines the per client rate limiting of the JSON-RPC server
+type RateLimit struct {
+	RequestsPerSecond uint64             `json:"requests_per_second" yaml:"requests_per_second"`
+	Burst             uint64             `json:"burst" yaml:"burst"`
+	APIKeyHeader      string             `json:"api_key_header" yaml:"api_key_header"`
+	APIKeys           []*APIKeyRateLimit `json:"api_keys" yaml:"api_keys"`
+	MethodWeights     map[string]uint64  `json:"method_weights" yaml:"method_weights"`
+}
+
+// APIKeyRateLimit defines the rate limit of the clients using the given API key
+type APIKeyRateLimit struct {
+	Key               string `json:"key" yaml:"key"`
+	RequestsPerSecond uint64 `json:"requests_per_second" yaml:"requests_per_second"`
+	Burst             uint64 `json:"burst" yaml:"burst"`
+}
+
+// JSONRPCNamespaces defines the JSON-RPC namespaces enabled per transport, the denied methods,
+// the API keys which authenticate the callers of the admin namespace
+// and the only directory the admin namespace can export the chain to
+type JSONRPCNamespaces struct {
+	HTTP           []string `json:"http" yaml:"http"`
+	WS             []string `json:"ws" yaml:"ws"`
+	DeniedMethods  []string `json:"denied_methods" yaml:"denied_methods"`
+	AdminAPIKeys   []string `json:"admin_api_keys" yaml:"admin_api_keys"`
+	AdminExportDir string   `json:"admin_export_dir" yaml:"admin_export_dir"`
+}
+
 // Headers defines the HTTP response headers required to enable CORS.
 type Headers struct {
 	AccessControlAllowOrigins []string `json:"access_control_allow_origins" yaml:"access_control_allow_origins"`
@@ -102,6 +142,10 @@ const (
 	// DefaultMetricsInterval specifies the time interval after which Prometheus metrics will be generated.
 	// A value of 0 means the metrics are disabled.
 	DefaultMetricsInterval time.Duration = time.Second * 8
+
+	// DefaultJSONRPCRateLimit specifies the number of json-rpc requests per second allowed per client.
+	// A value of 0 means the rate limiting is disabled.
+	DefaultJSONRPCRateLimit uint64 = 0
 )
 
 // DefaultConfig returns the default server configuration
@@ -126,6 +170,7 @@ func DefaultConfig() *Config {
 		ShouldSeal: true,
 		TxPool: &TxPool{
 			PriceLimit:         0,
+			PriceBump:          txpool.DefaultPriceBump,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
 		},
@@ -143,6 +188,16 @@ func DefaultConfig() *Config {
 		WebSocketReadLimit:         DefaultWebSocketReadLimit,
 		RelayerTrackerPollInterval: DefaultRelayerTrackerPollInterval,
 		MetricsInterval:            DefaultMetricsInterval,
+		DisableFlatState:           false,
+		ParallelExecution:          false,
+		GasPriceSuggestion:         false,
+		JSONRPCRateLimit: &RateLimit{
+			RequestsPerSecond: DefaultJSONRPCRateLimit,
+		},
+		JSONRPCNamespaces: &JSONRPCNamespaces{
+			HTTP: jsonrpc.DefaultNamespaces,
+			WS:   jsonrpc.DefaultNamespaces,
+		},
 	}
 }
 
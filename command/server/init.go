@@ -56,6 +56,10 @@ func (p *serverParams) initRawParams() error {
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
+	if err := p.initModuleLogLevels(); err != nil {
+		return err
+	}
+
 	p.relayer = p.rawConfig.Relayer
 
 	if p.relayer && p.rawConfig.RelayerTrackerPollInterval == 0 {
@@ -73,6 +77,17 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initModuleLogLevels() error {
+	moduleLogLevels, err := server.ParseModuleLogLevels(p.rawConfig.LogLevels)
+	if err != nil {
+		return err
+	}
+
+	p.moduleLogLevels = moduleLogLevels
+
+	return nil
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
@@ -3,9 +3,11 @@ package server
 import (
 	"errors"
 	"net"
+	"path/filepath"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
@@ -26,6 +28,7 @@ const (
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
 	priceLimitFlag               = "price-limit"
+	priceBumpFlag                = "price-bump"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
 	maxSlotsFlag                 = "max-slots"
@@ -37,6 +40,7 @@ const (
 	devFlag                      = "dev"
 	corsOriginFlag               = "access-control-allow-origins"
 	logFileLocationFlag          = "log-to"
+	logLevelsFlag                = "log-levels"
 
 	relayerFlag               = "relayer"
 	numBlockConfirmationsFlag = "num-block-confirmations"
@@ -47,6 +51,20 @@ const (
 	relayerTrackerPollIntervalFlag = "relayer-poll-interval"
 
 	metricsIntervalFlag = "metrics-interval"
+
+	disableFlatStateFlag = "disable-flat-state"
+
+	parallelExecutionFlag = "parallel-execution"
+
+	gasPriceSuggestionFlag = "gas-price-suggestion"
+
+	jsonRPCRateLimitFlag      = "json-rpc-rate-limit"
+	jsonRPCRateLimitBurstFlag = "json-rpc-rate-limit-burst"
+
+	jsonRPCHTTPAPIFlag        = "json-rpc-http-api"
+	jsonRPCWSAPIFlag          = "json-rpc-ws-api"
+	jsonRPCDenyMethodsFlag    = "json-rpc-deny-methods"
+	jsonRPCAdminExportDirFlag = "json-rpc-admin-export-dir"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -65,6 +83,9 @@ var (
 			Telemetry: &config.Telemetry{},
 			Network:   &config.Network{},
 			TxPool:    &config.TxPool{},
+
+			JSONRPCRateLimit:  &config.RateLimit{},
+			JSONRPCNamespaces: &config.JSONRPCNamespaces{},
 		},
 	}
 )
@@ -94,6 +115,7 @@ type serverParams struct {
 	secretsConfig *secrets.SecretsManagerConfig
 
 	logFileLocation string
+	moduleLogLevels map[string]hclog.Level
 
 	relayer bool
 }
@@ -161,6 +183,10 @@ func (p *serverParams) generateConfig() *server.Config {
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
 			ConcurrentRequestsDebug:  p.rawConfig.ConcurrentRequestsDebug,
 			WebSocketReadLimit:       p.rawConfig.WebSocketReadLimit,
+			RateLimit:                p.generateRateLimitConfig(),
+			Namespaces:               p.generateNamespaceConfig(),
+			AdminAPIKeys:             p.getAdminAPIKeys(),
+			ExportDir:                p.getAdminExportDir(),
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -181,11 +207,13 @@ func (p *serverParams) generateConfig() *server.Config {
 		DataDir:            p.rawConfig.DataDir,
 		Seal:               p.rawConfig.ShouldSeal,
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
+		PriceBump:          p.rawConfig.TxPool.PriceBump,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
+		ModuleLogLevels:    p.moduleLogLevels,
 		JSONLogFormat:      p.rawConfig.JSONLogFormat,
 		LogFilePath:        p.logFileLocation,
 
@@ -193,5 +221,69 @@ func (p *serverParams) generateConfig() *server.Config {
 		NumBlockConfirmations:      p.rawConfig.NumBlockConfirmations,
 		RelayerTrackerPollInterval: p.rawConfig.RelayerTrackerPollInterval,
 		MetricsInterval:            p.rawConfig.MetricsInterval,
+		DisableFlatState:           p.rawConfig.DisableFlatState,
+		ParallelExecution:          p.rawConfig.ParallelExecution,
+		GasPriceSuggestion:         p.rawConfig.GasPriceSuggestion,
+	}
+}
+
+func (p *serverParams) generateRateLimitConfig() *jsonrpc.RateLimitConfig {
+	rawRateLimit := p.rawConfig.JSONRPCRateLimit
+	if rawRateLimit == nil {
+		return nil
+	}
+
+	rateLimit := &jsonrpc.RateLimitConfig{
+		Default: jsonrpc.RateLimitRule{
+			RequestsPerSecond: rawRateLimit.RequestsPerSecond,
+			Burst:             rawRateLimit.Burst,
+		},
+		APIKeyHeader:  rawRateLimit.APIKeyHeader,
+		APIKeys:       make(map[string]jsonrpc.RateLimitRule, len(rawRateLimit.APIKeys)),
+		MethodWeights: rawRateLimit.MethodWeights,
+	}
+
+	for _, apiKey := range rawRateLimit.APIKeys {
+		rateLimit.APIKeys[apiKey.Key] = jsonrpc.RateLimitRule{
+			RequestsPerSecond: apiKey.RequestsPerSecond,
+			Burst:             apiKey.Burst,
+		}
+	}
+
+	return rateLimit
+}
+
+func (p *serverParams) generateNamespaceConfig() *jsonrpc.NamespaceConfig {
+	rawNamespaces := p.rawConfig.JSONRPCNamespaces
+	if rawNamespaces == nil {
+		return nil
+	}
+
+	return &jsonrpc.NamespaceConfig{
+		HTTP:          rawNamespaces.HTTP,
+		WS:            rawNamespaces.WS,
+		DeniedMethods: rawNamespaces.DeniedMethods,
+	}
+}
+
+func (p *serverParams) getAdminAPIKeys() []string {
+	if p.rawConfig.JSONRPCNamespaces == nil {
+		return nil
 	}
+
+	return p.rawConfig.JSONRPCNamespaces.AdminAPIKeys
+}
+
+// getAdminExportDir returns the directory the admin_exportChain backups are written to,
+// which defaults to the exports directory in the data directory
+func (p *serverParams) getAdminExportDir() string {
+	if p.rawConfig.JSONRPCNamespaces != nil && p.rawConfig.JSONRPCNamespaces.AdminExportDir != "" {
+		return p.rawConfig.JSONRPCNamespaces.AdminExportDir
+	}
+
+	if p.rawConfig.DataDir == "" {
+		return ""
+	}
+
+	return filepath.Join(p.rawConfig.DataDir, "exports")
 }
@@ -46,6 +46,14 @@ func setFlags(cmd *cobra.Command) {
 		"the log level for console output",
 	)
 
+	cmd.Flags().StringToStringVar(
+		&params.rawConfig.LogLevels,
+		logLevelsFlag,
+		defaultConfig.LogLevels,
+		"the log levels of the node subsystems overriding the global one (e.g. syncer=DEBUG,txpool=WARN). "+
+			"They can be changed at runtime using the log-level command",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.GenesisPath,
 		genesisPathFlag,
@@ -115,7 +123,8 @@ func setFlags(cmd *cobra.Command) {
 		&params.rawConfig.RestoreFile,
 		restoreFlag,
 		"",
-		"the path to the archive blockchain data to restore on initialization",
+		"the path to the archive blockchain data to restore on initialization, "+
+			"or the glob pattern matching the archive files split by the block range",
 	)
 
 	cmd.Flags().BoolVar(
@@ -171,6 +180,14 @@ func setFlags(cmd *cobra.Command) {
 		),
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TxPool.PriceBump,
+		"the minimum percentage by which both the tip and the fee cap of a transaction "+
+			"have to be increased to replace a transaction with the same nonce",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.MaxSlots,
 		maxSlotsFlag,
@@ -256,6 +273,73 @@ func setFlags(cmd *cobra.Command) {
 		"the interval (in seconds) at which special metrics are generated. a value of zero means the metrics are disabled",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.DisableFlatState,
+		disableFlatStateFlag,
+		defaultConfig.DisableFlatState,
+		"disable the flat state snapshot which serves the account and storage reads without walking the state trie",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ParallelExecution,
+		parallelExecutionFlag,
+		defaultConfig.ParallelExecution,
+		"execute the transactions of the verified blocks in parallel (the block building is configured per fork)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.GasPriceSuggestion,
+		gasPriceSuggestionFlag,
+		defaultConfig.GasPriceSuggestion,
+		"suggest the gas price and the priority fee of the standard fee tier, "+
+			"based on the base fee trend and the pending transactions",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.RequestsPerSecond,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCRateLimit.RequestsPerSecond,
+		"max number of json-rpc requests per second allowed per client IP address, "+
+			"expensive methods consume more requests. Value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.Burst,
+		jsonRPCRateLimitBurstFlag,
+		defaultConfig.JSONRPCRateLimit.Burst,
+		"max number of json-rpc requests a client can burst above its rate limit, "+
+			"value of 0 sets it to the rate limit",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCNamespaces.HTTP,
+		jsonRPCHTTPAPIFlag,
+		defaultConfig.JSONRPCNamespaces.HTTP,
+		"the json-rpc namespaces enabled on the HTTP transport. The admin namespace "+
+			"is served only to the callers authenticated by the admin API keys from the config file",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCNamespaces.WS,
+		jsonRPCWSAPIFlag,
+		defaultConfig.JSONRPCNamespaces.WS,
+		"the json-rpc namespaces enabled on the websocket transport",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCNamespaces.DeniedMethods,
+		jsonRPCDenyMethodsFlag,
+		defaultConfig.JSONRPCNamespaces.DeniedMethods,
+		"the json-rpc methods which are never served (e.g. debug_traceBlock)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCNamespaces.AdminExportDir,
+		jsonRPCAdminExportDirFlag,
+		defaultConfig.JSONRPCNamespaces.AdminExportDir,
+		"the only directory admin_exportChain writes the backups to (default: the exports directory in the data dir)",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
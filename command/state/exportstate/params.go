@@ -0,0 +1,110 @@
+package exportstate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	outFlag     = "out"
+	blockFlag   = "block"
+	blocksFlag  = "blocks"
+)
+
+// defaultExportedBlocks is the default number of the blocks exported along with the state
+const defaultExportedBlocks = 256
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeBlock = errors.New("unable to decode block value")
+)
+
+type exportParams struct {
+	dataDir string
+	out     string
+
+	blockRaw string
+	block    *uint64
+	blocks   uint64
+
+	header *types.Header
+}
+
+func (p *exportParams) validateFlags() error {
+	if p.blockRaw != "" {
+		block, err := common.ParseUint64orHex(&p.blockRaw)
+		if err != nil {
+			return errDecodeBlock
+		}
+
+		p.block = &block
+	}
+
+	return nil
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		outFlag,
+	}
+}
+
+func (p *exportParams) exportState() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "state-export",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	trieDir := filepath.Join(p.dataDir, "trie")
+	if _, err := os.Stat(trieDir); err != nil {
+		return fmt.Errorf("failed to open the state: %w", err)
+	}
+
+	chainDB, err := leveldb.NewLevelDBStorageWithOpt(
+		filepath.Join(p.dataDir, "blockchain"),
+		logger,
+		&opt.Options{ErrorIfMissing: true, ReadOnly: true},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to open the blockchain: %w", err)
+	}
+
+	defer chainDB.Close()
+
+	stateStorage, err := itrie.NewLevelDBStorage(trieDir, logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the state: %w", err)
+	}
+
+	defer stateStorage.Close()
+
+	p.header, err = archive.ExportState(chainDB, stateStorage, logger, p.block, p.blocks, p.out)
+
+	return err
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &StateExportResult{
+		Block:     p.header.Number,
+		Hash:      p.header.Hash.String(),
+		StateRoot: p.header.StateRoot.String(),
+		Out:       p.out,
+	}
+}
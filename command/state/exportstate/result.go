@@ -0,0 +1,31 @@
+package exportstate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type StateExportResult struct {
+	Block     uint64 `json:"block"`
+	Hash      string `json:"hash"`
+	StateRoot string `json:"stateRoot"`
+	Out       string `json:"out"`
+}
+
+func (r *StateExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE EXPORT]\n")
+	buffer.WriteString("Exported state successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
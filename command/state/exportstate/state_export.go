@@ -0,0 +1,71 @@
+package exportstate
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateExportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Exports the world state at the given block, along with the preceding blocks, " +
+			"from the data dir of the stopped node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(stateExportCmd)
+	helper.SetRequiredFlags(stateExportCmd, params.getRequiredFlags())
+
+	return stateExportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the export path for the state",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		"the height of the exported state (the head block if omitted)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.blocks,
+		blocksFlag,
+		defaultExportedBlocks,
+		"the number of the blocks (with receipts) up to the exported one included in the export, "+
+			"which must cover the blocks needed by the BLOCKHASH opcode. "+
+			"The headers of the preceding blocks are always exported for the consensus",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.exportState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,75 @@
+package importstate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	fileFlag    = "file"
+)
+
+var (
+	params = &importParams{}
+)
+
+type importParams struct {
+	dataDir string
+	file    string
+
+	header *types.Header
+}
+
+func (p *importParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		fileFlag,
+	}
+}
+
+func (p *importParams) importState() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "state-import",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	if err := common.SetupDataDir(p.dataDir, []string{"blockchain", "trie"}, 0770); err != nil {
+		return err
+	}
+
+	chainDB, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the blockchain: %w", err)
+	}
+
+	defer chainDB.Close()
+
+	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the state: %w", err)
+	}
+
+	defer stateStorage.Close()
+
+	p.header, err = archive.ImportState(chainDB, stateStorage, logger, p.file)
+
+	return err
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	return &StateImportResult{
+		Block:     p.header.Number,
+		Hash:      p.header.Hash.String(),
+		StateRoot: p.header.StateRoot.String(),
+	}
+}
@@ -0,0 +1,29 @@
+package importstate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type StateImportResult struct {
+	Block     uint64 `json:"block"`
+	Hash      string `json:"hash"`
+	StateRoot string `json:"stateRoot"`
+}
+
+func (r *StateImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE IMPORT]\n")
+	buffer.WriteString("Imported state successfully, the node continues syncing from the following block:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,50 @@
+package importstate
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateImportCmd := &cobra.Command{
+		Use: "import",
+		Short: "Bootstraps the new data dir with the exported state, " +
+			"so the node continues syncing from the block following the exported one",
+		Run: runCommand,
+	}
+
+	setFlags(stateImportCmd)
+	helper.SetRequiredFlags(stateImportCmd, params.getRequiredFlags())
+
+	return stateImportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the new node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the path to the state export",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.importState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,30 @@
+package state
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/state/exportstate"
+	"github.com/0xPolygon/polygon-edge/command/state/importstate"
+	"github.com/0xPolygon/polygon-edge/command/state/verifyflat"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateCmd := &cobra.Command{
+		Use:   "state",
+		Short: "Top level command for exporting, importing and verifying the world state. Only accepts subcommands.",
+	}
+
+	registerSubcommands(stateCmd)
+
+	return stateCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// state export
+		exportstate.GetCommand(),
+		// state import
+		importstate.GetCommand(),
+		// state verify-flat
+		verifyflat.GetCommand(),
+	)
+}
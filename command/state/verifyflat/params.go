@@ -0,0 +1,88 @@
+package verifyflat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+var (
+	params = &verifyFlatParams{}
+)
+
+type verifyFlatParams struct {
+	dataDir string
+
+	header *types.Header
+}
+
+func (p *verifyFlatParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *verifyFlatParams) verifyFlatState() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "state-verify-flat",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	trieDir := filepath.Join(p.dataDir, "trie")
+	if _, err := os.Stat(trieDir); err != nil {
+		return fmt.Errorf("failed to open the state: %w", err)
+	}
+
+	chainDB, err := leveldb.NewLevelDBStorageWithOpt(
+		filepath.Join(p.dataDir, "blockchain"),
+		logger,
+		&opt.Options{ErrorIfMissing: true, ReadOnly: true},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to open the blockchain: %w", err)
+	}
+
+	defer chainDB.Close()
+
+	headHash, ok := chainDB.ReadHeadHash()
+	if !ok {
+		return fmt.Errorf("couldn't get the head block hash")
+	}
+
+	if p.header, err = chainDB.ReadHeader(headHash); err != nil {
+		return fmt.Errorf("failed to read the head header: %w", err)
+	}
+
+	stateStorage, err := itrie.NewLevelDBStorage(trieDir, logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the state: %w", err)
+	}
+
+	defer stateStorage.Close()
+
+	st := itrie.NewState(stateStorage)
+	st.EnableFlatState(p.header.StateRoot, logger)
+
+	defer st.CloseFlatState(p.header.StateRoot)
+
+	return st.VerifyFlatState(p.header.StateRoot)
+}
+
+func (p *verifyFlatParams) getResult() command.CommandResult {
+	return &StateVerifyFlatResult{
+		Block:     p.header.Number,
+		StateRoot: p.header.StateRoot.String(),
+	}
+}
@@ -0,0 +1,27 @@
+package verifyflat
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type StateVerifyFlatResult struct {
+	Block     uint64 `json:"block"`
+	StateRoot string `json:"stateRoot"`
+}
+
+func (r *StateVerifyFlatResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE VERIFY FLAT]\n")
+	buffer.WriteString("Flat state matches the state trie:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,43 @@
+package verifyflat
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	verifyFlatCmd := &cobra.Command{
+		Use: "verify-flat",
+		Short: "Verifies the flat state at the head block against the state trie, " +
+			"in the data dir of the stopped node. The flat state is generated first if it is missing",
+		Run: runCommand,
+	}
+
+	setFlags(verifyFlatCmd)
+	helper.SetRequiredFlags(verifyFlatCmd, params.getRequiredFlags())
+
+	return verifyFlatCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.verifyFlatState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
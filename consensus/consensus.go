@@ -92,4 +92,33 @@ type BridgeDataProvider interface {
 
 	// GetStateSyncProof retrieves the StateSync proof
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+
+	// GetDepositStatus returns the status of the deposit with given state sync id
+	GetDepositStatus(stateSyncID uint64) (*types.DepositStatus, error)
+
+	// GetDepositStatusesByTxHash returns the statuses of the deposits made by given rootchain transaction
+	GetDepositStatusesByTxHash(txHash types.Hash) ([]*types.DepositStatus, error)
+
+	// GetExitStatus returns the status of the exit with given exit event id
+	GetExitStatus(exitID uint64) (*types.ExitStatus, error)
+
+	// GetExitStatusesByTxHash returns the statuses of the exits made by given child chain transaction
+	GetExitStatusesByTxHash(txHash types.Hash) ([]*types.ExitStatus, error)
+
+	// GetExitsByAddress returns the statuses of at most limit exits withdrawn by or to the given address,
+	// starting from the given exit event id
+	GetExitsByAddress(address types.Address, from, limit uint64) ([]*types.ExitStatus, error)
+
+	// GetCheckpointStatus returns the status of checkpoint submissions
+	// (e.g. the lag between the child chain head and the latest checkpointed block)
+	GetCheckpointStatus() (*types.CheckpointStatus, error)
+}
+
+// PolybftDataProvider is an interface providing polybft consensus specific data
+// (implemented only by the consensus engines which support it)
+type PolybftDataProvider interface {
+	// GetValidatorUptime returns the validators uptime for the given epoch (or for the current epoch if nil)
+	GetValidatorUptime(epoch *uint64) (*types.EpochUptime, error)
+	// GetEpochProof returns the epoch ending block header of the given epoch
+	GetEpochProof(epoch uint64) (*types.EpochProof, error)
 }
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -14,6 +16,9 @@ import (
 //nolint:godox
 // TODO: Add opentracing (to be fixed in EVM-540)
 
+// parallelExecutionBatchFactor is the number of the transactions per worker executed in parallel at once
+const parallelExecutionBatchFactor = 4
+
 // BlockBuilderParams are fields for the block that cannot be changed
 type BlockBuilderParams struct {
 	// Parent block
@@ -39,6 +44,15 @@ type BlockBuilderParams struct {
 
 	// BaseFee is the base fee
 	BaseFee uint64
+
+	// TxOrdering is the policy used to order the txpool transactions in the block
+	TxOrdering TxOrderingStrategy
+
+	// PriorityLanes configures the priority lanes tx ordering (if enabled)
+	PriorityLanes *PriorityLanesConfig
+
+	// ParallelExecution enables the optimistic parallel execution of the txpool transactions
+	ParallelExecution bool
 }
 
 func NewBlockBuilder(params *BlockBuilderParams) *BlockBuilder {
@@ -160,13 +174,25 @@ func (b *BlockBuilder) Fill() {
 	blockTimer := time.NewTimer(b.params.BlockTime)
 
 	b.params.TxPool.Prepare()
+
+	ordering := newTxOrderingPolicy(b.params.TxOrdering, b.params.TxPool,
+		b.params.GasLimit, b.params.BaseFee, b.params.PriorityLanes)
 write:
 	for {
 		select {
 		case <-blockTimer.C:
 			return
 		default:
-			tx := b.params.TxPool.Peek()
+			if b.params.ParallelExecution {
+				// execute the batch of transactions in parallel
+				if b.writeTxPoolTransactions(ordering) {
+					break write
+				}
+
+				continue
+			}
+
+			tx := ordering.next(b.state.TotalGas())
 
 			// execute transactions one by one
 			finished, err := b.writeTxPoolTransaction(tx)
@@ -215,6 +241,80 @@ func (b *BlockBuilder) writeTxPoolTransaction(tx *types.Transaction) (bool, erro
 	return false, nil
 }
 
+// writeTxPoolTransactions writes the batch of the txpool transactions, executing them in parallel.
+// The batch holds the transactions of the distinct accounts, as the next transaction of the account
+// is provided by the txpool only after the previous one is popped. It returns true if the block is finished
+func (b *BlockBuilder) writeTxPoolTransactions(ordering txOrderingPolicy) bool {
+	var (
+		workers  = state.ParallelExecutionWorkers()
+		batch    = make([]*types.Transaction, 0, parallelExecutionBatchFactor*workers)
+		gasUsed  = b.state.TotalGas()
+		finished = false
+	)
+
+	for len(batch) < cap(batch) && gasUsed < b.params.GasLimit {
+		tx := ordering.next(gasUsed)
+		if tx == nil {
+			finished = true
+
+			break
+		}
+
+		if tx.Gas > b.params.GasLimit {
+			b.params.TxPool.Drop(tx)
+			b.params.Logger.Debug("Fill transaction error", "hash", tx.Hash, "err", txpool.ErrBlockLimitExceeded)
+
+			continue
+		}
+
+		// the gas of the transaction is the upper bound of the gas it uses
+		batch = append(batch, tx)
+		gasUsed += tx.Gas
+	}
+
+	if len(batch) == 0 {
+		return true
+	}
+
+	for i, err := range b.state.WriteParallel(batch, workers) {
+		tx := batch[i]
+
+		if err == nil {
+			b.txns = append(b.txns, tx)
+			b.params.TxPool.Pop(tx)
+
+			continue
+		}
+
+		b.params.Logger.Debug("Fill transaction error", "hash", tx.Hash, "err", err)
+
+		if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
+			finished = true
+		} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
+			b.params.TxPool.Demote(tx)
+		} else {
+			b.params.TxPool.Drop(tx)
+		}
+	}
+
+	return finished
+}
+
+// getParallelExecution returns true if the parallel execution of the transactions
+// is enabled for the given block (which requires the ParallelExecution fork to be enabled)
+func getParallelExecution(blockNumber uint64) bool {
+	if !forkmanager.GetInstance().IsForkEnabled(chain.ParallelExecution, blockNumber) {
+		return false
+	}
+
+	params := forkmanager.GetInstance().GetParams(blockNumber)
+	if params == nil || params.ParallelExecution == nil {
+		return false
+	}
+
+	return *params.ParallelExecution
+}
+
 // GetState returns Transition reference
 func (b *BlockBuilder) GetState() *state.Transition {
 	return b.state
@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
@@ -158,3 +159,145 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 	assert.False(t, fb.Block.Header.LogsBloom.IsLogInBloom(
 		&types.Log{Address: types.StringToAddress("111177779999")}))
 }
+
+func TestBlockBuilder_FillParallelExecution(t *testing.T) {
+	t.Parallel()
+
+	const (
+		amount        = 1_000
+		gasPrice      = 1_000
+		gasLimit      = 21000
+		blockGasLimit = 21000 * 100
+		chainID       = 100
+	)
+
+	accounts := [8]*wallet.Account{}
+
+	for i := range accounts {
+		accounts[i] = generateTestAccount(t)
+	}
+
+	forks := &chain.Forks{}
+	logger := hclog.NewNullLogger()
+	signer := crypto.NewSigner(forks.At(0), chainID)
+
+	balanceMap := map[types.Address]*chain.GenesisAccount{}
+
+	for i, acc := range accounts {
+		// the third tx will fail because of insufficient balance
+		if i != 2 {
+			balanceMap[types.Address(acc.Ecdsa.Address())] = &chain.GenesisAccount{
+				Balance: ethgo.Ether(1),
+			}
+		}
+	}
+
+	txs := make([]*types.Transaction, len(accounts))
+
+	for i, acc := range accounts {
+		// every account pays the next one
+		receiver := types.Address(accounts[(i+1)%len(accounts)].Ecdsa.Address())
+		privateKey, err := acc.GetEcdsaPrivateKey()
+		require.NoError(t, err)
+
+		tx := &types.Transaction{
+			Value:    big.NewInt(amount),
+			GasPrice: big.NewInt(gasPrice),
+			Gas:      gasLimit,
+			Nonce:    0,
+			To:       &receiver,
+		}
+
+		// fifth tx exceeds the block gas limit
+		if i == 4 {
+			tx.Gas = blockGasLimit + 1
+		}
+
+		txs[i], err = signer.SignTx(tx, privateKey)
+		require.NoError(t, err)
+	}
+
+	build := func(parallelExecution bool) *types.FullBlock {
+		mstate := itrie.NewState(itrie.NewMemoryStorage())
+		executor := state.NewExecutor(&chain.Params{ChainID: chainID, Forks: forks}, mstate, logger)
+
+		executor.GetHash = func(header *types.Header) func(i uint64) types.Hash {
+			return func(i uint64) (res types.Hash) {
+				return types.BytesToHash(common.EncodeUint64ToBytes(i))
+			}
+		}
+
+		hash, err := executor.WriteGenesis(balanceMap, types.ZeroHash)
+		require.NoError(t, err)
+
+		txPool := &txPoolMock{}
+		txPool.On("Prepare").Once()
+
+		for i, tx := range txs {
+			txPool.On("Peek").Return(tx).Once()
+
+			switch i {
+			case 2:
+				txPool.On("Demote", tx).Once()
+			case 4:
+				txPool.On("Drop", tx).Once()
+			default:
+				txPool.On("Pop", tx).Once()
+			}
+		}
+
+		txPool.On("Peek").Return((*types.Transaction)(nil))
+
+		bb := NewBlockBuilder(&BlockBuilderParams{
+			BlockTime:         time.Millisecond * 100,
+			Parent:            &types.Header{StateRoot: hash, GasLimit: 1_000_000_000_000_000},
+			Coinbase:          types.ZeroAddress,
+			Executor:          executor,
+			GasLimit:          blockGasLimit,
+			TxPool:            txPool,
+			Logger:            logger,
+			ParallelExecution: parallelExecution,
+		})
+
+		require.NoError(t, bb.Reset())
+
+		bb.Fill()
+
+		fb, err := bb.Build(nil)
+		require.NoError(t, err)
+
+		txPool.AssertExpectations(t)
+
+		return fb
+	}
+
+	sequential, parallel := build(false), build(true)
+
+	require.Len(t, parallel.Block.Transactions, len(accounts)-2)
+	require.Equal(t, sequential.Block.Header.StateRoot, parallel.Block.Header.StateRoot)
+	require.Equal(t, sequential.Block.Header.GasUsed, parallel.Block.Header.GasUsed)
+	require.Equal(t, sequential.Receipts, parallel.Receipts)
+}
+
+func TestBlockBuilder_ParallelExecutionPerFork(t *testing.T) {
+	const forkName = "parallelExecutionTestFork"
+
+	enabled := true
+	fm := forkmanager.GetInstance()
+
+	fm.RegisterFork(forkName, &forkmanager.ForkParams{ParallelExecution: &enabled})
+	require.NoError(t, fm.ActivateFork(forkName, 3_000_000))
+
+	fm.RegisterFork(chain.ParallelExecution, nil)
+	require.NoError(t, fm.ActivateFork(chain.ParallelExecution, 3_000_010))
+
+	t.Cleanup(func() {
+		require.NoError(t, fm.DeactivateFork(forkName))
+		require.NoError(t, fm.DeactivateFork(chain.ParallelExecution))
+	})
+
+	require.False(t, getParallelExecution(2_999_999))
+	// the parallel execution set in the fork params is not used until the ParallelExecution fork is enabled
+	require.False(t, getParallelExecution(3_000_000))
+	require.True(t, getParallelExecution(3_000_010))
+}
@@ -40,6 +40,9 @@ type blockchainBackend interface {
 	// ProcessBlock builds a final block from given 'block' on top of 'parent'.
 	ProcessBlock(parent *types.Header, block *types.Block) (*types.FullBlock, error)
 
+	// PrefetchBlock warms up the state caches for the block which is about to be processed.
+	PrefetchBlock(block *types.Block)
+
 	// GetStateProviderForBlock returns a reference to make queries to the state at 'block'.
 	GetStateProviderForBlock(block *types.Header) (contract.Provider, error)
 
@@ -69,6 +72,8 @@ var _ blockchainBackend = &blockchainWrapper{}
 type blockchainWrapper struct {
 	executor   *state.Executor
 	blockchain *blockchain.Blockchain
+	// priorityLanes configures the priority lanes tx ordering of the built blocks
+	priorityLanes *PriorityLanesConfig
 }
 
 // CurrentHeader returns the header of blockchain block head
@@ -127,6 +132,12 @@ func (p *blockchainWrapper) ProcessBlock(parent *types.Header, block *types.Bloc
 	}, nil
 }
 
+// PrefetchBlock warms up the state caches for the block which is about to be processed,
+// by executing it in the background
+func (p *blockchainWrapper) PrefetchBlock(block *types.Block) {
+	p.blockchain.PrefetchBlock(block)
+}
+
 // GetStateProviderForBlock is an implementation of blockchainBackend interface
 func (p *blockchainWrapper) GetStateProviderForBlock(header *types.Header) (contract.Provider, error) {
 	transition, err := p.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
@@ -162,14 +173,17 @@ func (p *blockchainWrapper) NewBlockBuilder(
 	}
 
 	return NewBlockBuilder(&BlockBuilderParams{
-		BlockTime: blockTime,
-		Parent:    parent,
-		Coinbase:  coinbase,
-		Executor:  p.executor,
-		GasLimit:  gasLimit,
-		BaseFee:   p.blockchain.CalculateBaseFee(parent),
-		TxPool:    txPool,
-		Logger:    logger,
+		BlockTime:         blockTime,
+		Parent:            parent,
+		Coinbase:          coinbase,
+		Executor:          p.executor,
+		GasLimit:          gasLimit,
+		BaseFee:           p.blockchain.CalculateBaseFee(parent),
+		TxPool:            txPool,
+		Logger:            logger,
+		TxOrdering:        getTxOrderingStrategy(parent.Number + 1),
+		PriorityLanes:     p.priorityLanes,
+		ParallelExecution: getParallelExecution(parent.Number + 1),
 	}), nil
 }
 
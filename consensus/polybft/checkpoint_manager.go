@@ -2,10 +2,14 @@ package polybft
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"sort"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
@@ -16,21 +20,39 @@ import (
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 	hclog "github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/umbracle/ethgo"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
 	// currentCheckpointBlockNumMethod is an ABI method object representation for
 	// currentCheckpointBlockNumber getter function on CheckpointManager contract
 	currentCheckpointBlockNumMethod, _ = contractsapi.CheckpointManager.Abi.Methods["currentCheckpointBlockNumber"]
+	// processedExitsMethod is an ABI method object representation for
+	// processedExits getter function on ExitHelper contract
+	processedExitsMethod, _ = contractsapi.ExitHelper.Abi.Methods["processedExits"]
 	// frequency at which checkpoints are sent to the rootchain (in blocks count)
 	defaultCheckpointsOffset = uint64(900)
+
+	errBridgeNotEnabled = errors.New("bridge is not enabled")
+)
+
+const (
+	// exitTreeCacheSize is the number of exit trees (per checkpoint) kept in memory for exit proof generation
+	exitTreeCacheSize = 32
+
+	// checkpointCatchUpParallelism is the maximum number of pending checkpoints encoded in parallel
+	// (checkpoints are still submitted to the rootchain one by one, in order)
+	checkpointCatchUpParallelism = 4
 )
 
 type CheckpointManager interface {
 	PostBlock(req *PostBlockRequest) error
 	BuildEventRoot(epoch uint64) (types.Hash, error)
 	GenerateExitProof(exitID uint64) (types.Proof, error)
+	GetExitStatuses(exitIDs []uint64) ([]*types.ExitStatus, error)
+	GetCheckpointStatus() (*types.CheckpointStatus, error)
 }
 
 var _ CheckpointManager = (*dummyCheckpointManager)(nil)
@@ -44,6 +66,17 @@ func (d *dummyCheckpointManager) BuildEventRoot(epoch uint64) (types.Hash, error
 func (d *dummyCheckpointManager) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (d *dummyCheckpointManager) GetExitStatuses(exitIDs []uint64) ([]*types.ExitStatus, error) {
+	statuses := make([]*types.ExitStatus, len(exitIDs))
+	for i, exitID := range exitIDs {
+		statuses[i] = &types.ExitStatus{ExitID: exitID, Status: types.BridgeTransferUnknown}
+	}
+
+	return statuses, nil
+}
+func (d *dummyCheckpointManager) GetCheckpointStatus() (*types.CheckpointStatus, error) {
+	return nil, errBridgeNotEnabled
+}
 
 var _ CheckpointManager = (*checkpointManager)(nil)
 
@@ -61,6 +94,8 @@ type checkpointManager struct {
 	checkpointsOffset uint64
 	// checkpointManagerAddr is address of CheckpointManager smart contract
 	checkpointManagerAddr types.Address
+	// exitHelperAddr is address of ExitHelper smart contract
+	exitHelperAddr types.Address
 	// lastSentBlock represents the last block on which a checkpoint transaction was sent
 	lastSentBlock uint64
 	// logger instance
@@ -69,11 +104,29 @@ type checkpointManager struct {
 	state *State
 	// eventGetter gets exit events (missed or current) from blocks
 	eventGetter *eventsGetter[*ExitEvent]
+	// exitTreeCache holds the exit trees of the recently used checkpoints (exitTreeKey -> *merkle.MerkleTree)
+	exitTreeCache *lru.Cache
+	// submitLock serializes checkpoint submissions
+	submitLock sync.Mutex
+	// lastCheckpointedBlock is the latest known child chain block checkpointed on the rootchain
+	lastCheckpointedBlock atomic.Uint64
+	// rootchainCheckpointBlock is the checkpoint block last read from the CheckpointManager contract
+	rootchainCheckpointBlock atomic.Uint64
+	// pendingCheckpoints is the number of checkpoints which are still to be submitted
+	pendingCheckpoints atomic.Uint64
+	// catchingUp indicates whether the node is submitting the missed checkpoints
+	catchingUp atomic.Bool
+}
+
+// exitTreeKey identifies the exit tree of the checkpoint
+type exitTreeKey struct {
+	epoch           uint64
+	checkpointBlock uint64
 }
 
 // newCheckpointManager creates a new instance of checkpointManager
 func newCheckpointManager(key ethgo.Key, checkpointOffset uint64,
-	checkpointManagerSC types.Address, txRelayer txrelayer.TxRelayer,
+	checkpointManagerSC, exitHelperSC types.Address, txRelayer txrelayer.TxRelayer,
 	blockchain blockchainBackend, backend polybftBackend, logger hclog.Logger,
 	state *State) *checkpointManager {
 	retry := &eventsGetter[*ExitEvent]{
@@ -84,17 +137,33 @@ func newCheckpointManager(key ethgo.Key, checkpointOffset uint64,
 		parseEventFn: parseExitEvent,
 	}
 
-	return &checkpointManager{
+	// lru.New fails only for the non-positive size
+	exitTreeCache, _ := lru.New(exitTreeCacheSize)
+
+	c := &checkpointManager{
 		key:                   key,
 		blockchain:            blockchain,
 		consensusBackend:      backend,
 		rootChainRelayer:      txRelayer,
 		checkpointsOffset:     checkpointOffset,
 		checkpointManagerAddr: checkpointManagerSC,
+		exitHelperAddr:        exitHelperSC,
 		logger:                logger,
 		state:                 state,
 		eventGetter:           retry,
+		exitTreeCache:         exitTreeCache,
 	}
+
+	// restore the block of the last checkpoint submitted before the node restart,
+	// so the checkpoint offset is counted from it
+	submission, err := state.CheckpointStore.getCheckpointSubmission()
+	if err != nil {
+		logger.Warn("failed to get last checkpoint submission", "error", err)
+	} else if submission != nil {
+		c.lastSentBlock = submission.BlockNumber
+	}
+
+	return c
 }
 
 // getCurrentCheckpointBlock queries CheckpointManager smart contract and retrieves the current checkpoint block number
@@ -119,9 +188,22 @@ func getCurrentCheckpointBlock(relayer txrelayer.TxRelayer, checkpointManagerAdd
 	return currentCheckpointBlock, nil
 }
 
-// submitCheckpoint sends a transaction with checkpoint data to the rootchain
+// pendingCheckpoint represents the checkpoint block which is about to be submitted to the rootchain
+type pendingCheckpoint struct {
+	header       *types.Header
+	extra        *Extra
+	isEndOfEpoch bool
+}
+
+// submitCheckpoint sends transactions with checkpoint data to the rootchain.
+// In case the node missed some checkpoints (e.g. it was offline), it catches up by sending
+// the pending epoch-ending checkpoints first (in order), followed by the checkpoint for the latest block
 func (c *checkpointManager) submitCheckpoint(latestHeader *types.Header, isEndOfEpoch bool) error {
-	lastCheckpointBlockNumber, err := getCurrentCheckpointBlock(c.rootChainRelayer, c.checkpointManagerAddr)
+	// submissions are serialized, since the checkpoints must be sent in order
+	c.submitLock.Lock()
+	defer c.submitLock.Unlock()
+
+	lastCheckpointBlockNumber, err := c.getLastCheckpointedBlock()
 	if err != nil {
 		return err
 	}
@@ -130,50 +212,147 @@ func (c *checkpointManager) submitCheckpoint(latestHeader *types.Header, isEndOf
 		"latest checkpoint block", lastCheckpointBlockNumber,
 		"checkpoint block", latestHeader.Number)
 
+	if lastCheckpointBlockNumber >= latestHeader.Number {
+		c.logger.Debug("checkpoint is already submitted", "checkpoint block", latestHeader.Number)
+
+		return nil
+	}
+
+	inFlight, err := c.isCheckpointInFlight(latestHeader)
+	if err != nil {
+		return err
+	}
+
+	if inFlight {
+		c.logger.Debug("checkpoint is already sent, but not yet seen on the rootchain",
+			"checkpoint block", latestHeader.Number)
+
+		return nil
+	}
+
+	checkpoints, err := c.getPendingCheckpoints(lastCheckpointBlockNumber, latestHeader, isEndOfEpoch)
+	if err != nil {
+		return err
+	}
+
+	c.pendingCheckpoints.Store(uint64(len(checkpoints)))
+	c.catchingUp.Store(len(checkpoints) > 1)
+
+	defer func() {
+		c.pendingCheckpoints.Store(0)
+		c.catchingUp.Store(false)
+		updateCheckpointMetrics(latestHeader.Number, c.lastCheckpointedBlock.Load(), 0)
+	}()
+
+	if len(checkpoints) > 1 {
+		c.logger.Info("catching up with pending checkpoints",
+			"latest checkpoint block", lastCheckpointBlockNumber,
+			"pending checkpoints", len(checkpoints))
+	}
+
+	inputs, err := c.encodeCheckpoints(checkpoints)
+	if err != nil {
+		return err
+	}
+
+	for i, checkpoint := range checkpoints {
+		if err := c.sendCheckpoint(checkpoint, inputs[i]); err != nil {
+			return err
+		}
+
+		pending := uint64(len(checkpoints) - i - 1)
+		c.pendingCheckpoints.Store(pending)
+		updateCheckpointMetrics(latestHeader.Number, checkpoint.header.Number, pending)
+	}
+
+	return nil
+}
+
+// getLastCheckpointedBlock returns the latest checkpointed block read from the CheckpointManager contract.
+// In case the contract checkpoint block goes backwards (e.g. the rootchain got reorganized),
+// the persisted checkpoint submission is cleared, since it is not checkpointed anymore
+func (c *checkpointManager) getLastCheckpointedBlock() (uint64, error) {
+	lastCheckpointBlockNumber, err := getCurrentCheckpointBlock(c.rootChainRelayer, c.checkpointManagerAddr)
+	if err != nil {
+		return 0, err
+	}
+
+	if previous := c.rootchainCheckpointBlock.Swap(lastCheckpointBlockNumber); lastCheckpointBlockNumber < previous {
+		c.logger.Warn("rootchain checkpoint block went backwards, clearing the last checkpoint submission",
+			"previous checkpoint block", previous, "checkpoint block", lastCheckpointBlockNumber)
+
+		if err := c.state.CheckpointStore.deleteCheckpointSubmission(); err != nil {
+			return 0, fmt.Errorf("failed to clear last checkpoint submission: %w", err)
+		}
+	}
+
+	c.lastCheckpointedBlock.Store(lastCheckpointBlockNumber)
+
+	return lastCheckpointBlockNumber, nil
+}
+
+// isCheckpointInFlight returns true if the checkpoint for the given block (and its epoch) is the last one
+// submitted by the node, meaning that it is already sent, even though the rootchain does not report it yet
+func (c *checkpointManager) isCheckpointInFlight(header *types.Header) (bool, error) {
+	submission, err := c.state.CheckpointStore.getCheckpointSubmission()
+	if err != nil {
+		return false, fmt.Errorf("failed to get last checkpoint submission: %w", err)
+	}
+
+	if submission == nil || submission.BlockNumber != header.Number {
+		return false, nil
+	}
+
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return false, err
+	}
+
+	return extra.Checkpoint != nil && submission.EpochNumber == extra.Checkpoint.EpochNumber, nil
+}
+
+// getPendingCheckpoints returns the epoch-ending checkpoints which are not submitted yet
+// (starting from the given last checkpointed block), followed by the checkpoint for the latest block
+func (c *checkpointManager) getPendingCheckpoints(lastCheckpointBlockNumber uint64,
+	latestHeader *types.Header, isEndOfEpoch bool) ([]*pendingCheckpoint, error) {
 	var (
 		initialBlockNumber = lastCheckpointBlockNumber + 1
 		parentExtra        *Extra
 		parentHeader       *types.Header
 		currentExtra       *Extra
 		found              bool
+		err                error
+		checkpoints        []*pendingCheckpoint
 	)
 
 	if initialBlockNumber < latestHeader.Number {
 		parentHeader, found = c.blockchain.GetHeaderByNumber(initialBlockNumber)
 		if !found {
-			return fmt.Errorf("block %d was not found", initialBlockNumber)
+			return nil, fmt.Errorf("block %d was not found", initialBlockNumber)
 		}
 
 		parentExtra, err = GetIbftExtra(parentHeader.ExtraData)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	// detect any pending (previously failed) checkpoints and send them
+	// detect any pending (previously failed) checkpoints
 	for blockNumber := initialBlockNumber + 1; blockNumber <= latestHeader.Number; blockNumber++ {
 		currentHeader, found := c.blockchain.GetHeaderByNumber(blockNumber)
 		if !found {
-			return fmt.Errorf("block %d was not found", blockNumber)
+			return nil, fmt.Errorf("block %d was not found", blockNumber)
 		}
 
 		currentExtra, err = GetIbftExtra(currentHeader.ExtraData)
 		if err != nil {
-			return err
-		}
-
-		parentEpochNumber := parentExtra.Checkpoint.EpochNumber
-		currentEpochNumber := currentExtra.Checkpoint.EpochNumber
-		// send pending checkpoints only for epoch ending blocks
-		if blockNumber == 1 || parentEpochNumber == currentEpochNumber {
-			parentHeader = currentHeader
-			parentExtra = currentExtra
-
-			continue
+			return nil, err
 		}
 
-		if err = c.encodeAndSendCheckpoint(parentHeader, parentExtra, true); err != nil {
-			return err
+		// pending checkpoints are sent only for epoch ending blocks
+		if blockNumber != 1 && parentExtra.Checkpoint.EpochNumber != currentExtra.Checkpoint.EpochNumber {
+			checkpoints = append(checkpoints,
+				&pendingCheckpoint{header: parentHeader, extra: parentExtra, isEndOfEpoch: true})
 		}
 
 		parentHeader = currentHeader
@@ -186,36 +365,76 @@ func (c *checkpointManager) submitCheckpoint(latestHeader *types.Header, isEndOf
 		// we need to send checkpoint for the latest block
 		currentExtra, err = GetIbftExtra(latestHeader.ExtraData)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return c.encodeAndSendCheckpoint(latestHeader, currentExtra, isEndOfEpoch)
+	return append(checkpoints,
+		&pendingCheckpoint{header: latestHeader, extra: currentExtra, isEndOfEpoch: isEndOfEpoch}), nil
 }
 
-// encodeAndSendCheckpoint encodes checkpoint data for the given block and
-// sends a transaction to the CheckpointManager rootchain contract
-func (c *checkpointManager) encodeAndSendCheckpoint(header *types.Header, extra *Extra, isEndOfEpoch bool) error {
-	c.logger.Debug("send checkpoint txn...", "block number", header.Number)
+// encodeCheckpoints encodes the given checkpoints to the submit function ABI format.
+// Encoding is done in parallel (bounded by checkpointCatchUpParallelism),
+// while the order of the returned inputs corresponds to the order of the given checkpoints
+func (c *checkpointManager) encodeCheckpoints(checkpoints []*pendingCheckpoint) ([][]byte, error) {
+	inputs := make([][]byte, len(checkpoints))
 
-	checkpointManager := ethgo.Address(c.checkpointManagerAddr)
+	var g errgroup.Group
+
+	g.SetLimit(checkpointCatchUpParallelism)
+
+	for i, checkpoint := range checkpoints {
+		i, checkpoint := i, checkpoint
+
+		g.Go(func() error {
+			input, err := c.encodeCheckpoint(checkpoint)
+			if err != nil {
+				return err
+			}
+
+			inputs[i] = input
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
 
+	return inputs, nil
+}
+
+// encodeCheckpoint encodes checkpoint data for the given block to the submit function ABI format
+func (c *checkpointManager) encodeCheckpoint(checkpoint *pendingCheckpoint) ([]byte, error) {
 	nextEpochValidators := validator.AccountSet{}
 
-	if isEndOfEpoch {
+	if checkpoint.isEndOfEpoch {
 		var err error
-		nextEpochValidators, err = c.consensusBackend.GetValidators(header.Number, nil)
+		nextEpochValidators, err = c.consensusBackend.GetValidators(checkpoint.header.Number, nil)
 
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	input, err := c.abiEncodeCheckpointBlock(header.Number, header.Hash, extra, nextEpochValidators)
+	input, err := c.abiEncodeCheckpointBlock(checkpoint.header.Number, checkpoint.header.Hash,
+		checkpoint.extra, nextEpochValidators)
 	if err != nil {
-		return fmt.Errorf("failed to encode checkpoint data to ABI for block %d: %w", header.Number, err)
+		return nil, fmt.Errorf("failed to encode checkpoint data to ABI for block %d: %w",
+			checkpoint.header.Number, err)
 	}
 
+	return input, nil
+}
+
+// sendCheckpoint sends a transaction with encoded checkpoint data to the CheckpointManager rootchain contract
+// and persists the submission once the transaction is successfully executed
+func (c *checkpointManager) sendCheckpoint(checkpoint *pendingCheckpoint, input []byte) error {
+	c.logger.Debug("send checkpoint txn...", "block number", checkpoint.header.Number)
+
+	checkpointManager := ethgo.Address(c.checkpointManagerAddr)
+
 	txn := &ethgo.Transaction{
 		To:    &checkpointManager,
 		Input: input,
@@ -228,10 +447,24 @@ func (c *checkpointManager) encodeAndSendCheckpoint(header *types.Header, extra
 	}
 
 	if receipt.Status == uint64(types.ReceiptFailed) {
-		return fmt.Errorf("checkpoint submission transaction failed for block %d", header.Number)
+		return fmt.Errorf("checkpoint submission transaction failed for block %d", checkpoint.header.Number)
 	}
 
-	c.logger.Debug("send checkpoint txn success", "block number", header.Number, "gasUsed", receipt.GasUsed)
+	c.logger.Debug("send checkpoint txn success", "block number", checkpoint.header.Number,
+		"gasUsed", receipt.GasUsed)
+
+	c.lastCheckpointedBlock.Store(checkpoint.header.Number)
+
+	submission := &types.CheckpointSubmission{
+		BlockNumber: checkpoint.header.Number,
+		EpochNumber: checkpoint.extra.Checkpoint.EpochNumber,
+		TxHash:      types.Hash(receipt.TransactionHash),
+		Timestamp:   uint64(time.Now().UTC().Unix()),
+	}
+
+	if err := c.state.CheckpointStore.updateCheckpointSubmission(submission); err != nil {
+		return fmt.Errorf("failed to save checkpoint submission for block %d: %w", checkpoint.header.Number, err)
+	}
 
 	return nil
 }
@@ -315,11 +548,126 @@ func (c *checkpointManager) PostBlock(req *PostBlockRequest) error {
 		}(req.FullBlock.Block.Header, req.Epoch)
 
 		c.lastSentBlock = req.FullBlock.Block.Number()
+	} else if req.IsEpochEndingBlock {
+		// refresh the latest checkpointed block, since the checkpoint is submitted by another validator
+		go func() {
+			if _, err := c.getLastCheckpointedBlock(); err != nil {
+				c.logger.Debug("failed to get the latest checkpointed block", "error", err)
+			}
+		}()
 	}
 
+	updateCheckpointMetrics(block, c.lastCheckpointedBlock.Load(), c.pendingCheckpoints.Load())
+
 	return nil
 }
 
+// GetCheckpointStatus returns the status of checkpoint submissions
+// (i.e. how far the checkpoints on the rootchain lag behind the child chain)
+func (c *checkpointManager) GetCheckpointStatus() (*types.CheckpointStatus, error) {
+	lastCheckpointedBlock, err := c.getLastCheckpointedBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	lastSubmission, err := c.state.CheckpointStore.getCheckpointSubmission()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &types.CheckpointStatus{
+		ChildChainHead:        c.blockchain.CurrentHeader().Number,
+		LastCheckpointedBlock: lastCheckpointedBlock,
+		CatchingUp:            c.catchingUp.Load(),
+		PendingCheckpoints:    c.pendingCheckpoints.Load(),
+		LastSubmission:        lastSubmission,
+	}
+
+	if status.ChildChainHead > lastCheckpointedBlock {
+		status.Lag = status.ChildChainHead - lastCheckpointedBlock
+	}
+
+	return status, nil
+}
+
+// GetExitStatuses returns the statuses of the exit events with given ids
+func (c *checkpointManager) GetExitStatuses(exitIDs []uint64) ([]*types.ExitStatus, error) {
+	var (
+		statuses        = make([]*types.ExitStatus, len(exitIDs))
+		checkpointBlock *uint64
+	)
+
+	for i, exitID := range exitIDs {
+		status := &types.ExitStatus{ExitID: exitID, Status: types.BridgeTransferUnknown}
+		statuses[i] = status
+
+		exitEvent, err := c.state.CheckpointStore.getExitEvent(exitID)
+		if err != nil {
+			var notFoundErr *exitEventNotFoundError
+			if errors.Is(err, errNoExitEventEpoch) || errors.As(err, &notFoundErr) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		status.Status = types.BridgeTransferPending
+		status.Sender = &exitEvent.Sender
+		status.Receiver = &exitEvent.Receiver
+		status.EpochNumber = exitEvent.EpochNumber
+		status.BlockNumber = exitEvent.BlockNumber
+
+		// the current checkpoint block is queried only once for all the exits
+		if checkpointBlock == nil {
+			currentCheckpointBlock, err := getCurrentCheckpointBlock(c.rootChainRelayer, c.checkpointManagerAddr)
+			if err != nil {
+				return nil, err
+			}
+
+			checkpointBlock = &currentCheckpointBlock
+		}
+
+		status.CheckpointBlock = *checkpointBlock
+
+		if *checkpointBlock < exitEvent.BlockNumber {
+			continue
+		}
+
+		status.Status = types.BridgeTransferCheckpointed
+
+		isProcessed, err := c.isExitProcessed(exitID)
+		if err != nil {
+			return nil, err
+		}
+
+		if isProcessed {
+			status.Status = types.BridgeTransferExited
+		}
+	}
+
+	return statuses, nil
+}
+
+// isExitProcessed queries ExitHelper smart contract whether the exit event with given id is already processed
+func (c *checkpointManager) isExitProcessed(exitID uint64) (bool, error) {
+	input, err := processedExitsMethod.Encode([]interface{}{new(big.Int).SetUint64(exitID)})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode processedExits function parameters: %w", err)
+	}
+
+	processedRaw, err := c.rootChainRelayer.Call(ethgo.ZeroAddress, ethgo.Address(c.exitHelperAddr), input)
+	if err != nil {
+		return false, fmt.Errorf("failed to invoke processedExits function on the rootchain: %w", err)
+	}
+
+	processed, err := strconv.ParseUint(processedRaw, 0, 64)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert processed exit flag '%s': %w", processedRaw, err)
+	}
+
+	return processed == 1, nil
+}
+
 // BuildEventRoot returns an exit event root hash for exit tree of given epoch
 func (c *checkpointManager) BuildEventRoot(epoch uint64) (types.Hash, error) {
 	exitEvents, err := c.state.CheckpointStore.getExitEventsByEpoch(epoch)
@@ -405,12 +753,7 @@ func (c *checkpointManager) GenerateExitProof(exitID uint64) (types.Proof, error
 		return types.Proof{}, err
 	}
 
-	exitEvents, err := c.state.CheckpointStore.getExitEventsForProof(exitEvent.EpochNumber, checkpointBlock.Uint64())
-	if err != nil {
-		return types.Proof{}, err
-	}
-
-	tree, err := createExitTree(exitEvents)
+	tree, err := c.getExitTree(exitEvent.EpochNumber, checkpointBlock.Uint64())
 	if err != nil {
 		return types.Proof{}, err
 	}
@@ -439,6 +782,34 @@ func (c *checkpointManager) GenerateExitProof(exitID uint64) (types.Proof, error
 	}, nil
 }
 
+// getExitTree returns the exit tree of the given epoch, which includes the exit events
+// up to the given checkpoint block. Exit trees of checkpoints are cached, since they never change.
+func (c *checkpointManager) getExitTree(epoch, checkpointBlock uint64) (*merkle.MerkleTree, error) {
+	key := exitTreeKey{epoch: epoch, checkpointBlock: checkpointBlock}
+
+	if c.exitTreeCache != nil {
+		if tree, ok := c.exitTreeCache.Get(key); ok {
+			return tree.(*merkle.MerkleTree), nil //nolint:forcetypeassert
+		}
+	}
+
+	exitEvents, err := c.state.CheckpointStore.getExitEventsForProof(epoch, checkpointBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := createExitTree(exitEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.exitTreeCache != nil {
+		c.exitTreeCache.Add(key, tree)
+	}
+
+	return tree, nil
+}
+
 // createExitTree creates an exit event merkle tree from provided exit events
 func createExitTree(exitEvents []*ExitEvent) (*merkle.MerkleTree, error) {
 	numOfEvents := len(exitEvents)
@@ -97,6 +97,8 @@ func TestCheckpointManager_SubmitCheckpoint(t *testing.T) {
 	blockchainMock := new(blockchainMock)
 	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headersMap.getHeader)
 
+	blockchainMock.On("CurrentHeader").Return(headersMap.getHeader(blocksCount))
+
 	validatorAcc := validators.GetValidator("A")
 	c := &checkpointManager{
 		key:              wallet.NewEcdsaSigner(validatorAcc.Key()),
@@ -104,6 +106,7 @@ func TestCheckpointManager_SubmitCheckpoint(t *testing.T) {
 		consensusBackend: backendMock,
 		blockchain:       blockchainMock,
 		logger:           hclog.NewNullLogger(),
+		state:            newTestState(t),
 	}
 
 	err = c.submitCheckpoint(headersMap.getHeader(blocksCount), false)
@@ -116,6 +119,107 @@ func TestCheckpointManager_SubmitCheckpoint(t *testing.T) {
 		require.NotNil(t, header)
 		require.True(t, isEndOfPeriod(header.Number, epochSize))
 	}
+
+	// the last submission is persisted
+	submission, err := c.state.CheckpointStore.getCheckpointSubmission()
+	require.NoError(t, err)
+	require.NotNil(t, submission)
+	require.Equal(t, uint64(blocksCount), submission.BlockNumber)
+	require.Equal(t, epochNumber-1, submission.EpochNumber)
+
+	// rootchain does not report the sent checkpoint yet,
+	// but the persisted submission prevents resending the checkpoint for the same block and epoch
+	txRelayerMock = newDummyTxRelayer(t)
+	txRelayerMock.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Return("2", error(nil))
+	c.rootChainRelayer = txRelayerMock
+
+	require.NoError(t, c.submitCheckpoint(headersMap.getHeader(blocksCount), false))
+	txRelayerMock.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+
+	// the rootchain is the source of truth for the checkpointed block
+	status, err := c.GetCheckpointStatus()
+	require.NoError(t, err)
+	require.Equal(t, uint64(blocksCount), status.ChildChainHead)
+	require.Equal(t, uint64(2), status.LastCheckpointedBlock)
+	require.Equal(t, uint64(blocksCount-2), status.Lag)
+	require.False(t, status.CatchingUp)
+	require.Zero(t, status.PendingCheckpoints)
+	require.Equal(t, submission, status.LastSubmission)
+
+	// the submission for the other block does not prevent sending the checkpoints
+	txRelayerMock.On("SendTransaction", mock.Anything, mock.Anything).
+		Return(&ethgo.Receipt{Status: uint64(types.ReceiptSuccess)}, error(nil)).
+		Times(3) // send transactions for checkpoint blocks: 4, 6 (pending checkpoint blocks) and 7 (latest block)
+
+	require.NoError(t, c.submitCheckpoint(headersMap.getHeader(blocksCount-3), false))
+	txRelayerMock.AssertExpectations(t)
+}
+
+func TestCheckpointManager_GetLastCheckpointedBlock(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	submission := &types.CheckpointSubmission{BlockNumber: 20, EpochNumber: 2, TxHash: types.StringToHash("0x1")}
+	require.NoError(t, state.CheckpointStore.updateCheckpointSubmission(submission))
+
+	txRelayerMock := newDummyTxRelayer(t)
+	txRelayerMock.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Return("10", error(nil)).
+		Once()
+	txRelayerMock.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Return("5", error(nil)).
+		Once()
+
+	c := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), 5, types.ZeroAddress,
+		types.ZeroAddress, txRelayerMock, new(blockchainMock), nil, hclog.NewNullLogger(), state)
+
+	// the persisted submission does not override the rootchain checkpoint block
+	lastCheckpointedBlock, err := c.getLastCheckpointedBlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), lastCheckpointedBlock)
+
+	stored, err := state.CheckpointStore.getCheckpointSubmission()
+	require.NoError(t, err)
+	require.Equal(t, submission, stored)
+
+	// the submission is cleared once the rootchain checkpoint block goes backwards
+	lastCheckpointedBlock, err = c.getLastCheckpointedBlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), lastCheckpointedBlock)
+
+	stored, err = state.CheckpointStore.getCheckpointSubmission()
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
+
+func TestCheckpointManager_GetCheckpointStatus(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	require.NoError(t, state.CheckpointStore.updateCheckpointSubmission(
+		&types.CheckpointSubmission{BlockNumber: 20, EpochNumber: 2, TxHash: types.StringToHash("0x1")}))
+
+	txRelayerMock := newDummyTxRelayer(t)
+	txRelayerMock.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Return("30", error(nil))
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("CurrentHeader").Return(&types.Header{Number: 45})
+
+	c := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), 5, types.ZeroAddress,
+		types.ZeroAddress, txRelayerMock, blockchainMock, nil, hclog.NewNullLogger(), state)
+
+	// the last submission is restored on startup
+	require.Equal(t, uint64(20), c.lastSentBlock)
+
+	status, err := c.GetCheckpointStatus()
+	require.NoError(t, err)
+	require.Equal(t, uint64(45), status.ChildChainHead)
+	// rootchain is ahead of the node submissions (checkpoints were submitted by other validators)
+	require.Equal(t, uint64(30), status.LastCheckpointedBlock)
+	require.Equal(t, uint64(15), status.Lag)
+	require.Equal(t, uint64(20), status.LastSubmission.BlockNumber)
 }
 
 func TestCheckpointManager_abiEncodeCheckpointBlock(t *testing.T) {
@@ -282,7 +386,7 @@ func TestCheckpointManager_IsCheckpointBlock(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			t.Parallel()
 
-			checkpointMgr := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), c.checkpointsOffset, types.ZeroAddress, nil, nil, nil, hclog.NewNullLogger(), nil)
+			checkpointMgr := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), c.checkpointsOffset, types.ZeroAddress, types.ZeroAddress, nil, nil, nil, hclog.NewNullLogger(), newTestState(t))
 			require.Equal(t, c.isCheckpointBlock, checkpointMgr.isCheckpointBlock(c.blockNumber, c.isEpochEndingBlock))
 		})
 	}
@@ -320,9 +424,15 @@ func TestCheckpointManager_PostBlock(t *testing.T) {
 
 	req.FullBlock.Block.Header.ExtraData = extra.MarshalRLPTo(nil)
 
+	// latest checkpointed block is refreshed on epoch ending blocks
+	txRelayerMock := newDummyTxRelayer(t)
+	txRelayerMock.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Return("0", error(nil)).
+		Maybe()
+
 	blockchain := new(blockchainMock)
 	checkpointManager := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), 5, types.ZeroAddress,
-		nil, blockchain, nil, hclog.NewNullLogger(), state)
+		types.ZeroAddress, txRelayerMock, blockchain, nil, hclog.NewNullLogger(), state)
 
 	t.Run("PostBlock - not epoch ending block", func(t *testing.T) {
 		require.NoError(t, state.CheckpointStore.updateLastSaved(block-1)) // we got everything till the current block
@@ -478,6 +588,7 @@ func TestCheckpointManager_GenerateExitProof(t *testing.T) {
 		createTestKey(t)),
 		0,
 		types.ZeroAddress,
+		types.ZeroAddress,
 		dummyTxRelayer,
 		nil,
 		nil,
@@ -496,6 +607,14 @@ func TestCheckpointManager_GenerateExitProof(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, proof)
 
+	// exit tree of the checkpoint is cached and reused for the next proofs
+	require.Equal(t, 1, checkpointMgr.exitTreeCache.Len())
+
+	cachedProof, err := checkpointMgr.GenerateExitProof(correctBlockToGetExit)
+	require.NoError(t, err)
+	require.Equal(t, proof, cachedProof)
+	require.Equal(t, 1, checkpointMgr.exitTreeCache.Len())
+
 	t.Run("Generate and validate exit proof", func(t *testing.T) {
 		t.Parallel()
 		// verify generated proof on desired tree
@@ -544,6 +663,72 @@ func TestCheckpointManager_GenerateExitProof(t *testing.T) {
 	})
 }
 
+func TestCheckpointManager_GetExitStatuses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		checkpointManagerAddr = types.StringToAddress("0x1")
+		exitHelperAddr        = types.StringToAddress("0x2")
+	)
+
+	state := newTestState(t)
+	// exit events 0 and 1 are emitted in block 1 and exit events 2 and 3 in block 2
+	insertTestExitEvents(t, state, 1, 2, 2)
+
+	checkpointBlockInput, err := currentCheckpointBlockNumMethod.Encode([]interface{}{})
+	require.NoError(t, err)
+
+	processedExitInput := func(exitID int64) []byte {
+		input, err := processedExitsMethod.Encode([]interface{}{big.NewInt(exitID)})
+		require.NoError(t, err)
+
+		return input
+	}
+
+	dummyTxRelayer := newDummyTxRelayer(t)
+	dummyTxRelayer.On("Call", ethgo.ZeroAddress, ethgo.Address(checkpointManagerAddr), checkpointBlockInput).
+		Return("0x1", error(nil))
+	dummyTxRelayer.On("Call", ethgo.ZeroAddress, ethgo.Address(exitHelperAddr), processedExitInput(0)).
+		Return("0x1", error(nil))
+	dummyTxRelayer.On("Call", ethgo.ZeroAddress, ethgo.Address(exitHelperAddr), processedExitInput(1)).
+		Return("0x0", error(nil))
+
+	checkpointMgr := newCheckpointManager(wallet.NewEcdsaSigner(createTestKey(t)), 0,
+		checkpointManagerAddr, exitHelperAddr, dummyTxRelayer, nil, nil, hclog.NewNullLogger(), state)
+
+	cases := []struct {
+		exitID uint64
+		status types.BridgeTransferStatus
+	}{
+		{0, types.BridgeTransferExited},
+		{1, types.BridgeTransferCheckpointed},
+		{2, types.BridgeTransferPending},
+		{10, types.BridgeTransferUnknown},
+	}
+
+	exitIDs := make([]uint64, len(cases))
+	for i, c := range cases {
+		exitIDs[i] = c.exitID
+	}
+
+	statuses, err := checkpointMgr.GetExitStatuses(exitIDs)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(cases))
+
+	for i, c := range cases {
+		require.Equal(t, c.exitID, statuses[i].ExitID)
+		require.Equal(t, c.status, statuses[i].Status)
+
+		if c.status != types.BridgeTransferUnknown {
+			require.Equal(t, uint64(1), statuses[i].CheckpointBlock)
+		}
+	}
+
+	// current checkpoint block is queried only once
+	dummyTxRelayer.AssertNumberOfCalls(t, "Call", 3)
+	dummyTxRelayer.AssertExpectations(t)
+}
+
 var _ txrelayer.TxRelayer = (*dummyTxRelayer)(nil)
 
 type dummyTxRelayer struct {
@@ -56,3 +56,48 @@ func updateBlockExecutionMetric(start time.Time) {
 	metrics.SetGauge([]string{consensusMetricsPrefix, "block_execution_time"},
 		float32(time.Now().UTC().Sub(start).Seconds()))
 }
+
+// updateCheckpointMetrics updates checkpoint-related metrics
+// (e.g. the lag between the child chain head and the latest checkpointed block)
+func updateCheckpointMetrics(childChainHead, lastCheckpointedBlock, pendingCheckpoints uint64) {
+	lag := uint64(0)
+	if childChainHead > lastCheckpointedBlock {
+		lag = childChainHead - lastCheckpointedBlock
+	}
+
+	metrics.SetGauge([]string{consensusMetricsPrefix, "checkpoint_lag"}, float32(lag))
+	metrics.SetGauge([]string{consensusMetricsPrefix, "last_checkpointed_block"}, float32(lastCheckpointedBlock))
+	metrics.SetGauge([]string{consensusMetricsPrefix, "pending_checkpoints"}, float32(pendingCheckpoints))
+}
+
+// updateValidatorUptimeMetrics updates the uptime metrics of each validator for the given epoch.
+// Metrics of the previously reported validators, which are not validators in the given epoch, are reset.
+// It returns the validators whose metrics are reported
+func updateValidatorUptimeMetrics(uptime *types.EpochUptime,
+	reported map[types.Address]struct{}) map[types.Address]struct{} {
+	metrics.SetGauge([]string{consensusMetricsPrefix, "validator_uptime_epoch"}, float32(uptime.Epoch))
+
+	current := make(map[types.Address]struct{}, len(uptime.Validators))
+
+	for _, v := range uptime.Validators {
+		setValidatorUptimeMetrics(v.Address, v.Uptime, v.MissedBlocks)
+
+		current[v.Address] = struct{}{}
+	}
+
+	for addr := range reported {
+		if _, ok := current[addr]; !ok {
+			setValidatorUptimeMetrics(addr, 0, 0)
+		}
+	}
+
+	return current
+}
+
+func setValidatorUptimeMetrics(addr types.Address, uptime float64, missedBlocks uint64) {
+	labels := []metrics.Label{{Name: "validator", Value: addr.String()}}
+
+	metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_uptime"}, float32(uptime), labels)
+	metrics.SetGaugeWithLabels([]string{consensusMetricsPrefix, "validator_missed_blocks"},
+		float32(missedBlocks), labels)
+}
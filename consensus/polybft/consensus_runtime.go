@@ -8,12 +8,14 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
 	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 
@@ -26,6 +28,9 @@ const (
 	maxCommitmentSize       = 10
 	stateFileName           = "consensusState.db"
 	commitEpochLookbackSize = 2 // number of blocks to calculate commit epoch info from the previous epoch
+	// maxExitsPerPage is the maximum number of exits returned by a single exits by address lookup,
+	// as the status of every checkpointed exit is queried on the rootchain
+	maxExitsPerPage = 100
 )
 
 var (
@@ -40,6 +45,7 @@ type txPoolInterface interface {
 	Prepare()
 	Length() uint64
 	Peek() *types.Transaction
+	ArrivalTime(types.Hash) (time.Time, bool)
 	Pop(*types.Transaction)
 	Drop(*types.Transaction)
 	Demote(*types.Transaction)
@@ -117,6 +123,9 @@ type consensusRuntime struct {
 	// manager for handling validator stake change and updating validator set
 	stakeManager StakeManager
 
+	// uptimeMetricsValidators holds the validators whose uptime metrics are reported
+	uptimeMetricsValidators map[types.Address]struct{}
+
 	// logger instance
 	logger hcf.Logger
 }
@@ -199,7 +208,11 @@ func (c *consensusRuntime) initCheckpointManager(logger hcf.Logger) error {
 		// enable checkpoint manager
 		txRelayer, err := txrelayer.NewTxRelayer(
 			txrelayer.WithIPAddress(c.config.PolyBFTConfig.Bridge.JSONRPCEndpoint),
-			txrelayer.WithWriter(logger.StandardWriter(&hcf.StandardLoggerOptions{})))
+			txrelayer.WithWriter(logger.StandardWriter(&hcf.StandardLoggerOptions{})),
+			txrelayer.WithResubmitPolicy(&txrelayer.ResubmitPolicy{
+				Interval:          txrelayer.DefaultResubmitInterval,
+				FeeBumpPercentage: txrelayer.DefaultFeeBumpPercentage,
+			}))
 		if err != nil {
 			return err
 		}
@@ -208,6 +221,7 @@ func (c *consensusRuntime) initCheckpointManager(logger hcf.Logger) error {
 			wallet.NewEcdsaSigner(c.config.Key),
 			defaultCheckpointsOffset,
 			c.config.PolyBFTConfig.Bridge.CheckpointManagerAddr,
+			c.config.PolyBFTConfig.Bridge.ExitHelperAddr,
 			txRelayer,
 			c.config.blockchain,
 			c.config.polybftBackend,
@@ -316,6 +330,11 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		c.logger.Error("failed to post block in stake manager", "err", err)
 	}
 
+	// account committed seals of the parent block
+	if err := c.updateValidatorUptime(fullBlock.Block.Header); err != nil {
+		c.logger.Error("failed to update validator uptime", "err", err)
+	}
+
 	if isEndOfEpoch {
 		if epoch, err = c.restartEpoch(fullBlock.Block.Header); err != nil {
 			c.logger.Error("failed to restart epoch after block inserted", "error", err)
@@ -458,6 +477,10 @@ func (c *consensusRuntime) restartEpoch(header *types.Header) (*epochMetadata, e
 		c.logger.Error("Could not clean previous epochs from db.", "error", err)
 	}
 
+	if err := c.state.UptimeStore.cleanEpochUptimesFromDB(epochNumber); err != nil {
+		c.logger.Error("Could not clean validators uptime of previous epochs from db.", "error", err)
+	}
+
 	if err := c.state.EpochStore.insertEpoch(epochNumber); err != nil {
 		return nil, fmt.Errorf("an error occurred while inserting new epoch in db. Reason: %w", err)
 	}
@@ -589,6 +612,123 @@ func (c *consensusRuntime) calculateCommitEpochInput(
 	return commitEpoch, distributeRewards, nil
 }
 
+// updateValidatorUptime accounts the committed seals of the parent block
+// (carried by the given block parent signatures) to the uptime of the parent block epoch
+func (c *consensusRuntime) updateValidatorUptime(header *types.Header) error {
+	// genesis block is not sealed
+	if header.Number <= 1 {
+		return nil
+	}
+
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return err
+	}
+
+	if extra.Parent == nil {
+		return nil
+	}
+
+	parentHeader, parentExtra, err := getBlockData(header.Number-1, c.config.blockchain)
+	if err != nil {
+		return err
+	}
+
+	// validators which were supposed to seal the parent block
+	validators, err := c.config.polybftBackend.GetValidators(parentHeader.Number-1, nil)
+	if err != nil {
+		return err
+	}
+
+	signers, err := validators.GetFilteredValidators(extra.Parent.Bitmap)
+	if err != nil {
+		return err
+	}
+
+	uptime, err := c.state.UptimeStore.updateEpochUptime(parentExtra.Checkpoint.EpochNumber, parentHeader.Number,
+		validators.GetAddresses(), signers.GetAddresses())
+	if err != nil {
+		return err
+	}
+
+	c.uptimeMetricsValidators = updateValidatorUptimeMetrics(uptime, c.uptimeMetricsValidators)
+
+	return nil
+}
+
+// GetValidatorUptime returns the validators uptime for the given epoch (or for the current epoch if nil)
+func (c *consensusRuntime) GetValidatorUptime(epoch *uint64) (*types.EpochUptime, error) {
+	if epoch == nil {
+		c.lock.RLock()
+		currentEpoch := c.epoch.Number
+		c.lock.RUnlock()
+
+		epoch = &currentEpoch
+	}
+
+	uptime, err := c.state.UptimeStore.getEpochUptime(*epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if uptime == nil {
+		return nil, fmt.Errorf("no validator uptime recorded for epoch %d", *epoch)
+	}
+
+	return uptime, nil
+}
+
+// GetEpochProof returns the epoch ending block header of the given (already finished) epoch,
+// which light clients use to transition their trusted validator set to the next epoch
+func (c *consensusRuntime) GetEpochProof(epoch uint64) (*types.EpochProof, error) {
+	c.lock.RLock()
+	currentEpoch := c.epoch
+	c.lock.RUnlock()
+
+	if epoch == 0 || epoch >= currentEpoch.Number {
+		return nil, fmt.Errorf("epoch %d is not finished (current epoch is %d)", epoch, currentEpoch.Number)
+	}
+
+	var searchErr error
+
+	// epoch numbers are monotonic across blocks, so look up the first block of any following epoch,
+	// which leaves its predecessor as the epoch ending block
+	lastBlock := currentEpoch.FirstBlockInEpoch - 1
+	epochEndingBlock := uint64(sort.Search(int(lastBlock), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		_, extra, err := getBlockData(uint64(i)+1, c.config.blockchain)
+		if err != nil {
+			searchErr = err
+
+			return true
+		}
+
+		return extra.Checkpoint.EpochNumber > epoch
+	}))
+
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	header, extra, err := getBlockData(epochEndingBlock, c.config.blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	if extra.Checkpoint == nil || extra.Checkpoint.EpochNumber != epoch || extra.Validators == nil {
+		return nil, fmt.Errorf("epoch ending block for epoch %d not found", epoch)
+	}
+
+	return &types.EpochProof{
+		Epoch:            epoch,
+		EpochEndingBlock: epochEndingBlock,
+		Header:           hex.EncodeToHex(header.MarshalRLP()),
+	}, nil
+}
+
 // GenerateExitProof generates proof of exit and is a bridge endpoint store function
 func (c *consensusRuntime) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	return c.checkpointManager.GenerateExitProof(exitID)
@@ -599,6 +739,95 @@ func (c *consensusRuntime) GetStateSyncProof(stateSyncID uint64) (types.Proof, e
 	return c.stateSyncManager.GetStateSyncProof(stateSyncID)
 }
 
+// GetDepositStatus returns the status of the deposit with given state sync id and is a bridge endpoint store function
+func (c *consensusRuntime) GetDepositStatus(stateSyncID uint64) (*types.DepositStatus, error) {
+	status, err := c.stateSyncManager.GetDepositStatus(stateSyncID)
+	if err != nil {
+		return nil, err
+	}
+
+	// only committed state syncs can be executed
+	if status.Status != types.BridgeTransferCommitted {
+		return status, nil
+	}
+
+	systemState, err := c.getSystemState(c.config.blockchain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+
+	isProcessed, err := systemState.IsStateSyncProcessed(stateSyncID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check whether StateSync id %d is executed: %w", stateSyncID, err)
+	}
+
+	if isProcessed {
+		status.Status = types.BridgeTransferExecuted
+	}
+
+	return status, nil
+}
+
+// GetDepositStatusesByTxHash returns the statuses of the deposits made by the rootchain transaction
+// with given hash and is a bridge endpoint store function
+func (c *consensusRuntime) GetDepositStatusesByTxHash(txHash types.Hash) ([]*types.DepositStatus, error) {
+	stateSyncIDs, err := c.state.StateSyncStore.getStateSyncIDsByTxHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*types.DepositStatus, len(stateSyncIDs))
+
+	for i, stateSyncID := range stateSyncIDs {
+		if statuses[i], err = c.GetDepositStatus(stateSyncID); err != nil {
+			return nil, err
+		}
+	}
+
+	return statuses, nil
+}
+
+// GetExitStatus returns the status of the exit event with given id and is a bridge endpoint store function
+func (c *consensusRuntime) GetExitStatus(exitID uint64) (*types.ExitStatus, error) {
+	statuses, err := c.checkpointManager.GetExitStatuses([]uint64{exitID})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses[0], nil
+}
+
+// GetExitStatusesByTxHash returns the statuses of the exits made by the transaction
+// with given hash and is a bridge endpoint store function
+func (c *consensusRuntime) GetExitStatusesByTxHash(txHash types.Hash) ([]*types.ExitStatus, error) {
+	exitIDs, err := c.state.CheckpointStore.getExitEventIDsByTxHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.checkpointManager.GetExitStatuses(exitIDs)
+}
+
+// GetExitsByAddress returns the statuses of at most limit exits withdrawn by or to the given address,
+// starting from the given exit event id, and is a bridge endpoint store function
+func (c *consensusRuntime) GetExitsByAddress(address types.Address, from, limit uint64) ([]*types.ExitStatus, error) {
+	if limit == 0 || limit > maxExitsPerPage {
+		limit = maxExitsPerPage
+	}
+
+	exitIDs, err := c.state.CheckpointStore.getExitEventIDsByAddress(address, from, int(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.checkpointManager.GetExitStatuses(exitIDs)
+}
+
+// GetCheckpointStatus returns the status of checkpoint submissions and is a bridge endpoint store function
+func (c *consensusRuntime) GetCheckpointStatus() (*types.CheckpointStatus, error) {
+	return c.checkpointManager.GetCheckpointStatus()
+}
+
 // setIsActiveValidator updates the activeValidatorFlag field
 func (c *consensusRuntime) setIsActiveValidator(isActiveValidator bool) {
 	c.activeValidatorFlag.Store(isActiveValidator)
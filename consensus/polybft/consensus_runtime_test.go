@@ -16,6 +16,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -201,7 +202,8 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headerMap.getHeader)
 
 	polybftBackendMock := new(polybftBackendMock)
-	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(validatorSet).Times(3)
+	// validators are fetched for the epoch restart as well as for the validator uptime accounting
+	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(validatorSet).Times(4)
 
 	txPool := new(txPoolMock)
 	txPool.On("ResetWithHeaders", mock.Anything).Once()
@@ -235,6 +237,13 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 	require.True(t, runtime.state.EpochStore.isEpochInserted(currentEpochNumber+1))
 	require.Equal(t, newEpochNumber, runtime.epoch.Number)
 
+	// committed seals of the parent block are accounted to the validators uptime
+	uptime, err := runtime.state.UptimeStore.getEpochUptime(currentEpochNumber)
+	require.NoError(t, err)
+	require.NotNil(t, uptime)
+	require.Equal(t, header.Number-1, uptime.LastBlock)
+	require.Len(t, uptime.Validators, validatorsCount)
+
 	blockchainMock.AssertExpectations(t)
 	systemStateMock.AssertExpectations(t)
 }
@@ -985,6 +994,55 @@ func TestConsensusRuntime_BuildPrepareMessage(t *testing.T) {
 	assert.Equal(t, signedMsg, runtime.BuildPrepareMessage(proposalHash, view))
 }
 
+func TestConsensusRuntime_GetEpochProof(t *testing.T) {
+	t.Parallel()
+
+	const epochSize = 5
+
+	headerMap := &testHeadersMap{}
+
+	// current epoch is the fourth one, the previous epochs ended on blocks 5, 10 and 15
+	for i := uint64(1); i <= 17; i++ {
+		extra := &Extra{
+			Checkpoint: &CheckpointData{EpochNumber: (i-1)/epochSize + 1},
+		}
+
+		if i%epochSize == 0 {
+			extra.Validators = &validator.ValidatorSetDelta{}
+		}
+
+		headerMap.addHeader(&types.Header{Number: i, ExtraData: extra.MarshalRLPTo(nil)})
+	}
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headerMap.getHeader)
+
+	runtime := &consensusRuntime{
+		config: &runtimeConfig{blockchain: blockchainMock},
+		epoch:  &epochMetadata{Number: 4, FirstBlockInEpoch: 16},
+	}
+
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		proof, err := runtime.GetEpochProof(epoch)
+		require.NoError(t, err)
+		require.Equal(t, epoch, proof.Epoch)
+		require.Equal(t, epoch*epochSize, proof.EpochEndingBlock)
+
+		raw, err := hex.DecodeHex(proof.Header)
+		require.NoError(t, err)
+
+		header := &types.Header{}
+		require.NoError(t, header.UnmarshalRLP(raw))
+		require.Equal(t, epoch*epochSize, header.Number)
+	}
+
+	_, err := runtime.GetEpochProof(4)
+	require.ErrorContains(t, err, "is not finished")
+
+	_, err = runtime.GetEpochProof(0)
+	require.ErrorContains(t, err, "is not finished")
+}
+
 func createTestBlocks(t *testing.T, numberOfBlocks, defaultEpochSize uint64,
 	validatorSet validator.AccountSet) (*types.Header, *testHeadersMap) {
 	t.Helper()
@@ -48,6 +48,7 @@ func main() {
 			[]string{
 				"commit",
 				"execute",
+				"batchExecute",
 			},
 			[]string{
 				"StateSyncResult",
@@ -92,6 +93,7 @@ func main() {
 			[]string{
 				"initialize",
 				"exit",
+				"batchExit",
 			},
 			[]string{},
 		},
@@ -77,6 +77,23 @@ func (e *ExecuteStateReceiverFn) DecodeAbi(buf []byte) error {
 	return decodeMethod(StateReceiver.Abi.Methods["execute"], buf, e)
 }
 
+type BatchExecuteStateReceiverFn struct {
+	Proofs [][]types.Hash `abi:"proofs"`
+	Objs   []*StateSync   `abi:"objs"`
+}
+
+func (b *BatchExecuteStateReceiverFn) Sig() []byte {
+	return StateReceiver.Abi.Methods["batchExecute"].ID()
+}
+
+func (b *BatchExecuteStateReceiverFn) EncodeAbi() ([]byte, error) {
+	return StateReceiver.Abi.Methods["batchExecute"].Encode(b)
+}
+
+func (b *BatchExecuteStateReceiverFn) DecodeAbi(buf []byte) error {
+	return decodeMethod(StateReceiver.Abi.Methods["batchExecute"], buf, b)
+}
+
 type StateSyncResultEvent struct {
 	Counter *big.Int `abi:"counter"`
 	Status  bool     `abi:"status"`
@@ -354,6 +371,39 @@ func (e *ExitExitHelperFn) DecodeAbi(buf []byte) error {
 	return decodeMethod(ExitHelper.Abi.Methods["exit"], buf, e)
 }
 
+type BatchExitInput struct {
+	BlockNumber  *big.Int     `abi:"blockNumber"`
+	LeafIndex    *big.Int     `abi:"leafIndex"`
+	UnhashedLeaf []byte       `abi:"unhashedLeaf"`
+	Proof        []types.Hash `abi:"proof"`
+}
+
+var BatchExitInputABIType = abi.MustNewType("tuple(uint256 blockNumber,uint256 leafIndex,bytes unhashedLeaf,bytes32[] proof)")
+
+func (b *BatchExitInput) EncodeAbi() ([]byte, error) {
+	return BatchExitInputABIType.Encode(b)
+}
+
+func (b *BatchExitInput) DecodeAbi(buf []byte) error {
+	return decodeStruct(BatchExitInputABIType, buf, &b)
+}
+
+type BatchExitExitHelperFn struct {
+	Inputs []*BatchExitInput `abi:"inputs"`
+}
+
+func (b *BatchExitExitHelperFn) Sig() []byte {
+	return ExitHelper.Abi.Methods["batchExit"].ID()
+}
+
+func (b *BatchExitExitHelperFn) EncodeAbi() ([]byte, error) {
+	return ExitHelper.Abi.Methods["batchExit"].Encode(b)
+}
+
+func (b *BatchExitExitHelperFn) DecodeAbi(buf []byte) error {
+	return decodeMethod(ExitHelper.Abi.Methods["batchExit"], buf, b)
+}
+
 type InitializeChildERC20PredicateFn struct {
 	NewL2StateSender          types.Address `abi:"newL2StateSender"`
 	NewStateReceiver          types.Address `abi:"newStateReceiver"`
@@ -2,133 +2,47 @@ package polybft
 
 import (
 	"fmt"
-	"math/big"
 
-	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
-	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
-	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/blockextra"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
-	"github.com/umbracle/ethgo/abi"
-	"github.com/umbracle/fastrlp"
 )
 
 const (
 	// ExtraVanity represents a fixed number of extra-data bytes reserved for proposer vanity
-	ExtraVanity = 32
+	ExtraVanity = blockextra.ExtraVanity
 
 	// ExtraSeal represents the fixed number of extra-data bytes reserved for proposer seal
-	ExtraSeal = 65
+	ExtraSeal = blockextra.ExtraSeal
 )
 
 // PolyBFTMixDigest represents a hash of "PolyBFT Mix" to identify whether the block is from PolyBFT consensus engine
-var PolyBFTMixDigest = types.StringToHash("adce6e5230abe012342a44e4e9b6d05997d6f015387ae0e59be924afc7ec70c1")
+var PolyBFTMixDigest = blockextra.PolyBFTMixDigest
 
-// Extra defines the structure of the extra field for Istanbul
-type Extra struct {
-	Validators *validator.ValidatorSetDelta
-	Parent     *Signature
-	Committed  *Signature
-	Checkpoint *CheckpointData
-}
-
-// MarshalRLPTo defines the marshal function wrapper for Extra
-func (i *Extra) MarshalRLPTo(dst []byte) []byte {
-	ar := &fastrlp.Arena{}
-
-	return append(make([]byte, ExtraVanity), i.MarshalRLPWith(ar).MarshalTo(dst)...)
-}
-
-// MarshalRLPWith defines the marshal function implementation for Extra
-func (i *Extra) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
-	vv := ar.NewArray()
-
-	// Validators
-	if i.Validators == nil {
-		vv.Set(ar.NewNullArray())
-	} else {
-		vv.Set(i.Validators.MarshalRLPWith(ar))
-	}
-
-	// Parent Signatures
-	if i.Parent == nil {
-		vv.Set(ar.NewNullArray())
-	} else {
-		vv.Set(i.Parent.MarshalRLPWith(ar))
-	}
-
-	// Committed Signatures
-	if i.Committed == nil {
-		vv.Set(ar.NewNullArray())
-	} else {
-		vv.Set(i.Committed.MarshalRLPWith(ar))
-	}
+type (
+	// Extra defines the structure of the extra field for Istanbul
+	Extra = blockextra.Extra
 
-	// Checkpoint
-	if i.Checkpoint == nil {
-		vv.Set(ar.NewNullArray())
-	} else {
-		vv.Set(i.Checkpoint.MarshalRLPWith(ar))
-	}
+	// Signature represents aggregated signatures of signers accompanied with a bitmap
+	Signature = blockextra.Signature
 
-	return vv
-}
+	// CheckpointData represents data needed for checkpointing mechanism
+	CheckpointData = blockextra.CheckpointData
+)
 
-// UnmarshalRLP defines the unmarshal function wrapper for Extra
-func (i *Extra) UnmarshalRLP(input []byte) error {
-	return fastrlp.UnmarshalRLP(input[ExtraVanity:], i)
+// GetIbftExtraClean returns unmarshaled extra field from the passed in header,
+// but without signatures for the given header (it only includes signatures for the parent block)
+func GetIbftExtraClean(extraRaw []byte) ([]byte, error) {
+	return blockextra.GetIbftExtraClean(extraRaw)
 }
 
-// UnmarshalRLPWith defines the unmarshal implementation for Extra
-func (i *Extra) UnmarshalRLPWith(v *fastrlp.Value) error {
-	const expectedElements = 4
-
-	elems, err := v.GetElems()
-	if err != nil {
-		return err
-	}
-
-	if num := len(elems); num != expectedElements {
-		return fmt.Errorf("incorrect elements count to decode Extra, expected %d but found %d", expectedElements, num)
-	}
-
-	// Validators
-	if elems[0].Elems() > 0 {
-		i.Validators = &validator.ValidatorSetDelta{}
-		if err := i.Validators.UnmarshalRLPWith(elems[0]); err != nil {
-			return err
-		}
-	}
-
-	// Parent Signatures
-	if elems[1].Elems() > 0 {
-		i.Parent = &Signature{}
-		if err := i.Parent.UnmarshalRLPWith(elems[1]); err != nil {
-			return err
-		}
-	}
-
-	// Committed Signatures
-	if elems[2].Elems() > 0 {
-		i.Committed = &Signature{}
-		if err := i.Committed.UnmarshalRLPWith(elems[2]); err != nil {
-			return err
-		}
-	}
-
-	// Checkpoint
-	if elems[3].Elems() > 0 {
-		i.Checkpoint = &CheckpointData{}
-		if err := i.Checkpoint.UnmarshalRLPWith(elems[3]); err != nil {
-			return err
-		}
-	}
-
-	return nil
+// GetIbftExtra returns the istanbul extra data field from the passed in header
+func GetIbftExtra(extraRaw []byte) (*Extra, error) {
+	return blockextra.GetIbftExtra(extraRaw)
 }
 
-// ValidateFinalizedData contains extra data validations for finalized headers
-func (i *Extra) ValidateFinalizedData(header *types.Header, parent *types.Header, parents []*types.Header,
+// validateFinalizedData contains extra data validations for finalized headers
+func validateFinalizedData(i *Extra, header *types.Header, parent *types.Header, parents []*types.Header,
 	chainID uint64, consensusBackend polybftBackend, domain []byte, logger hclog.Logger) error {
 	// validate committed signatures
 	blockNumber := header.Number
@@ -162,7 +76,7 @@ func (i *Extra) ValidateFinalizedData(header *types.Header, parent *types.Header
 	}
 
 	// validate parent signatures
-	if err := i.ValidateParentSignatures(blockNumber, consensusBackend, parents,
+	if err := validateParentSignatures(i, blockNumber, consensusBackend, parents,
 		parent, parentExtra, chainID, domain, logger); err != nil {
 		return err
 	}
@@ -170,8 +84,8 @@ func (i *Extra) ValidateFinalizedData(header *types.Header, parent *types.Header
 	return i.Checkpoint.ValidateBasic(parentExtra.Checkpoint)
 }
 
-// ValidateParentSignatures validates signatures for parent block
-func (i *Extra) ValidateParentSignatures(blockNumber uint64, consensusBackend polybftBackend, parents []*types.Header,
+// validateParentSignatures validates signatures for parent block
+func validateParentSignatures(i *Extra, blockNumber uint64, consensusBackend polybftBackend, parents []*types.Header,
 	parent *types.Header, parentExtra *Extra, chainID uint64, domain []byte, logger hclog.Logger) error {
 	// skip block 1 because genesis does not have committed signatures
 	if blockNumber <= 1 {
@@ -205,301 +119,3 @@ func (i *Extra) ValidateParentSignatures(blockNumber uint64, consensusBackend po
 
 	return nil
 }
-
-// Signature represents aggregated signatures of signers accompanied with a bitmap
-// (in order to be able to determine identities of each signer)
-type Signature struct {
-	AggregatedSignature []byte
-	Bitmap              []byte
-}
-
-// MarshalRLPWith marshals Signature object into RLP format
-func (s *Signature) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
-	committed := ar.NewArray()
-	if s.AggregatedSignature == nil {
-		committed.Set(ar.NewNull())
-	} else {
-		committed.Set(ar.NewBytes(s.AggregatedSignature))
-	}
-
-	if s.Bitmap == nil {
-		committed.Set(ar.NewNull())
-	} else {
-		committed.Set(ar.NewBytes(s.Bitmap))
-	}
-
-	return committed
-}
-
-// UnmarshalRLPWith unmarshals Signature object from the RLP format
-func (s *Signature) UnmarshalRLPWith(v *fastrlp.Value) error {
-	vals, err := v.GetElems()
-	if err != nil {
-		return fmt.Errorf("array type expected for signature struct")
-	}
-
-	// there should be exactly two elements (aggregated signature and bitmap)
-	if num := len(vals); num != 2 {
-		return fmt.Errorf("incorrect elements count to decode Signature, expected 2 but found %d", num)
-	}
-
-	s.AggregatedSignature, err = vals[0].GetBytes(nil)
-	if err != nil {
-		return err
-	}
-
-	s.Bitmap, err = vals[1].GetBytes(nil)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// Verify is used to verify aggregated signature based on current validator set, message hash and domain
-func (s *Signature) Verify(blockNumber uint64, validators validator.AccountSet,
-	hash types.Hash, domain []byte, logger hclog.Logger) error {
-	signers, err := validators.GetFilteredValidators(s.Bitmap)
-	if err != nil {
-		return err
-	}
-
-	validatorSet := validator.NewValidatorSet(validators, logger)
-	if !validatorSet.HasQuorum(blockNumber, signers.GetAddressesAsSet()) {
-		return fmt.Errorf("quorum not reached")
-	}
-
-	blsPublicKeys := make([]*bls.PublicKey, len(signers))
-	for i, validator := range signers {
-		blsPublicKeys[i] = validator.BlsKey
-	}
-
-	aggs, err := bls.UnmarshalSignature(s.AggregatedSignature)
-	if err != nil {
-		return err
-	}
-
-	if !aggs.VerifyAggregated(blsPublicKeys, hash[:], domain) {
-		return fmt.Errorf("could not verify aggregated signature")
-	}
-
-	return nil
-}
-
-var checkpointDataABIType = abi.MustNewType(`tuple(
-	uint256 chainId,
-	uint256 blockNumber,
-	bytes32 blockHash,
-	uint256 blockRound, 
-	uint256 epochNumber,
-	bytes32 eventRoot,
-	bytes32 currentValidatorsHash,
-	bytes32 nextValidatorsHash)`)
-
-// CheckpointData represents data needed for checkpointing mechanism
-type CheckpointData struct {
-	BlockRound            uint64
-	EpochNumber           uint64
-	CurrentValidatorsHash types.Hash
-	NextValidatorsHash    types.Hash
-	EventRoot             types.Hash
-}
-
-// MarshalRLPWith defines the marshal function implementation for CheckpointData
-func (c *CheckpointData) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
-	vv := ar.NewArray()
-	// BlockRound
-	vv.Set(ar.NewUint(c.BlockRound))
-	// EpochNumber
-	vv.Set(ar.NewUint(c.EpochNumber))
-	// CurrentValidatorsHash
-	vv.Set(ar.NewBytes(c.CurrentValidatorsHash.Bytes()))
-	// NextValidatorsHash
-	vv.Set(ar.NewBytes(c.NextValidatorsHash.Bytes()))
-	// EventRoot
-	vv.Set(ar.NewBytes(c.EventRoot.Bytes()))
-
-	return vv
-}
-
-// UnmarshalRLPWith unmarshals CheckpointData object from the RLP format
-func (c *CheckpointData) UnmarshalRLPWith(v *fastrlp.Value) error {
-	vals, err := v.GetElems()
-	if err != nil {
-		return fmt.Errorf("array type expected for CheckpointData struct")
-	}
-
-	// there should be exactly 5 elements:
-	// BlockRound, EpochNumber, CurrentValidatorsHash, NextValidatorsHash, EventRoot
-	if num := len(vals); num != 5 {
-		return fmt.Errorf("incorrect elements count to decode CheckpointData, expected 5 but found %d", num)
-	}
-
-	// BlockRound
-	c.BlockRound, err = vals[0].GetUint64()
-	if err != nil {
-		return err
-	}
-
-	// EpochNumber
-	c.EpochNumber, err = vals[1].GetUint64()
-	if err != nil {
-		return err
-	}
-
-	// CurrentValidatorsHash
-	currentValidatorsHashRaw, err := vals[2].GetBytes(nil)
-	if err != nil {
-		return err
-	}
-
-	c.CurrentValidatorsHash = types.BytesToHash(currentValidatorsHashRaw)
-
-	// NextValidatorsHash
-	nextValidatorsHashRaw, err := vals[3].GetBytes(nil)
-	if err != nil {
-		return err
-	}
-
-	c.NextValidatorsHash = types.BytesToHash(nextValidatorsHashRaw)
-
-	// EventRoot
-	eventRootRaw, err := vals[4].GetBytes(nil)
-	if err != nil {
-		return err
-	}
-
-	c.EventRoot = types.BytesToHash(eventRootRaw)
-
-	return nil
-}
-
-// Copy returns deep copy of CheckpointData instance
-func (c *CheckpointData) Copy() *CheckpointData {
-	newCheckpointData := new(CheckpointData)
-	*newCheckpointData = *c
-
-	return newCheckpointData
-}
-
-// Hash calculates keccak256 hash of the CheckpointData.
-// CheckpointData is ABI encoded and then hashed.
-func (c *CheckpointData) Hash(chainID uint64, blockNumber uint64, blockHash types.Hash) (types.Hash, error) {
-	checkpointMap := map[string]interface{}{
-		"chainId":               new(big.Int).SetUint64(chainID),
-		"blockNumber":           new(big.Int).SetUint64(blockNumber),
-		"blockHash":             blockHash,
-		"blockRound":            new(big.Int).SetUint64(c.BlockRound),
-		"epochNumber":           new(big.Int).SetUint64(c.EpochNumber),
-		"eventRoot":             c.EventRoot,
-		"currentValidatorsHash": c.CurrentValidatorsHash,
-		"nextValidatorsHash":    c.NextValidatorsHash,
-	}
-
-	abiEncoded, err := checkpointDataABIType.Encode(checkpointMap)
-	if err != nil {
-		return types.ZeroHash, err
-	}
-
-	return types.BytesToHash(crypto.Keccak256(abiEncoded)), nil
-}
-
-// ValidateBasic encapsulates basic validation logic for checkpoint data.
-// It only checks epoch numbers validity and whether validators hashes are non-empty.
-func (c *CheckpointData) ValidateBasic(parentCheckpoint *CheckpointData) error {
-	if c.EpochNumber != parentCheckpoint.EpochNumber &&
-		c.EpochNumber != parentCheckpoint.EpochNumber+1 {
-		// epoch-beginning block
-		// epoch number must be incremented by one compared to parent block's checkpoint
-		return fmt.Errorf("invalid epoch number for epoch-beginning block")
-	}
-
-	if c.CurrentValidatorsHash == types.ZeroHash {
-		return fmt.Errorf("current validators hash must not be empty")
-	}
-
-	if c.NextValidatorsHash == types.ZeroHash {
-		return fmt.Errorf("next validators hash must not be empty")
-	}
-
-	return nil
-}
-
-// Validate encapsulates validation logic for checkpoint data
-// (with regards to current and next epoch validators)
-func (c *CheckpointData) Validate(parentCheckpoint *CheckpointData,
-	currentValidators validator.AccountSet, nextValidators validator.AccountSet,
-	exitRootHash types.Hash) error {
-	if err := c.ValidateBasic(parentCheckpoint); err != nil {
-		return err
-	}
-
-	// check if currentValidatorsHash, present in CheckpointData is correct
-	currentValidatorsHash, err := currentValidators.Hash()
-	if err != nil {
-		return fmt.Errorf("failed to calculate current validators hash: %w", err)
-	}
-
-	if currentValidatorsHash != c.CurrentValidatorsHash {
-		return fmt.Errorf("current validators hashes don't match")
-	}
-
-	// check if nextValidatorsHash, present in CheckpointData is correct
-	nextValidatorsHash, err := nextValidators.Hash()
-	if err != nil {
-		return fmt.Errorf("failed to calculate next validators hash: %w", err)
-	}
-
-	if nextValidatorsHash != c.NextValidatorsHash {
-		return fmt.Errorf("next validators hashes don't match")
-	}
-
-	// epoch ending blocks have validator set transitions
-	if !currentValidators.Equals(nextValidators) &&
-		c.EpochNumber != parentCheckpoint.EpochNumber {
-		// epoch ending blocks should have the same epoch number as parent block
-		// (as they belong to the same epoch)
-		return fmt.Errorf("epoch number should not change for epoch-ending block")
-	}
-
-	// exit root hash of proposer and
-	// validator that validates proposal have to match
-	if exitRootHash != c.EventRoot {
-		return fmt.Errorf("exit root hash not as expected")
-	}
-
-	return nil
-}
-
-// GetIbftExtraClean returns unmarshaled extra field from the passed in header,
-// but without signatures for the given header (it only includes signatures for the parent block)
-func GetIbftExtraClean(extraRaw []byte) ([]byte, error) {
-	extra, err := GetIbftExtra(extraRaw)
-	if err != nil {
-		return nil, err
-	}
-
-	ibftExtra := &Extra{
-		Parent:     extra.Parent,
-		Validators: extra.Validators,
-		Checkpoint: extra.Checkpoint,
-		Committed:  &Signature{},
-	}
-
-	return ibftExtra.MarshalRLPTo(nil), nil
-}
-
-// GetIbftExtra returns the istanbul extra data field from the passed in header
-func GetIbftExtra(extraRaw []byte) (*Extra, error) {
-	if len(extraRaw) < ExtraVanity {
-		return nil, fmt.Errorf("wrong extra size: %d", len(extraRaw))
-	}
-
-	extra := &Extra{}
-
-	if err := extra.UnmarshalRLP(extraRaw); err != nil {
-		return nil, err
-	}
-
-	return extra, nil
-}
@@ -261,14 +261,14 @@ func TestExtra_ValidateFinalizedData_UnhappyPath(t *testing.T) {
 
 	// missing Committed field
 	extra := &Extra{}
-	err := extra.ValidateFinalizedData(
-		header, parent, nil, chainID, nil, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err := validateFinalizedData(
+		extra, header, parent, nil, chainID, nil, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err, fmt.Sprintf("failed to verify signatures for block %d, because signatures are not present", headerNum))
 
 	// missing Checkpoint field
 	extra = &Extra{Committed: &Signature{}}
-	err = extra.ValidateFinalizedData(
-		header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateFinalizedData(
+		extra, header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err, fmt.Sprintf("failed to verify signatures for block %d, because checkpoint data are not present", headerNum))
 
 	// failed to retrieve validators from snapshot
@@ -278,8 +278,8 @@ func TestExtra_ValidateFinalizedData_UnhappyPath(t *testing.T) {
 		EventRoot:   types.BytesToHash(generateRandomBytes(t)),
 	}
 	extra = &Extra{Committed: &Signature{}, Checkpoint: checkpoint}
-	err = extra.ValidateFinalizedData(
-		header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateFinalizedData(
+		extra, header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err,
 		fmt.Sprintf("failed to validate header for block %d. could not retrieve block validators:validators not found", headerNum))
 
@@ -292,16 +292,16 @@ func TestExtra_ValidateFinalizedData_UnhappyPath(t *testing.T) {
 	checkpointHash, err := checkpoint.Hash(chainID, headerNum, header.Hash)
 	require.NoError(t, err)
 
-	err = extra.ValidateFinalizedData(
-		header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateFinalizedData(
+		extra, header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err,
 		fmt.Sprintf("failed to verify signatures for block %d (proposal hash %s): quorum not reached", headerNum, checkpointHash))
 
 	// incorrect parent extra size
 	validSignature := createSignature(t, validators.GetPrivateIdentities(), checkpointHash, bls.DomainCheckpointManager)
 	extra = &Extra{Committed: validSignature, Checkpoint: checkpoint}
-	err = extra.ValidateFinalizedData(
-		header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateFinalizedData(
+		extra, header, parent, nil, chainID, polyBackendMock, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err,
 		fmt.Sprintf("failed to verify signatures for block %d: wrong extra size: 0", headerNum))
 }
@@ -319,13 +319,13 @@ func TestExtra_ValidateParentSignatures(t *testing.T) {
 
 	// validation is skipped for blocks 0 and 1
 	extra := &Extra{}
-	err := extra.ValidateParentSignatures(
-		1, polyBackendMock, nil, nil, nil, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err := validateParentSignatures(
+		extra, 1, polyBackendMock, nil, nil, nil, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.NoError(t, err)
 
 	// parent signatures not present
-	err = extra.ValidateParentSignatures(
-		headerNum, polyBackendMock, nil, nil, nil, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateParentSignatures(
+		extra, headerNum, polyBackendMock, nil, nil, nil, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err, fmt.Sprintf("failed to verify signatures for parent of block %d because signatures are not present", headerNum))
 
 	// validators not found
@@ -333,8 +333,8 @@ func TestExtra_ValidateParentSignatures(t *testing.T) {
 	incorrectHash := types.BytesToHash([]byte("Hello World"))
 	invalidSig := createSignature(t, validators.GetPrivateIdentities(), incorrectHash, bls.DomainCheckpointManager)
 	extra = &Extra{Parent: invalidSig}
-	err = extra.ValidateParentSignatures(
-		headerNum, polyBackendMock, nil, nil, nil, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateParentSignatures(
+		extra, headerNum, polyBackendMock, nil, nil, nil, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err,
 		fmt.Sprintf("failed to validate header for block %d. could not retrieve parent validators: no validators", headerNum))
 
@@ -349,16 +349,18 @@ func TestExtra_ValidateParentSignatures(t *testing.T) {
 	parentCheckpointHash, err := parentCheckpoint.Hash(chainID, parent.Number, parent.Hash)
 	require.NoError(t, err)
 
-	err = extra.ValidateParentSignatures(
-		headerNum, polyBackendMock, nil, parent, parentExtra, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateParentSignatures(
+		extra, headerNum, polyBackendMock, nil, parent, parentExtra, chainID,
+		bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.ErrorContains(t, err,
 		fmt.Sprintf("failed to verify signatures for parent of block %d (proposal hash: %s): could not verify aggregated signature", headerNum, parentCheckpointHash))
 
 	// valid signature provided
 	validSig := createSignature(t, validators.GetPrivateIdentities(), parentCheckpointHash, bls.DomainCheckpointManager)
 	extra = &Extra{Parent: validSig}
-	err = extra.ValidateParentSignatures(
-		headerNum, polyBackendMock, nil, parent, parentExtra, chainID, bls.DomainCheckpointManager, hclog.NewNullLogger())
+	err = validateParentSignatures(
+		extra, headerNum, polyBackendMock, nil, parent, parentExtra, chainID,
+		bls.DomainCheckpointManager, hclog.NewNullLogger())
 	require.NoError(t, err)
 }
 
@@ -307,6 +307,9 @@ func (f *fsm) Validate(proposal []byte) error {
 		)
 	}
 
+	// read the proposal state in the background, while its signatures and state transactions are validated
+	f.backend.PrefetchBlock(&block)
+
 	extra, err := GetIbftExtra(block.Header.ExtraData)
 	if err != nil {
 		return fmt.Errorf("cannot get extra data:%w", err)
@@ -325,7 +328,7 @@ func (f *fsm) Validate(proposal []byte) error {
 		return fmt.Errorf("checkpoint data for parent block %d is missing", f.parent.Number)
 	}
 
-	if err := extra.ValidateParentSignatures(block.Number(), f.polybftBackend, nil, f.parent, parentExtra,
+	if err := validateParentSignatures(extra, block.Number(), f.polybftBackend, nil, f.parent, parentExtra,
 		f.backend.GetChainID(), bls.DomainCheckpointManager, f.logger); err != nil {
 		return err
 	}
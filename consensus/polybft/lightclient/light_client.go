@@ -0,0 +1,287 @@
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/blockextra"
+	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	errNoTrustedValidators = errors.New("trusted validator set is empty")
+	errMissingCheckpoint   = errors.New("header extra does not contain checkpoint data")
+	errMissingCommitted    = errors.New("header extra does not contain committed seals")
+)
+
+// TrustedState is the light client view of the chain, which is either provided
+// out of band (trusted checkpoint) or obtained by verifying the headers
+type TrustedState struct {
+	// BlockNumber is the number of the last trusted block
+	BlockNumber uint64
+	// BlockHash is the hash of the last trusted block
+	BlockHash types.Hash
+	// Epoch is the epoch of the blocks following the last trusted block
+	Epoch uint64
+	// Validators is the validator set which seals the blocks following the last trusted block
+	Validators validator.AccountSet
+}
+
+// Copy returns a deep copy of the trusted state
+func (s *TrustedState) Copy() *TrustedState {
+	return &TrustedState{
+		BlockNumber: s.BlockNumber,
+		BlockHash:   s.BlockHash,
+		Epoch:       s.Epoch,
+		Validators:  s.Validators.Copy(),
+	}
+}
+
+// NewTrustedState creates the trusted state out of the trusted header
+// and the validator set which sealed it
+func NewTrustedState(header *types.Header, validators validator.AccountSet) (*TrustedState, error) {
+	if len(validators) == 0 {
+		return nil, errNoTrustedValidators
+	}
+
+	extra, err := blockextra.GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+
+	if extra.Checkpoint == nil {
+		return nil, errMissingCheckpoint
+	}
+
+	hash, err := headerHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &TrustedState{
+		BlockNumber: header.Number,
+		BlockHash:   hash,
+		Epoch:       extra.Checkpoint.EpochNumber,
+		Validators:  validators.Copy(),
+	}
+
+	if extra.Validators != nil {
+		// trusted header is the epoch ending one, so the next epoch validators seal the following blocks
+		if state.Validators, err = validators.ApplyDelta(extra.Validators); err != nil {
+			return nil, err
+		}
+
+		state.Epoch++
+	}
+
+	return state, nil
+}
+
+// LightClient verifies PolyBFT headers without executing the blocks, by checking their committed seals
+// against the trusted validator set and by following the validator set changes across epochs
+type LightClient struct {
+	chainID uint64
+	logger  hclog.Logger
+
+	lock    sync.RWMutex
+	trusted *TrustedState
+}
+
+// NewLightClient creates a light client which starts from the given trusted state
+func NewLightClient(chainID uint64, trusted *TrustedState, logger hclog.Logger) (*LightClient, error) {
+	if trusted == nil || len(trusted.Validators) == 0 {
+		return nil, errNoTrustedValidators
+	}
+
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &LightClient{
+		chainID: chainID,
+		logger:  logger.Named("light-client"),
+		trusted: trusted.Copy(),
+	}, nil
+}
+
+// TrustedState returns a copy of the current light client trusted state
+func (l *LightClient) TrustedState() *TrustedState {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.trusted.Copy()
+}
+
+// VerifyHeaders verifies the given headers in order and advances the trusted state after each of them.
+// Verification stops on the first invalid header, leaving the trusted state at its parent
+func (l *LightClient) VerifyHeaders(headers []*types.Header) error {
+	for _, header := range headers {
+		if err := l.VerifyHeader(header); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyHeader verifies a header of the trusted epoch and advances the trusted state to it.
+// Headers of the trusted epoch can be skipped, but headers of the following epochs can only be verified
+// once the epoch ending header (see VerifyEpochProof) of the trusted epoch is verified
+func (l *LightClient) VerifyHeader(header *types.Header) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	state, err := l.verifyHeader(l.trusted, header)
+	if err != nil {
+		return fmt.Errorf("header %d verification failed: %w", header.Number, err)
+	}
+
+	l.trusted = state
+
+	return nil
+}
+
+// VerifyEpochProof verifies the epoch ending header of the trusted epoch
+// and moves the trusted state to the next epoch validator set
+func (l *LightClient) VerifyEpochProof(proof *types.EpochProof) error {
+	raw, err := hex.DecodeHex(proof.Header)
+	if err != nil {
+		return fmt.Errorf("failed to decode epoch %d proof header: %w", proof.Epoch, err)
+	}
+
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(raw); err != nil {
+		return fmt.Errorf("failed to unmarshal epoch %d proof header: %w", proof.Epoch, err)
+	}
+
+	if header.Number != proof.EpochEndingBlock {
+		return fmt.Errorf("epoch %d proof header number mismatch: expected %d, but got %d",
+			proof.Epoch, proof.EpochEndingBlock, header.Number)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if proof.Epoch != l.trusted.Epoch {
+		return fmt.Errorf("epoch %d proof can not be applied on trusted epoch %d", proof.Epoch, l.trusted.Epoch)
+	}
+
+	state, err := l.verifyHeader(l.trusted, header)
+	if err != nil {
+		return fmt.Errorf("epoch %d proof verification failed: %w", proof.Epoch, err)
+	}
+
+	if state.Epoch == proof.Epoch {
+		return fmt.Errorf("epoch %d proof header %d is not an epoch ending header", proof.Epoch, header.Number)
+	}
+
+	l.trusted = state
+
+	return nil
+}
+
+// verifyHeader verifies the header against the trusted state and returns the trusted state after the header
+func (l *LightClient) verifyHeader(trusted *TrustedState, header *types.Header) (*TrustedState, error) {
+	if header.Number <= trusted.BlockNumber {
+		return nil, fmt.Errorf("header is not newer than the trusted block %d", trusted.BlockNumber)
+	}
+
+	hash, err := headerHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Number == trusted.BlockNumber+1 && header.ParentHash != trusted.BlockHash {
+		return nil, fmt.Errorf("parent hash mismatch: expected %s, but got %s", trusted.BlockHash, header.ParentHash)
+	}
+
+	extra, err := blockextra.GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+
+	if extra.Checkpoint == nil {
+		return nil, errMissingCheckpoint
+	}
+
+	if extra.Committed == nil {
+		return nil, errMissingCommitted
+	}
+
+	if extra.Checkpoint.EpochNumber != trusted.Epoch {
+		return nil, fmt.Errorf("header belongs to epoch %d, but trusted epoch is %d",
+			extra.Checkpoint.EpochNumber, trusted.Epoch)
+	}
+
+	currentValidatorsHash, err := trusted.Validators.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	if extra.Checkpoint.CurrentValidatorsHash != currentValidatorsHash {
+		return nil, fmt.Errorf("current validators hash mismatch: expected %s, but got %s",
+			currentValidatorsHash, extra.Checkpoint.CurrentValidatorsHash)
+	}
+
+	checkpointHash, err := extra.Checkpoint.Hash(l.chainID, header.Number, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := extra.Committed.Verify(header.Number, trusted.Validators, checkpointHash,
+		bls.DomainCheckpointManager, l.logger); err != nil {
+		return nil, fmt.Errorf("invalid committed seals: %w", err)
+	}
+
+	nextValidators := trusted.Validators
+	nextEpoch := trusted.Epoch
+
+	if extra.Validators != nil {
+		// only epoch ending headers carry the validator set delta (which may be empty)
+		if nextValidators, err = trusted.Validators.ApplyDelta(extra.Validators); err != nil {
+			return nil, err
+		}
+
+		nextEpoch++
+	}
+
+	nextValidatorsHash, err := nextValidators.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	if extra.Checkpoint.NextValidatorsHash != nextValidatorsHash {
+		return nil, fmt.Errorf("next validators hash mismatch: expected %s, but got %s",
+			nextValidatorsHash, extra.Checkpoint.NextValidatorsHash)
+	}
+
+	if nextEpoch != trusted.Epoch {
+		l.logger.Debug("validator set transition verified", "block", header.Number,
+			"epoch", nextEpoch, "validators", len(nextValidators))
+	}
+
+	return &TrustedState{
+		BlockNumber: header.Number,
+		BlockHash:   hash,
+		Epoch:       nextEpoch,
+		Validators:  nextValidators,
+	}, nil
+}
+
+// headerHash calculates the PolyBFT header hash, which omits the committed seals from the extra data
+func headerHash(header *types.Header) (types.Hash, error) {
+	extra, err := blockextra.GetIbftExtraClean(header.ExtraData)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	h := header.Copy()
+	h.ExtraData = extra
+
+	return h.ComputeHash().Hash, nil
+}
@@ -0,0 +1,290 @@
+package lightclient
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/blockextra"
+	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = 100
+
+// testChain holds the headers of a chain with two epochs of three blocks each,
+// where validator E joins the validator set at the end of the first epoch
+type testChain struct {
+	validators     *validator.TestValidators
+	firstEpochSet  validator.AccountSet
+	secondEpochSet validator.AccountSet
+	genesisHash    types.Hash
+	headers        map[uint64]*types.Header
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D", "E"})
+	firstEpochSet := validators.GetPublicIdentities("A", "B", "C", "D")
+	delta := &validator.ValidatorSetDelta{
+		Added:   validators.GetPublicIdentities("E"),
+		Removed: bitmap.Bitmap{},
+	}
+
+	secondEpochSet, err := firstEpochSet.ApplyDelta(delta)
+	require.NoError(t, err)
+
+	c := &testChain{
+		validators:     validators,
+		firstEpochSet:  firstEpochSet,
+		secondEpochSet: secondEpochSet,
+		genesisHash:    types.StringToHash("genesis"),
+		headers:        map[uint64]*types.Header{},
+	}
+
+	parentHash := c.genesisHash
+
+	for number := uint64(1); number <= 6; number++ {
+		var (
+			epoch   = uint64(1)
+			current = firstEpochSet
+			next    = firstEpochSet
+			signers = []string{"A", "B", "C", "D"}
+			blDelta *validator.ValidatorSetDelta
+		)
+
+		switch {
+		case number == 3:
+			next = secondEpochSet
+			blDelta = delta
+		case number > 3:
+			epoch = 2
+			current = secondEpochSet
+			next = secondEpochSet
+			signers = []string{"A", "B", "C", "D", "E"}
+		}
+
+		c.headers[number] = c.buildHeader(t, number, parentHash, epoch, current, next, blDelta, signers)
+		parentHash = c.headers[number].Hash
+	}
+
+	return c
+}
+
+func (c *testChain) buildHeader(t *testing.T, number uint64, parentHash types.Hash, epoch uint64,
+	current, next validator.AccountSet, delta *validator.ValidatorSetDelta, signers []string) *types.Header {
+	t.Helper()
+
+	currentHash, err := current.Hash()
+	require.NoError(t, err)
+
+	nextHash, err := next.Hash()
+	require.NoError(t, err)
+
+	extra := &blockextra.Extra{
+		Validators: delta,
+		Parent:     &blockextra.Signature{},
+		Committed:  &blockextra.Signature{},
+		Checkpoint: &blockextra.CheckpointData{
+			BlockRound:            0,
+			EpochNumber:           epoch,
+			CurrentValidatorsHash: currentHash,
+			NextValidatorsHash:    nextHash,
+		},
+	}
+
+	header := &types.Header{
+		Number:     number,
+		ParentHash: parentHash,
+		Timestamp:  number,
+		ExtraData:  extra.MarshalRLPTo(nil),
+	}
+
+	hash, err := headerHash(header)
+	require.NoError(t, err)
+
+	checkpointHash, err := extra.Checkpoint.Hash(testChainID, number, hash)
+	require.NoError(t, err)
+
+	extra.Committed = c.sign(t, current, checkpointHash, signers)
+	header.ExtraData = extra.MarshalRLPTo(nil)
+	header.Hash = hash
+
+	return header
+}
+
+func (c *testChain) sign(t *testing.T, set validator.AccountSet, hash types.Hash, aliases []string) *blockextra.Signature {
+	t.Helper()
+
+	var (
+		signatures bls.Signatures
+		bmp        bitmap.Bitmap
+	)
+
+	for _, alias := range aliases {
+		v := c.validators.GetValidator(alias)
+		idx := set.Index(v.Address())
+		require.NotEqual(t, -1, idx)
+
+		bmp.Set(uint64(idx))
+		signatures = append(signatures, v.MustSign(hash[:], bls.DomainCheckpointManager))
+	}
+
+	aggs, err := signatures.Aggregate().Marshal()
+	require.NoError(t, err)
+
+	return &blockextra.Signature{AggregatedSignature: aggs, Bitmap: bmp}
+}
+
+func (c *testChain) newLightClient(t *testing.T) *LightClient {
+	t.Helper()
+
+	client, err := NewLightClient(testChainID, &TrustedState{
+		BlockNumber: 0,
+		BlockHash:   c.genesisHash,
+		Epoch:       1,
+		Validators:  c.firstEpochSet,
+	}, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	return client
+}
+
+func (c *testChain) epochProof(epoch, number uint64) *types.EpochProof {
+	return &types.EpochProof{
+		Epoch:            epoch,
+		EpochEndingBlock: number,
+		Header:           hex.EncodeToHex(c.headers[number].MarshalRLP()),
+	}
+}
+
+func TestLightClient_VerifyHeaders(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t)
+	client := chain.newLightClient(t)
+
+	headers := make([]*types.Header, 0, len(chain.headers))
+	for number := uint64(1); number <= 6; number++ {
+		headers = append(headers, chain.headers[number])
+	}
+
+	require.NoError(t, client.VerifyHeaders(headers))
+
+	trusted := client.TrustedState()
+	require.Equal(t, uint64(6), trusted.BlockNumber)
+	require.Equal(t, chain.headers[6].Hash, trusted.BlockHash)
+	require.Equal(t, uint64(2), trusted.Epoch)
+	require.Equal(t, chain.secondEpochSet.GetAddresses(), trusted.Validators.GetAddresses())
+}
+
+func TestLightClient_SkipHeadersWithEpochProof(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t)
+	client := chain.newLightClient(t)
+
+	// headers of the trusted epoch can be skipped
+	require.NoError(t, client.VerifyHeader(chain.headers[2]))
+
+	// the next epoch headers can not be verified before the epoch ending header
+	require.ErrorContains(t, client.VerifyHeader(chain.headers[5]), "trusted epoch is 1")
+
+	// epoch proof must match the trusted epoch
+	require.ErrorContains(t, client.VerifyEpochProof(chain.epochProof(2, 3)), "can not be applied")
+
+	// only epoch ending header is accepted as the epoch proof
+	client2 := chain.newLightClient(t)
+	require.ErrorContains(t, client2.VerifyEpochProof(chain.epochProof(1, 2)), "not an epoch ending header")
+	require.Equal(t, uint64(0), client2.TrustedState().BlockNumber)
+
+	require.NoError(t, client.VerifyEpochProof(chain.epochProof(1, 3)))
+	require.Equal(t, uint64(2), client.TrustedState().Epoch)
+
+	require.NoError(t, client.VerifyHeader(chain.headers[5]))
+	require.Equal(t, uint64(5), client.TrustedState().BlockNumber)
+
+	// older headers are rejected
+	require.ErrorContains(t, client.VerifyHeader(chain.headers[4]), "not newer")
+}
+
+func TestLightClient_VerifyHeader_Invalid(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t)
+
+	t.Run("parent hash mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		header := chain.headers[1].Copy()
+		header.ParentHash = types.StringToHash("foo")
+
+		require.ErrorContains(t, chain.newLightClient(t).VerifyHeader(header), "parent hash mismatch")
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		t.Parallel()
+
+		header := chain.buildHeader(t, 1, chain.genesisHash, 1,
+			chain.firstEpochSet, chain.firstEpochSet, nil, []string{"A", "B"})
+
+		require.ErrorContains(t, chain.newLightClient(t).VerifyHeader(header), "quorum not reached")
+	})
+
+	t.Run("signed by unknown validators", func(t *testing.T) {
+		t.Parallel()
+
+		header := chain.buildHeader(t, 1, chain.genesisHash, 1,
+			chain.secondEpochSet, chain.secondEpochSet, nil, []string{"A", "B", "C", "D", "E"})
+
+		require.ErrorContains(t, chain.newLightClient(t).VerifyHeader(header), "current validators hash mismatch")
+	})
+
+	t.Run("next validators hash mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		// validator set transition without the delta
+		header := chain.buildHeader(t, 1, chain.genesisHash, 1,
+			chain.firstEpochSet, chain.secondEpochSet, nil, []string{"A", "B", "C", "D"})
+
+		require.ErrorContains(t, chain.newLightClient(t).VerifyHeader(header), "next validators hash mismatch")
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		t.Parallel()
+
+		header := chain.headers[1].Copy()
+		header.StateRoot = types.StringToHash("bar")
+
+		require.ErrorContains(t, chain.newLightClient(t).VerifyHeader(header), "invalid committed seals")
+	})
+}
+
+func TestNewTrustedState(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t)
+
+	_, err := NewTrustedState(chain.headers[2], nil)
+	require.ErrorIs(t, err, errNoTrustedValidators)
+
+	state, err := NewTrustedState(chain.headers[2], chain.firstEpochSet)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), state.BlockNumber)
+	require.Equal(t, chain.headers[2].Hash, state.BlockHash)
+	require.Equal(t, uint64(1), state.Epoch)
+
+	// trusted epoch ending header moves the trusted state to the next epoch
+	state, err = NewTrustedState(chain.headers[3], chain.firstEpochSet)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), state.Epoch)
+	require.Equal(t, chain.secondEpochSet.GetAddresses(), state.Validators.GetAddresses())
+
+	client, err := NewLightClient(testChainID, state, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.VerifyHeader(chain.headers[6]))
+}
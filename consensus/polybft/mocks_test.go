@@ -46,6 +46,8 @@ func (m *blockchainMock) ProcessBlock(parent *types.Header, block *types.Block)
 	return args.Get(0).(*types.FullBlock), args.Error(1) //nolint:forcetypeassert
 }
 
+func (m *blockchainMock) PrefetchBlock(_ *types.Block) {}
+
 func (m *blockchainMock) GetStateProviderForBlock(block *types.Header) (contract.Provider, error) {
 	args := m.Called(block)
 	stateProvider, _ := args.Get(0).(contract.Provider)
@@ -219,6 +221,12 @@ func (m *systemStateMock) GetNextCommittedIndex() (uint64, error) {
 	return 0, nil
 }
 
+func (m *systemStateMock) IsStateSyncProcessed(stateSyncID uint64) (bool, error) {
+	args := m.Called(stateSyncID)
+
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *systemStateMock) GetEpoch() (uint64, error) {
 	args := m.Called()
 	if len(args) == 1 {
@@ -319,6 +327,12 @@ func (tp *txPoolMock) Peek() *types.Transaction {
 	return args[0].(*types.Transaction) //nolint
 }
 
+func (tp *txPoolMock) ArrivalTime(hash types.Hash) (time.Time, bool) {
+	args := tp.Called(hash)
+
+	return args[0].(time.Time), args[1].(bool) //nolint
+}
+
 func (tp *txPoolMock) Pop(tx *types.Transaction) {
 	tp.Called(tx)
 }
@@ -439,8 +439,9 @@ func (p *Polybft) Initialize() error {
 
 	// set blockchain backend
 	p.blockchain = &blockchainWrapper{
-		blockchain: p.config.Blockchain,
-		executor:   p.config.Executor,
+		blockchain:    p.config.Blockchain,
+		executor:      p.config.Executor,
+		priorityLanes: p.consensusConfig.PriorityLanes,
 	}
 
 	// create bridge and consensus topics
@@ -486,12 +487,40 @@ func ForkManagerInitialParamsFactory(config *chain.Chain) (*forkmanager.ForkPara
 		return nil, err
 	}
 
+	if err := pbftConfig.ProposerSelection.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateForksProposerSelection(config.Params.Forks); err != nil {
+		return nil, err
+	}
+
+	if err := pbftConfig.TxOrdering.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateForksTxOrdering(config.Params.Forks); err != nil {
+		return nil, err
+	}
+
+	if pbftConfig.PriorityLanes != nil {
+		if err := pbftConfig.PriorityLanes.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	proposerSelection := string(pbftConfig.ProposerSelection.orDefault())
+	txOrdering := string(pbftConfig.TxOrdering.orDefault())
+
 	return &forkmanager.ForkParams{
 		MaxValidatorSetSize: &pbftConfig.MaxValidatorSetSize,
 		EpochSize:           &pbftConfig.EpochSize,
 		SprintSize:          &pbftConfig.SprintSize,
 		BlockTime:           &pbftConfig.BlockTime,
 		BlockTimeDrift:      &pbftConfig.BlockTimeDrift,
+		ProposerSelection:   &proposerSelection,
+		TxOrdering:          &txOrdering,
+		ParallelExecution:   &pbftConfig.ParallelExecution,
 	}, nil
 }
 
@@ -715,8 +744,8 @@ func (p *Polybft) verifyHeaderImpl(parent, header *types.Header, blockTimeDrift
 	}
 
 	// validate extra data
-	return extra.ValidateFinalizedData(
-		header, parent, parents, p.blockchain.GetChainID(), p, bls.DomainCheckpointManager, p.logger)
+	return validateFinalizedData(
+		extra, header, parent, parents, p.blockchain.GetChainID(), p, bls.DomainCheckpointManager, p.logger)
 }
 
 func (p *Polybft) GetValidators(blockNumber uint64, parents []*types.Header) (validator.AccountSet, error) {
@@ -780,6 +809,16 @@ func (p *Polybft) GetBridgeProvider() consensus.BridgeDataProvider {
 	return p.runtime
 }
 
+// GetValidatorUptime returns the validators uptime for the given epoch (or for the current epoch if nil)
+func (p *Polybft) GetValidatorUptime(epoch *uint64) (*types.EpochUptime, error) {
+	return p.runtime.GetValidatorUptime(epoch)
+}
+
+// GetEpochProof returns the epoch ending block header of the given epoch, used by light clients
+func (p *Polybft) GetEpochProof(epoch uint64) (*types.EpochProof, error) {
+	return p.runtime.GetEpochProof(epoch)
+}
+
 // FilterExtra is an implementation of Consensus interface
 func (p *Polybft) FilterExtra(extra []byte) ([]byte, error) {
 	return GetIbftExtraClean(extra)
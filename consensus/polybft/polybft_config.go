@@ -62,6 +62,22 @@ type PolyBFTConfig struct {
 	// ProxyContractsAdmin is the address that will have the privilege to change both the proxy
 	// implementation address and the admin
 	ProxyContractsAdmin types.Address `json:"proxyContractsAdmin,omitempty"`
+
+	// ProposerSelection is the initial block proposer selection strategy
+	// (weighted round robin by voting power if not set). It can be changed per fork (see forkmanager.ForkParams)
+	ProposerSelection ProposerSelectionStrategy `json:"proposerSelection,omitempty"`
+
+	// TxOrdering is the initial policy used to order the txpool transactions in the block
+	// (by the gas price if not set). It can be changed per fork (see forkmanager.ForkParams)
+	TxOrdering TxOrderingStrategy `json:"txOrdering,omitempty"`
+
+	// PriorityLanes configures the allowlisted senders and their reserved share of the block gas,
+	// used by the priority lanes tx ordering
+	PriorityLanes *PriorityLanesConfig `json:"priorityLanes,omitempty"`
+
+	// ParallelExecution enables the optimistic parallel execution of the transactions while
+	// the block is being built (disabled if not set). It can be changed per fork (see forkmanager.ForkParams)
+	ParallelExecution bool `json:"parallelExecution,omitempty"`
 }
 
 // LoadPolyBFTConfig loads chain config from provided path and unmarshals PolyBFTConfig
@@ -30,6 +30,10 @@ type ProposerSnapshot struct {
 	Round      uint64
 	Proposer   *PrioritizedValidator
 	Validators []*PrioritizedValidator
+	// Strategy is the proposer selection strategy active for the snapshot height
+	Strategy ProposerSelectionStrategy
+	// ParentHash is the hash of the block preceding the snapshot height (it seeds the randomized selection)
+	ParentHash types.Hash
 }
 
 // NewProposerSnapshotFromState create ProposerSnapshot from state if possible or from genesis block
@@ -49,6 +53,18 @@ func NewProposerSnapshotFromState(config *runtimeConfig) (*ProposerSnapshot, err
 		snapshot = NewProposerSnapshot(1, genesisValidatorsSet)
 	}
 
+	// snapshots persisted before the proposer selection strategies were introduced do not carry
+	// the strategy and the parent hash, so they are populated from the fork params and the chain
+	if snapshot.Strategy == "" {
+		snapshot.Strategy = getProposerSelectionStrategy(snapshot.Height)
+	}
+
+	if snapshot.ParentHash == types.ZeroHash && snapshot.Height > 0 {
+		if parent, found := config.blockchain.GetHeaderByNumber(snapshot.Height - 1); found {
+			snapshot.ParentHash = parent.Hash
+		}
+	}
+
 	return snapshot, nil
 }
 
@@ -79,9 +95,7 @@ func (pcs *ProposerSnapshot) CalcProposer(round, height uint64) (types.Address,
 		return pcs.Proposer.Metadata.Address, nil
 	}
 
-	// do not change priorities on original snapshot while executing CalcProposer
-	// if round = 0 then we need one iteration
-	proposer, err := incrementProposerPriorityNTimes(pcs.Copy(), round+1)
+	proposer, err := pcs.selectProposer(round)
 	if err != nil {
 		return types.ZeroAddress, err
 	}
@@ -92,6 +106,20 @@ func (pcs *ProposerSnapshot) CalcProposer(round, height uint64) (types.Address,
 	return proposer.Metadata.Address, nil
 }
 
+// selectProposer selects the proposer for the given round according to the snapshot proposer selection strategy
+func (pcs *ProposerSnapshot) selectProposer(round uint64) (*PrioritizedValidator, error) {
+	switch pcs.Strategy.orDefault() {
+	case EqualRotationProposerSelection:
+		return selectEqualRotationProposer(pcs, round)
+	case RandomizedProposerSelection:
+		return selectRandomizedProposer(pcs, round)
+	default:
+		// do not change priorities on original snapshot while executing CalcProposer
+		// if round = 0 then we need one iteration
+		return incrementProposerPriorityNTimes(pcs.Copy(), round+1)
+	}
+}
+
 // GetLatestProposer returns latest calculated proposer if any
 func (pcs *ProposerSnapshot) GetLatestProposer(round, height uint64) (types.Address, error) {
 	// round must be same as saved one and proposer must exist
@@ -133,6 +161,8 @@ func (pcs *ProposerSnapshot) Copy() *ProposerSnapshot {
 		Height:     pcs.Height,
 		Round:      pcs.Round,
 		Proposer:   proposer,
+		Strategy:   pcs.Strategy,
+		ParentHash: pcs.ParentHash,
 	}
 }
 
@@ -247,7 +277,7 @@ func (pc *ProposerCalculator) updatePerBlock(blockNumber uint64) error {
 			blockNumber, pc.snapshot.Height)
 	}
 
-	_, extra, err := getBlockData(blockNumber, pc.config.blockchain)
+	header, extra, err := getBlockData(blockNumber, pc.config.blockchain)
 	if err != nil {
 		return fmt.Errorf("cannot get block header and extra while updating proposers snapshot %d: %w", blockNumber, err)
 	}
@@ -261,7 +291,9 @@ func (pc *ProposerCalculator) updatePerBlock(blockNumber uint64) error {
 		}
 	}
 
-	// if round = 0 then we need one iteration
+	// priorities are updated regardless of the proposer selection strategy,
+	// so they are accurate in case weighted round robin gets (re)activated by a fork.
+	// If round = 0 then we need one iteration
 	_, err = incrementProposerPriorityNTimes(pc.snapshot, extra.Checkpoint.BlockRound+1)
 	if err != nil {
 		return fmt.Errorf("failed to update proposers snapshot for block %d: %w", blockNumber, err)
@@ -275,6 +307,8 @@ func (pc *ProposerCalculator) updatePerBlock(blockNumber uint64) error {
 	pc.snapshot.Height = blockNumber + 1 // snapshot (validator priorities) is prepared for the next block
 	pc.snapshot.Round = 0
 	pc.snapshot.Proposer = nil
+	pc.snapshot.ParentHash = header.Hash
+	pc.snapshot.Strategy = getProposerSelectionStrategy(pc.snapshot.Height)
 
 	return nil
 }
@@ -0,0 +1,123 @@
+package polybft
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+// ProposerSelectionStrategy is the strategy used to select the block proposer
+type ProposerSelectionStrategy string
+
+const (
+	// WeightedRoundRobinProposerSelection selects the proposer by the Tendermint-style
+	// weighted round robin algorithm (proposer priorities are proportional to the voting power)
+	WeightedRoundRobinProposerSelection ProposerSelectionStrategy = "weighted-round-robin"
+
+	// EqualRotationProposerSelection rotates the proposer through the validator set, regardless of the voting power
+	EqualRotationProposerSelection ProposerSelectionStrategy = "equal-rotation"
+
+	// RandomizedProposerSelection selects the proposer pseudo-randomly (weighted by the voting power),
+	// where the randomness is seeded from the previous block hash and the round
+	RandomizedProposerSelection ProposerSelectionStrategy = "randomized"
+)
+
+// Validate returns an error if the proposer selection strategy is not supported
+// (empty strategy is allowed and stands for the weighted round robin)
+func (s ProposerSelectionStrategy) Validate() error {
+	switch s {
+	case "", WeightedRoundRobinProposerSelection, EqualRotationProposerSelection, RandomizedProposerSelection:
+		return nil
+	default:
+		return fmt.Errorf("unsupported proposer selection strategy: %s", s)
+	}
+}
+
+// orDefault returns the weighted round robin strategy in case the strategy is not set
+func (s ProposerSelectionStrategy) orDefault() ProposerSelectionStrategy {
+	if s == "" {
+		return WeightedRoundRobinProposerSelection
+	}
+
+	return s
+}
+
+// getProposerSelectionStrategy returns the proposer selection strategy active for the given block.
+// Strategies other than the weighted round robin one are used only once the ProposerSelection fork is enabled
+func getProposerSelectionStrategy(blockNumber uint64) ProposerSelectionStrategy {
+	if !forkmanager.GetInstance().IsForkEnabled(chain.ProposerSelection, blockNumber) {
+		return WeightedRoundRobinProposerSelection
+	}
+
+	params := forkmanager.GetInstance().GetParams(blockNumber)
+	if params == nil || params.ProposerSelection == nil {
+		return WeightedRoundRobinProposerSelection
+	}
+
+	return ProposerSelectionStrategy(*params.ProposerSelection).orDefault()
+}
+
+// validateForksProposerSelection validates the proposer selection strategies set in fork params
+func validateForksProposerSelection(forks *chain.Forks) error {
+	if forks == nil {
+		return nil
+	}
+
+	for name, fork := range *forks {
+		if fork.Params == nil || fork.Params.ProposerSelection == nil {
+			continue
+		}
+
+		if err := ProposerSelectionStrategy(*fork.Params.ProposerSelection).Validate(); err != nil {
+			return fmt.Errorf("invalid %s fork params: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// selectEqualRotationProposer selects the proposer by rotating through the validator set
+// (each validator proposes the same number of blocks, regardless of its voting power)
+func selectEqualRotationProposer(snapshot *ProposerSnapshot, round uint64) (*PrioritizedValidator, error) {
+	if len(snapshot.Validators) == 0 {
+		return nil, fmt.Errorf("validator set cannot be nul or empty")
+	}
+
+	index := (snapshot.Height + round) % uint64(len(snapshot.Validators))
+
+	return snapshot.Validators[index], nil
+}
+
+// selectRandomizedProposer selects the proposer pseudo-randomly, where the probability of
+// the validator being selected is proportional to its voting power.
+// The randomness is seeded from the previous block hash and the round, so all the validators select the same proposer
+func selectRandomizedProposer(snapshot *ProposerSnapshot, round uint64) (*PrioritizedValidator, error) {
+	if len(snapshot.Validators) == 0 {
+		return nil, fmt.Errorf("validator set cannot be nul or empty")
+	}
+
+	totalVotingPower := snapshot.GetTotalVotingPower()
+	if totalVotingPower.Sign() <= 0 {
+		return nil, fmt.Errorf("total voting power must be positive")
+	}
+
+	seed := crypto.Keccak256(snapshot.ParentHash.Bytes(), common.EncodeUint64ToBytes(round))
+	target := new(big.Int).Mod(new(big.Int).SetBytes(seed), totalVotingPower)
+
+	cumulativeVotingPower := new(big.Int)
+
+	for _, v := range snapshot.Validators {
+		cumulativeVotingPower.Add(cumulativeVotingPower, v.Metadata.VotingPower)
+
+		if target.Cmp(cumulativeVotingPower) < 0 {
+			return v, nil
+		}
+	}
+
+	// unreachable, since the target is less than the total voting power
+	return snapshot.Validators[len(snapshot.Validators)-1], nil
+}
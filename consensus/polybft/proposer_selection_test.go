@@ -0,0 +1,151 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposerSelection_EqualRotation(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"}, []uint64{1, 100, 1, 1000})
+	metadata := validators.GetPublicIdentities()
+
+	proposed := make(map[types.Address]int, len(metadata))
+
+	for height := uint64(1); height <= 40; height++ {
+		snapshot := NewProposerSnapshot(height, metadata)
+		snapshot.Strategy = EqualRotationProposerSelection
+
+		proposer, err := snapshot.CalcProposer(0, height)
+		require.NoError(t, err)
+
+		proposed[proposer]++
+
+		// next round is proposed by the next validator
+		nextProposer, err := snapshot.CalcProposer(1, height)
+		require.NoError(t, err)
+		require.Equal(t, metadata[(height+1)%uint64(len(metadata))].Address, nextProposer)
+	}
+
+	// voting power is ignored, so every validator proposes the same number of blocks
+	for _, v := range metadata {
+		require.Equal(t, 10, proposed[v.Address])
+	}
+}
+
+func TestProposerSelection_Randomized(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D", "E"}, []uint64{1, 1, 1, 1, 1000})
+	metadata := validators.GetPublicIdentities()
+
+	newSnapshot := func(parentHash types.Hash) *ProposerSnapshot {
+		snapshot := NewProposerSnapshot(1, metadata)
+		snapshot.Strategy = RandomizedProposerSelection
+		snapshot.ParentHash = parentHash
+
+		return snapshot
+	}
+
+	proposedByE := 0
+
+	for i := 0; i < 50; i++ {
+		parentHash := types.BytesToHash(generateRandomBytes(t))
+
+		proposer, err := newSnapshot(parentHash).CalcProposer(0, 1)
+		require.NoError(t, err)
+
+		// selection is deterministic for the given parent hash and round
+		sameProposer, err := newSnapshot(parentHash).CalcProposer(0, 1)
+		require.NoError(t, err)
+		require.Equal(t, proposer, sameProposer)
+
+		if proposer == metadata[4].Address {
+			proposedByE++
+		}
+	}
+
+	// selection is weighted by the voting power
+	require.Greater(t, proposedByE, 40)
+
+	// copied snapshot selects the same proposer
+	snapshot := newSnapshot(types.StringToHash("0x1"))
+	proposer, err := snapshot.CalcProposer(3, 1)
+	require.NoError(t, err)
+
+	copiedProposer, err := snapshot.Copy().CalcProposer(3, 1)
+	require.NoError(t, err)
+	require.Equal(t, proposer, copiedProposer)
+}
+
+func TestProposerSelection_Validate(t *testing.T) {
+	t.Parallel()
+
+	for _, strategy := range []ProposerSelectionStrategy{"", WeightedRoundRobinProposerSelection,
+		EqualRotationProposerSelection, RandomizedProposerSelection} {
+		require.NoError(t, strategy.Validate())
+	}
+
+	require.ErrorContains(t, ProposerSelectionStrategy("lottery").Validate(), "unsupported proposer selection")
+
+	invalid := "lottery"
+	forks := &chain.Forks{
+		chain.ProposerSelection: chain.Fork{Block: 10, Params: &forkmanager.ForkParams{ProposerSelection: &invalid}},
+	}
+	require.ErrorContains(t, validateForksProposerSelection(forks), chain.ProposerSelection)
+	require.NoError(t, validateForksProposerSelection(chain.AllForksEnabled))
+}
+
+func TestProposerSelection_StrategyPerFork(t *testing.T) {
+	const forkName = "proposerSelectionTestFork"
+
+	randomized := string(RandomizedProposerSelection)
+	fm := forkmanager.GetInstance()
+
+	fm.RegisterFork(forkName, &forkmanager.ForkParams{ProposerSelection: &randomized})
+	require.NoError(t, fm.ActivateFork(forkName, 1_000_000))
+
+	fm.RegisterFork(chain.ProposerSelection, nil)
+	require.NoError(t, fm.ActivateFork(chain.ProposerSelection, 1_000_010))
+
+	t.Cleanup(func() {
+		require.NoError(t, fm.DeactivateFork(forkName))
+		require.NoError(t, fm.DeactivateFork(chain.ProposerSelection))
+	})
+
+	require.Equal(t, WeightedRoundRobinProposerSelection, getProposerSelectionStrategy(999_999))
+	// the strategy set in the fork params is not used until the ProposerSelection fork is enabled
+	require.Equal(t, WeightedRoundRobinProposerSelection, getProposerSelectionStrategy(1_000_000))
+	require.Equal(t, RandomizedProposerSelection, getProposerSelectionStrategy(1_000_010))
+	require.Equal(t, RandomizedProposerSelection, getProposerSelectionStrategy(1_000_011))
+}
+
+func TestProposerSelection_SnapshotPersistence(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C"}, []uint64{1, 2, 3})
+	snapshot := NewProposerSnapshot(5, validators.GetPublicIdentities())
+	snapshot.Strategy = EqualRotationProposerSelection
+	snapshot.ParentHash = types.StringToHash("0x5")
+
+	state := newTestState(t)
+	require.NoError(t, state.ProposerSnapshotStore.writeProposerSnapshot(snapshot))
+
+	restored, err := state.ProposerSnapshotStore.getProposerSnapshot()
+	require.NoError(t, err)
+	require.Equal(t, EqualRotationProposerSelection, restored.Strategy)
+	require.Equal(t, snapshot.ParentHash, restored.ParentHash)
+
+	expected, err := snapshot.CalcProposer(2, 5)
+	require.NoError(t, err)
+
+	actual, err := restored.CalcProposer(2, 5)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
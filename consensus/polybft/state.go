@@ -1,6 +1,7 @@
 package polybft
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/go-hclog"
@@ -37,6 +38,7 @@ type State struct {
 	EpochStore            *EpochStore
 	ProposerSnapshotStore *ProposerSnapshotStore
 	StakeStore            *StakeStore
+	UptimeStore           *UptimeStore
 }
 
 // newState creates new instance of State
@@ -54,6 +56,7 @@ func newState(path string, logger hclog.Logger, closeCh chan struct{}) (*State,
 		EpochStore:            &EpochStore{db: db},
 		ProposerSnapshotStore: &ProposerSnapshotStore{db: db},
 		StakeStore:            &StakeStore{db: db},
+		UptimeStore:           &UptimeStore{db: db},
 	}
 
 	if err = s.initStorages(); err != nil {
@@ -80,7 +83,11 @@ func (s *State) initStorages() error {
 			return err
 		}
 
-		return s.StakeStore.initialize(tx)
+		if err := s.StakeStore.initialize(tx); err != nil {
+			return err
+		}
+
+		return s.UptimeStore.initialize(tx)
 	})
 }
 
@@ -101,3 +108,38 @@ func bucketStats(bucketName []byte, db *bolt.DB) (*bolt.BucketStats, error) {
 
 	return stats, nil
 }
+
+// insertIDLookup appends the given id to the ids of the bridge events saved under the given lookup key
+// (e.g. hash of the transaction which emitted the events)
+func insertIDLookup(bucket *bolt.Bucket, key []byte, id uint64) error {
+	ids, err := getIDLookup(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	for _, existingID := range ids {
+		if existingID == id {
+			return nil
+		}
+	}
+
+	raw, err := json.Marshal(append(ids, id))
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put(key, raw)
+}
+
+// getIDLookup returns the ids of the bridge events saved under the given lookup key
+func getIDLookup(bucket *bolt.Bucket, key []byte) ([]uint64, error) {
+	var ids []uint64
+
+	if v := bucket.Get(key); v != nil {
+		if err := json.Unmarshal(v, &ids); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
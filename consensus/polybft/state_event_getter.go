@@ -80,7 +80,11 @@ func (e *eventsGetter[T]) getEventsFromReceipts(blockHeader *types.Header,
 				continue
 			}
 
-			event, doesMatch, err := e.parseEventFn(blockHeader, convertLog(log))
+			ethLog := convertLog(log)
+			ethLog.BlockNumber = blockHeader.Number
+			ethLog.TransactionHash = ethgo.Hash(receipt.TxHash)
+
+			event, doesMatch, err := e.parseEventFn(blockHeader, ethLog)
 			if err != nil {
 				return nil, err
 			}
@@ -8,9 +8,11 @@ import (
 	"sort"
 
 	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
 	bolt "go.etcd.io/bbolt"
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -20,9 +22,25 @@ var (
 	exitEventsBucket                  = []byte("exitEvent")
 	exitEventToEpochLookupBucket      = []byte("exitIdToEpochLookup")
 	exitEventLastProcessedBlockBucket = []byte("lastProcessedBlock")
-
-	lastProcessedBlockKey = []byte("lastProcessedBlock")
-	errNoLastSavedEntry   = errors.New("there is no last saved block in last saved bucket")
+	exitEventTxLookupBucket           = []byte("exitEventTxLookup")
+	exitEventAddressLookupBucket      = []byte("exitEventAddressIndex")
+	// bucket to store the last checkpoint submitted by the node
+	checkpointSubmissionBucket = []byte("checkpointSubmission")
+
+	lastProcessedBlockKey      = []byte("lastProcessedBlock")
+	lastCheckpointSubmittedKey = []byte("lastCheckpointSubmitted")
+	errNoLastSavedEntry        = errors.New("there is no last saved block in last saved bucket")
+	errNoExitEventEpoch        = errors.New("its epoch was not found in lookup table")
+
+	// signatures of the withdrawal payloads sent by the child chain predicates
+	withdrawSignature      = crypto.Keccak256Hash([]byte("WITHDRAW"))
+	withdrawBatchSignature = crypto.Keccak256Hash([]byte("WITHDRAW_BATCH"))
+
+	// leading fields of the ERC20, ERC721 and ERC1155 withdrawal payloads
+	withdrawABIType = abi.MustNewType(
+		"tuple(bytes32 signature, address rootToken, address withdrawer, address receiver)")
+	withdrawBatchABIType = abi.MustNewType(
+		"tuple(bytes32 signature, address rootToken, address withdrawer, address[] receivers)")
 )
 
 type exitEventNotFoundError struct {
@@ -41,6 +59,8 @@ type ExitEvent struct {
 	EpochNumber uint64 `abi:"-"`
 	// BlockNumber is the block in which exit event was added
 	BlockNumber uint64 `abi:"-"`
+	// TxHash is the hash of the transaction which emitted exit event
+	TxHash types.Hash `abi:"-"`
 }
 
 /*
@@ -50,6 +70,11 @@ exit events/
 |--> (id+epoch+blockNumber) -> *ExitEvent (json marshalled)
 |--> (exitEventID) -> epochNumber
 |--> (lastProcessedBlockKey) -> block number
+|--> (txHash) -> []exitEventID (json marshalled)
+|--> (withdrawer or receiver address+exitEventID) -> empty value
+
+checkpoint submission/
+|--> (lastCheckpointSubmittedKey) -> *types.CheckpointSubmission (json marshalled)
 */
 type CheckpointStore struct {
 	db *bolt.DB
@@ -69,6 +94,28 @@ func (s *CheckpointStore) initialize(tx *bolt.Tx) error {
 		return fmt.Errorf("failed to create bucket=%s: %w", string(exitEventLastProcessedBlockBucket), err)
 	}
 
+	if _, err := tx.CreateBucketIfNotExists(exitEventTxLookupBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(exitEventTxLookupBucket), err)
+	}
+
+	// address lookup is missing in the databases created prior to its introduction,
+	// so it is built from the already stored exit events once the bucket is created
+	backfillAddressLookup := tx.Bucket(exitEventAddressLookupBucket) == nil
+
+	if _, err := tx.CreateBucketIfNotExists(exitEventAddressLookupBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(exitEventAddressLookupBucket), err)
+	}
+
+	if backfillAddressLookup {
+		if err := backfillExitEventAddressLookup(tx); err != nil {
+			return fmt.Errorf("failed to backfill bucket=%s: %w", string(exitEventAddressLookupBucket), err)
+		}
+	}
+
+	if _, err := tx.CreateBucketIfNotExists(checkpointSubmissionBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(checkpointSubmissionBucket), err)
+	}
+
 	return tx.Bucket(exitEventLastProcessedBlockBucket).Put(lastProcessedBlockKey, common.EncodeUint64ToBytes(0))
 }
 
@@ -82,8 +129,47 @@ func (s *CheckpointStore) insertExitEvents(exitEvents []*ExitEvent) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		exitEventBucket := tx.Bucket(exitEventsBucket)
 		lookupBucket := tx.Bucket(exitEventToEpochLookupBucket)
+		txLookupBucket := tx.Bucket(exitEventTxLookupBucket)
+		addressLookupBucket := tx.Bucket(exitEventAddressLookupBucket)
 		for i := 0; i < len(exitEvents); i++ {
-			if err := insertExitEventToBucket(exitEventBucket, lookupBucket, exitEvents[i]); err != nil {
+			exitEvent := exitEvents[i]
+			if err := insertExitEventToBucket(exitEventBucket, lookupBucket, exitEvent); err != nil {
+				return err
+			}
+
+			exitID := exitEvent.ID.Uint64()
+			for _, address := range getWithdrawalAddresses(exitEvent) {
+				if err := addressLookupBucket.Put(exitEventAddressKey(address, exitID), []byte{}); err != nil {
+					return err
+				}
+			}
+
+			if exitEvent.TxHash == types.ZeroHash {
+				continue
+			}
+
+			if err := insertIDLookup(txLookupBucket, exitEvent.TxHash.Bytes(), exitID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// backfillExitEventAddressLookup indexes all the stored exit events by their withdrawal addresses
+func backfillExitEventAddressLookup(tx *bolt.Tx) error {
+	addressLookupBucket := tx.Bucket(exitEventAddressLookupBucket)
+
+	return tx.Bucket(exitEventsBucket).ForEach(func(_, v []byte) error {
+		var exitEvent *ExitEvent
+		if err := json.Unmarshal(v, &exitEvent); err != nil {
+			return err
+		}
+
+		exitID := exitEvent.ID.Uint64()
+		for _, address := range getWithdrawalAddresses(exitEvent) {
+			if err := addressLookupBucket.Put(exitEventAddressKey(address, exitID), []byte{}); err != nil {
 				return err
 			}
 		}
@@ -123,8 +209,7 @@ func (s *CheckpointStore) getExitEvent(exitEventID uint64) (*ExitEvent, error) {
 
 		epochBytes := lookupBucket.Get(exitIDBytes)
 		if epochBytes == nil {
-			return fmt.Errorf("could not find any exit event that has an id: %v. Its epoch was not found in lookup table",
-				exitEventID)
+			return fmt.Errorf("could not find any exit event that has an id: %v: %w", exitEventID, errNoExitEventEpoch)
 		}
 
 		key := bytes.Join([][]byte{epochBytes, exitIDBytes}, nil)
@@ -143,6 +228,97 @@ func (s *CheckpointStore) getExitEvent(exitEventID uint64) (*ExitEvent, error) {
 	return exitEvent, err
 }
 
+// getExitEventIDsByTxHash returns ids of the exit events emitted by the transaction with given hash
+func (s *CheckpointStore) getExitEventIDsByTxHash(txHash types.Hash) ([]uint64, error) {
+	var ids []uint64
+
+	err := s.db.View(func(tx *bolt.Tx) (err error) {
+		ids, err = getIDLookup(tx.Bucket(exitEventTxLookupBucket), txHash.Bytes())
+
+		return err
+	})
+
+	return ids, err
+}
+
+// getExitEventIDsByAddress returns at most limit ids of the exit events withdrawn by or to the given address,
+// starting from the given exit event id
+func (s *CheckpointStore) getExitEventIDsByAddress(address types.Address, from uint64, limit int) ([]uint64, error) {
+	var ids []uint64
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(exitEventAddressLookupBucket).Cursor()
+
+		for k, _ := cursor.Seek(exitEventAddressKey(address, from)); bytes.HasPrefix(k, address.Bytes()) &&
+			len(ids) < limit; k, _ = cursor.Next() {
+			ids = append(ids, common.EncodeBytesToUint64(k[types.AddressLength:]))
+		}
+
+		return nil
+	})
+
+	return ids, err
+}
+
+// exitEventAddressKey returns the address lookup key of the given exit event id,
+// which keeps the exit events of an address next to each other, ordered by their ids
+func exitEventAddressKey(address types.Address, exitID uint64) []byte {
+	return bytes.Join([][]byte{address.Bytes(), common.EncodeUint64ToBytes(exitID)}, nil)
+}
+
+// getWithdrawalAddresses returns the withdrawer and the receivers of the withdrawal sent by the exit event.
+// Exit events which are not withdrawals sent by the child chain predicates have no addresses
+func getWithdrawalAddresses(exitEvent *ExitEvent) []types.Address {
+	if len(exitEvent.Data) < types.HashLength {
+		return nil
+	}
+
+	var addresses []ethgo.Address
+
+	switch types.BytesToHash(exitEvent.Data[:types.HashLength]) {
+	case withdrawSignature:
+		var withdrawal struct {
+			Withdrawer ethgo.Address `abi:"withdrawer"`
+			Receiver   ethgo.Address `abi:"receiver"`
+		}
+
+		if err := withdrawABIType.DecodeStruct(exitEvent.Data, &withdrawal); err != nil {
+			return nil
+		}
+
+		addresses = []ethgo.Address{withdrawal.Withdrawer, withdrawal.Receiver}
+
+	case withdrawBatchSignature:
+		var withdrawal struct {
+			Withdrawer ethgo.Address   `abi:"withdrawer"`
+			Receivers  []ethgo.Address `abi:"receivers"`
+		}
+
+		if err := withdrawBatchABIType.DecodeStruct(exitEvent.Data, &withdrawal); err != nil {
+			return nil
+		}
+
+		addresses = append([]ethgo.Address{withdrawal.Withdrawer}, withdrawal.Receivers...)
+
+	default:
+		return nil
+	}
+
+	result := make([]types.Address, 0, len(addresses))
+	seen := make(map[types.Address]struct{}, len(addresses))
+
+	for _, address := range addresses {
+		if _, ok := seen[types.Address(address)]; ok {
+			continue
+		}
+
+		seen[types.Address(address)] = struct{}{}
+		result = append(result, types.Address(address))
+	}
+
+	return result
+}
+
 // getExitEventsByEpoch returns all exit events that happened in the given epoch
 func (s *CheckpointStore) getExitEventsByEpoch(epoch uint64) ([]*ExitEvent, error) {
 	return s.getExitEvents(epoch, func(exitEvent *ExitEvent) bool {
@@ -214,6 +390,55 @@ func (s *CheckpointStore) getLastSaved() (uint64, error) {
 	return lastSavedBlock, err
 }
 
+// updateCheckpointSubmission saves the checkpoint submitted by the node,
+// unless a checkpoint for the later block was already saved
+func (s *CheckpointStore) updateCheckpointSubmission(submission *types.CheckpointSubmission) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(checkpointSubmissionBucket)
+
+		if v := bucket.Get(lastCheckpointSubmittedKey); v != nil {
+			var last types.CheckpointSubmission
+			if err := json.Unmarshal(v, &last); err != nil {
+				return err
+			}
+
+			if last.BlockNumber >= submission.BlockNumber {
+				return nil
+			}
+		}
+
+		raw, err := json.Marshal(submission)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(lastCheckpointSubmittedKey, raw)
+	})
+}
+
+// deleteCheckpointSubmission removes the last checkpoint submitted by the node
+func (s *CheckpointStore) deleteCheckpointSubmission() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(checkpointSubmissionBucket).Delete(lastCheckpointSubmittedKey)
+	})
+}
+
+// getCheckpointSubmission returns the last checkpoint submitted by the node (nil if there is none)
+func (s *CheckpointStore) getCheckpointSubmission() (*types.CheckpointSubmission, error) {
+	var submission *types.CheckpointSubmission
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(checkpointSubmissionBucket).Get(lastCheckpointSubmittedKey)
+		if v == nil {
+			return nil
+		}
+
+		return json.Unmarshal(v, &submission)
+	})
+
+	return submission, err
+}
+
 // decodeExitEvent tries to decode exit event from the provided log
 func decodeExitEvent(log *ethgo.Log, epoch, block uint64) (*ExitEvent, error) {
 	var l2StateSyncedEvent contractsapi.L2StateSyncedEvent
@@ -231,6 +456,7 @@ func decodeExitEvent(log *ethgo.Log, epoch, block uint64) (*ExitEvent, error) {
 		L2StateSyncedEvent: &l2StateSyncedEvent,
 		EpochNumber:        epoch,
 		BlockNumber:        block,
+		TxHash:             types.Hash(log.TransactionHash),
 	}, nil
 }
 
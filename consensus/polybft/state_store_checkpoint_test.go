@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
@@ -113,6 +114,174 @@ func TestState_NoEpochForExitEventInLookup(t *testing.T) {
 	require.ErrorContains(t, err, "epoch was not found in lookup table")
 }
 
+func TestState_GetExitEventIDsByTxHash(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	txHash := types.StringToHash("0x1")
+
+	exitEvents := make([]*ExitEvent, 3)
+	for i := range exitEvents {
+		exitEvents[i] = &ExitEvent{
+			L2StateSyncedEvent: &contractsapi.L2StateSyncedEvent{
+				ID:   big.NewInt(int64(i)),
+				Data: []byte{},
+			},
+			EpochNumber: 1,
+			BlockNumber: 1,
+		}
+	}
+
+	// the last exit event is emitted by other transaction
+	exitEvents[0].TxHash = txHash
+	exitEvents[1].TxHash = txHash
+
+	require.NoError(t, state.CheckpointStore.insertExitEvents(exitEvents))
+	// inserting the same exit events again does not duplicate the lookup entries
+	require.NoError(t, state.CheckpointStore.insertExitEvents(exitEvents[:1]))
+
+	ids, err := state.CheckpointStore.getExitEventIDsByTxHash(txHash)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 1}, ids)
+
+	ids, err = state.CheckpointStore.getExitEventIDsByTxHash(types.StringToHash("0x2"))
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestState_GetExitEventIDsByAddress(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+
+	var (
+		rootToken     = types.StringToAddress("0x100")
+		rootPredicate = types.StringToAddress("0x200")
+		alice         = types.StringToAddress("0xa")
+		bob           = types.StringToAddress("0xb")
+		carol         = types.StringToAddress("0xc")
+		dave          = types.StringToAddress("0xd")
+	)
+
+	erc20WithdrawType := abi.MustNewType(
+		"tuple(bytes32 withdrawSignature, address rootToken, address withdrawer, address receiver, uint256 amount)")
+	erc721WithdrawBatchType := abi.MustNewType(
+		"tuple(bytes32 withdrawSignature, address rootToken, address withdrawer, address[] receivers, uint256[] tokenIds)")
+
+	encodeWithdraw := func(withdrawer, receiver types.Address) []byte {
+		t.Helper()
+
+		data, err := erc20WithdrawType.Encode(map[string]interface{}{
+			"withdrawSignature": withdrawSignature,
+			"rootToken":         ethgo.Address(rootToken),
+			"withdrawer":        ethgo.Address(withdrawer),
+			"receiver":          ethgo.Address(receiver),
+			"amount":            big.NewInt(100),
+		})
+		require.NoError(t, err)
+
+		return data
+	}
+
+	withdrawBatch, err := erc721WithdrawBatchType.Encode(map[string]interface{}{
+		"withdrawSignature": withdrawBatchSignature,
+		"rootToken":         ethgo.Address(rootToken),
+		"withdrawer":        ethgo.Address(carol),
+		"receivers":         []ethgo.Address{ethgo.Address(alice), ethgo.Address(dave)},
+		"tokenIds":          []*big.Int{big.NewInt(1), big.NewInt(2)},
+	})
+	require.NoError(t, err)
+
+	exitsData := [][]byte{
+		encodeWithdraw(alice, bob),
+		encodeWithdraw(bob, bob),
+		withdrawBatch,
+		// not a withdrawal, so it is not indexed by any address
+		encodeWithdraw(alice, alice)[types.HashLength:],
+		encodeWithdraw(bob, dave),
+	}
+
+	exitEvents := make([]*ExitEvent, len(exitsData))
+	for i, data := range exitsData {
+		exitEvents[i] = &ExitEvent{
+			L2StateSyncedEvent: &contractsapi.L2StateSyncedEvent{
+				ID:       big.NewInt(int64(i)),
+				Sender:   contracts.ChildERC20PredicateContract,
+				Receiver: rootPredicate,
+				Data:     data,
+			},
+			EpochNumber: 1,
+			BlockNumber: 1,
+		}
+	}
+
+	require.NoError(t, state.CheckpointStore.insertExitEvents(exitEvents))
+
+	cases := []struct {
+		address  types.Address
+		from     uint64
+		limit    int
+		expected []uint64
+	}{
+		{alice, 0, maxExitsPerPage, []uint64{0, 2}},
+		{bob, 0, maxExitsPerPage, []uint64{0, 1, 4}},
+		{carol, 0, maxExitsPerPage, []uint64{2}},
+		{dave, 0, maxExitsPerPage, []uint64{2, 4}},
+		// the predicate contracts are not indexed
+		{contracts.ChildERC20PredicateContract, 0, maxExitsPerPage, nil},
+		{rootPredicate, 0, maxExitsPerPage, nil},
+		// pagination
+		{bob, 0, 2, []uint64{0, 1}},
+		{bob, 1, 1, []uint64{1}},
+		{bob, 2, maxExitsPerPage, []uint64{4}},
+		{bob, 5, maxExitsPerPage, nil},
+	}
+
+	for _, c := range cases {
+		ids, err := state.CheckpointStore.getExitEventIDsByAddress(c.address, c.from, c.limit)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, ids, c.address)
+	}
+
+	// address lookup of the database which predates it is backfilled from the stored exit events
+	require.NoError(t, state.db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket(exitEventAddressLookupBucket)
+	}))
+	require.NoError(t, state.db.Update(state.CheckpointStore.initialize))
+
+	for _, c := range cases {
+		ids, err := state.CheckpointStore.getExitEventIDsByAddress(c.address, c.from, c.limit)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, ids, c.address)
+	}
+}
+
+func TestState_CheckpointSubmission(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+
+	submission, err := state.CheckpointStore.getCheckpointSubmission()
+	require.NoError(t, err)
+	require.Nil(t, submission)
+
+	expected := &types.CheckpointSubmission{
+		BlockNumber: 10,
+		EpochNumber: 1,
+		TxHash:      types.StringToHash("0x1"),
+		Timestamp:   100,
+	}
+	require.NoError(t, state.CheckpointStore.updateCheckpointSubmission(expected))
+
+	// submission for the earlier block does not override the saved one
+	require.NoError(t, state.CheckpointStore.updateCheckpointSubmission(
+		&types.CheckpointSubmission{BlockNumber: 5, EpochNumber: 1}))
+
+	submission, err = state.CheckpointStore.getCheckpointSubmission()
+	require.NoError(t, err)
+	require.Equal(t, expected, submission)
+}
+
 func TestState_decodeExitEvent(t *testing.T) {
 	t.Parallel()
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -19,6 +20,8 @@ var (
 	stateSyncProofsBucket = []byte("stateSyncProofs")
 	// bucket to store message votes (signatures)
 	messageVotesBucket = []byte("votes")
+	// bucket to store ids of state sync events emitted by rootchain transactions
+	stateSyncTxLookupBucket = []byte("stateSyncTxLookup")
 
 	// errNotEnoughStateSyncs error message
 	errNotEnoughStateSyncs = errors.New("there is either a gap or not enough sync events")
@@ -39,6 +42,9 @@ commitments/
 
 stateSyncProofs/
 |--> stateSyncProof.StateSync.Id -> *StateSyncProof (json marshalled)
+
+state sync tx lookup/
+|--> rootchain tx hash -> []stateSyncEvent.Id (json marshalled)
 */
 
 type StateSyncStore struct {
@@ -59,6 +65,10 @@ func (s *StateSyncStore) initialize(tx *bolt.Tx) error {
 		return fmt.Errorf("failed to create bucket=%s: %w", string(stateSyncProofsBucket), err)
 	}
 
+	if _, err := tx.CreateBucketIfNotExists(stateSyncTxLookupBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(stateSyncTxLookupBucket), err)
+	}
+
 	return nil
 }
 
@@ -76,6 +86,41 @@ func (s *StateSyncStore) insertStateSyncEvent(event *contractsapi.StateSyncedEve
 	})
 }
 
+// insertStateSyncTxLookup saves that the state sync event with given id was emitted by the rootchain transaction
+func (s *StateSyncStore) insertStateSyncTxLookup(txHash types.Hash, stateSyncID uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return insertIDLookup(tx.Bucket(stateSyncTxLookupBucket), txHash.Bytes(), stateSyncID)
+	})
+}
+
+// getStateSyncIDsByTxHash returns ids of the state sync events emitted by the rootchain transaction with given hash
+func (s *StateSyncStore) getStateSyncIDsByTxHash(txHash types.Hash) ([]uint64, error) {
+	var ids []uint64
+
+	err := s.db.View(func(tx *bolt.Tx) (err error) {
+		ids, err = getIDLookup(tx.Bucket(stateSyncTxLookupBucket), txHash.Bytes())
+
+		return err
+	})
+
+	return ids, err
+}
+
+// getStateSyncEvent returns the state sync event with given id (nil if it is not saved)
+func (s *StateSyncStore) getStateSyncEvent(stateSyncID uint64) (*contractsapi.StateSyncedEvent, error) {
+	var event *contractsapi.StateSyncedEvent
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(stateSyncEventsBucket).Get(common.EncodeUint64ToBytes(stateSyncID)); v != nil {
+			return json.Unmarshal(v, &event)
+		}
+
+		return nil
+	})
+
+	return event, err
+}
+
 // list iterates through all events in events bucket in db, un-marshals them, and returns as array
 func (s *StateSyncStore) list() ([]*contractsapi.StateSyncedEvent, error) {
 	events := []*contractsapi.StateSyncedEvent{}
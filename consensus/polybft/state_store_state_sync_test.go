@@ -33,6 +33,34 @@ func TestState_InsertEvent(t *testing.T) {
 	assert.Len(t, events, 1)
 }
 
+func TestState_GetStateSyncEventAndTxLookup(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	txHash := types.StringToHash("0x1")
+
+	for _, event := range generateStateSyncEvents(t, 3, 1) {
+		require.NoError(t, state.StateSyncStore.insertStateSyncEvent(event))
+		require.NoError(t, state.StateSyncStore.insertStateSyncTxLookup(txHash, event.ID.Uint64()))
+	}
+
+	event, err := state.StateSyncStore.getStateSyncEvent(2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), event.ID.Uint64())
+
+	event, err = state.StateSyncStore.getStateSyncEvent(4)
+	require.NoError(t, err)
+	require.Nil(t, event)
+
+	ids, err := state.StateSyncStore.getStateSyncIDsByTxHash(txHash)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3}, ids)
+
+	ids, err = state.StateSyncStore.getStateSyncIDsByTxHash(types.StringToHash("0x2"))
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
 func TestState_Insert_And_Get_MessageVotes(t *testing.T) {
 	t.Parallel()
 
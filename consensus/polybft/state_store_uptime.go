@@ -0,0 +1,194 @@
+package polybft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+/*
+Bolt DB schema:
+
+validator uptime/
+|--> epoch -> *epochUptime (json marshalled)
+*/
+const (
+	// numberOfEpochUptimesToLeaveInDB defines a number of the latest epochs whose validators uptime is left in db
+	numberOfEpochUptimesToLeaveInDB = 20
+)
+
+var (
+	// bucket to store the validators uptime per epoch
+	validatorUptimeBucket = []byte("validatorUptime")
+)
+
+// validatorUptimeCounter counts the committed seals of a validator during an epoch
+type validatorUptimeCounter struct {
+	Signed   uint64 `json:"signed"`
+	Expected uint64 `json:"expected"`
+}
+
+// epochUptime accumulates the committed seals of the validators during an epoch
+type epochUptime struct {
+	TotalBlocks uint64                                    `json:"totalBlocks"`
+	LastBlock   uint64                                    `json:"lastBlock"`
+	Validators  map[types.Address]*validatorUptimeCounter `json:"validators"`
+}
+
+// toEpochUptime converts the accumulated uptime to the API representation
+// (validators are sorted by address, so the result is deterministic)
+func (e *epochUptime) toEpochUptime(epoch uint64) *types.EpochUptime {
+	result := &types.EpochUptime{
+		Epoch:       epoch,
+		TotalBlocks: e.TotalBlocks,
+		LastBlock:   e.LastBlock,
+		Validators:  make([]*types.ValidatorUptime, 0, len(e.Validators)),
+	}
+
+	for addr, counter := range e.Validators {
+		uptime := &types.ValidatorUptime{
+			Address:      addr,
+			SignedBlocks: counter.Signed,
+			MissedBlocks: counter.Expected - counter.Signed,
+		}
+
+		if counter.Expected > 0 {
+			uptime.Uptime = float64(counter.Signed) * 100 / float64(counter.Expected)
+		}
+
+		result.Validators = append(result.Validators, uptime)
+	}
+
+	sort.Slice(result.Validators, func(i, j int) bool {
+		return bytes.Compare(result.Validators[i].Address.Bytes(), result.Validators[j].Address.Bytes()) < 0
+	})
+
+	return result
+}
+
+type UptimeStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *UptimeStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(validatorUptimeBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(validatorUptimeBucket), err)
+	}
+
+	return nil
+}
+
+// updateEpochUptime accounts the committed seals of the given epoch block,
+// where validators are the ones expected to seal the block and signers are the ones who sealed it.
+// Blocks which are already accounted are skipped
+func (s *UptimeStore) updateEpochUptime(epoch, blockNumber uint64,
+	validators, signers []types.Address) (*types.EpochUptime, error) {
+	var result *types.EpochUptime
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorUptimeBucket)
+		key := common.EncodeUint64ToBytes(epoch)
+
+		uptime := &epochUptime{Validators: map[types.Address]*validatorUptimeCounter{}}
+
+		if v := bucket.Get(key); v != nil {
+			if err := json.Unmarshal(v, uptime); err != nil {
+				return err
+			}
+		}
+
+		if uptime.TotalBlocks > 0 && uptime.LastBlock >= blockNumber {
+			result = uptime.toEpochUptime(epoch)
+
+			return nil
+		}
+
+		for _, addr := range validators {
+			counter, exists := uptime.Validators[addr]
+			if !exists {
+				counter = &validatorUptimeCounter{}
+				uptime.Validators[addr] = counter
+			}
+
+			counter.Expected++
+		}
+
+		for _, addr := range signers {
+			if counter, exists := uptime.Validators[addr]; exists {
+				counter.Signed++
+			}
+		}
+
+		uptime.TotalBlocks++
+		uptime.LastBlock = blockNumber
+
+		raw, err := json.Marshal(uptime)
+		if err != nil {
+			return err
+		}
+
+		result = uptime.toEpochUptime(epoch)
+
+		return bucket.Put(key, raw)
+	})
+
+	return result, err
+}
+
+// getEpochUptime returns the validators uptime for the given epoch (nil if nothing is accounted for the epoch)
+func (s *UptimeStore) getEpochUptime(epoch uint64) (*types.EpochUptime, error) {
+	var result *types.EpochUptime
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(validatorUptimeBucket).Get(common.EncodeUint64ToBytes(epoch))
+		if v == nil {
+			return nil
+		}
+
+		var uptime epochUptime
+		if err := json.Unmarshal(v, &uptime); err != nil {
+			return err
+		}
+
+		result = uptime.toEpochUptime(epoch)
+
+		return nil
+	})
+
+	return result, err
+}
+
+// cleanEpochUptimesFromDB removes the validators uptime of the epochs preceding the latest (n) epochs
+// (up to the given epoch)
+func (s *UptimeStore) cleanEpochUptimesFromDB(epoch uint64) error {
+	if epoch < numberOfEpochUptimesToLeaveInDB {
+		return nil
+	}
+
+	oldestKept := common.EncodeUint64ToBytes(epoch - numberOfEpochUptimesToLeaveInDB + 1)
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorUptimeBucket)
+
+		keys := [][]byte{}
+		cursor := bucket.Cursor()
+
+		for k, _ := cursor.First(); k != nil && bytes.Compare(k, oldestKept) < 0; k, _ = cursor.Next() {
+			keys = append(keys, k)
+		}
+
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
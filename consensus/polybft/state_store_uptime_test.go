@@ -0,0 +1,84 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_UpdateAndGetEpochUptime(t *testing.T) {
+	t.Parallel()
+
+	var (
+		state      = newTestState(t)
+		a          = types.StringToAddress("0xa")
+		b          = types.StringToAddress("0xb")
+		c          = types.StringToAddress("0xc")
+		validators = []types.Address{c, b, a}
+	)
+
+	uptime, err := state.UptimeStore.getEpochUptime(1)
+	require.NoError(t, err)
+	require.Nil(t, uptime)
+
+	_, err = state.UptimeStore.updateEpochUptime(1, 1, validators, []types.Address{a, b, c})
+	require.NoError(t, err)
+
+	_, err = state.UptimeStore.updateEpochUptime(1, 2, validators, []types.Address{a, b})
+	require.NoError(t, err)
+
+	uptime, err = state.UptimeStore.updateEpochUptime(1, 3, validators, []types.Address{a})
+	require.NoError(t, err)
+
+	// already accounted block is skipped
+	_, err = state.UptimeStore.updateEpochUptime(1, 3, validators, []types.Address{a})
+	require.NoError(t, err)
+
+	stored, err := state.UptimeStore.getEpochUptime(1)
+	require.NoError(t, err)
+	require.Equal(t, uptime, stored)
+
+	require.Equal(t, uint64(1), stored.Epoch)
+	require.Equal(t, uint64(3), stored.TotalBlocks)
+	require.Equal(t, uint64(3), stored.LastBlock)
+	require.Equal(t, []*types.ValidatorUptime{
+		{Address: a, SignedBlocks: 3, MissedBlocks: 0, Uptime: 100},
+		{Address: b, SignedBlocks: 2, MissedBlocks: 1, Uptime: float64(200) / 3},
+		{Address: c, SignedBlocks: 1, MissedBlocks: 2, Uptime: float64(100) / 3},
+	}, stored.Validators)
+
+	// other epochs are accounted separately
+	uptime, err = state.UptimeStore.updateEpochUptime(2, 11, validators[:2], []types.Address{b})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), uptime.TotalBlocks)
+	require.Len(t, uptime.Validators, 2)
+}
+
+func TestState_CleanEpochUptimesFromDB(t *testing.T) {
+	t.Parallel()
+
+	var (
+		state      = newTestState(t)
+		validators = []types.Address{types.StringToAddress("0xa")}
+		lastEpoch  = uint64(numberOfEpochUptimesToLeaveInDB + 5)
+	)
+
+	for epoch := uint64(1); epoch <= lastEpoch; epoch++ {
+		_, err := state.UptimeStore.updateEpochUptime(epoch, epoch*10, validators, validators)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, state.UptimeStore.cleanEpochUptimesFromDB(lastEpoch))
+
+	for epoch := uint64(1); epoch <= lastEpoch; epoch++ {
+		uptime, err := state.UptimeStore.getEpochUptime(epoch)
+		require.NoError(t, err)
+
+		if epoch+numberOfEpochUptimesToLeaveInDB <= lastEpoch {
+			require.Nil(t, uptime, "epoch %d", epoch)
+		} else {
+			require.NotNil(t, uptime, "epoch %d", epoch)
+		}
+	}
+}
@@ -39,6 +39,7 @@ type StateSyncManager interface {
 	Close()
 	Commitment(blockNumber uint64) (*CommitmentMessageSigned, error)
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+	GetDepositStatus(stateSyncID uint64) (*types.DepositStatus, error)
 	PostBlock(req *PostBlockRequest) error
 	PostEpoch(req *PostEpochRequest) error
 }
@@ -58,6 +59,9 @@ func (n *dummyStateSyncManager) PostEpoch(req *PostEpochRequest) error { return
 func (n *dummyStateSyncManager) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (n *dummyStateSyncManager) GetDepositStatus(stateSyncID uint64) (*types.DepositStatus, error) {
+	return &types.DepositStatus{StateSyncID: stateSyncID, Status: types.BridgeTransferUnknown}, nil
+}
 
 // stateSyncConfig holds the configuration data of state sync manager
 type stateSyncConfig struct {
@@ -263,6 +267,13 @@ func (s *stateSyncManager) AddLog(eventLog *ethgo.Log) error {
 		return err
 	}
 
+	if err := s.state.StateSyncStore.insertStateSyncTxLookup(
+		types.Hash(eventLog.TransactionHash), event.ID.Uint64()); err != nil {
+		s.logger.Error("could not save state sync event transaction to boltDb", "err", err)
+
+		return err
+	}
+
 	if err := s.buildCommitment(); err != nil {
 		// we don't return an error here. If state sync event is inserted in db,
 		// we will just try to build a commitment on next block or next event arrival
@@ -463,6 +474,42 @@ func (s *stateSyncManager) GetStateSyncProof(stateSyncID uint64) (types.Proof, e
 	}, nil
 }
 
+// GetDepositStatus returns the status of the deposit (state sync) with given id,
+// based on the state sync events and commitments saved so far
+func (s *stateSyncManager) GetDepositStatus(stateSyncID uint64) (*types.DepositStatus, error) {
+	status := &types.DepositStatus{StateSyncID: stateSyncID, Status: types.BridgeTransferUnknown}
+
+	event, err := s.state.StateSyncStore.getStateSyncEvent(stateSyncID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get state sync event for StateSync id %d: %w", stateSyncID, err)
+	}
+
+	if event == nil {
+		return status, nil
+	}
+
+	status.Status = types.BridgeTransferPending
+	status.Sender = &event.Sender
+	status.Receiver = &event.Receiver
+
+	commitment, err := s.state.StateSyncStore.getCommitmentForStateSync(stateSyncID)
+	if err != nil {
+		if errors.Is(err, errNoCommitmentForStateSync) {
+			return status, nil
+		}
+
+		return nil, fmt.Errorf("cannot find commitment for StateSync id %d: %w", stateSyncID, err)
+	}
+
+	startID, endID := commitment.Message.StartID.Uint64(), commitment.Message.EndID.Uint64()
+
+	status.Status = types.BridgeTransferCommitted
+	status.CommitmentStartID = &startID
+	status.CommitmentEndID = &endID
+
+	return status, nil
+}
+
 // buildProofs builds state sync proofs for the submitted commitment and saves them in boltDb for later execution
 func (s *stateSyncManager) buildProofs(commitmentMsg *contractsapi.StateSyncCommitment) error {
 	from := commitmentMsg.StartID.Uint64()
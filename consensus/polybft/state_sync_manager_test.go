@@ -501,6 +501,35 @@ func TestStateSyncManager_GetProofs(t *testing.T) {
 	require.NotEmpty(t, proof.Data)
 }
 
+func TestStateSyncManager_GetDepositStatus(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	stateSyncManager := &stateSyncManager{state: state}
+
+	for _, event := range generateStateSyncEvents(t, maxCommitmentSize+1, 1) {
+		require.NoError(t, state.StateSyncStore.insertStateSyncEvent(event))
+	}
+
+	require.NoError(t, state.StateSyncStore.insertCommitmentMessage(createTestCommitmentMessage(t, 1)))
+
+	status, err := stateSyncManager.GetDepositStatus(1)
+	require.NoError(t, err)
+	require.Equal(t, types.BridgeTransferCommitted, status.Status)
+	require.Equal(t, uint64(1), *status.CommitmentStartID)
+	require.Equal(t, uint64(maxCommitmentSize), *status.CommitmentEndID)
+
+	status, err = stateSyncManager.GetDepositStatus(maxCommitmentSize + 1)
+	require.NoError(t, err)
+	require.Equal(t, types.BridgeTransferPending, status.Status)
+	require.Nil(t, status.CommitmentEndID)
+
+	status, err = stateSyncManager.GetDepositStatus(maxCommitmentSize + 2)
+	require.NoError(t, err)
+	require.Equal(t, types.BridgeTransferUnknown, status.Status)
+	require.Nil(t, status.Sender)
+}
+
 func TestStateSyncManager_GetProofs_NoProof_NoCommitment(t *testing.T) {
 	t.Parallel()
 
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,22 +18,56 @@ import (
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
 
+	"github.com/armon/go-metrics"
 	hcf "github.com/hashicorp/go-hclog"
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/jsonrpc"
 )
 
+const (
+	// maxStateSyncsPerBatch is the maximum number of state syncs executed in a single transaction.
+	// Each state sync execution is given the state transaction gas limit,
+	// so the batch fits into the default block gas limit
+	maxStateSyncsPerBatch = 4
+
+	// baseRetryBackoff is the delay before the first retry of a failed state sync execution,
+	// which doubles with each subsequent failure
+	baseRetryBackoff = 5 * time.Second
+
+	// maxRetryBackoff is the maximum delay between the retries of a failed state sync execution
+	maxRetryBackoff = 5 * time.Minute
+
+	// relayerMetrics is a prefix used for state sync relayer metrics
+	relayerMetrics = "state_sync_relayer"
+)
+
+var (
+	// processedStateSyncsMethod is an ABI method object representation for
+	// processedStateSyncs getter function on StateReceiver contract
+	processedStateSyncsMethod = contractsapi.StateReceiver.Abi.Methods["processedStateSyncs"]
+)
+
+// rpcClient is the JSON-RPC client used to query the state sync proofs
+type rpcClient interface {
+	Call(method string, out interface{}, params ...interface{}) error
+}
+
 type StateSyncRelayer struct {
 	dataDir                string
 	rpcEndpoint            string
 	stateReceiverAddr      ethgo.Address
 	eventTrackerStartBlock uint64
 	logger                 hcf.Logger
-	client                 *jsonrpc.Client
+	client                 rpcClient
 	txRelayer              txrelayer.TxRelayer
 	key                    ethgo.Key
 	closeCh                chan struct{}
 	pollInterval           time.Duration
+
+	// store persists the state syncs which are waiting for the execution
+	store *stateSyncStore
+	// notifyCh signals that new state syncs are committed
+	notifyCh chan struct{}
 }
 
 func sanitizeRPCEndpoint(rpcEndpoint string) string {
@@ -82,10 +118,18 @@ func NewRelayer(
 		closeCh:                make(chan struct{}),
 		eventTrackerStartBlock: stateReceiverTrackerStartBlock,
 		pollInterval:           pollInterval,
+		notifyCh:               make(chan struct{}, 1),
 	}
 }
 
 func (r *StateSyncRelayer) Start() error {
+	store, err := newStateSyncStore(path.Join(r.dataDir, "/relayer_state_syncs.db"))
+	if err != nil {
+		return fmt.Errorf("failed to open the pending state syncs database: %w", err)
+	}
+
+	r.store = store
+
 	et := tracker.NewEventTracker(
 		path.Join(r.dataDir, "/relayer.db"),
 		r.rpcEndpoint,
@@ -104,7 +148,16 @@ func (r *StateSyncRelayer) Start() error {
 		cancelFn()
 	}()
 
-	return et.Start(ctx)
+	if err := et.Start(ctx); err != nil {
+		cancelFn()
+		r.store.close()
+
+		return err
+	}
+
+	go r.run(ctx)
+
+	return nil
 }
 
 // Stop function is used to tear down all the allocated resources
@@ -133,27 +186,207 @@ func (r *StateSyncRelayer) AddLog(log *ethgo.Log) error {
 
 	r.logger.Info("Execute commitment", "Block", log.BlockNumber, "StartID", startID, "EndID", endID)
 
-	// we don't return errors if some client logic fails,
-	// only if event is not parsed
-	for i := startID; i <= endID; i++ {
+	// the committed state syncs are persisted first, so they are executed
+	// even if the execution fails or the relayer is restarted in the meantime
+	if err := r.store.insert(startID, endID); err != nil {
+		r.logger.Error("Failed to store pending state syncs", "StartID", startID, "EndID", endID, "err", err)
+
+		return err
+	}
+
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// run executes the pending state syncs whenever new ones are committed
+// and periodically retries the failed ones, until the context is done
+func (r *StateSyncRelayer) run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+
+	defer func() {
+		ticker.Stop()
+
+		if err := r.store.close(); err != nil {
+			r.logger.Error("Failed to close pending state syncs database", "err", err)
+		}
+	}()
+
+	for {
+		r.processPendingStateSyncs()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.notifyCh:
+		}
+	}
+}
+
+// processPendingStateSyncs executes the pending state syncs which are due for execution in batches
+func (r *StateSyncRelayer) processPendingStateSyncs() {
+	defer r.updateMetrics()
+
+	for {
+		ready, err := r.store.getReady(time.Now(), maxStateSyncsPerBatch)
+		if err != nil {
+			r.logger.Error("Failed to read pending state syncs", "err", err)
+
+			return
+		}
+
+		if len(ready) == 0 {
+			return
+		}
+
+		if err := r.processBatch(ready); err != nil {
+			r.logger.Error("Failed to update pending state syncs", "err", err)
+
+			return
+		}
+
+		if len(ready) < maxStateSyncsPerBatch {
+			return
+		}
+	}
+}
+
+// processBatch executes the given pending state syncs in a single transaction.
+// The state syncs which are already executed on the StateReceiver contract are skipped,
+// and the ones which fail are scheduled for a retry with backoff
+func (r *StateSyncRelayer) processBatch(batch []*pendingStateSync) error {
+	var (
+		executed   = make([]uint64, 0, len(batch))
+		failed     = make([]*pendingStateSync, 0, len(batch))
+		executable = make([]*pendingStateSync, 0, len(batch))
+		proofs     = make([][]types.Hash, 0, len(batch))
+		events     = make([]*contractsapi.StateSync, 0, len(batch))
+	)
+
+	for _, stateSync := range batch {
+		processed, err := r.isStateSyncProcessed(stateSync.ID)
+		if err != nil {
+			failed = append(failed, r.markFailed(stateSync, err))
+
+			continue
+		}
+
+		if processed {
+			r.logger.Debug("State sync already executed", "ID", stateSync.ID)
+
+			executed = append(executed, stateSync.ID)
+
+			continue
+		}
+
 		// query the state sync proof
-		stateSyncProof, err := r.queryStateSyncProof(fmt.Sprintf("0x%x", i))
+		proof, err := r.queryStateSyncProof(fmt.Sprintf("0x%x", stateSync.ID))
 		if err != nil {
-			r.logger.Error("Failed to query state sync proof", "err", err)
+			failed = append(failed, r.markFailed(stateSync, fmt.Errorf("failed to query state sync proof: %w", err)))
 
 			continue
 		}
 
-		if err := r.executeStateSync(stateSyncProof); err != nil {
-			r.logger.Error("State sync execution failed", "err", err)
+		event, err := decodeStateSyncEvent(proof)
+		if err != nil {
+			failed = append(failed, r.markFailed(stateSync, err))
 
 			continue
 		}
 
-		r.logger.Info("State sync executed", "ID", i)
+		executable = append(executable, stateSync)
+		proofs = append(proofs, proof.Data)
+		events = append(events, event)
 	}
 
-	return nil
+	if len(executable) > 0 {
+		results, err := r.executeStateSyncs(proofs, events)
+
+		for _, stateSync := range executable {
+			switch {
+			case err != nil:
+				failed = append(failed, r.markFailed(stateSync, err))
+			case !results[stateSync.ID]:
+				failed = append(failed, r.markFailed(stateSync,
+					fmt.Errorf("failed to execute state sync id: %d", stateSync.ID)))
+			default:
+				r.logger.Info("State sync executed", "ID", stateSync.ID)
+
+				executed = append(executed, stateSync.ID)
+			}
+		}
+	}
+
+	if err := r.store.remove(executed...); err != nil {
+		return err
+	}
+
+	return r.store.update(failed...)
+}
+
+// markFailed records the failed execution attempt of the state sync and schedules its retry
+func (r *StateSyncRelayer) markFailed(stateSync *pendingStateSync, err error) *pendingStateSync {
+	stateSync.Attempts++
+	stateSync.LastError = err.Error()
+	stateSync.NextAttempt = time.Now().Add(retryBackoff(stateSync.Attempts))
+
+	r.logger.Warn("State sync execution failed", "ID", stateSync.ID,
+		"attempts", stateSync.Attempts, "next attempt", stateSync.NextAttempt, "err", err)
+
+	return stateSync
+}
+
+// retryBackoff returns the delay before the next execution attempt,
+// given the number of failed attempts
+func retryBackoff(attempts uint64) time.Duration {
+	backoff := baseRetryBackoff
+
+	for i := uint64(1); i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+
+	return backoff
+}
+
+// updateMetrics publishes the number of the pending and failed state syncs
+func (r *StateSyncRelayer) updateMetrics() {
+	pending, failed, err := r.store.counts()
+	if err != nil {
+		r.logger.Error("Failed to count pending state syncs", "err", err)
+
+		return
+	}
+
+	metrics.SetGauge([]string{relayerMetrics, "pending_state_syncs"}, float32(pending))
+	metrics.SetGauge([]string{relayerMetrics, "failed_state_syncs"}, float32(failed))
+}
+
+// isStateSyncProcessed checks if the state sync is already executed on the StateReceiver contract
+func (r *StateSyncRelayer) isStateSyncProcessed(stateSyncID uint64) (bool, error) {
+	input, err := processedStateSyncsMethod.Encode([]interface{}{new(big.Int).SetUint64(stateSyncID)})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode processedStateSyncs function parameters: %w", err)
+	}
+
+	response, err := r.txRelayer.Call(ethgo.ZeroAddress, r.stateReceiverAddr, input)
+	if err != nil {
+		return false, fmt.Errorf("failed to invoke processedStateSyncs function on the StateReceiver: %w", err)
+	}
+
+	processed, err := strconv.ParseUint(response, 0, 64)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert processedStateSyncs result '%s': %w", response, err)
+	}
+
+	return processed == 1, nil
 }
 
 // queryStateSyncProof queries the state sync proof
@@ -171,11 +404,11 @@ func (r *StateSyncRelayer) queryStateSyncProof(stateSyncID string) (*types.Proof
 	return &stateSyncProof, nil
 }
 
-// executeStateSync executes the state sync
-func (r *StateSyncRelayer) executeStateSync(proof *types.Proof) error {
+// decodeStateSyncEvent decodes the state sync event from the metadata of the state sync proof
+func decodeStateSyncEvent(proof *types.Proof) (*contractsapi.StateSync, error) {
 	sseMap, ok := proof.Metadata["StateSync"].(map[string]interface{})
 	if !ok {
-		return errors.New("could not get state sync event from proof")
+		return nil, errors.New("could not get state sync event from proof")
 	}
 
 	var sse *contractsapi.StateSync
@@ -186,55 +419,62 @@ func (r *StateSyncRelayer) executeStateSync(proof *types.Proof) error {
 	// event from the marshaled map
 	raw, err := json.Marshal(sseMap)
 	if err != nil {
-		return fmt.Errorf("failed to marshal state sync map into JSON. Error: %w", err)
+		return nil, fmt.Errorf("failed to marshal state sync map into JSON. Error: %w", err)
 	}
 
 	if err = json.Unmarshal(raw, &sse); err != nil {
-		return fmt.Errorf("failed to unmarshal state sync event from JSON. Error: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal state sync event from JSON. Error: %w", err)
 	}
 
-	execute := &contractsapi.ExecuteStateReceiverFn{
-		Proof: proof.Data,
-		Obj:   sse,
+	return sse, nil
+}
+
+// executeStateSyncs executes the given state syncs in a single transaction
+// and returns the execution status of each state sync by its id
+func (r *StateSyncRelayer) executeStateSyncs(
+	proofs [][]types.Hash, events []*contractsapi.StateSync) (map[uint64]bool, error) {
+	batchExecute := &contractsapi.BatchExecuteStateReceiverFn{
+		Proofs: proofs,
+		Objs:   events,
 	}
 
-	input, err := execute.EncodeAbi()
+	input, err := batchExecute.EncodeAbi()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// execute the state sync
+	// execute the state syncs
 	txn := &ethgo.Transaction{
 		From:  r.key.Address(),
 		To:    (*ethgo.Address)(&contracts.StateReceiverContract),
-		Gas:   types.StateTransactionGasLimit,
+		Gas:   types.StateTransactionGasLimit * uint64(len(events)),
 		Input: input,
 	}
 
 	receipt, err := r.txRelayer.SendTransaction(txn, r.key)
 	if err != nil {
-		return fmt.Errorf("failed to send execute state sync transaction for id %d: %w", sse.ID, err)
+		return nil, fmt.Errorf("failed to send batch execute state syncs transaction: %w", err)
 	}
 
 	if receipt.Status == uint64(types.ReceiptFailed) {
-		return fmt.Errorf("transaction execution reverted for state sync id: %d", sse.ID)
+		return nil, errors.New("batch execute state syncs transaction reverted")
 	}
 
+	results := make(map[uint64]bool, len(events))
+
 	var stateSyncResult contractsapi.StateSyncResultEvent
 	for _, log := range receipt.Logs {
 		matches, err := stateSyncResult.ParseLog(log)
 		if err != nil {
-			return fmt.Errorf("failed to find state sync event result log for state sync id: %d", sse.ID)
+			return nil, fmt.Errorf("failed to parse state sync result log: %w", err)
 		}
 
 		if !matches {
 			continue
 		}
 
-		if !stateSyncResult.Status {
-			return fmt.Errorf("failed to execute state sync id: %d", sse.ID)
-		}
+		results[stateSyncResult.Counter.Uint64()] = stateSyncResult.Status
 	}
 
-	return nil
+	return results, nil
 }
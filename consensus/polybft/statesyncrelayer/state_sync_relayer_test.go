@@ -1,10 +1,14 @@
 package statesyncrelayer
 
 import (
+	"encoding/binary"
+	"errors"
 	"math/big"
+	"path"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -12,6 +16,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
 	"github.com/umbracle/ethgo/jsonrpc"
 	"github.com/umbracle/ethgo/wallet"
 )
@@ -44,37 +49,207 @@ func (t *txRelayerMock) Client() *jsonrpc.Client {
 	return nil
 }
 
-func Test_executeStateSync(t *testing.T) {
-	t.Parallel()
+type rpcClientMock struct {
+	proofs map[string]*types.Proof
+}
 
-	txRelayer := &txRelayerMock{}
-	key, _ := wallet.GenerateKey()
+func (c *rpcClientMock) Call(method string, out interface{}, params ...interface{}) error {
+	stateSyncID, _ := params[0].(string) //nolint:forcetypeassert
 
-	r := &StateSyncRelayer{
-		txRelayer: txRelayer,
-		key:       key,
+	proof, ok := c.proofs[stateSyncID]
+	if !ok {
+		return errors.New("proof not found")
 	}
 
-	txRelayer.On("SendTransaction", mock.Anything, mock.Anything).
-		Return(&ethgo.Receipt{Status: uint64(types.ReceiptSuccess)}, nil).Once()
+	*out.(*types.Proof) = *proof //nolint:forcetypeassert
+
+	return nil
+}
 
-	proof := &types.Proof{
+func newStateSyncProof(id uint64) *types.Proof {
+	return &types.Proof{
 		Data: []types.Hash{},
 		Metadata: map[string]interface{}{
 			"StateSync": map[string]interface{}{
-				"ID":       big.NewInt(1),
+				"ID":       new(big.Int).SetUint64(id),
 				"Sender":   types.ZeroAddress,
 				"Receiver": types.ZeroAddress,
 				"Data":     []byte{},
 			},
 		},
 	}
+}
 
-	require.NoError(t, r.executeStateSync(proof))
+func newStateSyncResultLog(t *testing.T, id uint64, status bool) *ethgo.Log {
+	t.Helper()
+
+	data, err := abi.MustNewType("tuple(bytes message)").Encode(map[string]interface{}{"message": []byte{}})
+	require.NoError(t, err)
+
+	var idTopic, statusTopic ethgo.Hash
+
+	binary.BigEndian.PutUint64(idTopic[24:], id)
+
+	if status {
+		statusTopic[31] = 1
+	}
+
+	return &ethgo.Log{
+		Topics: []ethgo.Hash{contractsapi.StateReceiver.Abi.Events["StateSyncResult"].ID(), idTopic, statusTopic},
+		Data:   data,
+	}
+}
+
+func Test_executeStateSyncs(t *testing.T) {
+	t.Parallel()
+
+	txRelayer := &txRelayerMock{}
+	key, _ := wallet.GenerateKey()
+
+	r := &StateSyncRelayer{
+		txRelayer: txRelayer,
+		key:       key,
+	}
+
+	txRelayer.On("SendTransaction", mock.Anything, mock.Anything).
+		Return(&ethgo.Receipt{
+			Status: uint64(types.ReceiptSuccess),
+			Logs:   []*ethgo.Log{newStateSyncResultLog(t, 1, true), newStateSyncResultLog(t, 2, false)},
+		}, nil).Once()
+
+	var (
+		proofs [][]types.Hash
+		events []*contractsapi.StateSync
+	)
+
+	for _, id := range []uint64{1, 2} {
+		event, err := decodeStateSyncEvent(newStateSyncProof(id))
+		require.NoError(t, err)
+
+		proofs = append(proofs, []types.Hash{})
+		events = append(events, event)
+	}
+
+	results, err := r.executeStateSyncs(proofs, events)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]bool{1: true, 2: false}, results)
 
 	txRelayer.AssertExpectations(t)
 }
 
+func TestStateSyncRelayer_ProcessPendingStateSyncs(t *testing.T) {
+	t.Parallel()
+
+	store, err := newStateSyncStore(path.Join(t.TempDir(), "relayer_state_syncs.db"))
+	require.NoError(t, err)
+
+	defer store.close()
+
+	txRelayer := &txRelayerMock{}
+	key, _ := wallet.GenerateKey()
+
+	r := &StateSyncRelayer{
+		txRelayer: txRelayer,
+		key:       key,
+		logger:    hclog.NewNullLogger(),
+		store:     store,
+		client: &rpcClientMock{proofs: map[string]*types.Proof{
+			"0x2": newStateSyncProof(2),
+			"0x3": newStateSyncProof(3),
+		}},
+	}
+
+	isProcessed := func(id uint64, processed string) {
+		input, err := processedStateSyncsMethod.Encode([]interface{}{new(big.Int).SetUint64(id)})
+		require.NoError(t, err)
+
+		txRelayer.On("Call", ethgo.ZeroAddress, r.stateReceiverAddr, input).Return(processed, nil).Once()
+	}
+
+	// state sync 1 is already executed, the proof of state sync 4 is not available yet
+	isProcessed(1, "0x1")
+	isProcessed(2, "0x0")
+	isProcessed(3, "0x0")
+	isProcessed(4, "0x0")
+
+	txRelayer.On("SendTransaction", mock.Anything, mock.Anything).
+		Return(&ethgo.Receipt{
+			Status: uint64(types.ReceiptSuccess),
+			Logs:   []*ethgo.Log{newStateSyncResultLog(t, 2, true), newStateSyncResultLog(t, 3, false)},
+		}, nil).Once()
+
+	require.NoError(t, store.insert(1, 4))
+	// inserting already pending state syncs does not reset them
+	require.NoError(t, store.insert(1, 2))
+
+	r.processPendingStateSyncs()
+
+	txRelayer.AssertExpectations(t)
+
+	pending, failed, err := store.counts()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), pending)
+	require.Equal(t, uint64(2), failed)
+
+	// the failed state syncs are retried with backoff
+	ready, err := store.getReady(time.Now(), maxStateSyncsPerBatch)
+	require.NoError(t, err)
+	require.Empty(t, ready)
+
+	ready, err = store.getReady(time.Now().Add(baseRetryBackoff), maxStateSyncsPerBatch)
+	require.NoError(t, err)
+	require.Len(t, ready, 2)
+	require.Equal(t, uint64(3), ready[0].ID)
+	require.Equal(t, uint64(4), ready[1].ID)
+	require.Equal(t, uint64(1), ready[1].Attempts)
+	require.Contains(t, ready[1].LastError, "proof not found")
+}
+
+func TestStateSyncRelayer_AddLogAfterClose(t *testing.T) {
+	t.Parallel()
+
+	store, err := newStateSyncStore(path.Join(t.TempDir(), "relayer_state_syncs.db"))
+	require.NoError(t, err)
+
+	r := &StateSyncRelayer{
+		logger:   hclog.NewNullLogger(),
+		store:    store,
+		notifyCh: make(chan struct{}, 1),
+	}
+
+	var startIDTopic, endIDTopic ethgo.Hash
+
+	binary.BigEndian.PutUint64(startIDTopic[24:], 1)
+	binary.BigEndian.PutUint64(endIDTopic[24:], 2)
+
+	commitmentLog := &ethgo.Log{
+		Topics: []ethgo.Hash{new(contractsapi.NewCommitmentEvent).Sig(), startIDTopic, endIDTopic},
+		Data:   make([]byte, types.HashLength),
+	}
+
+	require.NoError(t, r.AddLog(commitmentLog))
+
+	// the logs delivered by the event tracker while the relayer stops are rejected,
+	// so they are not marked as processed and are delivered again after the restart
+	require.NoError(t, store.close())
+	require.ErrorIs(t, r.AddLog(commitmentLog), errStoreClosed)
+
+	_, _, err = store.counts()
+	require.ErrorIs(t, err, errStoreClosed)
+
+	// closing the store again is a no-op
+	require.NoError(t, store.close())
+}
+
+func Test_retryBackoff(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, baseRetryBackoff, retryBackoff(1))
+	require.Equal(t, 2*baseRetryBackoff, retryBackoff(2))
+	require.Equal(t, 8*baseRetryBackoff, retryBackoff(4))
+	require.Equal(t, maxRetryBackoff, retryBackoff(100))
+}
+
 // Test sanitizeRPCEndpoint
 func Test_sanitizeRPCEndpoint(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,213 @@
+package statesyncrelayer
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucket to store the state syncs which are waiting for the execution
+	pendingStateSyncsBucket = []byte("pendingStateSyncs")
+
+	// errStoreClosed is returned when the pending state syncs are accessed after the store is closed
+	errStoreClosed = errors.New("pending state syncs database is closed")
+)
+
+// pendingStateSync is a state sync which is not executed on the StateReceiver contract yet
+type pendingStateSync struct {
+	ID uint64 `json:"id"`
+	// Attempts is the number of failed execution attempts
+	Attempts uint64 `json:"attempts"`
+	// NextAttempt is the time after which the execution is retried
+	NextAttempt time.Time `json:"nextAttempt"`
+	// LastError is the error of the last failed execution attempt
+	LastError string `json:"lastError,omitempty"`
+}
+
+// isFailed returns true if the execution of the state sync has already failed at least once
+func (p *pendingStateSync) isFailed() bool {
+	return p.Attempts > 0
+}
+
+// stateSyncStore persists the pending state syncs, so they survive the relayer restarts
+type stateSyncStore struct {
+	db *bolt.DB
+
+	// lock guards the database against the access after it is closed,
+	// since the event tracker may still deliver logs while the relayer stops
+	lock   sync.RWMutex
+	closed bool
+}
+
+// newStateSyncStore opens (or creates) the database of the pending state syncs on the given path
+func newStateSyncStore(path string) (*stateSyncStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(pendingStateSyncsBucket)
+
+		return err
+	}); err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &stateSyncStore{db: db}, nil
+}
+
+// close closes the underlying database, once the ongoing transactions are finished.
+// Any subsequent access to the store fails with errStoreClosed
+func (s *stateSyncStore) close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	return s.db.Close()
+}
+
+// updateTx executes the given read-write transaction, unless the store is closed
+func (s *stateSyncStore) updateTx(fn func(tx *bolt.Tx) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return errStoreClosed
+	}
+
+	return s.db.Update(fn)
+}
+
+// viewTx executes the given read-only transaction, unless the store is closed
+func (s *stateSyncStore) viewTx(fn func(tx *bolt.Tx) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return errStoreClosed
+	}
+
+	return s.db.View(fn)
+}
+
+// insert adds the state syncs in the given range (inclusive) to the pending ones.
+// Already pending state syncs are left untouched
+func (s *stateSyncStore) insert(startID, endID uint64) error {
+	return s.updateTx(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(pendingStateSyncsBucket)
+
+		for id := startID; id <= endID; id++ {
+			key := common.EncodeUint64ToBytes(id)
+			if bucket.Get(key) != nil {
+				continue
+			}
+
+			raw, err := json.Marshal(&pendingStateSync{ID: id})
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(key, raw); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// update stores the given pending state syncs
+func (s *stateSyncStore) update(stateSyncs ...*pendingStateSync) error {
+	return s.updateTx(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(pendingStateSyncsBucket)
+
+		for _, stateSync := range stateSyncs {
+			raw, err := json.Marshal(stateSync)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(common.EncodeUint64ToBytes(stateSync.ID), raw); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// remove deletes the state syncs with the given ids from the pending ones
+func (s *stateSyncStore) remove(ids ...uint64) error {
+	return s.updateTx(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(pendingStateSyncsBucket)
+
+		for _, id := range ids {
+			if err := bucket.Delete(common.EncodeUint64ToBytes(id)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// getReady returns up to limit pending state syncs (ordered by id)
+// whose next execution attempt is due at the given time
+func (s *stateSyncStore) getReady(now time.Time, limit int) ([]*pendingStateSync, error) {
+	var ready []*pendingStateSync
+
+	err := s.viewTx(func(tx *bolt.Tx) error {
+		c := tx.Bucket(pendingStateSyncsBucket).Cursor()
+
+		for k, v := c.First(); k != nil && len(ready) < limit; k, v = c.Next() {
+			var stateSync pendingStateSync
+			if err := json.Unmarshal(v, &stateSync); err != nil {
+				return err
+			}
+
+			if !stateSync.NextAttempt.After(now) {
+				ready = append(ready, &stateSync)
+			}
+		}
+
+		return nil
+	})
+
+	return ready, err
+}
+
+// counts returns the number of the pending state syncs which were not attempted yet
+// and the number of the ones whose execution has already failed
+func (s *stateSyncStore) counts() (pending uint64, failed uint64, err error) {
+	err = s.viewTx(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingStateSyncsBucket).ForEach(func(_, v []byte) error {
+			var stateSync pendingStateSync
+			if err := json.Unmarshal(v, &stateSync); err != nil {
+				return err
+			}
+
+			if stateSync.isFailed() {
+				failed++
+			} else {
+				pending++
+			}
+
+			return nil
+		})
+	})
+
+	return pending, failed, err
+}
@@ -26,6 +26,8 @@ type SystemState interface {
 	GetEpoch() (uint64, error)
 	// GetNextCommittedIndex retrieves next committed bridge state sync index
 	GetNextCommittedIndex() (uint64, error)
+	// IsStateSyncProcessed checks whether bridge state sync with given id is executed
+	IsStateSyncProcessed(stateSyncID uint64) (bool, error)
 }
 
 var _ SystemState = &SystemStateImpl{}
@@ -81,3 +83,19 @@ func (s *SystemStateImpl) GetNextCommittedIndex() (uint64, error) {
 
 	return nextCommittedIndex.Uint64() + 1, nil
 }
+
+// IsStateSyncProcessed checks whether bridge state sync with given id is executed
+func (s *SystemStateImpl) IsStateSyncProcessed(stateSyncID uint64) (bool, error) {
+	rawResult, err := s.sidechainBridgeContract.Call("processedStateSyncs", ethgo.Latest,
+		new(big.Int).SetUint64(stateSyncID))
+	if err != nil {
+		return false, err
+	}
+
+	isProcessed, isOk := rawResult["0"].(bool)
+	if !isOk {
+		return false, fmt.Errorf("failed to decode processed state sync flag")
+	}
+
+	return isProcessed, nil
+}
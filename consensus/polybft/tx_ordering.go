@@ -0,0 +1,300 @@
+package polybft
+
+import (
+	"container/heap"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// TxOrderingStrategy is the policy used to order the txpool transactions in the block
+type TxOrderingStrategy string
+
+const (
+	// PriceTxOrdering orders transactions by their (effective) gas price, as provided by the txpool
+	PriceTxOrdering TxOrderingStrategy = "price"
+
+	// FIFOTxOrdering orders transactions by the time they arrived to the txpool
+	FIFOTxOrdering TxOrderingStrategy = "fifo"
+
+	// PriorityLanesTxOrdering orders the transactions of the allowlisted senders first
+	// (up to the reserved share of the block gas), while the rest are ordered by the gas price
+	PriorityLanesTxOrdering TxOrderingStrategy = "priority-lanes"
+
+	// maxPriorityLanesGasShare is the maximum share (percentage) of the block gas reserved for the priority lanes
+	maxPriorityLanesGasShare = 100
+)
+
+// Validate returns an error if the tx ordering strategy is not supported
+// (empty strategy is allowed and stands for the price ordering)
+func (s TxOrderingStrategy) Validate() error {
+	switch s {
+	case "", PriceTxOrdering, FIFOTxOrdering, PriorityLanesTxOrdering:
+		return nil
+	default:
+		return fmt.Errorf("unsupported tx ordering strategy: %s", s)
+	}
+}
+
+// orDefault returns the price ordering strategy in case the strategy is not set
+func (s TxOrderingStrategy) orDefault() TxOrderingStrategy {
+	if s == "" {
+		return PriceTxOrdering
+	}
+
+	return s
+}
+
+// PriorityLanesConfig configures the priority lanes tx ordering
+type PriorityLanesConfig struct {
+	// Senders are the allowlisted accounts (e.g. oracles and relayers) whose transactions are prioritized
+	Senders []types.Address `json:"senders"`
+
+	// ReservedGasShare is the share (percentage) of the block gas limit reserved for the priority senders
+	ReservedGasShare uint64 `json:"reservedGasShare"`
+}
+
+// Validate returns an error if the priority lanes configuration is invalid
+func (c *PriorityLanesConfig) Validate() error {
+	if c.ReservedGasShare > maxPriorityLanesGasShare {
+		return fmt.Errorf("priority lanes reserved gas share must not exceed %d%%, but got %d%%",
+			maxPriorityLanesGasShare, c.ReservedGasShare)
+	}
+
+	return nil
+}
+
+// getTxOrderingStrategy returns the tx ordering strategy active for the given block.
+// Policies other than the price one are used only once the TxOrdering fork is enabled
+func getTxOrderingStrategy(blockNumber uint64) TxOrderingStrategy {
+	if !forkmanager.GetInstance().IsForkEnabled(chain.TxOrdering, blockNumber) {
+		return PriceTxOrdering
+	}
+
+	params := forkmanager.GetInstance().GetParams(blockNumber)
+	if params == nil || params.TxOrdering == nil {
+		return PriceTxOrdering
+	}
+
+	return TxOrderingStrategy(*params.TxOrdering).orDefault()
+}
+
+// validateForksTxOrdering validates the tx ordering strategies set in fork params
+func validateForksTxOrdering(forks *chain.Forks) error {
+	if forks == nil {
+		return nil
+	}
+
+	for name, fork := range *forks {
+		if fork.Params == nil || fork.Params.TxOrdering == nil {
+			continue
+		}
+
+		if err := TxOrderingStrategy(*fork.Params.TxOrdering).Validate(); err != nil {
+			return fmt.Errorf("invalid %s fork params: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// txOrderingPolicy decides in which order the txpool transactions are written to the block.
+// The transactions are still popped, demoted or dropped by the block builder
+// (which makes the txpool provide the next transaction of the same account)
+type txOrderingPolicy interface {
+	// next returns the next transaction to be written to the block (or nil if there are no more transactions),
+	// given the gas already used by the block
+	next(gasUsed uint64) *types.Transaction
+}
+
+// newTxOrderingPolicy creates the tx ordering policy for the given strategy
+func newTxOrderingPolicy(strategy TxOrderingStrategy, pool txPoolInterface,
+	gasLimit, baseFee uint64, priorityLanes *PriorityLanesConfig) txOrderingPolicy {
+	switch strategy.orDefault() {
+	case FIFOTxOrdering:
+		return newFIFOTxOrdering(pool)
+	case PriorityLanesTxOrdering:
+		return newPriorityLanesTxOrdering(pool, gasLimit, baseFee, priorityLanes)
+	default:
+		return &priceTxOrdering{pool: pool}
+	}
+}
+
+var _ txOrderingPolicy = (*priceTxOrdering)(nil)
+
+// priceTxOrdering returns the transactions in the order provided by the txpool (by the gas price)
+type priceTxOrdering struct {
+	pool txPoolInterface
+}
+
+func (o *priceTxOrdering) next(uint64) *types.Transaction {
+	return o.pool.Peek()
+}
+
+var _ txOrderingPolicy = (*fifoTxOrdering)(nil)
+
+// fifoTxOrdering returns the transactions in the order they arrived to the txpool
+type fifoTxOrdering struct {
+	pool     txPoolInterface
+	queue    *txHeap
+	arrivals map[types.Hash]time.Time
+}
+
+func newFIFOTxOrdering(pool txPoolInterface) *fifoTxOrdering {
+	o := &fifoTxOrdering{
+		pool:     pool,
+		arrivals: map[types.Hash]time.Time{},
+	}
+
+	o.queue = &txHeap{less: func(a, b *types.Transaction) bool {
+		arrivalA, arrivalB := o.arrivals[a.Hash], o.arrivals[b.Hash]
+		if !arrivalA.Equal(arrivalB) {
+			return arrivalA.Before(arrivalB)
+		}
+
+		return a.Nonce < b.Nonce
+	}}
+
+	return o
+}
+
+func (o *fifoTxOrdering) next(uint64) *types.Transaction {
+	for tx := o.pool.Peek(); tx != nil; tx = o.pool.Peek() {
+		arrival, ok := o.pool.ArrivalTime(tx.Hash)
+		if !ok {
+			// transaction is not tracked by the pool anymore, so put it at the end of the queue
+			arrival = time.Now()
+		}
+
+		o.arrivals[tx.Hash] = arrival
+		heap.Push(o.queue, tx)
+	}
+
+	if o.queue.Len() == 0 {
+		return nil
+	}
+
+	tx, _ := heap.Pop(o.queue).(*types.Transaction)
+	delete(o.arrivals, tx.Hash)
+
+	return tx
+}
+
+var _ txOrderingPolicy = (*priorityLanesTxOrdering)(nil)
+
+// priorityLanesTxOrdering returns the transactions of the priority senders first, until they use the reserved
+// share of the block gas. After that, the remaining transactions of both lanes are ordered by the gas price
+type priorityLanesTxOrdering struct {
+	pool        txPoolInterface
+	senders     map[types.Address]struct{}
+	reservedGas uint64
+	priority    *txHeap
+	regular     *txHeap
+	baseFee     *big.Int
+}
+
+func newPriorityLanesTxOrdering(pool txPoolInterface, gasLimit, baseFee uint64,
+	config *PriorityLanesConfig) *priorityLanesTxOrdering {
+	o := &priorityLanesTxOrdering{
+		pool:    pool,
+		senders: map[types.Address]struct{}{},
+		baseFee: new(big.Int).SetUint64(baseFee),
+	}
+
+	if config != nil {
+		for _, sender := range config.Senders {
+			o.senders[sender] = struct{}{}
+		}
+
+		o.reservedGas = gasLimit * config.ReservedGasShare / maxPriorityLanesGasShare
+	}
+
+	o.priority = &txHeap{less: o.higherPrice}
+	o.regular = &txHeap{less: o.higherPrice}
+
+	return o
+}
+
+func (o *priorityLanesTxOrdering) next(gasUsed uint64) *types.Transaction {
+	for tx := o.pool.Peek(); tx != nil; tx = o.pool.Peek() {
+		if _, ok := o.senders[tx.From]; ok {
+			heap.Push(o.priority, tx)
+		} else {
+			heap.Push(o.regular, tx)
+		}
+	}
+
+	var lane *txHeap
+
+	switch {
+	case o.priority.Len() == 0 && o.regular.Len() == 0:
+		return nil
+	case o.priority.Len() == 0:
+		lane = o.regular
+	case o.regular.Len() == 0 || gasUsed < o.reservedGas:
+		lane = o.priority
+	case o.higherPrice(o.regular.txs[0], o.priority.txs[0]):
+		lane = o.regular
+	default:
+		lane = o.priority
+	}
+
+	tx, _ := heap.Pop(lane).(*types.Transaction)
+
+	return tx
+}
+
+// higherPrice returns true if the transaction a pays higher (effective) gas price than the transaction b
+func (o *priorityLanesTxOrdering) higherPrice(a, b *types.Transaction) bool {
+	if c := a.EffectiveGasTip(o.baseFee).Cmp(b.EffectiveGasTip(o.baseFee)); c != 0 {
+		return c > 0
+	}
+
+	if c := a.GetGasFeeCap().Cmp(b.GetGasFeeCap()); c != 0 {
+		return c > 0
+	}
+
+	return a.Nonce < b.Nonce
+}
+
+var _ heap.Interface = (*txHeap)(nil)
+
+// txHeap is a heap of transactions ordered by the given less function
+type txHeap struct {
+	txs  []*types.Transaction
+	less func(a, b *types.Transaction) bool
+}
+
+func (h *txHeap) Len() int {
+	return len(h.txs)
+}
+
+func (h *txHeap) Less(i, j int) bool {
+	return h.less(h.txs[i], h.txs[j])
+}
+
+func (h *txHeap) Swap(i, j int) {
+	h.txs[i], h.txs[j] = h.txs[j], h.txs[i]
+}
+
+func (h *txHeap) Push(x interface{}) {
+	tx, ok := x.(*types.Transaction)
+	if !ok {
+		return
+	}
+
+	h.txs = append(h.txs, tx)
+}
+
+func (h *txHeap) Pop() interface{} {
+	old := h.txs
+	n := len(old)
+	x := old[n-1]
+	h.txs = old[0 : n-1]
+
+	return x
+}
@@ -0,0 +1,145 @@
+package polybft
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxOrdering_Validate(t *testing.T) {
+	t.Parallel()
+
+	for _, strategy := range []TxOrderingStrategy{"", PriceTxOrdering, FIFOTxOrdering, PriorityLanesTxOrdering} {
+		require.NoError(t, strategy.Validate())
+	}
+
+	require.ErrorContains(t, TxOrderingStrategy("random").Validate(), "unsupported tx ordering")
+
+	invalid := "random"
+	forks := &chain.Forks{
+		chain.TxOrdering: chain.Fork{Block: 10, Params: &forkmanager.ForkParams{TxOrdering: &invalid}},
+	}
+	require.ErrorContains(t, validateForksTxOrdering(forks), chain.TxOrdering)
+	require.NoError(t, validateForksTxOrdering(chain.AllForksEnabled))
+
+	require.NoError(t, (&PriorityLanesConfig{ReservedGasShare: 100}).Validate())
+	require.ErrorContains(t, (&PriorityLanesConfig{ReservedGasShare: 101}).Validate(), "must not exceed")
+}
+
+func TestTxOrdering_StrategyPerFork(t *testing.T) {
+	const forkName = "txOrderingTestFork"
+
+	fifo := string(FIFOTxOrdering)
+	fm := forkmanager.GetInstance()
+
+	fm.RegisterFork(forkName, &forkmanager.ForkParams{TxOrdering: &fifo})
+	require.NoError(t, fm.ActivateFork(forkName, 2_000_000))
+
+	fm.RegisterFork(chain.TxOrdering, nil)
+	require.NoError(t, fm.ActivateFork(chain.TxOrdering, 2_000_010))
+
+	t.Cleanup(func() {
+		require.NoError(t, fm.DeactivateFork(forkName))
+		require.NoError(t, fm.DeactivateFork(chain.TxOrdering))
+	})
+
+	require.Equal(t, PriceTxOrdering, getTxOrderingStrategy(1_999_999))
+	// the policy set in the fork params is not used until the TxOrdering fork is enabled
+	require.Equal(t, PriceTxOrdering, getTxOrderingStrategy(2_000_000))
+	require.Equal(t, FIFOTxOrdering, getTxOrderingStrategy(2_000_010))
+}
+
+func TestTxOrdering_Price(t *testing.T) {
+	t.Parallel()
+
+	txs := []*types.Transaction{newOrderingTestTx(1, 10), newOrderingTestTx(2, 5)}
+
+	pool := newOrderingTestTxPool(t, txs)
+	ordering := newTxOrderingPolicy(PriceTxOrdering, pool, 1_000, 0, nil)
+
+	// transactions are returned in the txpool order
+	require.Equal(t, txs[0], ordering.next(0))
+	require.Equal(t, txs[1], ordering.next(0))
+	require.Nil(t, ordering.next(0))
+}
+
+func TestTxOrdering_FIFO(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	txs := []*types.Transaction{newOrderingTestTx(1, 10), newOrderingTestTx(2, 5), newOrderingTestTx(3, 1)}
+
+	pool := newOrderingTestTxPool(t, txs)
+	pool.On("ArrivalTime", txs[0].Hash).Return(now, true).Once()
+	pool.On("ArrivalTime", txs[1].Hash).Return(now.Add(-time.Minute), true).Once()
+	pool.On("ArrivalTime", txs[2].Hash).Return(time.Time{}, false).Once()
+
+	ordering := newTxOrderingPolicy(FIFOTxOrdering, pool, 1_000, 0, nil)
+
+	// the oldest transaction goes first, while the ones unknown to the pool go last
+	require.Equal(t, txs[1], ordering.next(0))
+	require.Equal(t, txs[0], ordering.next(0))
+	require.Equal(t, txs[2], ordering.next(0))
+	require.Nil(t, ordering.next(0))
+
+	pool.AssertExpectations(t)
+}
+
+func TestTxOrdering_PriorityLanes(t *testing.T) {
+	t.Parallel()
+
+	regular := newOrderingTestTx(1, 10)
+	oracle := newOrderingTestTx(2, 1)
+	relayer := newOrderingTestTx(3, 2)
+
+	pool := newOrderingTestTxPool(t, []*types.Transaction{regular, oracle, relayer})
+	ordering := newTxOrderingPolicy(PriorityLanesTxOrdering, pool, 1_000, 0, &PriorityLanesConfig{
+		Senders:          []types.Address{oracle.From, relayer.From},
+		ReservedGasShare: 50,
+	})
+
+	// priority senders go first until the reserved gas (500) is used
+	require.Equal(t, relayer, ordering.next(0))
+	require.Equal(t, oracle, ordering.next(499))
+
+	pool = newOrderingTestTxPool(t, []*types.Transaction{regular, oracle, relayer})
+	ordering = newTxOrderingPolicy(PriorityLanesTxOrdering, pool, 1_000, 0, &PriorityLanesConfig{
+		Senders:          []types.Address{oracle.From, relayer.From},
+		ReservedGasShare: 50,
+	})
+
+	require.Equal(t, relayer, ordering.next(0))
+
+	// after the reserved gas is used, both lanes compete by the gas price
+	require.Equal(t, regular, ordering.next(500))
+	require.Equal(t, oracle, ordering.next(600))
+	require.Nil(t, ordering.next(700))
+}
+
+func newOrderingTestTx(sender byte, gasPrice int64) *types.Transaction {
+	return &types.Transaction{
+		From:     types.BytesToAddress([]byte{sender}),
+		GasPrice: big.NewInt(gasPrice),
+		Hash:     types.BytesToHash([]byte{sender}),
+	}
+}
+
+// newOrderingTestTxPool creates the txpool mock which returns the given transactions once
+func newOrderingTestTxPool(t *testing.T, txs []*types.Transaction) *txPoolMock {
+	t.Helper()
+
+	pool := &txPoolMock{}
+
+	for _, tx := range txs {
+		pool.On("Peek").Return(tx).Once()
+	}
+
+	pool.On("Peek").Return((*types.Transaction)(nil))
+
+	return pool
+}
@@ -92,6 +92,10 @@ var (
 	AllowListBridgeAddr = types.StringToAddress("0x0200000000000000000000000000000000000004")
 	// BlockListBridgeAddr is the address of the bridge block list
 	BlockListBridgeAddr = types.StringToAddress("0x0300000000000000000000000000000000000004")
+	// CallPolicyAddr is the address of the call policy
+	CallPolicyAddr = types.StringToAddress("0x0200000000000000000000000000000000000006")
+	// AllowListSponsorsAddr is the address of the sponsors allow list
+	AllowListSponsorsAddr = types.StringToAddress("0x0200000000000000000000000000000000000008")
 )
 
 // GetProxyImplementationMapping retrieves the addresses of proxy contracts that should be deployed unconditionally
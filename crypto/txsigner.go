@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
 )
 
 // Magic numbers from Ethereum, used in v calculation
@@ -41,7 +42,12 @@ func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	// London signer requires a fallback signer that is defined above.
 	// This is the reason why the london signer check is separated.
 	if forks.London {
-		return NewLondonSigner(chainID, forks.Homestead, signer)
+		signer = NewLondonSigner(chainID, forks.Homestead, signer)
+	}
+
+	// Sponsor signer falls back to the signers defined above as well
+	if forks.Sponsorship {
+		return NewSponsorSigner(chainID, forks.Homestead, signer)
 	}
 
 	return signer
@@ -68,10 +74,26 @@ func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 // keccak256(RLP(type, chainId, nonce, gasPrice, gas, to, value, input, accessList))
 // DynamicFeeTx:
 // keccak256(RLP(type, chainId, nonce, gasTipCap, gasFeeCap, gas, to, value, input, accessList))
+// SponsoredTx:
+// keccak256(RLP(type, chainId, nonce, gasTipCap, gasFeeCap, gas, to, value, input, accessList, sponsor))
 func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	a := signerPool.Get()
 	defer signerPool.Put(a)
 
+	v := txHashFields(a, tx, chainID)
+
+	var hash []byte
+	if tx.Type == types.LegacyTx {
+		hash = keccak.Keccak256Rlp(nil, v)
+	} else {
+		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
+	}
+
+	return types.BytesToHash(hash)
+}
+
+// txHashFields returns the RLP array of the transaction fields signed by the sender
+func txHashFields(a *fastrlp.Arena, tx *types.Transaction, chainID uint64) *fastrlp.Value {
 	v := a.NewArray()
 
 	if tx.Type != types.LegacyTx {
@@ -80,7 +102,7 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 
 	v.Set(a.NewUint(tx.Nonce))
 
-	if tx.Type == types.DynamicFeeTx {
+	if tx.IsDynamicFee() {
 		v.Set(a.NewBigInt(tx.GasTipCap))
 		v.Set(a.NewBigInt(tx.GasFeeCap))
 	} else {
@@ -111,12 +133,10 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 		v.Set(a.NewArray())
 	}
 
-	var hash []byte
-	if tx.Type == types.LegacyTx {
-		hash = keccak.Keccak256Rlp(nil, v)
-	} else {
-		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
+	// the sender agrees to the sponsor paying the gas of the transaction
+	if tx.Type == types.SponsoredTx {
+		v.Set(a.NewCopyBytes(tx.Sponsor.Bytes()))
 	}
 
-	return types.BytesToHash(hash)
+	return v
 }
@@ -0,0 +1,160 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrNotSponsoredTx is returned when the sponsorship is requested for a non-sponsored transaction
+	ErrNotSponsoredTx = errors.New("transaction is not sponsored")
+	// ErrInvalidSponsor is returned when the sponsor signature isn't made by the transaction sponsor
+	ErrInvalidSponsor = errors.New("sponsor signature doesn't match the transaction sponsor")
+)
+
+// SponsorSigner implements signer for the sponsored transactions, whose gas is paid
+// by the sponsor signing the transaction on top of the sender
+type SponsorSigner struct {
+	chainID        uint64
+	isHomestead    bool
+	fallbackSigner TxSigner
+}
+
+// NewSponsorSigner returns a new SponsorSigner object
+func NewSponsorSigner(chainID uint64, isHomestead bool, fallbackSigner TxSigner) *SponsorSigner {
+	return &SponsorSigner{
+		chainID:        chainID,
+		isHomestead:    isHomestead,
+		fallbackSigner: fallbackSigner,
+	}
+}
+
+// Hash is a wrapper function that calls calcTxHash with the SponsorSigner's fields
+func (e *SponsorSigner) Hash(tx *types.Transaction) types.Hash {
+	return calcTxHash(tx, e.chainID)
+}
+
+// Sender returns the transaction sender
+func (e *SponsorSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	// Apply fallback signer for non-sponsored txs
+	if tx.Type != types.SponsoredTx {
+		return e.fallbackSigner.Sender(tx)
+	}
+
+	return e.recover(e.Hash(tx), tx.R, tx.S, tx.V)
+}
+
+// SignTx signs the transaction using the passed in private key
+func (e *SponsorSigner) SignTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*types.Transaction, error) {
+	// Apply fallback signer for non-sponsored txs
+	if tx.Type != types.SponsoredTx {
+		return e.fallbackSigner.SignTx(tx, pk)
+	}
+
+	tx = tx.Copy()
+
+	h := e.Hash(tx)
+
+	sig, err := Sign(pk, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	tx.V = new(big.Int).SetBytes(e.calculateV(sig[64]))
+
+	return tx, nil
+}
+
+// SponsorHash calculates the hash signed by the sponsor, which binds the sponsorship
+// to the sender and its nonce, so it can't be replayed for other transactions
+// keccak256(RLP(type, chainId, nonce, gasTipCap, gasFeeCap, gas, to, value, input, accessList, sponsor, from))
+func (e *SponsorSigner) SponsorHash(tx *types.Transaction) types.Hash {
+	a := signerPool.Get()
+	defer signerPool.Put(a)
+
+	v := txHashFields(a, tx, e.chainID)
+	v.Set(a.NewCopyBytes(tx.From.Bytes()))
+
+	return types.BytesToHash(keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v))
+}
+
+// Sponsor returns the sponsor of the transaction, after checking
+// that the sponsor signature is made by the transaction sponsor.
+// The sender of the transaction must be set
+func (e *SponsorSigner) Sponsor(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.SponsoredTx {
+		return types.ZeroAddress, ErrNotSponsoredTx
+	}
+
+	sponsor, err := e.recover(e.SponsorHash(tx), tx.SponsorR, tx.SponsorS, tx.SponsorV)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	if sponsor != tx.Sponsor {
+		return types.ZeroAddress, ErrInvalidSponsor
+	}
+
+	return sponsor, nil
+}
+
+// SignSponsorTx signs the sponsorship of the transaction signed by the sender,
+// using the private key of the transaction sponsor
+func (e *SponsorSigner) SignSponsorTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*types.Transaction, error) {
+	if tx.Type != types.SponsoredTx {
+		return nil, ErrNotSponsoredTx
+	}
+
+	if PubKeyToAddress(&pk.PublicKey) != tx.Sponsor {
+		return nil, ErrInvalidSponsor
+	}
+
+	from, err := e.Sender(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx = tx.Copy()
+	tx.From = from
+
+	h := e.SponsorHash(tx)
+
+	sig, err := Sign(pk, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.SponsorR = new(big.Int).SetBytes(sig[:32])
+	tx.SponsorS = new(big.Int).SetBytes(sig[32:64])
+	tx.SponsorV = new(big.Int).SetBytes(e.calculateV(sig[64]))
+
+	return tx, nil
+}
+
+// recover returns the address which signed the hash with the given signature values
+func (e *SponsorSigner) recover(hash types.Hash, r, s, v *big.Int) (types.Address, error) {
+	sig, err := encodeSignature(r, s, v, e.isHomestead)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(hash.Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
+// calculateV returns the V value for transaction signatures. Based on EIP155
+func (e *SponsorSigner) calculateV(parity byte) []byte {
+	return big.NewInt(int64(parity)).Bytes()
+}
@@ -0,0 +1,91 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestSponsorSigner(t *testing.T) {
+	t.Parallel()
+
+	chainID := uint64(100)
+	toAddress := types.StringToAddress("1")
+
+	senderKey, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sponsorKey, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	otherKey, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	signer := NewSigner(chain.AllForksEnabled.At(0), chainID)
+	sponsorSigner, ok := signer.(*SponsorSigner)
+	require.True(t, ok)
+
+	txn := &types.Transaction{
+		Type:      types.SponsoredTx,
+		ChainID:   new(big.Int).SetUint64(chainID),
+		Nonce:     1,
+		To:        &toAddress,
+		Value:     big.NewInt(1),
+		Gas:       21000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Sponsor:   PubKeyToAddress(&sponsorKey.PublicKey),
+	}
+
+	signedTx, err := signer.SignTx(txn, senderKey)
+	require.NoError(t, err)
+
+	// only the transaction sponsor can sign the sponsorship
+	_, err = sponsorSigner.SignSponsorTx(signedTx, otherKey)
+	require.ErrorIs(t, err, ErrInvalidSponsor)
+
+	sponsoredTx, err := sponsorSigner.SignSponsorTx(signedTx, sponsorKey)
+	require.NoError(t, err)
+
+	from, err := signer.Sender(sponsoredTx)
+	require.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&senderKey.PublicKey), from)
+
+	sponsor, err := sponsorSigner.Sponsor(sponsoredTx)
+	require.NoError(t, err)
+	assert.Equal(t, txn.Sponsor, sponsor)
+
+	// the sponsorship is bound to the sender nonce
+	replayedTx := sponsoredTx.Copy()
+	replayedTx.Nonce++
+
+	_, err = sponsorSigner.Sponsor(replayedTx)
+	require.Error(t, err)
+
+	// the sender signature covers the sponsor
+	swappedTx := sponsoredTx.Copy()
+	swappedTx.Sponsor = PubKeyToAddress(&otherKey.PublicKey)
+
+	swappedFrom, err := signer.Sender(swappedTx)
+	require.NoError(t, err)
+	assert.NotEqual(t, from, swappedFrom)
+
+	// the non-sponsored transactions fall back to the london signer
+	dynamicTx := txn.Copy()
+	dynamicTx.Type = types.DynamicFeeTx
+
+	dynamicTx, err = signer.SignTx(dynamicTx, senderKey)
+	require.NoError(t, err)
+
+	from, err = signer.Sender(dynamicTx)
+	require.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&senderKey.PublicKey), from)
+
+	_, err = sponsorSigner.Sponsor(dynamicTx)
+	require.ErrorIs(t, err, ErrNotSponsoredTx)
+}
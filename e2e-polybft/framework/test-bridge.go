@@ -54,6 +54,10 @@ func (t *TestBridge) Start() error {
 		"--data-dir", t.clusterConfig.Dir("test-rootchain"),
 	}
 
+	if t.clusterConfig.EmbeddedRootchain {
+		args = append(args, "--embedded")
+	}
+
 	stdout := t.clusterConfig.GetStdout("bridge")
 
 	bridgeNode, err := newNode(t.clusterConfig.Binary, args, stdout)
@@ -44,6 +44,10 @@ const (
 	// envStdoutEnabled signal whether the output of the nodes get piped to stdout
 	envStdoutEnabled = "E2E_STDOUT"
 
+	// envEmbeddedRootchain signal whether the rootchain runs as an in-process polygon-edge chain
+	// (instead of the geth docker container)
+	envEmbeddedRootchain = "E2E_EMBEDDED_ROOTCHAIN"
+
 	// prefix for validator directory
 	defaultValidatorPrefix = "test-chain-"
 
@@ -120,6 +124,8 @@ type TestClusterConfig struct {
 
 	ProxyContractsAdmin string
 
+	EmbeddedRootchain bool
+
 	logsDirOnce sync.Once
 }
 
@@ -389,6 +395,12 @@ func WithProxyContractsAdmin(address string) ClusterOption {
 	}
 }
 
+func WithEmbeddedRootchain() ClusterOption {
+	return func(h *TestClusterConfig) {
+		h.EmbeddedRootchain = true
+	}
+}
+
 func isTrueEnv(e string) bool {
 	return strings.ToLower(os.Getenv(e)) == "true"
 }
@@ -407,14 +419,15 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 	var err error
 
 	config := &TestClusterConfig{
-		t:             t,
-		WithLogs:      isTrueEnv(envLogsEnabled),
-		WithStdout:    isTrueEnv(envStdoutEnabled),
-		Binary:        resolveBinary(),
-		EpochSize:     10,
-		EpochReward:   1,
-		BlockGasLimit: 1e7, // 10M
-		StakeAmounts:  []*big.Int{},
+		t:                 t,
+		WithLogs:          isTrueEnv(envLogsEnabled),
+		WithStdout:        isTrueEnv(envStdoutEnabled),
+		EmbeddedRootchain: isTrueEnv(envEmbeddedRootchain),
+		Binary:            resolveBinary(),
+		EpochSize:         10,
+		EpochReward:       1,
+		BlockGasLimit:     1e7, // 10M
+		StakeAmounts:      []*big.Int{},
 	}
 
 	if config.ValidatorPrefix == "" {
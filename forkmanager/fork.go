@@ -38,6 +38,18 @@ type ForkParams struct {
 
 	// BlockTimeDrift defines the time slot in which a new block can be created
 	BlockTimeDrift *uint64 `json:"blockTimeDrift,omitempty"`
+
+	// ProposerSelection is the strategy used to select the block proposer
+	// (e.g. "weighted-round-robin", "equal-rotation" or "randomized")
+	ProposerSelection *string `json:"proposerSelection,omitempty"`
+
+	// TxOrdering is the policy used to order the transactions in the block
+	// (e.g. "price", "fifo" or "priority-lanes")
+	TxOrdering *string `json:"txOrdering,omitempty"`
+
+	// ParallelExecution enables the optimistic parallel execution of the transactions
+	// while the block is being built
+	ParallelExecution *bool `json:"parallelExecution,omitempty"`
 }
 
 // forkHandler defines one custom handler
@@ -12,6 +12,7 @@ var (
 	ErrInvalidPercentile = errors.New("invalid percentile")
 	ErrBlockCount        = errors.New("blockCount must be greater than 0")
 	ErrBlockNotFound     = errors.New("could not find block")
+	ErrReceiptsNotFound  = errors.New("could not find block receipts")
 )
 
 const (
@@ -129,13 +130,18 @@ func (g *GasHelper) FeeHistory(blockCount uint64, newestBlock uint64, rewardPerc
 			continue
 		}
 
+		// the percentiles are weighted by the gas used by the transactions
+		receipts, err := g.backend.GetReceiptsByHash(block.Hash())
+		if err != nil || len(receipts) != len(block.Transactions) {
+			return &FeeHistoryReturn{0, nil, nil, nil}, ErrReceiptsNotFound
+		}
+
 		sorter := make([]*txGasAndReward, len(block.Transactions))
 		baseFee := new(big.Int).SetUint64(block.Header.BaseFee)
 
 		for j, tx := range block.Transactions {
-			cost := tx.Cost()
 			sorter[j] = &txGasAndReward{
-				gasUsed: cost.Sub(cost, tx.Value),
+				gasUsed: new(big.Int).SetUint64(receipts[j].GasUsed),
 				reward:  tx.EffectiveGasTip(baseFee),
 			}
 		}
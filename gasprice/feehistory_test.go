@@ -1,6 +1,7 @@
 package gasprice
 
 import (
+	"math/big"
 	"math/rand"
 	"testing"
 	"time"
@@ -207,6 +208,60 @@ func TestGasHelper_FeeHistory(t *testing.T) {
 						require.NoError(t, err)
 						b.Transactions[i] = tx
 					}
+
+					backend.addTestReceipts(b, 21000)
+				}
+
+				return backend
+			},
+		},
+		{
+			Name:                  "rewardPercentile weighted by gas used",
+			BlockRange:            1,
+			NewestBlock:           1,
+			RewardPercentiles:     []float64{50, 95},
+			ExpectedOldestBlock:   1,
+			ExpectedBaseFeePerGas: []uint64{chain.GenesisBaseFee, chain.GenesisBaseFee},
+			ExpectedGasUsedRatio:  []float64{0.1},
+			// only the gas used by the transactions counts, regardless of their gas limits and paid fees
+			ExpectedRewards: [][]uint64{{1e9, 10e9}},
+			GetBackend: func() Blockchain {
+				backend := createTestBlocks(t, 1)
+				block := backend.blocksByNumber[1]
+				block.Header.GasLimit = 1_000_000
+				block.Header.GasUsed = 100_000
+				block.Transactions = []*types.Transaction{
+					{
+						Type:      types.DynamicFeeTx,
+						Gas:       100_000,
+						Value:     big.NewInt(0),
+						GasTipCap: ethgo.Gwei(10),
+						GasFeeCap: ethgo.Gwei(100),
+					},
+					{
+						Type:      types.DynamicFeeTx,
+						Gas:       900_000,
+						Value:     big.NewInt(0),
+						GasTipCap: ethgo.Gwei(1),
+						GasFeeCap: ethgo.Gwei(100),
+					},
+				}
+
+				backend.addTestReceipts(block, 10_000, 90_000)
+
+				return backend
+			},
+		},
+		{
+			Name:              "receipts not found",
+			Error:             true,
+			BlockRange:        1,
+			NewestBlock:       1,
+			RewardPercentiles: []float64{50},
+			GetBackend: func() Blockchain {
+				backend := createTestBlocks(t, 1)
+				backend.blocksByNumber[1].Transactions = []*types.Transaction{
+					{Type: types.DynamicFeeTx, Gas: 21000, Value: big.NewInt(0), GasFeeCap: ethgo.Gwei(100)},
 				}
 
 				return backend
@@ -246,7 +301,7 @@ func TestGasHelper_FeeHistory(t *testing.T) {
 			t.Parallel()
 
 			backend := tc.GetBackend()
-			gasHelper, err := NewGasHelper(DefaultGasHelperConfig, backend)
+			gasHelper, err := NewGasHelper(DefaultGasHelperConfig, backend, nil)
 			require.NoError(t, err)
 			history, err := gasHelper.FeeHistory(tc.BlockRange, tc.NewestBlock, tc.RewardPercentiles)
 
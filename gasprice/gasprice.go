@@ -41,14 +41,24 @@ type Config struct {
 	// IgnorePrice is the lowest price to take into consideration
 	// when collecting transactions
 	IgnorePrice *big.Int
+	// SuggestionMode makes maxPriorityFeePerGas return the tip of the standard fee tier,
+	// blending the fee history, the projected base fee and the pending transactions
+	SuggestionMode bool
 }
 
 // Blockchain is the interface representing blockchain
 type Blockchain interface {
 	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
 	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 	Header() *types.Header
 	Config() *chain.Params
+	CalculateBaseFee(parent *types.Header) uint64
+}
+
+// TxPool is the interface representing the transaction pool
+type TxPool interface {
+	GetTxs(inclQueued bool) (allPromoted, allEnqueued map[types.Address][]*types.Transaction)
 }
 
 // GasStore interface is providing functions regarding gas and fees
@@ -57,6 +67,8 @@ type GasStore interface {
 	MaxPriorityFeePerGas() (*big.Int, error)
 	// FeeHistory returns the collection of historical gas information
 	FeeHistory(uint64, uint64, []float64) (*FeeHistoryReturn, error)
+	// FeeTiers returns the slow, standard and fast fee suggestions
+	FeeTiers() (*FeeTiers, error)
 }
 
 var _ GasStore = (*GasHelper)(nil)
@@ -76,10 +88,18 @@ type GasHelper struct {
 	// ignorePrice is the lowest price to take into consideration
 	// when collecting transactions
 	ignorePrice *big.Int
+	// suggestionMode makes maxPriorityFeePerGas return the tip of the standard fee tier
+	suggestionMode bool
 	// backend is an abstraction of blockchain
 	backend Blockchain
+	// pool is the transaction pool whose pending transactions are competing for the next blocks,
+	// nil if the pool pressure is not considered
+	pool TxPool
 	// lastHeaderHash is the last header for which maxPriorityFeePerGas was returned
 	lastHeaderHash types.Hash
+	// lastFeeTiers is the last fee suggestion, calculated for the lastFeeTiersHash header
+	lastFeeTiers     *FeeTiers
+	lastFeeTiersHash types.Hash
 
 	lock sync.Mutex
 
@@ -87,7 +107,7 @@ type GasHelper struct {
 }
 
 // NewGasHelper is the constructor function for GasHelper struct
-func NewGasHelper(config *Config, backend Blockchain) (*GasHelper, error) {
+func NewGasHelper(config *Config, backend Blockchain, pool TxPool) (*GasHelper, error) {
 	pricePercentile := config.PricePercentile
 	if pricePercentile > 100 {
 		pricePercentile = 100
@@ -105,7 +125,9 @@ func NewGasHelper(config *Config, backend Blockchain) (*GasHelper, error) {
 		ignorePrice:        config.IgnorePrice,
 		lastPrice:          config.LastPrice,
 		maxPrice:           config.MaxPrice,
+		suggestionMode:     config.SuggestionMode,
 		backend:            backend,
+		pool:               pool,
 		historyCache:       cache,
 	}, nil
 }
@@ -119,7 +141,18 @@ func NewGasHelper(config *Config, backend Blockchain) (*GasHelper, error) {
 //     more accurate calculation
 //   - when enough transactions and their tips are collected, take the one that is in pricePercentile
 //   - if given price is larger then maxPrice then return the maxPrice
+//
+// In the suggestion mode the tip of the standard fee tier is returned instead
 func (g *GasHelper) MaxPriorityFeePerGas() (*big.Int, error) {
+	if g.suggestionMode {
+		tiers, err := g.FeeTiers()
+		if err != nil {
+			return nil, err
+		}
+
+		return tiers.Standard.MaxPriorityFeePerGas, nil
+	}
+
 	currentHeader := g.backend.Header()
 
 	currentBlock, found := g.backend.GetBlockByHash(currentHeader.Hash, true)
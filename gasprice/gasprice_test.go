@@ -154,7 +154,7 @@ func TestGasHelper_MaxPriorityFeePerGas(t *testing.T) {
 			t.Parallel()
 
 			backend := tc.GetBackend()
-			gasHelper, err := NewGasHelper(DefaultGasHelperConfig, backend)
+			gasHelper, err := NewGasHelper(DefaultGasHelperConfig, backend, nil)
 			require.NoError(t, err)
 			price, err := gasHelper.MaxPriorityFeePerGas()
 
@@ -171,7 +171,11 @@ func TestGasHelper_MaxPriorityFeePerGas(t *testing.T) {
 func createTestBlocks(t *testing.T, numOfBlocks int) *backendMock {
 	t.Helper()
 
-	backend := &backendMock{blocks: make(map[types.Hash]*types.Block), blocksByNumber: make(map[uint64]*types.Block)}
+	backend := &backendMock{
+		blocks:         make(map[types.Hash]*types.Block),
+		blocksByNumber: make(map[uint64]*types.Block),
+		receipts:       make(map[types.Hash][]*types.Receipt),
+	}
 	genesis := &types.Block{
 		Header: &types.Header{
 			Number:  0,
@@ -233,6 +237,8 @@ func createTestTxs(t *testing.T, backend *backendMock, numOfTxsPerBlock, txCap i
 
 			b.Transactions[i] = tx
 		}
+
+		backend.addTestReceipts(b, 21000)
 	}
 }
 
@@ -242,6 +248,7 @@ type backendMock struct {
 	mock.Mock
 	blocks         map[types.Hash]*types.Block
 	blocksByNumber map[uint64]*types.Block
+	receipts       map[types.Hash][]*types.Receipt
 }
 
 func (b *backendMock) Header() *types.Header {
@@ -262,9 +269,40 @@ func (b *backendMock) GetBlockByHash(hash types.Hash, full bool) (*types.Block,
 	return block, exists
 }
 
+func (b *backendMock) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return b.receipts[hash], nil
+}
+
+// addTestReceipts sets the receipts of the block transactions, the transactions using the given gas
+func (b *backendMock) addTestReceipts(block *types.Block, gasUsed ...uint64) {
+	receipts := make([]*types.Receipt, len(block.Transactions))
+	cumulativeGasUsed := uint64(0)
+
+	for i := range receipts {
+		used := gasUsed[0]
+		if i < len(gasUsed) {
+			used = gasUsed[i]
+		}
+
+		cumulativeGasUsed += used
+		receipts[i] = &types.Receipt{GasUsed: used, CumulativeGasUsed: cumulativeGasUsed}
+	}
+
+	b.receipts[block.Hash()] = receipts
+}
+
 func (b *backendMock) Config() *chain.Params {
 	return &chain.Params{
 		ChainID: 1,
 		Forks:   chain.AllForksEnabled,
 	}
 }
+
+func (b *backendMock) CalculateBaseFee(parent *types.Header) uint64 {
+	// the base fee rises by 1/8 of its value if the parent block is more than half full
+	if parent.GasUsed > parent.GasLimit/2 {
+		return parent.BaseFee + parent.BaseFee/8
+	}
+
+	return parent.BaseFee
+}
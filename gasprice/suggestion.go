@@ -0,0 +1,313 @@
+package gasprice
+
+import (
+	"container/heap"
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// feeTier describes the fee tier by the percentile of the tips sampled from the fee history
+// and the number of blocks in which the transaction paying the fee is expected to be included
+type feeTier struct {
+	percentile float64
+	blocks     uint64
+}
+
+var (
+	slowFeeTier     = feeTier{percentile: 10, blocks: 8}
+	standardFeeTier = feeTier{percentile: 50, blocks: 3}
+	fastFeeTier     = feeTier{percentile: 90, blocks: 1}
+)
+
+// FeeTier is the fee suggested for the transaction to be included within the given number of blocks
+type FeeTier struct {
+	// MaxPriorityFeePerGas is the suggested tip
+	MaxPriorityFeePerGas *big.Int
+	// MaxFeePerGas is the suggested fee cap, covering the base fee projected for the given number of blocks
+	MaxFeePerGas *big.Int
+	// Blocks is the number of blocks in which the transaction is expected to be included
+	Blocks uint64
+}
+
+// FeeTiers holds the fee suggestions along with the chain conditions they are based on
+type FeeTiers struct {
+	// BaseFee is the projected base fee of the next block
+	BaseFee uint64
+	// GasUsedRatio is the average gas used ratio of the sampled blocks
+	GasUsedRatio float64
+	// PendingGas is the gas of the pending transactions able to pay the base fee of the next block
+	PendingGas uint64
+
+	Slow     *FeeTier
+	Standard *FeeTier
+	Fast     *FeeTier
+}
+
+// pendingTxTip is the tip paid by the pending transaction for its gas
+type pendingTxTip struct {
+	tip *big.Int
+	gas uint64
+}
+
+// FeeTiers returns the slow, standard and fast fee suggestions. The tip of each tier is the larger of:
+//   - the tier percentile of the tips paid in the last numOfBlocksToCheck blocks,
+//     where the recent blocks weigh more
+//   - the tip of the first pending transaction left out of the blocks the tier waits for,
+//     when the pending transactions are ordered by their tips
+//
+// The fee cap of each tier covers the base fee projected over the blocks the tier waits for,
+// assuming the blocks are as full as the sampled ones.
+// The suggestion is calculated once per chain head
+func (g *GasHelper) FeeTiers() (*FeeTiers, error) {
+	header := g.backend.Header()
+
+	g.lock.Lock()
+	lastFeeTiers, lastFeeTiersHash := g.lastFeeTiers, g.lastFeeTiersHash
+	g.lock.Unlock()
+
+	if lastFeeTiers != nil && lastFeeTiersHash == header.Hash {
+		return lastFeeTiers.copy(), nil
+	}
+
+	nextBaseFee := g.backend.CalculateBaseFee(header)
+
+	standard := standardFeeTier
+	// the standard tier follows the configured percentile, within the bounds of the other tiers
+	standard.percentile = float64(g.pricePercentile)
+
+	if standard.percentile < slowFeeTier.percentile {
+		standard.percentile = slowFeeTier.percentile
+	} else if standard.percentile > fastFeeTier.percentile {
+		standard.percentile = fastFeeTier.percentile
+	}
+
+	tiers := []feeTier{slowFeeTier, standard, fastFeeTier}
+	percentiles := make([]float64, len(tiers))
+
+	for i, tier := range tiers {
+		percentiles[i] = tier.percentile
+	}
+
+	history, err := g.FeeHistory(g.numOfBlocksToCheck, header.Number, percentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	g.lock.Lock()
+	lastPrice := g.lastPrice
+	g.lock.Unlock()
+
+	// the slow tier waits for the most blocks, so it needs the most of the pending tips
+	pendingTips, pendingGas := g.pendingTips(nextBaseFee, slowFeeTier.blocks*header.GasLimit)
+	gasUsedRatio := averageGasUsedRatio(history.GasUsedRatio)
+
+	result := make([]*FeeTier, len(tiers))
+
+	for i, tier := range tiers {
+		tip := historicalTip(history, i, lastPrice)
+
+		if poolTip := pendingTip(pendingTips, tier.blocks*header.GasLimit); poolTip.Cmp(tip) > 0 {
+			tip = poolTip
+		}
+
+		if tip.Cmp(g.maxPrice) > 0 {
+			tip = new(big.Int).Set(g.maxPrice)
+		}
+
+		baseFee := g.projectBaseFee(header, nextBaseFee, gasUsedRatio, tier.blocks)
+
+		result[i] = &FeeTier{
+			MaxPriorityFeePerGas: tip,
+			MaxFeePerGas:         new(big.Int).Add(new(big.Int).SetUint64(baseFee), tip),
+			Blocks:               tier.blocks,
+		}
+	}
+
+	feeTiers := &FeeTiers{
+		BaseFee:      nextBaseFee,
+		GasUsedRatio: gasUsedRatio,
+		PendingGas:   pendingGas,
+		Slow:         result[0],
+		Standard:     result[1],
+		Fast:         result[2],
+	}
+
+	// cache the calculated suggestion and header hash
+	g.lock.Lock()
+	g.lastFeeTiers = feeTiers
+	g.lastFeeTiersHash = header.Hash
+	g.lock.Unlock()
+
+	return feeTiers.copy(), nil
+}
+
+// copy returns a deep copy of the fee tiers
+func (f *FeeTiers) copy() *FeeTiers {
+	c := *f
+	c.Slow, c.Standard, c.Fast = f.Slow.copy(), f.Standard.copy(), f.Fast.copy()
+
+	return &c
+}
+
+// copy returns a deep copy of the fee tier
+func (f *FeeTier) copy() *FeeTier {
+	return &FeeTier{
+		MaxPriorityFeePerGas: new(big.Int).Set(f.MaxPriorityFeePerGas),
+		MaxFeePerGas:         new(big.Int).Set(f.MaxFeePerGas),
+		Blocks:               f.Blocks,
+	}
+}
+
+// pendingTips returns the highest tips of the pending transactions able to pay the given base fee,
+// sorted from the highest to the lowest, along with the total gas of those transactions.
+// Only the tips of the transactions filling the given gas (and the first one left out) are kept
+func (g *GasHelper) pendingTips(baseFee, maxGas uint64) ([]*pendingTxTip, uint64) {
+	if g.pool == nil {
+		return nil, 0
+	}
+
+	var (
+		tips      pendingTxTips
+		tipsGas   uint64
+		totalGas  uint64
+		baseFeeBI = new(big.Int).SetUint64(baseFee)
+	)
+
+	promoted, _ := g.pool.GetTxs(false)
+
+	for _, txs := range promoted {
+		for _, tx := range txs {
+			if tx.GetGasFeeCap().Cmp(baseFeeBI) < 0 {
+				// the transaction waits for the base fee to drop
+				continue
+			}
+
+			totalGas += tx.Gas
+
+			heap.Push(&tips, &pendingTxTip{tip: tx.EffectiveGasTip(baseFeeBI), gas: tx.Gas})
+			tipsGas += tx.Gas
+
+			// drop the lowest tip once the higher ones fill the gas on their own
+			for tipsGas-tips[0].gas > maxGas {
+				tipsGas -= heap.Pop(&tips).(*pendingTxTip).gas //nolint:forcetypeassert
+			}
+		}
+	}
+
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].tip.Cmp(tips[j].tip) > 0
+	})
+
+	return tips, totalGas
+}
+
+// pendingTxTips is the min heap of the pending transaction tips
+type pendingTxTips []*pendingTxTip
+
+func (t pendingTxTips) Len() int { return len(t) }
+
+func (t pendingTxTips) Less(i, j int) bool { return t[i].tip.Cmp(t[j].tip) < 0 }
+
+func (t pendingTxTips) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+
+func (t *pendingTxTips) Push(x interface{}) {
+	*t = append(*t, x.(*pendingTxTip)) //nolint:forcetypeassert
+}
+
+func (t *pendingTxTips) Pop() interface{} {
+	old := *t
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*t = old[:n-1]
+
+	return x
+}
+
+// projectBaseFee returns the highest base fee of the given number of blocks following the header,
+// assuming each of them uses the given ratio of its gas limit
+func (g *GasHelper) projectBaseFee(header *types.Header, nextBaseFee uint64, gasUsedRatio float64,
+	blocks uint64) uint64 {
+	if nextBaseFee == 0 {
+		// london hardfork is not enabled
+		return 0
+	}
+
+	baseFee := nextBaseFee
+	parent := &types.Header{
+		Number:   header.Number + 1,
+		GasLimit: header.GasLimit,
+		GasUsed:  uint64(gasUsedRatio * float64(header.GasLimit)),
+		BaseFee:  nextBaseFee,
+	}
+
+	for i := uint64(1); i < blocks; i++ {
+		parent.BaseFee = g.backend.CalculateBaseFee(parent)
+		parent.Number++
+
+		if parent.BaseFee > baseFee {
+			baseFee = parent.BaseFee
+		}
+	}
+
+	return baseFee
+}
+
+// historicalTip returns the average of the tips at the given percentile index of the fee history,
+// weighted by the block recency. Empty blocks are skipped and lastPrice is returned
+// if all the sampled blocks are empty
+func historicalTip(history *FeeHistoryReturn, index int, lastPrice *big.Int) *big.Int {
+	var (
+		weighted = new(big.Int)
+		weights  uint64
+	)
+
+	for i, ratio := range history.GasUsedRatio {
+		if ratio == 0 || len(history.Reward[i]) <= index {
+			continue
+		}
+
+		weight := uint64(i + 1)
+		reward := new(big.Int).SetUint64(history.Reward[i][index])
+
+		weighted.Add(weighted, reward.Mul(reward, new(big.Int).SetUint64(weight)))
+		weights += weight
+	}
+
+	if weights == 0 {
+		return new(big.Int).Set(lastPrice)
+	}
+
+	return weighted.Div(weighted, new(big.Int).SetUint64(weights))
+}
+
+// pendingTip returns the tip of the first pending transaction which doesn't fit into the given gas,
+// or zero if all of them fit
+func pendingTip(tips []*pendingTxTip, gas uint64) *big.Int {
+	var used uint64
+
+	for _, t := range tips {
+		if used += t.gas; used > gas {
+			return new(big.Int).Set(t.tip)
+		}
+	}
+
+	return big.NewInt(0)
+}
+
+// averageGasUsedRatio returns the average of the gas used ratios, or zero if there are none
+func averageGasUsedRatio(ratios []float64) float64 {
+	if len(ratios) == 0 {
+		return 0
+	}
+
+	var sum float64
+
+	for _, ratio := range ratios {
+		sum += ratio
+	}
+
+	return sum / float64(len(ratios))
+}
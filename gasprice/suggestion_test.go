@@ -0,0 +1,226 @@
+package gasprice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+const testBlockGasLimit = 1_000_000
+
+// createTestFullBlocks returns the backend with the blocks using the given ratio of their gas limit,
+// each of them including ten transactions paying the tips from 1 to 10 gwei and sharing its gas used
+func createTestFullBlocks(t *testing.T, numOfBlocks int, gasUsedRatio float64) *backendMock {
+	t.Helper()
+
+	backend := createTestBlocks(t, numOfBlocks)
+
+	for _, b := range backend.blocks {
+		if b.Number() == 0 {
+			continue
+		}
+
+		b.Header.GasLimit = testBlockGasLimit
+		b.Header.GasUsed = uint64(gasUsedRatio * testBlockGasLimit)
+		b.Transactions = make([]*types.Transaction, 10)
+
+		for i := range b.Transactions {
+			b.Transactions[i] = &types.Transaction{
+				Type:      types.DynamicFeeTx,
+				Gas:       b.Header.GasUsed / 10,
+				Value:     big.NewInt(0),
+				GasTipCap: ethgo.Gwei(uint64(i + 1)),
+				GasFeeCap: ethgo.Gwei(100),
+			}
+		}
+
+		backend.addTestReceipts(b, b.Header.GasUsed/10)
+	}
+
+	return backend
+}
+
+type poolMock struct {
+	txs map[types.Address][]*types.Transaction
+}
+
+func (p *poolMock) GetTxs(bool) (map[types.Address][]*types.Transaction, map[types.Address][]*types.Transaction) {
+	return p.txs, nil
+}
+
+func TestGasHelper_FeeTiers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Chain just started", func(t *testing.T) {
+		t.Parallel()
+
+		config := *DefaultGasHelperConfig
+		config.LastPrice = ethgo.Gwei(3)
+
+		gasHelper, err := NewGasHelper(&config, createTestBlocks(t, 0), nil)
+		require.NoError(t, err)
+
+		tiers, err := gasHelper.FeeTiers()
+		require.NoError(t, err)
+
+		// the last price is suggested until there are transactions to sample
+		for _, tier := range []*FeeTier{tiers.Slow, tiers.Standard, tiers.Fast} {
+			assert.Equal(t, ethgo.Gwei(3), tier.MaxPriorityFeePerGas)
+		}
+	})
+
+	t.Run("Base fee trends up", func(t *testing.T) {
+		t.Parallel()
+
+		gasHelper, err := NewGasHelper(DefaultGasHelperConfig, createTestFullBlocks(t, 10, 0.9), nil)
+		require.NoError(t, err)
+
+		tiers, err := gasHelper.FeeTiers()
+		require.NoError(t, err)
+
+		assert.InDelta(t, 0.9, tiers.GasUsedRatio, 0.01)
+		assert.Zero(t, tiers.PendingGas)
+
+		// the faster tiers pay more
+		assert.True(t, tiers.Slow.MaxPriorityFeePerGas.Cmp(tiers.Standard.MaxPriorityFeePerGas) < 0)
+		assert.True(t, tiers.Standard.MaxPriorityFeePerGas.Cmp(tiers.Fast.MaxPriorityFeePerGas) < 0)
+
+		// the fast tier covers the next base fee, while the slower ones cover its rise
+		nextBaseFee := new(big.Int).SetUint64(tiers.BaseFee)
+
+		assert.Equal(t, new(big.Int).Add(nextBaseFee, tiers.Fast.MaxPriorityFeePerGas), tiers.Fast.MaxFeePerGas)
+
+		for _, tier := range []*FeeTier{tiers.Slow, tiers.Standard} {
+			baseFee := new(big.Int).Sub(tier.MaxFeePerGas, tier.MaxPriorityFeePerGas)
+			assert.True(t, baseFee.Cmp(nextBaseFee) > 0)
+		}
+	})
+
+	t.Run("Base fee trends down", func(t *testing.T) {
+		t.Parallel()
+
+		gasHelper, err := NewGasHelper(DefaultGasHelperConfig, createTestFullBlocks(t, 10, 0.1), nil)
+		require.NoError(t, err)
+
+		tiers, err := gasHelper.FeeTiers()
+		require.NoError(t, err)
+
+		for _, tier := range []*FeeTier{tiers.Slow, tiers.Standard, tiers.Fast} {
+			assert.Equal(t,
+				new(big.Int).Add(new(big.Int).SetUint64(tiers.BaseFee), tier.MaxPriorityFeePerGas), tier.MaxFeePerGas)
+		}
+	})
+
+	t.Run("Pending pool pressure", func(t *testing.T) {
+		t.Parallel()
+
+		pendingTx := func(tip uint64) *types.Transaction {
+			return &types.Transaction{
+				Type:      types.DynamicFeeTx,
+				Gas:       testBlockGasLimit / 2,
+				GasTipCap: ethgo.Gwei(tip),
+				GasFeeCap: ethgo.Gwei(1000),
+			}
+		}
+
+		pool := &poolMock{txs: map[types.Address][]*types.Transaction{
+			types.StringToAddress("1"): {pendingTx(50), pendingTx(50), pendingTx(40)},
+			// the transaction which can't pay the base fee is not competing
+			types.StringToAddress("2"): {{Type: types.DynamicFeeTx, Gas: testBlockGasLimit, GasFeeCap: big.NewInt(1)}},
+		}}
+
+		gasHelper, err := NewGasHelper(DefaultGasHelperConfig, createTestFullBlocks(t, 10, 0.5), pool)
+		require.NoError(t, err)
+
+		tiers, err := gasHelper.FeeTiers()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(3*testBlockGasLimit/2), tiers.PendingGas)
+
+		// the next block is filled by the pending transactions
+		assert.Equal(t, ethgo.Gwei(40), tiers.Fast.MaxPriorityFeePerGas)
+		// while there is room for the transactions waiting for more blocks
+		assert.True(t, tiers.Standard.MaxPriorityFeePerGas.Cmp(ethgo.Gwei(10)) <= 0)
+	})
+
+	t.Run("Cached per chain head", func(t *testing.T) {
+		t.Parallel()
+
+		pool := &poolMock{txs: map[types.Address][]*types.Transaction{
+			types.StringToAddress("1"): {{
+				Type:      types.DynamicFeeTx,
+				Gas:       testBlockGasLimit / 2,
+				GasTipCap: ethgo.Gwei(50),
+				GasFeeCap: ethgo.Gwei(1000),
+			}},
+		}}
+
+		gasHelper, err := NewGasHelper(DefaultGasHelperConfig, createTestFullBlocks(t, 10, 0.5), pool)
+		require.NoError(t, err)
+
+		tiers, err := gasHelper.FeeTiers()
+		require.NoError(t, err)
+		require.Equal(t, uint64(testBlockGasLimit/2), tiers.PendingGas)
+
+		// the returned suggestion doesn't share the cached one
+		tiers.Fast.MaxPriorityFeePerGas.SetUint64(0)
+		pool.txs = nil
+
+		cached, err := gasHelper.FeeTiers()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(testBlockGasLimit/2), cached.PendingGas)
+		assert.NotZero(t, cached.Fast.MaxPriorityFeePerGas.Sign())
+	})
+
+	t.Run("Suggestion mode", func(t *testing.T) {
+		t.Parallel()
+
+		config := *DefaultGasHelperConfig
+		config.SuggestionMode = true
+
+		gasHelper, err := NewGasHelper(&config, createTestFullBlocks(t, 10, 0.9), nil)
+		require.NoError(t, err)
+
+		tiers, err := gasHelper.FeeTiers()
+		require.NoError(t, err)
+
+		price, err := gasHelper.MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		assert.Equal(t, tiers.Standard.MaxPriorityFeePerGas, price)
+	})
+}
+
+func TestGasHelper_PendingTips(t *testing.T) {
+	t.Parallel()
+
+	txs := make([]*types.Transaction, 100)
+	for i := range txs {
+		txs[i] = &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			Gas:       testBlockGasLimit / 10,
+			GasTipCap: ethgo.Gwei(uint64(i + 1)),
+			GasFeeCap: ethgo.Gwei(1000),
+		}
+	}
+
+	pool := &poolMock{txs: map[types.Address][]*types.Transaction{types.StringToAddress("1"): txs}}
+
+	gasHelper, err := NewGasHelper(DefaultGasHelperConfig, createTestBlocks(t, 0), pool)
+	require.NoError(t, err)
+
+	// only the highest tips filling the gas of two blocks (and the first one left out) are kept
+	tips, totalGas := gasHelper.pendingTips(0, 2*testBlockGasLimit)
+	require.Equal(t, uint64(100*testBlockGasLimit/10), totalGas)
+	require.Len(t, tips, 21)
+
+	for i, tip := range tips {
+		require.Equal(t, ethgo.Gwei(uint64(100-i)), tip.tip)
+	}
+
+	assert.Equal(t, ethgo.Gwei(80), pendingTip(tips, 2*testBlockGasLimit))
+}
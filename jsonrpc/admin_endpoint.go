@@ -0,0 +1,202 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/versioning"
+)
+
+var (
+	errExportPathMissing   = errors.New("export file path is missing")
+	errExportPathAbsolute  = errors.New("export file path must be relative to the export directory")
+	errExportPathTraversal = errors.New("export file path must not leave the export directory")
+)
+
+// adminStore provides access to the methods needed by admin endpoint
+type adminStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
+
+	// GetLocalPeerInfo returns the networking information of the local node
+	GetLocalPeerInfo() (*PeerInfo, error)
+
+	// GetPeersInfo returns the networking information of all connected peers
+	GetPeersInfo() ([]*PeerInfo, error)
+
+	// JoinPeer marks the given peer multiaddress ready for dialing
+	JoinPeer(rawPeerMultiaddr string) error
+
+	// DisconnectPeer disconnects from the peer with the given ID
+	DisconnectPeer(peerID string) error
+
+	// SetLogLevel changes the log level of the given node subsystem,
+	// or the global one if the module is empty
+	SetLogLevel(module string, level string) error
+
+	// ExportChain writes the blocks in the given range to a new backup file in the export directory
+	// of the node, at the given relative path. It returns the range of the written blocks
+	ExportChain(filePath string, from uint64, to *uint64) (uint64, uint64, error)
+}
+
+// PeerInfo holds the networking information of a node
+type PeerInfo struct {
+	ID        string   `json:"id"`
+	Addrs     []string `json:"addrs"`
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+type nodeHead struct {
+	Number argUint64  `json:"number"`
+	Hash   types.Hash `json:"hash"`
+}
+
+type nodeInfo struct {
+	*PeerInfo
+	Name    string      `json:"name"`
+	ChainID argUint64   `json:"chainId"`
+	Head    nodeHead    `json:"head"`
+	Syncing interface{} `json:"syncing"`
+}
+
+type exportChainResult struct {
+	Path string    `json:"path"`
+	From argUint64 `json:"from"`
+	To   argUint64 `json:"to"`
+}
+
+// Admin is the admin jsonrpc endpoint
+type Admin struct {
+	store     adminStore
+	chainID   uint64
+	chainName string
+}
+
+// NodeInfo returns the networking, chain and sync information of the node
+func (a *Admin) NodeInfo() (interface{}, error) {
+	peerInfo, err := a.store.GetLocalPeerInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	header := a.store.Header()
+
+	info := &nodeInfo{
+		PeerInfo: peerInfo,
+		Name:     fmt.Sprintf(clientVersionTemplate, a.chainName, a.chainID, versioning.Version),
+		ChainID:  argUint64(a.chainID),
+		Head: nodeHead{
+			Number: argUint64(header.Number),
+			Hash:   header.Hash,
+		},
+		Syncing: false,
+	}
+
+	if syncProgression := a.store.GetSyncProgression(); syncProgression != nil {
+		info.Syncing = progression{
+			Type:          string(syncProgression.SyncType),
+			StartingBlock: argUint64(syncProgression.StartingBlock),
+			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
+			HighestBlock:  argUint64(syncProgression.HighestBlock),
+		}
+	}
+
+	return info, nil
+}
+
+// Peers returns the networking information of all connected peers
+func (a *Admin) Peers() (interface{}, error) {
+	return a.store.GetPeersInfo()
+}
+
+// AddPeer connects to the peer with the given multiaddress
+func (a *Admin) AddPeer(peerAddr string) (interface{}, error) {
+	if err := a.store.JoinPeer(peerAddr); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer disconnects from the peer with the given ID
+func (a *Admin) RemovePeer(peerID string) (interface{}, error) {
+	if err := a.store.DisconnectPeer(peerID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SetLogLevel changes the log level (TRACE, DEBUG, INFO, WARN, ERROR, OFF) of the node,
+// or only of the given subsystem (e.g. syncer, txpool, network) if the module is set
+func (a *Admin) SetLogLevel(level string, module *string) (interface{}, error) {
+	var targetModule string
+	if module != nil {
+		targetModule = *module
+	}
+
+	if err := a.store.SetLogLevel(targetModule, level); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ExportChain exports the blocks in the given range to a new backup file in the export directory
+// of the node, which can be restored using the --restore server flag. The file path is relative
+// to the export directory. If the last block is omitted, the blocks up to the current head are exported
+func (a *Admin) ExportChain(filePath string, from *argUint64, to *argUint64) (interface{}, error) {
+	if err := validateExportPath(filePath); err != nil {
+		return nil, err
+	}
+
+	var (
+		fromBlock uint64
+		toBlock   *uint64
+	)
+
+	if from != nil {
+		fromBlock = uint64(*from)
+	}
+
+	if to != nil {
+		toBlock = (*uint64)(to)
+	}
+
+	writtenFrom, writtenTo, err := a.store.ExportChain(filePath, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportChainResult{
+		Path: filePath,
+		From: argUint64(writtenFrom),
+		To:   argUint64(writtenTo),
+	}, nil
+}
+
+// validateExportPath ensures the export file path stays within the export directory
+func validateExportPath(filePath string) error {
+	if filePath == "" {
+		return errExportPathMissing
+	}
+
+	if filepath.IsAbs(filePath) || strings.HasPrefix(filepath.ToSlash(filePath), "/") {
+		return errExportPathAbsolute
+	}
+
+	for _, elem := range strings.Split(filepath.ToSlash(filePath), "/") {
+		if elem == ".." {
+			return errExportPathTraversal
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package jsonrpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAdminStore struct {
+	*mockStore
+
+	header      *types.Header
+	progression *progress.Progression
+	peers       map[string]*PeerInfo
+	logLevels   map[string]string
+}
+
+func newMockAdminStore() *mockAdminStore {
+	return &mockAdminStore{
+		mockStore: newMockStore(),
+		logLevels: map[string]string{},
+		header:    &types.Header{Number: 10, Hash: types.StringToHash("0x10")},
+		peers: map[string]*PeerInfo{
+			"peer1": {ID: "peer1", Addrs: []string{"/ip4/127.0.0.1/tcp/30301"}},
+		},
+	}
+}
+
+func (m *mockAdminStore) Header() *types.Header {
+	return m.header
+}
+
+func (m *mockAdminStore) GetSyncProgression() *progress.Progression {
+	return m.progression
+}
+
+func (m *mockAdminStore) GetLocalPeerInfo() (*PeerInfo, error) {
+	return &PeerInfo{ID: "local", Addrs: []string{"/ip4/127.0.0.1/tcp/1478/p2p/local"}}, nil
+}
+
+func (m *mockAdminStore) GetPeersInfo() ([]*PeerInfo, error) {
+	peers := make([]*PeerInfo, 0, len(m.peers))
+	for _, p := range m.peers {
+		peers = append(peers, p)
+	}
+
+	return peers, nil
+}
+
+func (m *mockAdminStore) JoinPeer(rawPeerMultiaddr string) error {
+	m.peers[rawPeerMultiaddr] = &PeerInfo{ID: rawPeerMultiaddr}
+
+	return nil
+}
+
+func (m *mockAdminStore) DisconnectPeer(peerID string) error {
+	if _, ok := m.peers[peerID]; !ok {
+		return errors.New("peer not connected")
+	}
+
+	delete(m.peers, peerID)
+
+	return nil
+}
+
+func (m *mockAdminStore) SetLogLevel(module string, level string) error {
+	if hclog.LevelFromString(level) == hclog.NoLevel {
+		return errors.New("invalid log level")
+	}
+
+	m.logLevels[module] = level
+
+	return nil
+}
+
+func (m *mockAdminStore) ExportChain(_ string, from uint64, to *uint64) (uint64, uint64, error) {
+	targetTo := m.header.Number
+	if to != nil {
+		targetTo = *to
+	}
+
+	return from, targetTo, nil
+}
+
+func TestAdminEndpoint(t *testing.T) {
+	t.Parallel()
+
+	store := newMockAdminStore()
+	admin := &Admin{store: store, chainID: 100, chainName: "test-chain"}
+
+	t.Run("node info", func(t *testing.T) {
+		res, err := admin.NodeInfo()
+		require.NoError(t, err)
+
+		info, ok := res.(*nodeInfo)
+		require.True(t, ok)
+		assert.Equal(t, "local", info.ID)
+		assert.Equal(t, argUint64(100), info.ChainID)
+		assert.Equal(t, argUint64(10), info.Head.Number)
+		assert.Equal(t, false, info.Syncing)
+	})
+
+	t.Run("add and remove peers", func(t *testing.T) {
+		_, err := admin.AddPeer("peer2")
+		require.NoError(t, err)
+
+		res, err := admin.Peers()
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+
+		_, err = admin.RemovePeer("peer2")
+		require.NoError(t, err)
+
+		_, err = admin.RemovePeer("peer3")
+		require.Error(t, err)
+	})
+
+	t.Run("set log level", func(t *testing.T) {
+		_, err := admin.SetLogLevel("DEBUG", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "DEBUG", store.logLevels[""])
+
+		module := "syncer"
+
+		_, err = admin.SetLogLevel("TRACE", &module)
+		require.NoError(t, err)
+		assert.Equal(t, "TRACE", store.logLevels["syncer"])
+
+		_, err = admin.SetLogLevel("LOUD", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("export chain", func(t *testing.T) {
+		_, err := admin.ExportChain("", nil, nil)
+		require.ErrorIs(t, err, errExportPathMissing)
+
+		from := argUint64(2)
+
+		res, err := admin.ExportChain("backups/backup", &from, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &exportChainResult{Path: "backups/backup", From: 2, To: 10}, res)
+
+		// the backup can be written only within the export directory
+		_, err = admin.ExportChain("/tmp/backup", &from, nil)
+		require.ErrorIs(t, err, errExportPathAbsolute)
+
+		for _, path := range []string{"..", "../backup", "backups/../../backup", "backups/.."} {
+			_, err = admin.ExportChain(path, &from, nil)
+			require.ErrorIs(t, err, errExportPathTraversal, path)
+		}
+	})
+}
+
+func TestDispatcher_AdminRestricted(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockAdminStore(),
+		&dispatcherParams{
+			chainID: 1,
+			namespaces: &NamespaceConfig{
+				HTTP: []string{"eth", "admin"},
+			},
+		},
+	)
+
+	req := Request{Method: "admin_peers", Params: []byte(`[]`)}
+
+	_, err := dispatcher.handleReq(req, &caller{transport: serverHTTP})
+	assert.Equal(t, NewMethodNotFoundError(req.Method), err)
+
+	_, err = dispatcher.handleReq(req, &caller{transport: serverWS, authenticated: true})
+	assert.Equal(t, NewMethodNotFoundError(req.Method), err)
+
+	_, err = dispatcher.handleReq(req, &caller{transport: serverHTTP, authenticated: true})
+	assert.Nil(t, err)
+
+	_, err = dispatcher.handleReq(req, &caller{transport: serverIPC})
+	assert.Nil(t, err)
+}
@@ -8,6 +8,12 @@ import (
 type bridgeStore interface {
 	GenerateExitProof(exitID uint64) (types.Proof, error)
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+	GetDepositStatus(stateSyncID uint64) (*types.DepositStatus, error)
+	GetDepositStatusesByTxHash(txHash types.Hash) ([]*types.DepositStatus, error)
+	GetExitStatus(exitID uint64) (*types.ExitStatus, error)
+	GetExitStatusesByTxHash(txHash types.Hash) ([]*types.ExitStatus, error)
+	GetExitsByAddress(address types.Address, from, limit uint64) ([]*types.ExitStatus, error)
+	GetCheckpointStatus() (*types.CheckpointStatus, error)
 }
 
 // Bridge is the bridge jsonrpc endpoint
@@ -24,3 +30,44 @@ func (b *Bridge) GenerateExitProof(exitID argUint64) (interface{}, error) {
 func (b *Bridge) GetStateSyncProof(stateSyncID argUint64) (interface{}, error) {
 	return b.store.GetStateSyncProof(uint64(stateSyncID))
 }
+
+// GetDepositStatus returns the status of the deposit with given state sync id
+func (b *Bridge) GetDepositStatus(stateSyncID argUint64) (interface{}, error) {
+	return b.store.GetDepositStatus(uint64(stateSyncID))
+}
+
+// GetDepositStatusByTxHash returns the statuses of the deposits made by the rootchain transaction with given hash
+func (b *Bridge) GetDepositStatusByTxHash(txHash types.Hash) (interface{}, error) {
+	return b.store.GetDepositStatusesByTxHash(txHash)
+}
+
+// GetExitStatus returns the status of the exit with given exit event id
+func (b *Bridge) GetExitStatus(exitID argUint64) (interface{}, error) {
+	return b.store.GetExitStatus(uint64(exitID))
+}
+
+// GetExitStatusByTxHash returns the statuses of the exits made by the child chain transaction with given hash
+func (b *Bridge) GetExitStatusByTxHash(txHash types.Hash) (interface{}, error) {
+	return b.store.GetExitStatusesByTxHash(txHash)
+}
+
+// GetExitsByAddress returns a page of the statuses of the exits withdrawn by or to the given address,
+// starting from the given exit event id. The page size is capped by the node
+func (b *Bridge) GetExitsByAddress(address types.Address, from *argUint64, limit *argUint64) (interface{}, error) {
+	var fromID, pageSize uint64
+
+	if from != nil {
+		fromID = uint64(*from)
+	}
+
+	if limit != nil {
+		pageSize = uint64(*limit)
+	}
+
+	return b.store.GetExitsByAddress(address, fromID, pageSize)
+}
+
+// GetCheckpointStatus returns the status of checkpoint submissions to the rootchain
+func (b *Bridge) GetCheckpointStatus() (interface{}, error) {
+	return b.store.GetCheckpointStatus()
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
 )
@@ -30,7 +31,7 @@ func TestBridgeEndpoint(t *testing.T) {
 		"id": 1
 	}`)
 
-	data, err := dispatcher.HandleWs(msg, mockConnection)
+	data, err := dispatcher.HandleWs(msg, mockConnection, nil)
 	require.NoError(t, err)
 
 	resp := new(SuccessResponse)
@@ -44,7 +45,7 @@ func TestBridgeEndpoint(t *testing.T) {
 		"id": 1
 	}`)
 
-	data, err = dispatcher.HandleWs(msg, mockConnection)
+	data, err = dispatcher.HandleWs(msg, mockConnection, nil)
 	require.NoError(t, err)
 
 	resp = new(SuccessResponse)
@@ -52,3 +53,115 @@ func TestBridgeEndpoint(t *testing.T) {
 	require.Nil(t, resp.Error)
 	require.NotNil(t, resp.Result)
 }
+
+func TestBridgeEndpoint_TransferStatus(t *testing.T) {
+	store := newMockStore()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	call := func(method, param string, result interface{}) {
+		t.Helper()
+
+		msg := []byte(`{"method": "` + method + `", "params": ["` + param + `"], "id": 1}`)
+
+		data, err := dispatcher.HandleWs(msg, mockConnection, nil)
+		require.NoError(t, err)
+
+		resp := new(SuccessResponse)
+		require.NoError(t, json.Unmarshal(data, resp))
+		require.Nil(t, resp.Error)
+		require.NoError(t, json.Unmarshal(resp.Result, result))
+	}
+
+	txHash := types.StringToHash("0x1").String()
+
+	var depositStatus types.DepositStatus
+
+	call("bridge_getDepositStatus", "0x5", &depositStatus)
+	require.Equal(t, uint64(5), depositStatus.StateSyncID)
+	require.Equal(t, types.BridgeTransferExecuted, depositStatus.Status)
+
+	var depositStatuses []*types.DepositStatus
+
+	call("bridge_getDepositStatusByTxHash", txHash, &depositStatuses)
+	require.Len(t, depositStatuses, 1)
+	require.Equal(t, types.BridgeTransferPending, depositStatuses[0].Status)
+
+	var exitStatus types.ExitStatus
+
+	call("bridge_getExitStatus", "0x7", &exitStatus)
+	require.Equal(t, uint64(7), exitStatus.ExitID)
+	require.Equal(t, types.BridgeTransferCheckpointed, exitStatus.Status)
+
+	var exitStatuses []*types.ExitStatus
+
+	call("bridge_getExitStatusByTxHash", txHash, &exitStatuses)
+	require.Len(t, exitStatuses, 1)
+	require.Equal(t, types.BridgeTransferExited, exitStatuses[0].Status)
+
+	address := types.StringToAddress("0x1")
+	exitStatuses = nil
+
+	call("bridge_getExitsByAddress", address.String(), &exitStatuses)
+	require.Len(t, exitStatuses, 2)
+	require.Equal(t, address, *exitStatuses[1].Receiver)
+
+	exitStatuses = nil
+
+	// the page starts from the given exit id and is limited to the given size
+	msg := []byte(`{"method": "bridge_getExitsByAddress", "params": ["` + address.String() + `", "0x2", "0x1"], "id": 1}`)
+
+	data, err := dispatcher.HandleWs(msg, mockConnection, nil)
+	require.NoError(t, err)
+
+	resp := new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+	require.NoError(t, json.Unmarshal(resp.Result, &exitStatuses))
+	require.Len(t, exitStatuses, 1)
+	require.Equal(t, uint64(2), exitStatuses[0].ExitID)
+}
+
+func TestBridgeEndpoint_GetCheckpointStatus(t *testing.T) {
+	store := newMockStore()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	msg := []byte(`{"method": "bridge_getCheckpointStatus", "params": [], "id": 1}`)
+
+	data, err := dispatcher.HandleWs(msg, mockConnection, nil)
+	require.NoError(t, err)
+
+	resp := new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var status types.CheckpointStatus
+
+	require.NoError(t, json.Unmarshal(resp.Result, &status))
+	require.Equal(t, uint64(100), status.ChildChainHead)
+	require.Equal(t, uint64(90), status.LastCheckpointedBlock)
+	require.Equal(t, uint64(10), status.Lag)
+}
@@ -0,0 +1,65 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// devAccounts holds the unlocked accounts of the local development chain,
+// which sign the transactions submitted through eth_sendTransaction
+type devAccounts struct {
+	lock sync.Mutex
+	keys map[types.Address]*ecdsa.PrivateKey
+}
+
+// newDevAccounts indexes the provided development account keys by their addresses
+func newDevAccounts(keys []*ecdsa.PrivateKey) *devAccounts {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	accounts := &devAccounts{
+		keys: make(map[types.Address]*ecdsa.PrivateKey, len(keys)),
+	}
+
+	for _, key := range keys {
+		accounts.keys[crypto.PubKeyToAddress(&key.PublicKey)] = key
+	}
+
+	return accounts
+}
+
+// addresses returns sorted addresses of the development accounts
+func (d *devAccounts) addresses() []types.Address {
+	if d == nil {
+		return []types.Address{}
+	}
+
+	addresses := make([]types.Address, 0, len(d.keys))
+
+	for addr := range d.keys {
+		addresses = append(addresses, addr)
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	return addresses
+}
+
+// key returns the private key of the development account with given address
+func (d *devAccounts) key(addr types.Address) (*ecdsa.PrivateKey, bool) {
+	if d == nil {
+		return nil, false
+	}
+
+	key, ok := d.keys[addr]
+
+	return key, ok
+}
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,12 +34,14 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth    *Eth
-	Web3   *Web3
-	Net    *Net
-	TxPool *TxPool
-	Bridge *Bridge
-	Debug  *Debug
+	Eth     *Eth
+	Web3    *Web3
+	Net     *Net
+	TxPool  *TxPool
+	Bridge  *Bridge
+	Polybft *Polybft
+	Debug   *Debug
+	Admin   *Admin
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -48,6 +51,8 @@ type Dispatcher struct {
 	serviceMap    map[string]*serviceData
 	filterManager *FilterManager
 	endpoints     endpoints
+	rateLimiter   *RateLimiter
+	namespaces    *namespaceFilter
 
 	params *dispatcherParams
 }
@@ -61,6 +66,11 @@ type dispatcherParams struct {
 	blockRangeLimit         uint64
 
 	concurrentRequestsDebug uint64
+
+	rateLimit  *RateLimitConfig
+	namespaces *NamespaceConfig
+
+	devAccounts []*ecdsa.PrivateKey
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
@@ -72,9 +82,16 @@ func newDispatcher(
 	store JSONRPCStore,
 	params *dispatcherParams,
 ) (*Dispatcher, error) {
+	namespaces, err := newNamespaceFilter(params.namespaces)
+	if err != nil {
+		return nil, err
+	}
+
 	d := &Dispatcher{
-		logger: logger.Named("dispatcher"),
-		params: params,
+		logger:      logger.Named("dispatcher"),
+		params:      params,
+		rateLimiter: NewRateLimiter(params.rateLimit),
+		namespaces:  namespaces,
 	}
 
 	if store != nil {
@@ -96,6 +113,7 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		d.params.chainID,
 		d.filterManager,
 		d.params.priceLimit,
+		newDevAccounts(d.params.devAccounts),
 	}
 	d.endpoints.Net = &Net{
 		store,
@@ -111,34 +129,46 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 	d.endpoints.Bridge = &Bridge{
 		store,
 	}
+	d.endpoints.Polybft = &Polybft{
+		store,
+	}
 	d.endpoints.Debug = NewDebug(store, d.params.concurrentRequestsDebug)
-
-	var err error
-
-	if err = d.registerService("eth", d.endpoints.Eth); err != nil {
-		return err
+	d.endpoints.Admin = &Admin{
+		store,
+		d.params.chainID,
+		d.params.chainName,
 	}
 
-	if err = d.registerService("net", d.endpoints.Net); err != nil {
-		return err
+	services := []struct {
+		name    string
+		service interface{}
+	}{
+		{"eth", d.endpoints.Eth},
+		{"net", d.endpoints.Net},
+		{"web3", d.endpoints.Web3},
+		{"txpool", d.endpoints.TxPool},
+		{"bridge", d.endpoints.Bridge},
+		{"polybft", d.endpoints.Polybft},
+		{"debug", d.endpoints.Debug},
+		{"admin", d.endpoints.Admin},
 	}
 
-	if err = d.registerService("web3", d.endpoints.Web3); err != nil {
-		return err
-	}
+	for _, s := range services {
+		if !d.namespaces.isRegistered(s.name) {
+			d.logger.Info("namespace disabled", "namespace", s.name)
 
-	if err = d.registerService("txpool", d.endpoints.TxPool); err != nil {
-		return err
-	}
+			continue
+		}
 
-	if err = d.registerService("bridge", d.endpoints.Bridge); err != nil {
-		return err
+		if err := d.registerService(s.name, s.service); err != nil {
+			return err
+		}
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	return nil
 }
 
-func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
+func (d *Dispatcher) getFnHandler(req Request, c *caller) (*serviceData, *funcData, Error) {
 	callName := strings.SplitN(req.Method, "_", 2)
 	if len(callName) != 2 {
 		return nil, nil, NewMethodNotFoundError(req.Method)
@@ -146,6 +176,10 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 
 	serviceName, funcName := callName[0], callName[1]
 
+	if !d.namespaces.isServed(req.Method, c) {
+		return nil, nil, NewMethodNotFoundError(req.Method)
+	}
+
 	service, ok := d.serviceMap[serviceName]
 	if !ok {
 		return nil, nil, NewMethodNotFoundError(req.Method)
@@ -240,7 +274,20 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+// allowRequest checks whether the caller is allowed to execute the method
+// with respect to its rate limit
+func (d *Dispatcher) allowRequest(method string, c *caller) Error {
+	if d.rateLimiter == nil || d.rateLimiter.Allow(c, method) {
+		return nil
+	}
+
+	// measure requests rejected by the rate limiter
+	metrics.IncrCounter([]string{jsonRPCMetric, "rate_limited"}, 1)
+
+	return NewRateLimitExceededError(method)
+}
+
+func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn, c *caller) ([]byte, error) {
 	const (
 		openSquareBracket  byte = '['
 		closeSquareBracket byte = ']'
@@ -272,7 +319,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		responses := make([][]byte, len(batchReq))
 
 		for i, req := range batchReq {
-			responses[i], err = d.handleSingleWs(req, conn).Bytes()
+			responses[i], err = d.handleSingleWs(req, conn, c).Bytes()
 			if err != nil {
 				return nil, err
 			}
@@ -294,17 +341,28 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
-	return d.handleSingleWs(req, conn).Bytes()
+	return d.handleSingleWs(req, conn, c).Bytes()
 }
 
-func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
+func (d *Dispatcher) handleSingleWs(req Request, conn wsConn, c *caller) Response {
 	id, err := formatID(req.ID)
 	if err != nil {
 		return NewRPCResponse(nil, "2.0", nil, err)
 	}
 
+	if err = d.allowRequest(req.Method, c); err != nil {
+		return NewRPCResponse(id, "2.0", nil, err)
+	}
+
 	var response []byte
 
+	// subscriptions don't go through the registered endpoints,
+	// so they are checked against the namespace configuration here
+	if (req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe") &&
+		!d.namespaces.isServed(req.Method, c) {
+		return NewRPCResponse(id, "2.0", nil, NewMethodNotFoundError(req.Method))
+	}
+
 	switch req.Method {
 	case "eth_subscribe":
 		var filterID string
@@ -321,13 +379,13 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 		}
 	default:
 		// its a normal query that we handle with the dispatcher
-		response, err = d.handleReq(req)
+		response, err = d.handleReq(req, c)
 	}
 
 	return NewRPCResponse(id, "2.0", response, err)
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+func (d *Dispatcher) Handle(reqBody []byte, c *caller) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -343,7 +401,11 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		if err := d.allowRequest(req.Method, c); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		resp, err := d.handleReq(req, c)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -372,7 +434,13 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		if err := d.allowRequest(req.Method, c); err != nil {
+			responses = append(responses, NewRPCResponse(req.ID, "2.0", nil, err))
+
+			continue
+		}
+
+		var response, err = d.handleReq(req, c)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", response, err)
 			responses = append(responses, errorResponse)
@@ -392,10 +460,14 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
-func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
+func (d *Dispatcher) handleReq(req Request, c *caller) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
-	service, fd, ferr := d.getFnHandler(req)
+	if req.Method == rpcModulesMethod {
+		return d.handleModules(c)
+	}
+
+	service, fd, ferr := d.getFnHandler(req, c)
 	if ferr != nil {
 		return nil, ferr
 	}
@@ -456,6 +528,28 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	return data, nil
 }
 
+// handleModules returns the namespaces served to the caller (rpc_modules)
+func (d *Dispatcher) handleModules(c *caller) ([]byte, Error) {
+	modules := map[string]string{
+		rpcNamespace: namespaceVersion,
+	}
+
+	for serviceName := range d.serviceMap {
+		if d.namespaces.isEnabled(serviceName, c) {
+			modules[serviceName] = namespaceVersion
+		}
+	}
+
+	data, err := json.Marshal(modules)
+	if err != nil {
+		d.logInternalError(rpcModulesMethod, err)
+
+		return nil, NewInternalError("Internal error")
+	}
+
+	return data, nil
+}
+
 func (d *Dispatcher) logInternalError(method string, err error) {
 	d.logger.Warn("failed to dispatch", "method", method, "err", err)
 }
@@ -485,6 +579,13 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) er
 
 		name := lowerCaseFirst(mv.Name)
 		funcName := serviceName + "_" + name
+
+		if d.namespaces.isDenied(funcName) {
+			d.logger.Info("method disabled", "method", funcName)
+
+			continue
+		}
+
 		fd := &funcData{
 			fv: mv.Func,
 		}
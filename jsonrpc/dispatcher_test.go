@@ -82,7 +82,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		_, err := dispatcher.HandleWs(req, mockConnection)
+		_, err := dispatcher.HandleWs(req, mockConnection, nil)
 		require.NoError(t, err)
 
 		store.emitEvent(&mockEvent{
@@ -111,7 +111,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newPendingTransactions"]
 	}`)
-		_, err := dispatcher.HandleWs(req, mockConnection)
+		_, err := dispatcher.HandleWs(req, mockConnection, nil)
 		require.NoError(t, err)
 
 		store.emitTxPoolEvent(proto.EventType_ADDED, "evt1")
@@ -186,7 +186,7 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(c.msg, mockConnection, nil)
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -258,7 +258,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 		_, err := dispatcher.handleReq(Request{
 			Method: "mock_" + typ,
 			Params: []byte(msg),
-		})
+		}, nil)
 		assert.NoError(t, err)
 
 		return <-srv.msgCh
@@ -496,11 +496,11 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			t.Parallel()
 
-			res, _ := c.dispatcher.HandleWs(c.reqBody, mock)
+			res, _ := c.dispatcher.HandleWs(c.reqBody, mock, nil)
 
 			check(c, res)
 
-			res, _ = c.dispatcher.Handle(c.reqBody)
+			res, _ = c.dispatcher.Handle(c.reqBody, nil)
 
 			check(c, res)
 		})
@@ -538,19 +538,19 @@ func TestDispatcher_WebsocketConnection_Unsubscribe(t *testing.T) {
 	}
 
 	// non existing subscription
-	r, err := dispatcher.HandleWs(reqUnsub("\"787832\""), mockConn)
+	r, err := dispatcher.HandleWs(reqUnsub("\"787832\""), mockConn, nil)
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
 	assert.Equal(t, "false", string(resp.Result))
 
-	r, err = dispatcher.HandleWs([]byte(`{"method": "eth_subscribe", "params": ["newHeads"]}`), mockConn)
+	r, err = dispatcher.HandleWs([]byte(`{"method": "eth_subscribe", "params": ["newHeads"]}`), mockConn, nil)
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
 
 	// existing subscription
-	r, err = dispatcher.HandleWs(reqUnsub(string(resp.Result)), mockConn)
+	r, err = dispatcher.HandleWs(reqUnsub(string(resp.Result)), mockConn, nil)
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
@@ -1,18 +1,21 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -328,6 +331,44 @@ func TestEth_GasPrice_WithLondonFork(t *testing.T) {
 	})
 }
 
+func TestEth_FeeTiers(t *testing.T) {
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	_, err := eth.FeeTiers()
+	assert.Error(t, err)
+
+	tier := func(tip, feeCap, blocks uint64) *gasprice.FeeTier {
+		return &gasprice.FeeTier{
+			MaxPriorityFeePerGas: new(big.Int).SetUint64(tip),
+			MaxFeePerGas:         new(big.Int).SetUint64(feeCap),
+			Blocks:               blocks,
+		}
+	}
+
+	store.feeTiers = &gasprice.FeeTiers{
+		BaseFee:      1000,
+		GasUsedRatio: 0.75,
+		PendingGas:   21000,
+		Slow:         tier(1, 1500, 8),
+		Standard:     tier(2, 1200, 3),
+		Fast:         tier(5, 1005, 1),
+	}
+
+	res, err := eth.FeeTiers()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	expected := `{"baseFee":"0x3e8","gasUsedRatio":0.75,"pendingGas":"0x5208",` +
+		`"slow":{"maxPriorityFeePerGas":"0x1","maxFeePerGas":"0x5dc","blocks":"0x8"},` +
+		`"standard":{"maxPriorityFeePerGas":"0x2","maxFeePerGas":"0x4b0","blocks":"0x3"},` +
+		`"fast":{"maxPriorityFeePerGas":"0x5","maxFeePerGas":"0x3ed","blocks":"0x1"}}`
+
+	assert.JSONEq(t, expected, string(data))
+}
+
 func TestEth_GasPrice_WithoutLondonFork(t *testing.T) {
 	const priceLimit = 100000
 
@@ -458,6 +499,7 @@ type mockBlockStore struct {
 	baseFee         uint64
 
 	maxPriorityFeePerGasFn func() (*big.Int, error)
+	feeTiers               *gasprice.FeeTiers
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -667,6 +709,14 @@ func (m *mockBlockStore) MaxPriorityFeePerGas() (*big.Int, error) {
 	return big.NewInt(0), nil
 }
 
+func (m *mockBlockStore) FeeTiers() (*gasprice.FeeTiers, error) {
+	if m.feeTiers == nil {
+		return nil, errors.New("no fee tiers")
+	}
+
+	return m.feeTiers, nil
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
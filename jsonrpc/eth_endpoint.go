@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
@@ -98,10 +99,14 @@ type Eth struct {
 	chainID       uint64
 	filterManager *FilterManager
 	priceLimit    uint64
+	devAccounts   *devAccounts
 }
 
 var (
 	ErrInsufficientFunds = errors.New("insufficient funds for execution")
+
+	errSendTransactionNotSupported = errors.New("request calls to eth_sendTransaction method are not supported," +
+		" use eth_sendRawTransaction instead")
 )
 
 // ChainId returns the chain id of the client
@@ -216,10 +221,80 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 	return tx.Hash.String(), nil
 }
 
-// SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
-func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
-	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
-		" use eth_sendRawTransaction instead")
+// Accounts returns the addresses of the unlocked development accounts (if any)
+func (e *Eth) Accounts() (interface{}, error) {
+	return e.devAccounts.addresses(), nil
+}
+
+// SendTransaction signs the transaction with the unlocked development account and sends it to the tx pool.
+// Since we don't support wallet management, the call is rejected unless the sender is a development account.
+func (e *Eth) SendTransaction(arg *txnArgs) (interface{}, error) {
+	if arg == nil || arg.From == nil {
+		return nil, errSendTransactionNotSupported
+	}
+
+	key, ok := e.devAccounts.key(*arg.From)
+	if !ok {
+		return nil, errSendTransactionNotSupported
+	}
+
+	if arg.Gas == nil {
+		// EstimateGas decodes (and so modifies) the provided arguments, hence use a copy
+		argCopy := *arg
+
+		gas, err := e.EstimateGas(&argCopy, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+
+		gasLimit, ok := gas.(argUint64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected gas estimation result: %v", gas)
+		}
+
+		arg.Gas = &gasLimit
+	}
+
+	// nonce is resolved and the transaction is added to the pool atomically,
+	// so that the concurrent calls don't end up with the same nonce
+	// (pending nonce of the tx pool accounts for the just added transactions)
+	e.devAccounts.lock.Lock()
+	defer e.devAccounts.lock.Unlock()
+
+	if arg.Nonce == nil {
+		arg.Nonce = argUintPtr(e.store.GetNonce(*arg.From))
+	}
+
+	header := e.store.Header()
+
+	tx, err := DecodeTxn(arg, header.Number, e.store, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// dynamic fee transaction may specify gas price only
+	if tx.IsDynamicFee() && tx.GasFeeCap.BitLen() == 0 && tx.GasPrice.BitLen() > 0 {
+		tx.GasFeeCap = new(big.Int).Set(tx.GasPrice)
+		tx.GasTipCap = new(big.Int).Set(tx.GasPrice)
+	}
+
+	if err = e.fillTransactionGasPrice(tx); err != nil {
+		return nil, err
+	}
+
+	signer := crypto.NewSigner(e.store.GetForksInTime(header.Number), e.chainID)
+
+	tx, err = signer.SignTx(tx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	// tx hash will be calculated inside e.store.AddTx
+	if err := e.store.AddTx(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
 }
 
 // GetTransactionByHash returns a transaction by its hash.
@@ -371,6 +446,12 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		Logs:              logs,
 	}
 
+	// the gas of the sponsored transaction is paid by the sponsor
+	if txn.Type == types.SponsoredTx {
+		sponsor := txn.Sponsor
+		res.Sponsor = &sponsor
+	}
+
 	return res, nil
 }
 
@@ -438,7 +519,7 @@ func (e *Eth) fillTransactionGasPrice(tx *types.Transaction) error {
 		return err
 	}
 
-	if tx.Type == types.DynamicFeeTx {
+	if tx.IsDynamicFee() {
 		tx.GasFeeCap = new(big.Int).SetUint64(estimatedGasPrice)
 	} else {
 		tx.GasPrice = new(big.Int).SetUint64(estimatedGasPrice)
@@ -863,6 +944,24 @@ func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
 	return argBigPtr(priorityFee), nil
 }
 
+// FeeTiers returns the slow, standard and fast fee suggestions, based on the fee history,
+// the projected base fee and the pending transactions
+func (e *Eth) FeeTiers() (interface{}, error) {
+	tiers, err := e.store.FeeTiers()
+	if err != nil {
+		return nil, err
+	}
+
+	return &feeTiersResult{
+		BaseFee:      argUint64(tiers.BaseFee),
+		GasUsedRatio: tiers.GasUsedRatio,
+		PendingGas:   argUint64(tiers.PendingGas),
+		Slow:         toFeeTierResult(tiers.Slow),
+		Standard:     toFeeTierResult(tiers.Standard),
+		Fast:         toFeeTierResult(tiers.Fast),
+	}, nil
+}
+
 func (e *Eth) FeeHistory(blockCount argUint64, newestBlock BlockNumber,
 	rewardPercentiles []float64) (interface{}, error) {
 	block, err := GetNumericBlockNumber(newestBlock, e.store)
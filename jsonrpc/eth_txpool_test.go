@@ -1,11 +1,15 @@
 package jsonrpc
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
@@ -46,6 +50,56 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
 
+func TestEth_TxnPool_SendTransaction_DevAccount(t *testing.T) {
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	devAddr := crypto.PubKeyToAddress(&key.PublicKey)
+
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	args := &txnArgs{
+		From:     &devAddr,
+		To:       argAddrPtr(addr0),
+		Gas:      argUintPtr(21000),
+		GasPrice: argBytesPtr(big.NewInt(10).Bytes()),
+		Value:    argBytesPtr(big.NewInt(1).Bytes()),
+	}
+
+	// no development accounts are configured
+	_, err = eth.SendTransaction(args)
+	require.ErrorIs(t, err, errSendTransactionNotSupported)
+
+	accounts, err := eth.Accounts()
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	eth.devAccounts = newDevAccounts([]*ecdsa.PrivateKey{key})
+
+	accounts, err = eth.Accounts()
+	require.NoError(t, err)
+	require.Equal(t, []types.Address{devAddr}, accounts)
+
+	// unknown sender
+	_, err = eth.SendTransaction(&txnArgs{From: &addr0, To: argAddrPtr(addr0)})
+	require.ErrorIs(t, err, errSendTransactionNotSupported)
+
+	hash, err := eth.SendTransaction(args)
+	require.NoError(t, err)
+	require.Equal(t, store.txn.Hash.String(), hash)
+
+	// transaction is signed by the development account
+	signer := crypto.NewSigner(chain.AllForksEnabled.At(0), 100)
+	sender, err := signer.Sender(store.txn)
+	require.NoError(t, err)
+	require.Equal(t, devAddr, sender)
+	require.Equal(t, uint64(21000), store.txn.Gas)
+
+	// nonce is the pending one reported by the tx pool
+	require.Equal(t, uint64(1), store.txn.Nonce)
+}
+
 type mockStoreTxn struct {
 	ethStore
 	accounts map[types.Address]*mockAccount
@@ -91,3 +145,11 @@ func (m *mockStoreTxn) GetAccount(root types.Hash, addr types.Address) (*Account
 
 	return acct.account, nil
 }
+
+func (m *mockStoreTxn) GetForksInTime(blockNumber uint64) chain.ForksInTime {
+	return chain.AllForksEnabled.At(blockNumber)
+}
+
+func (m *mockStoreTxn) GetBaseFee() uint64 {
+	return 0
+}
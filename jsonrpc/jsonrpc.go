@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"crypto/ecdsa"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -44,8 +46,8 @@ type JSONRPC struct {
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(reqBody []byte, conn wsConn, c *caller) ([]byte, error)
+	Handle(reqBody []byte, c *caller) ([]byte, error)
 }
 
 // JSONRPCStore defines all the methods required
@@ -56,7 +58,9 @@ type JSONRPCStore interface {
 	txPoolStore
 	filterManagerStore
 	bridgeStore
+	polybftStore
 	debugStore
+	adminStore
 }
 
 type Config struct {
@@ -71,10 +75,23 @@ type Config struct {
 
 	ConcurrentRequestsDebug uint64
 	WebSocketReadLimit      uint64
+
+	RateLimit  *RateLimitConfig
+	Namespaces *NamespaceConfig
+
+	// AdminAPIKeys are the API keys which authenticate the callers of the admin namespace
+	AdminAPIKeys []string
+
+	// DevAccounts are the unlocked accounts exposed by eth_accounts,
+	// which sign the eth_sendTransaction calls (meant only for local development chains)
+	DevAccounts []*ecdsa.PrivateKey
 }
 
 // NewJSONRPC returns the JSONRPC http server
 func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
+	// the dispatcher and the filter manager log under the jsonrpc subsystem
+	logger = logger.Named("jsonrpc")
+
 	d, err := newDispatcher(
 		logger,
 		config.Store,
@@ -85,6 +102,9 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
 			concurrentRequestsDebug: config.ConcurrentRequestsDebug,
+			rateLimit:               config.RateLimit,
+			namespaces:              config.Namespaces,
+			devAccounts:             config.DevAccounts,
 		},
 	)
 
@@ -93,7 +113,7 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 	}
 
 	srv := &JSONRPC{
-		logger:     logger.Named("jsonrpc"),
+		logger:     logger,
 		config:     config,
 		dispatcher: d,
 	}
@@ -239,6 +259,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	}(ws)
 
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	wsCaller := j.newCaller(serverWS, req)
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -265,7 +286,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, wsCaller)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -286,7 +307,7 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
 	w.Header().Set(
 		"Access-Control-Allow-Headers",
-		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
+		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+DefaultAPIKeyHeader,
 	)
 
 	switch req.Method {
@@ -312,7 +333,7 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.Handle(data, j.newCaller(serverHTTP, req))
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
 	} else {
@@ -322,6 +343,37 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	j.logger.Debug("handle", "response", string(resp))
 }
 
+// newCaller extracts the client IP address and API key from the HTTP request
+func (j *JSONRPC) newCaller(transport serverType, req *http.Request) *caller {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		ip = req.RemoteAddr
+	}
+
+	apiKeyHeader := DefaultAPIKeyHeader
+	if j.config.RateLimit != nil && j.config.RateLimit.APIKeyHeader != "" {
+		apiKeyHeader = j.config.RateLimit.APIKeyHeader
+	}
+
+	c := &caller{
+		transport: transport,
+		ip:        ip,
+		apiKey:    req.Header.Get(apiKeyHeader),
+	}
+
+	if c.apiKey != "" {
+		for _, adminKey := range j.config.AdminAPIKeys {
+			if subtle.ConstantTimeCompare([]byte(c.apiKey), []byte(adminKey)) == 1 {
+				c.authenticated = true
+
+				break
+			}
+		}
+	}
+
+	return c
+}
+
 type GetResponse struct {
 	Name    string `json:"name"`
 	ChainID uint64 `json:"chain_id"`
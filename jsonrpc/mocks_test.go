@@ -192,6 +192,14 @@ func (m *mockStore) GetCapacity() (uint64, uint64) {
 	return 0, 0
 }
 
+func (m *mockStore) DropTransaction(txHash types.Hash) ([]*types.Transaction, error) {
+	return nil, nil
+}
+
+func (m *mockStore) DropAccount(addr types.Address) ([]*types.Transaction, error) {
+	return nil, nil
+}
+
 func (m *mockStore) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	hash := types.BytesToHash([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
 
@@ -203,6 +211,62 @@ func (m *mockStore) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	}, nil
 }
 
+func (m *mockStore) GetDepositStatus(stateSyncID uint64) (*types.DepositStatus, error) {
+	return &types.DepositStatus{StateSyncID: stateSyncID, Status: types.BridgeTransferExecuted}, nil
+}
+
+func (m *mockStore) GetDepositStatusesByTxHash(txHash types.Hash) ([]*types.DepositStatus, error) {
+	return []*types.DepositStatus{{StateSyncID: 1, Status: types.BridgeTransferPending}}, nil
+}
+
+func (m *mockStore) GetExitStatus(exitID uint64) (*types.ExitStatus, error) {
+	return &types.ExitStatus{ExitID: exitID, Status: types.BridgeTransferCheckpointed}, nil
+}
+
+func (m *mockStore) GetExitStatusesByTxHash(txHash types.Hash) ([]*types.ExitStatus, error) {
+	return []*types.ExitStatus{{ExitID: 1, Status: types.BridgeTransferExited}}, nil
+}
+
+func (m *mockStore) GetExitsByAddress(address types.Address, from, limit uint64) ([]*types.ExitStatus, error) {
+	statuses := []*types.ExitStatus{
+		{ExitID: 1, Status: types.BridgeTransferPending, Receiver: &address},
+		{ExitID: 2, Status: types.BridgeTransferCheckpointed, Receiver: &address},
+	}
+
+	var page []*types.ExitStatus
+
+	for _, status := range statuses {
+		if status.ExitID >= from && (limit == 0 || uint64(len(page)) < limit) {
+			page = append(page, status)
+		}
+	}
+
+	return page, nil
+}
+
+func (m *mockStore) GetCheckpointStatus() (*types.CheckpointStatus, error) {
+	return &types.CheckpointStatus{ChildChainHead: 100, LastCheckpointedBlock: 90, Lag: 10}, nil
+}
+
+func (m *mockStore) GetValidatorUptime(epoch *uint64) (*types.EpochUptime, error) {
+	epochNumber := uint64(3)
+	if epoch != nil {
+		epochNumber = *epoch
+	}
+
+	return &types.EpochUptime{
+		Epoch:       epochNumber,
+		TotalBlocks: 10,
+		Validators: []*types.ValidatorUptime{
+			{Address: types.StringToAddress("0x1"), SignedBlocks: 9, MissedBlocks: 1, Uptime: 90},
+		},
+	}, nil
+}
+
+func (m *mockStore) GetEpochProof(epoch uint64) (*types.EpochProof, error) {
+	return &types.EpochProof{Epoch: epoch, EpochEndingBlock: epoch * 10, Header: "0x01"}, nil
+}
+
 func (m *mockStore) GetPeers() int {
 	return 20
 }
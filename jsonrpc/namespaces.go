@@ -0,0 +1,186 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// rpcNamespace is always enabled, as it only describes the node's JSON-RPC modules
+	rpcNamespace = "rpc"
+
+	// rpcModulesMethod returns the namespaces enabled on the transport of the caller
+	rpcModulesMethod = "rpc_modules"
+
+	// namespaceVersion is the version reported by rpc_modules for each namespace
+	namespaceVersion = "1.0"
+
+	// adminNamespace exposes the node operations, so it is disabled by default
+	adminNamespace = "admin"
+)
+
+var (
+	// DefaultNamespaces are the namespaces enabled on every transport, unless configured otherwise
+	DefaultNamespaces = []string{"eth", "net", "web3", "txpool", "bridge", "polybft", "debug"}
+
+	// restrictedNamespaces are served only to the IPC, in-process or authenticated callers,
+	// even when enabled on a transport
+	restrictedNamespaces = map[string]struct{}{
+		adminNamespace: {},
+	}
+
+	// restrictedMethods are the node operations exposed in the public namespaces,
+	// which are served only to the IPC, in-process or authenticated callers
+	restrictedMethods = map[string]struct{}{
+		"txpool_dropTransaction": {},
+		"txpool_dropAccount":     {},
+	}
+)
+
+// NamespaceConfig defines the namespaces enabled per transport and the denied methods
+type NamespaceConfig struct {
+	// HTTP are the namespaces enabled on the HTTP transport
+	HTTP []string
+	// WS are the namespaces enabled on the WebSocket transport
+	WS []string
+	// DeniedMethods are the methods (e.g. debug_traceBlock) which are never registered nor served
+	DeniedMethods []string
+}
+
+// namespaceFilter resolves which namespaces and methods are served to the callers
+type namespaceFilter struct {
+	transports    map[serverType]map[string]struct{}
+	deniedMethods map[string]struct{}
+}
+
+// newNamespaceFilter validates the namespace configuration and builds the filter out of it.
+// Nil configuration (or nil transport list) enables the default namespaces
+func newNamespaceFilter(config *NamespaceConfig) (*namespaceFilter, error) {
+	if config == nil {
+		config = &NamespaceConfig{}
+	}
+
+	f := &namespaceFilter{
+		transports:    make(map[serverType]map[string]struct{}, 2),
+		deniedMethods: make(map[string]struct{}, len(config.DeniedMethods)),
+	}
+
+	for transport, namespaces := range map[serverType][]string{
+		serverHTTP: config.HTTP,
+		serverWS:   config.WS,
+	} {
+		if namespaces == nil {
+			namespaces = DefaultNamespaces
+		}
+
+		enabled := make(map[string]struct{}, len(namespaces))
+
+		for _, namespace := range namespaces {
+			namespace = strings.TrimSpace(namespace)
+			if namespace == "" {
+				continue
+			}
+
+			if !isKnownNamespace(namespace) {
+				return nil, fmt.Errorf("jsonrpc: unknown namespace '%s' enabled for %s transport", namespace, transport)
+			}
+
+			enabled[namespace] = struct{}{}
+		}
+
+		f.transports[transport] = enabled
+	}
+
+	for _, method := range config.DeniedMethods {
+		if len(strings.SplitN(method, "_", 2)) != 2 {
+			return nil, fmt.Errorf("jsonrpc: invalid denied method '%s', expected <namespace>_<method>", method)
+		}
+
+		f.deniedMethods[method] = struct{}{}
+	}
+
+	return f, nil
+}
+
+// isRegistered returns true if the namespace is enabled on at least one transport
+func (f *namespaceFilter) isRegistered(namespace string) bool {
+	if namespace == rpcNamespace {
+		return true
+	}
+
+	for _, enabled := range f.transports {
+		if _, ok := enabled[namespace]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDenied returns true if the method must not be registered nor served
+func (f *namespaceFilter) isDenied(method string) bool {
+	_, ok := f.deniedMethods[method]
+
+	return ok
+}
+
+// isServed returns true if the method is served to the caller, whichever transport
+// and dispatch path (regular call or subscription) the request goes through
+func (f *namespaceFilter) isServed(method string, c *caller) bool {
+	callName := strings.SplitN(method, "_", 2)
+	if len(callName) != 2 {
+		return false
+	}
+
+	return !f.isDenied(method) && f.isEnabled(callName[0], c) && f.isMethodAllowed(method, c)
+}
+
+// isEnabled returns true if the namespace is served to the caller.
+// Callers without a transport restriction (in-process and IPC) are served every registered namespace
+func (f *namespaceFilter) isEnabled(namespace string, c *caller) bool {
+	if namespace == rpcNamespace || c == nil {
+		return true
+	}
+
+	enabled, ok := f.transports[c.transport]
+	if !ok {
+		return true
+	}
+
+	if _, restricted := restrictedNamespaces[namespace]; restricted && !c.authenticated {
+		return false
+	}
+
+	_, ok = enabled[namespace]
+
+	return ok
+}
+
+// isMethodAllowed returns true if the method is served to the caller
+func (f *namespaceFilter) isMethodAllowed(method string, c *caller) bool {
+	if c == nil || c.authenticated {
+		return true
+	}
+
+	if _, ok := f.transports[c.transport]; !ok {
+		return true
+	}
+
+	_, restricted := restrictedMethods[method]
+
+	return !restricted
+}
+
+func isKnownNamespace(namespace string) bool {
+	if _, restricted := restrictedNamespaces[namespace]; restricted {
+		return true
+	}
+
+	for _, known := range DefaultNamespaces {
+		if known == namespace {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,151 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceFilter_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := newNamespaceFilter(&NamespaceConfig{HTTP: []string{"eth", "admin2"}})
+	require.ErrorContains(t, err, "unknown namespace 'admin2'")
+
+	_, err = newNamespaceFilter(&NamespaceConfig{DeniedMethods: []string{"traceBlock"}})
+	require.ErrorContains(t, err, "invalid denied method")
+}
+
+func TestDispatcher_Namespaces(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID: 1,
+			namespaces: &NamespaceConfig{
+				HTTP:          []string{"eth", "web3"},
+				WS:            []string{"eth", "net", "debug"},
+				DeniedMethods: []string{"web3_sha3"},
+			},
+		},
+	)
+
+	httpCaller := &caller{transport: serverHTTP, ip: "127.0.0.1"}
+	wsCaller := &caller{transport: serverWS, ip: "127.0.0.1"}
+
+	// namespaces disabled on every transport are not registered
+	assert.NotContains(t, dispatcher.serviceMap, "txpool")
+	assert.NotContains(t, dispatcher.serviceMap, "bridge")
+
+	// denied methods are not registered
+	assert.NotContains(t, dispatcher.serviceMap["web3"].funcMap, "sha3")
+	assert.Contains(t, dispatcher.serviceMap["web3"].funcMap, "clientVersion")
+
+	cases := []struct {
+		method  string
+		caller  *caller
+		enabled bool
+	}{
+		{"eth_chainId", httpCaller, true},
+		{"eth_chainId", wsCaller, true},
+		{"web3_clientVersion", httpCaller, true},
+		{"web3_clientVersion", wsCaller, false},
+		{"web3_sha3", httpCaller, false},
+		{"net_version", httpCaller, false},
+		{"net_version", wsCaller, true},
+		{"txpool_content", wsCaller, false},
+		{"net_version", nil, true},
+	}
+
+	for _, c := range cases {
+		_, err := dispatcher.handleReq(Request{Method: c.method, Params: []byte(`[]`)}, c.caller)
+		if c.enabled {
+			assert.NotEqual(t, NewMethodNotFoundError(c.method), err, c.method)
+		} else {
+			assert.Equal(t, NewMethodNotFoundError(c.method), err, c.method)
+		}
+	}
+
+	var modules map[string]string
+
+	resp, err := dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"rpc_modules","params":[]}`), httpCaller)
+	require.NoError(t, err)
+	require.NoError(t, expectJSONResult(resp, &modules))
+	assert.Equal(t, map[string]string{"rpc": "1.0", "eth": "1.0", "web3": "1.0"}, modules)
+
+	modules = nil
+	resp, err = dispatcher.HandleWs([]byte(`{"id":1,"jsonrpc":"2.0","method":"rpc_modules","params":[]}`),
+		&mockWsConn{}, wsCaller)
+	require.NoError(t, err)
+	require.NoError(t, expectJSONResult(resp, &modules))
+	assert.Equal(t, map[string]string{"rpc": "1.0", "eth": "1.0", "net": "1.0", "debug": "1.0"}, modules)
+}
+
+func TestDispatcher_DeniedSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:    1,
+			namespaces: &NamespaceConfig{DeniedMethods: []string{"eth_subscribe"}},
+		},
+	)
+
+	mockConn := &mockWsConn{
+		SetFilterIDFn:  func(s string) {},
+		GetFilterIDFn:  func() string { return "" },
+		WriteMessageFn: func(i int, b []byte) error { return nil },
+	}
+
+	var resp ErrorResponse
+
+	// the denied subscription is rejected over websocket, whichever the caller is
+	for _, c := range []*caller{{transport: serverWS, ip: "127.0.0.1"}, nil} {
+		r, err := dispatcher.HandleWs([]byte(`{"id":1,"method":"eth_subscribe","params":["newHeads"]}`), mockConn, c)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(r, &resp))
+		assert.Equal(t, NewMethodNotFoundError("eth_subscribe").Error(), resp.Error.Message)
+	}
+
+	// the other methods of the namespace are still served over websocket
+	r, err := dispatcher.HandleWs([]byte(`{"id":1,"method":"eth_unsubscribe","params":["0x1"]}`), mockConn, nil)
+	require.NoError(t, err)
+
+	var success SuccessResponse
+
+	require.NoError(t, json.Unmarshal(r, &success))
+	assert.Nil(t, success.Error)
+	assert.Equal(t, "false", string(success.Result))
+}
+
+func TestDispatcher_RestrictedMethods(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{chainID: 1},
+	)
+
+	req := Request{Method: "txpool_dropAccount", Params: []byte(`["0x0000000000000000000000000000000000000001"]`)}
+
+	_, err := dispatcher.handleReq(req, &caller{transport: serverHTTP})
+	assert.Equal(t, NewMethodNotFoundError(req.Method), err)
+
+	_, err = dispatcher.handleReq(req, &caller{transport: serverHTTP, authenticated: true})
+	assert.Nil(t, err)
+
+	_, err = dispatcher.handleReq(req, nil)
+	assert.Nil(t, err)
+
+	// the other methods of the namespace are still public
+	_, err = dispatcher.handleReq(Request{Method: "txpool_status", Params: []byte(`[]`)}, &caller{transport: serverHTTP})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,34 @@
+package jsonrpc
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// polybftStore interface provides access to the methods needed by polybft endpoint
+type polybftStore interface {
+	GetValidatorUptime(epoch *uint64) (*types.EpochUptime, error)
+	GetEpochProof(epoch uint64) (*types.EpochProof, error)
+}
+
+// Polybft is the polybft consensus jsonrpc endpoint
+type Polybft struct {
+	store polybftStore
+}
+
+// GetValidatorUptime returns the validators uptime (committed seals) for the given epoch.
+// If the epoch is omitted, the uptime for the current epoch is returned
+func (p *Polybft) GetValidatorUptime(epoch *argUint64) (interface{}, error) {
+	if epoch == nil {
+		return p.store.GetValidatorUptime(nil)
+	}
+
+	epochNumber := uint64(*epoch)
+
+	return p.store.GetValidatorUptime(&epochNumber)
+}
+
+// GetEpochProof returns the epoch ending block header of the given epoch,
+// which light clients need in order to verify the validator set of the next epoch
+func (p *Polybft) GetEpochProof(epoch argUint64) (interface{}, error) {
+	return p.store.GetEpochProof(uint64(epoch))
+}
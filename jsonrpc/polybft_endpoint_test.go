@@ -0,0 +1,88 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolybftEndpoint_GetValidatorUptime(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	call := func(params string) *types.EpochUptime {
+		t.Helper()
+
+		msg := []byte(`{"method": "polybft_getValidatorUptime", "params": [` + params + `], "id": 1}`)
+
+		data, err := dispatcher.HandleWs(msg, mockConnection, nil)
+		require.NoError(t, err)
+
+		resp := new(SuccessResponse)
+		require.NoError(t, json.Unmarshal(data, resp))
+		require.Nil(t, resp.Error)
+
+		var uptime types.EpochUptime
+
+		require.NoError(t, json.Unmarshal(resp.Result, &uptime))
+
+		return &uptime
+	}
+
+	uptime := call(`"0x5"`)
+	require.Equal(t, uint64(5), uptime.Epoch)
+	require.Len(t, uptime.Validators, 1)
+	require.Equal(t, uint64(1), uptime.Validators[0].MissedBlocks)
+
+	// current epoch is used if the epoch is omitted
+	uptime = call(``)
+	require.Equal(t, uint64(3), uptime.Epoch)
+}
+
+func TestPolybftEndpoint_GetEpochProof(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	msg := []byte(`{"method": "polybft_getEpochProof", "params": ["0x4"], "id": 1}`)
+
+	data, err := dispatcher.HandleWs(msg, mockConnection, nil)
+	require.NoError(t, err)
+
+	resp := new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var proof types.EpochProof
+
+	require.NoError(t, json.Unmarshal(resp.Result, &proof))
+	require.Equal(t, uint64(4), proof.Epoch)
+	require.Equal(t, uint64(40), proof.EpochEndingBlock)
+	require.Equal(t, "0x01", proof.Header)
+}
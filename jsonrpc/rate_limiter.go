@@ -0,0 +1,187 @@
+package jsonrpc
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultAPIKeyHeader is the HTTP header from which the API key of the client is read
+	DefaultAPIKeyHeader = "X-Api-Key"
+
+	// rateLimiterCleanupInterval is the interval at which idle client buckets are evicted
+	rateLimiterCleanupInterval = time.Minute
+)
+
+// DefaultMethodWeights holds the cost of the expensive JSON-RPC methods.
+// Methods not listed here cost a single token
+var DefaultMethodWeights = map[string]uint64{
+	"eth_getLogs":              10,
+	"eth_call":                 2,
+	"eth_estimateGas":          2,
+	"eth_feeHistory":           2,
+	"eth_feeTiers":             2,
+	"eth_getFilterLogs":        10,
+	"eth_subscribe":            5,
+	"debug_traceBlock":         20,
+	"debug_traceBlockByNumber": 20,
+	"debug_traceBlockByHash":   20,
+	"debug_traceTransaction":   10,
+	"debug_traceCall":          10,
+	"txpool_content":           5,
+}
+
+// RateLimitRule defines the token bucket of a single client
+type RateLimitRule struct {
+	// RequestsPerSecond is the rate at which the bucket is refilled
+	RequestsPerSecond uint64
+	// Burst is the capacity of the bucket
+	Burst uint64
+}
+
+// RateLimitConfig holds the configuration of the per client rate limiting
+type RateLimitConfig struct {
+	// Default is the rule applied per client IP address. Zero rate disables the rate limiting
+	Default RateLimitRule
+	// APIKeyHeader is the HTTP header carrying the API key of the client
+	APIKeyHeader string
+	// APIKeys maps known API keys to their own rules
+	APIKeys map[string]RateLimitRule
+	// MethodWeights maps a method to the number of tokens it consumes,
+	// overriding the DefaultMethodWeights of the listed methods
+	MethodWeights map[string]uint64
+}
+
+// caller describes the origin of a JSON-RPC request
+type caller struct {
+	transport     serverType
+	ip            string
+	apiKey        string
+	authenticated bool
+}
+
+// clientBucket is the token bucket of a single client
+type clientBucket struct {
+	limiter  *rate.Limiter
+	burst    uint64
+	lastSeen time.Time
+}
+
+// RateLimiter applies token bucket rate limiting per client IP address and per API key,
+// where each method consumes a configurable number of tokens
+type RateLimiter struct {
+	config RateLimitConfig
+
+	lock        sync.Mutex
+	buckets     map[string]*clientBucket
+	lastCleanup time.Time
+}
+
+// NewRateLimiter creates a new rate limiter. It returns nil if rate limiting is disabled
+func NewRateLimiter(config *RateLimitConfig) *RateLimiter {
+	if config == nil || (config.Default.RequestsPerSecond == 0 && len(config.APIKeys) == 0) {
+		return nil
+	}
+
+	// the configured weights are applied on top of the default ones
+	weights := make(map[string]uint64, len(DefaultMethodWeights)+len(config.MethodWeights))
+
+	for method, weight := range DefaultMethodWeights {
+		weights[method] = weight
+	}
+
+	for method, weight := range config.MethodWeights {
+		weights[method] = weight
+	}
+
+	limiterConfig := *config
+	limiterConfig.MethodWeights = weights
+
+	return &RateLimiter{
+		config:      limiterConfig,
+		buckets:     map[string]*clientBucket{},
+		lastCleanup: time.Now(),
+	}
+}
+
+// Allow consumes the tokens of the given method from the bucket of the caller
+// and returns false if the bucket does not hold enough tokens
+func (r *RateLimiter) Allow(c *caller, method string) bool {
+	if c == nil {
+		// requests which do not originate from a remote client are not limited
+		return true
+	}
+
+	key, rule := r.resolveRule(c)
+	if rule.RequestsPerSecond == 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.cleanup(now)
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		burst := rule.Burst
+		if burst == 0 {
+			burst = rule.RequestsPerSecond
+		}
+
+		bucket = &clientBucket{
+			limiter: rate.NewLimiter(rate.Limit(rule.RequestsPerSecond), int(burst)),
+			burst:   burst,
+		}
+		r.buckets[key] = bucket
+	}
+
+	bucket.lastSeen = now
+
+	return bucket.limiter.AllowN(now, int(r.weight(method, bucket.burst)))
+}
+
+// resolveRule returns the bucket key and the rule applied to the caller.
+// Unknown API keys are ignored and the caller is limited by its IP address
+func (r *RateLimiter) resolveRule(c *caller) (string, RateLimitRule) {
+	if c.apiKey != "" {
+		if rule, ok := r.config.APIKeys[c.apiKey]; ok {
+			return "key:" + c.apiKey, rule
+		}
+	}
+
+	return "ip:" + c.ip, r.config.Default
+}
+
+// weight returns the number of tokens consumed by the method, capped to the bucket capacity
+func (r *RateLimiter) weight(method string, burst uint64) uint64 {
+	weight, ok := r.config.MethodWeights[method]
+	if !ok || weight == 0 {
+		weight = 1
+	}
+
+	if weight > burst {
+		weight = burst
+	}
+
+	return weight
+}
+
+// cleanup evicts the buckets of the clients which have been idle for the cleanup interval
+func (r *RateLimiter) cleanup(now time.Time) {
+	if now.Sub(r.lastCleanup) < rateLimiterCleanupInterval {
+		return
+	}
+
+	for key, bucket := range r.buckets {
+		if now.Sub(bucket.lastSeen) >= rateLimiterCleanupInterval {
+			delete(r.buckets, key)
+		}
+	}
+
+	r.lastCleanup = now
+}
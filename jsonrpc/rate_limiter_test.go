@@ -0,0 +1,132 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewRateLimiter(nil))
+	assert.Nil(t, NewRateLimiter(&RateLimitConfig{}))
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(&RateLimitConfig{
+		Default: RateLimitRule{RequestsPerSecond: 1, Burst: 5},
+		APIKeys: map[string]RateLimitRule{
+			"partner": {RequestsPerSecond: 1, Burst: 20},
+		},
+		MethodWeights: map[string]uint64{
+			"eth_getLogs":      2,
+			"debug_traceBlock": 100,
+		},
+	})
+	require.NotNil(t, limiter)
+
+	first := &caller{transport: serverHTTP, ip: "10.0.0.1"}
+	second := &caller{transport: serverHTTP, ip: "10.0.0.2"}
+
+	t.Run("weighted methods consume more tokens", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, limiter.Allow(first, "eth_getLogs"))
+		assert.True(t, limiter.Allow(first, "eth_getLogs"))
+		assert.True(t, limiter.Allow(first, "eth_chainId"))
+		assert.False(t, limiter.Allow(first, "eth_getLogs"))
+	})
+
+	t.Run("weight is capped to the burst", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, limiter.Allow(second, "debug_traceBlock"))
+		assert.False(t, limiter.Allow(second, "eth_chainId"))
+	})
+
+	t.Run("known API keys have their own bucket", func(t *testing.T) {
+		t.Parallel()
+
+		partner := &caller{transport: serverWS, ip: "10.0.0.3", apiKey: "partner"}
+
+		for i := 0; i < 20; i++ {
+			assert.True(t, limiter.Allow(partner, "eth_chainId"))
+		}
+
+		assert.False(t, limiter.Allow(partner, "eth_chainId"))
+
+		// unknown API key falls back to the IP address rule
+		unknown := &caller{transport: serverWS, ip: "10.0.0.3", apiKey: "unknown"}
+		assert.True(t, limiter.Allow(unknown, "eth_chainId"))
+	})
+
+	t.Run("default weights of the methods which are not configured are kept", func(t *testing.T) {
+		t.Parallel()
+
+		third := &caller{transport: serverHTTP, ip: "10.0.0.4"}
+
+		assert.True(t, limiter.Allow(third, "eth_subscribe"))
+		assert.False(t, limiter.Allow(third, "eth_chainId"))
+	})
+
+	t.Run("internal requests are not limited", func(t *testing.T) {
+		t.Parallel()
+
+		for i := 0; i < 10; i++ {
+			assert.True(t, limiter.Allow(nil, "eth_getLogs"))
+		}
+	})
+}
+
+func TestDispatcher_RateLimitedBatch(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:                 1,
+			jsonRPCBatchLengthLimit: 20,
+			rateLimit: &RateLimitConfig{
+				Default: RateLimitRule{RequestsPerSecond: 1, Burst: 2},
+			},
+		},
+	)
+
+	c := &caller{transport: serverHTTP, ip: "127.0.0.1"}
+
+	resp, err := dispatcher.Handle([]byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]},
+		{"id":2,"jsonrpc":"2.0","method":"eth_chainId","params":[]},
+		{"id":3,"jsonrpc":"2.0","method":"eth_chainId","params":[]}
+	]`), c)
+	require.NoError(t, err)
+
+	var res []SuccessResponse
+
+	require.NoError(t, json.Unmarshal(resp, &res))
+	require.Len(t, res, 3)
+	assert.Nil(t, res[0].Error)
+	assert.Nil(t, res[1].Error)
+	require.NotNil(t, res[2].Error)
+	assert.Equal(t, -32005, res[2].Error.Code)
+
+	// single requests are rejected as well
+	resp, err = dispatcher.Handle([]byte(`{"id":4,"jsonrpc":"2.0","method":"eth_chainId","params":[]}`), c)
+	require.NoError(t, err)
+	assert.Contains(t, string(resp), "-32005")
+
+	// other clients are not affected
+	resp, err = dispatcher.Handle(
+		[]byte(`{"id":5,"jsonrpc":"2.0","method":"eth_chainId","params":[]}`),
+		&caller{transport: serverHTTP, ip: "127.0.0.2"},
+	)
+	require.NoError(t, err)
+	assert.NotContains(t, string(resp), "error")
+}
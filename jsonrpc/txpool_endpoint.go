@@ -17,6 +17,12 @@ type txPoolStore interface {
 
 	// GetBaseFee returns current base fee
 	GetBaseFee() uint64
+
+	// DropTransaction evicts the transaction and the account transactions with higher nonces from the pool
+	DropTransaction(txHash types.Hash) ([]*types.Transaction, error)
+
+	// DropAccount evicts all the transactions of the account from the pool
+	DropAccount(addr types.Address) ([]*types.Transaction, error)
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -125,3 +131,35 @@ func (t *TxPool) Status() (interface{}, error) {
 
 	return resp, nil
 }
+
+// DropTransaction evicts the transaction with the given hash from the pool, together with
+// the transactions of the same account with higher nonces. It returns the evicted transaction hashes.
+// Only served to the authenticated callers
+func (t *TxPool) DropTransaction(txHash types.Hash) (interface{}, error) {
+	evicted, err := t.store.DropTransaction(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTxHashes(evicted), nil
+}
+
+// DropAccount evicts all the transactions of the given account from the pool.
+// It returns the evicted transaction hashes. Only served to the authenticated callers
+func (t *TxPool) DropAccount(addr types.Address) (interface{}, error) {
+	evicted, err := t.store.DropAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTxHashes(evicted), nil
+}
+
+func toTxHashes(txs []*types.Transaction) []types.Hash {
+	hashes := make([]types.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash
+	}
+
+	return hashes
+}
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"errors"
 	"math/big"
 	"strconv"
 	"testing"
@@ -264,6 +265,34 @@ func TestStatusEndpoint(t *testing.T) {
 	})
 }
 
+func TestDropEndpoints(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	address2 := types.Address{0x2}
+	testTx1 := newTestTransaction(1, address1)
+	testTx2 := newTestTransaction(2, address1)
+	testTx3 := newTestTransaction(3, address1)
+	testTx4 := newTestTransaction(4, address2)
+	mockStore.pending[address1] = []*types.Transaction{testTx1, testTx2, testTx3}
+	mockStore.pending[address2] = []*types.Transaction{testTx4}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, err := txPoolEndpoint.DropTransaction(testTx2.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{testTx2.Hash, testTx3.Hash}, result)
+	assert.Equal(t, []*types.Transaction{testTx1}, mockStore.pending[address1])
+
+	_, err = txPoolEndpoint.DropTransaction(testTx3.Hash)
+	assert.Error(t, err)
+
+	result, err = txPoolEndpoint.DropAccount(address2)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{testTx4.Hash}, result)
+	assert.Empty(t, mockStore.pending[address2])
+}
+
 type mockTxPoolStore struct {
 	pending       map[types.Address][]*types.Transaction
 	queued        map[types.Address][]*types.Transaction
@@ -294,6 +323,38 @@ func (s *mockTxPoolStore) GetBaseFee() uint64 {
 	return s.baseFee
 }
 
+func (s *mockTxPoolStore) DropTransaction(txHash types.Hash) ([]*types.Transaction, error) {
+	for addr, txs := range s.pending {
+		for _, tx := range txs {
+			if tx.Hash == txHash {
+				return s.dropFrom(addr, tx.Nonce), nil
+			}
+		}
+	}
+
+	return nil, errors.New("tx not found")
+}
+
+func (s *mockTxPoolStore) DropAccount(addr types.Address) ([]*types.Transaction, error) {
+	return s.dropFrom(addr, 0), nil
+}
+
+func (s *mockTxPoolStore) dropFrom(addr types.Address, nonce uint64) (dropped []*types.Transaction) {
+	kept := make([]*types.Transaction, 0, len(s.pending[addr]))
+
+	for _, tx := range s.pending[addr] {
+		if tx.Nonce >= nonce {
+			dropped = append(dropped, tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+
+	s.pending[addr] = kept
+
+	return dropped
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -36,6 +37,10 @@ type transaction struct {
 	TxIndex     *argUint64     `json:"transactionIndex"`
 	ChainID     *argBig        `json:"chainId,omitempty"`
 	Type        argUint64      `json:"type"`
+	Sponsor     *types.Address `json:"sponsor,omitempty"`
+	SponsorV    *argBig        `json:"sponsorV,omitempty"`
+	SponsorR    *argBig        `json:"sponsorR,omitempty"`
+	SponsorS    *argBig        `json:"sponsorS,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -95,6 +100,16 @@ func toTransaction(
 		res.ChainID = &chainID
 	}
 
+	if t.Type == types.SponsoredTx {
+		sponsor := t.Sponsor
+		res.Sponsor = &sponsor
+
+		if t.SponsorV != nil && t.SponsorR != nil && t.SponsorS != nil {
+			sponsorV, sponsorR, sponsorS := argBig(*t.SponsorV), argBig(*t.SponsorR), argBig(*t.SponsorS)
+			res.SponsorV, res.SponsorR, res.SponsorS = &sponsorV, &sponsorR, &sponsorS
+		}
+	}
+
 	if txIndex != nil {
 		res.TxIndex = argUintPtr(uint64(*txIndex))
 	}
@@ -206,6 +221,7 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	Sponsor           *types.Address `json:"sponsor,omitempty"`
 }
 
 type Log struct {
@@ -362,6 +378,29 @@ type feeHistoryResult struct {
 	Reward        [][]argUint64 `json:"reward,omitempty"`
 }
 
+type feeTierResult struct {
+	MaxPriorityFeePerGas argBig    `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         argBig    `json:"maxFeePerGas"`
+	Blocks               argUint64 `json:"blocks"`
+}
+
+type feeTiersResult struct {
+	BaseFee      argUint64      `json:"baseFee"`
+	GasUsedRatio float64        `json:"gasUsedRatio"`
+	PendingGas   argUint64      `json:"pendingGas"`
+	Slow         *feeTierResult `json:"slow"`
+	Standard     *feeTierResult `json:"standard"`
+	Fast         *feeTierResult `json:"fast"`
+}
+
+func toFeeTierResult(tier *gasprice.FeeTier) *feeTierResult {
+	return &feeTierResult{
+		MaxPriorityFeePerGas: argBig(*tier.MaxPriorityFeePerGas),
+		MaxFeePerGas:         argBig(*tier.MaxFeePerGas),
+		Blocks:               argUint64(tier.Blocks),
+	}
+}
+
 func convertToArgUint64Slice(slice []uint64) []argUint64 {
 	argSlice := make([]argUint64, len(slice))
 	for i, value := range slice {
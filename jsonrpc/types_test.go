@@ -161,6 +161,44 @@ func TestToTransaction_EIP1559(t *testing.T) {
 	assert.Equal(t, hexWithoutLeading0, string(jsonS))
 }
 
+func TestToTransaction_Sponsored(t *testing.T) {
+	sponsor := types.StringToAddress("2")
+	txn := types.Transaction{
+		Type:      types.SponsoredTx,
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(10),
+		Value:     big.NewInt(0),
+		V:         big.NewInt(1),
+		R:         big.NewInt(2),
+		S:         big.NewInt(3),
+		From:      types.StringToAddress("1"),
+		Sponsor:   sponsor,
+		SponsorV:  big.NewInt(0),
+		SponsorR:  big.NewInt(4),
+		SponsorS:  big.NewInt(5),
+	}
+
+	jsonTx := toTransaction(&txn, nil, nil, nil)
+
+	require.NotNil(t, jsonTx.Sponsor)
+	assert.Equal(t, sponsor, *jsonTx.Sponsor)
+
+	jsonSponsorV, _ := jsonTx.SponsorV.MarshalText()
+	jsonSponsorR, _ := jsonTx.SponsorR.MarshalText()
+	jsonSponsorS, _ := jsonTx.SponsorS.MarshalText()
+
+	assert.Equal(t, "0x0", string(jsonSponsorV))
+	assert.Equal(t, "0x4", string(jsonSponsorR))
+	assert.Equal(t, "0x5", string(jsonSponsorS))
+
+	// the sponsor fields are omitted for the non-sponsored transactions
+	txn.Type = types.DynamicFeeTx
+
+	data, err := json.Marshal(toTransaction(&txn, nil, nil, nil))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "sponsor")
+}
+
 func TestBlock_Copy(t *testing.T) {
 	b := &block{
 		ExtraData: []byte{0x1},
@@ -1,12 +1,14 @@
 package server
 
 import (
+	"crypto/ecdsa"
 	"net"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -23,6 +25,7 @@ type Config struct {
 	LibP2PAddr *net.TCPAddr
 
 	PriceLimit         uint64
+	PriceBump          uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
 
@@ -36,7 +39,8 @@ type Config struct {
 
 	SecretsManager *secrets.SecretsManagerConfig
 
-	LogLevel hclog.Level
+	LogLevel        hclog.Level
+	ModuleLogLevels map[string]hclog.Level
 
 	JSONLogFormat bool
 
@@ -47,6 +51,15 @@ type Config struct {
 	NumBlockConfirmations      uint64
 	RelayerTrackerPollInterval time.Duration
 	MetricsInterval            time.Duration
+
+	// DisableFlatState disables the flat state snapshot used for the account and storage reads
+	DisableFlatState bool
+
+	// ParallelExecution enables the optimistic parallel execution of the transactions of the verified blocks
+	ParallelExecution bool
+
+	// GasPriceSuggestion makes eth_gasPrice and eth_maxPriorityFeePerGas follow the standard fee tier
+	GasPriceSuggestion bool
 }
 
 // Telemetry holds the config details for metric services
@@ -62,4 +75,10 @@ type JSONRPC struct {
 	BlockRangeLimit          uint64
 	ConcurrentRequestsDebug  uint64
 	WebSocketReadLimit       uint64
+	RateLimit                *jsonrpc.RateLimitConfig
+	Namespaces               *jsonrpc.NamespaceConfig
+	AdminAPIKeys             []string
+	// ExportDir is the only directory admin_exportChain writes to, the export is disabled if empty
+	ExportDir   string
+	DevAccounts []*ecdsa.PrivateKey
 }
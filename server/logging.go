@@ -0,0 +1,253 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+var errInvalidLogLevel = errors.New("invalid log level")
+
+// logLevels holds the global log level and the log levels of the node subsystems
+// (network, syncer, txpool, polybft, jsonrpc...), which can be changed at runtime.
+// A subsystem is matched against the segments of the logger name, so the level of
+// the "syncer" subsystem applies to both "polygon.syncer" and "polygon.server.polybft.syncer" loggers
+type logLevels struct {
+	lock sync.RWMutex
+
+	// root is the logger all other loggers are derived from.
+	// Its level is kept at the most verbose configured level, so it only prefilters log entries
+	root hclog.Logger
+
+	global  hclog.Level
+	modules map[string]hclog.Level
+}
+
+// newLogLevels creates the log levels with the given global and per subsystem levels
+func newLogLevels(global hclog.Level, modules map[string]hclog.Level) *logLevels {
+	l := &logLevels{
+		global:  global,
+		modules: make(map[string]hclog.Level, len(modules)),
+	}
+
+	for module, level := range modules {
+		l.modules[module] = level
+	}
+
+	return l
+}
+
+// wrap creates the root logger out of the given options, whose subloggers respect the log levels
+func (l *logLevels) wrap(opts *hclog.LoggerOptions) hclog.Logger {
+	opts.SubloggerHook = func(sub hclog.Logger) hclog.Logger {
+		return newModuleLogger(sub, l)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	opts.Level = l.lowestLevel()
+	l.root = hclog.New(opts)
+
+	return newModuleLogger(l.root, l)
+}
+
+// Global returns the global log level
+func (l *logLevels) Global() hclog.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.global
+}
+
+// Modules returns the log levels of the subsystems which override the global one
+func (l *logLevels) Modules() map[string]hclog.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	modules := make(map[string]hclog.Level, len(l.modules))
+	for module, level := range l.modules {
+		modules[module] = level
+	}
+
+	return modules
+}
+
+// SetLevel changes the log level of the given subsystem, or the global one if the module is empty
+func (l *logLevels) SetLevel(module string, level hclog.Level) error {
+	if level == hclog.NoLevel {
+		return errInvalidLogLevel
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if module == "" {
+		l.global = level
+	} else {
+		l.modules[module] = level
+	}
+
+	l.updateRootLevel()
+
+	return nil
+}
+
+// ResetLevel removes the log level of the given subsystem, so the global one applies to it again
+func (l *logLevels) ResetLevel(module string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.modules, module)
+
+	l.updateRootLevel()
+}
+
+// levelOf returns the log level of the logger with the given name segments.
+// The most specific configured subsystem wins
+func (l *logLevels) levelOf(segments []string) hclog.Level {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		if level, ok := l.modules[segments[i]]; ok {
+			return level
+		}
+	}
+
+	return l.global
+}
+
+// lowestLevel returns the most verbose configured level
+func (l *logLevels) lowestLevel() hclog.Level {
+	lowest := l.global
+
+	for _, level := range l.modules {
+		if level < lowest {
+			lowest = level
+		}
+	}
+
+	return lowest
+}
+
+func (l *logLevels) updateRootLevel() {
+	if l.root != nil {
+		l.root.SetLevel(l.lowestLevel())
+	}
+}
+
+// parseLogLevel parses the log level, returning an error on an unknown one
+func parseLogLevel(rawLevel string) (hclog.Level, error) {
+	level := hclog.LevelFromString(rawLevel)
+	if level == hclog.NoLevel {
+		return hclog.NoLevel, fmt.Errorf("%w: %s", errInvalidLogLevel, rawLevel)
+	}
+
+	return level, nil
+}
+
+// ParseModuleLogLevels parses the log levels of the node subsystems
+func ParseModuleLogLevels(rawLevels map[string]string) (map[string]hclog.Level, error) {
+	levels := make(map[string]hclog.Level, len(rawLevels))
+
+	for module, rawLevel := range rawLevels {
+		level, err := parseLogLevel(rawLevel)
+		if err != nil {
+			return nil, fmt.Errorf("log level of %s: %w", module, err)
+		}
+
+		levels[module] = level
+	}
+
+	return levels, nil
+}
+
+// moduleLogger filters the log entries of the wrapped logger by the level of its subsystem
+type moduleLogger struct {
+	hclog.Logger
+
+	levels   *logLevels
+	segments []string
+}
+
+func newModuleLogger(logger hclog.Logger, levels *logLevels) hclog.Logger {
+	return &moduleLogger{
+		Logger:   logger,
+		levels:   levels,
+		segments: strings.Split(logger.Name(), "."),
+	}
+}
+
+func (m *moduleLogger) isEnabled(level hclog.Level) bool {
+	return level >= m.levels.levelOf(m.segments)
+}
+
+func (m *moduleLogger) Log(level hclog.Level, msg string, args ...interface{}) {
+	if m.isEnabled(level) {
+		m.Logger.Log(level, msg, args...)
+	}
+}
+
+func (m *moduleLogger) Trace(msg string, args ...interface{}) {
+	if m.isEnabled(hclog.Trace) {
+		m.Logger.Trace(msg, args...)
+	}
+}
+
+func (m *moduleLogger) Debug(msg string, args ...interface{}) {
+	if m.isEnabled(hclog.Debug) {
+		m.Logger.Debug(msg, args...)
+	}
+}
+
+func (m *moduleLogger) Info(msg string, args ...interface{}) {
+	if m.isEnabled(hclog.Info) {
+		m.Logger.Info(msg, args...)
+	}
+}
+
+func (m *moduleLogger) Warn(msg string, args ...interface{}) {
+	if m.isEnabled(hclog.Warn) {
+		m.Logger.Warn(msg, args...)
+	}
+}
+
+func (m *moduleLogger) Error(msg string, args ...interface{}) {
+	if m.isEnabled(hclog.Error) {
+		m.Logger.Error(msg, args...)
+	}
+}
+
+func (m *moduleLogger) IsTrace() bool {
+	return m.isEnabled(hclog.Trace)
+}
+
+func (m *moduleLogger) IsDebug() bool {
+	return m.isEnabled(hclog.Debug)
+}
+
+func (m *moduleLogger) IsInfo() bool {
+	return m.isEnabled(hclog.Info)
+}
+
+func (m *moduleLogger) IsWarn() bool {
+	return m.isEnabled(hclog.Warn)
+}
+
+func (m *moduleLogger) IsError() bool {
+	return m.isEnabled(hclog.Error)
+}
+
+// SetLevel changes the global log level, as the loggers share it
+func (m *moduleLogger) SetLevel(level hclog.Level) {
+	_ = m.levels.SetLevel("", level)
+}
+
+// GetLevel returns the log level applied to the logger
+func (m *moduleLogger) GetLevel() hclog.Level {
+	return m.levels.levelOf(m.segments)
+}
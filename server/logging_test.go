@@ -0,0 +1,63 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevels_ModuleFiltering(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	levels := newLogLevels(hclog.Info, map[string]hclog.Level{"syncer": hclog.Debug})
+	root := levels.wrap(&hclog.LoggerOptions{Name: "polygon", Output: &buf})
+
+	syncerLogger := root.Named("server").Named("syncer")
+	txpoolLogger := root.Named("txpool")
+
+	syncerLogger.Debug("syncer debug")
+	txpoolLogger.Debug("txpool debug")
+	txpoolLogger.Info("txpool info")
+
+	assert.Contains(t, buf.String(), "syncer debug")
+	assert.NotContains(t, buf.String(), "txpool debug")
+	assert.Contains(t, buf.String(), "txpool info")
+	assert.True(t, syncerLogger.IsDebug())
+	assert.False(t, txpoolLogger.IsDebug())
+
+	// change the levels at runtime
+	require.NoError(t, levels.SetLevel("txpool", hclog.Trace))
+	require.NoError(t, levels.SetLevel("", hclog.Error))
+
+	buf.Reset()
+
+	txpoolLogger.Trace("txpool trace")
+	root.Named("network").Warn("network warn")
+
+	assert.Contains(t, buf.String(), "txpool trace")
+	assert.NotContains(t, buf.String(), "network warn")
+
+	// resetting the module level applies the global one
+	levels.ResetLevel("syncer")
+
+	assert.Equal(t, hclog.Error, syncerLogger.GetLevel())
+	assert.Equal(t, map[string]hclog.Level{"txpool": hclog.Trace}, levels.Modules())
+
+	require.ErrorIs(t, levels.SetLevel("txpool", hclog.NoLevel), errInvalidLogLevel)
+}
+
+func TestParseModuleLogLevels(t *testing.T) {
+	t.Parallel()
+
+	levels, err := ParseModuleLogLevels(map[string]string{"syncer": "debug", "txpool": "WARN"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]hclog.Level{"syncer": hclog.Debug, "txpool": hclog.Warn}, levels)
+
+	_, err = ParseModuleLogLevels(map[string]string{"syncer": "LOUD"})
+	require.ErrorIs(t, err, errInvalidLogLevel)
+}
@@ -1320,6 +1320,220 @@ var _ interface {
 	ErrorName() string
 } = ExportEventValidationError{}
 
+// Validate checks the field values on SetLogLevelRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetLogLevelRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetLogLevelRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetLogLevelRequestMultiError, or nil if none found.
+func (m *SetLogLevelRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetLogLevelRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Module
+
+	// no validation rules for Level
+
+	// no validation rules for ResetModule
+
+	if len(errors) > 0 {
+		return SetLogLevelRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetLogLevelRequestMultiError is an error wrapping multiple validation errors
+// returned by SetLogLevelRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SetLogLevelRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetLogLevelRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetLogLevelRequestMultiError) AllErrors() []error { return m }
+
+// SetLogLevelRequestValidationError is the validation error returned by
+// SetLogLevelRequest.Validate if the designated constraints aren't met.
+type SetLogLevelRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetLogLevelRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetLogLevelRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetLogLevelRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetLogLevelRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetLogLevelRequestValidationError) ErrorName() string {
+	return "SetLogLevelRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetLogLevelRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetLogLevelRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetLogLevelRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetLogLevelRequestValidationError{}
+
+// Validate checks the field values on LogLevelsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *LogLevelsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LogLevelsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LogLevelsResponseMultiError, or nil if none found.
+func (m *LogLevelsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LogLevelsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Global
+
+	// no validation rules for Modules
+
+	if len(errors) > 0 {
+		return LogLevelsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// LogLevelsResponseMultiError is an error wrapping multiple validation errors
+// returned by LogLevelsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type LogLevelsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogLevelsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogLevelsResponseMultiError) AllErrors() []error { return m }
+
+// LogLevelsResponseValidationError is the validation error returned by
+// LogLevelsResponse.Validate if the designated constraints aren't met.
+type LogLevelsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogLevelsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogLevelsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogLevelsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogLevelsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogLevelsResponseValidationError) ErrorName() string {
+	return "LogLevelsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e LogLevelsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogLevelsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogLevelsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogLevelsResponseValidationError{}
+
 // Validate checks the field values on BlockchainEvent_Header with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -31,6 +31,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
+	networkCommon "github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -42,6 +43,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validate"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/umbracle/ethgo"
@@ -51,11 +53,15 @@ import (
 var (
 	errBlockTimeMissing = errors.New("block time configuration is missing")
 	errBlockTimeInvalid = errors.New("block time configuration is invalid")
+
+	errPolybftDataNotSupported = errors.New("consensus does not provide polybft data")
+	errExportDisabled          = errors.New("chain export is disabled, the export directory is not configured")
 )
 
 // Server is the central manager of the blockchain client
 type Server struct {
 	logger       hclog.Logger
+	logLevels    *logLevels
 	config       *Config
 	state        state.State
 	stateStorage itrie.Storage
@@ -98,25 +104,23 @@ type Server struct {
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
 // If log file can't be created, it returns an error
-func newFileLogger(config *Config) (hclog.Logger, error) {
+func newFileLogger(config *Config, levels *logLevels) (hclog.Logger, error) {
 	logFileWriter, err := os.Create(config.LogFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not create log file, %w", err)
 	}
 
-	return hclog.New(&hclog.LoggerOptions{
+	return levels.wrap(&hclog.LoggerOptions{
 		Name:       "polygon",
-		Level:      config.LogLevel,
 		Output:     logFileWriter,
 		JSONFormat: config.JSONLogFormat,
 	}), nil
 }
 
 // newCLILogger returns minimal logger instance that sends all logs to standard output
-func newCLILogger(config *Config) hclog.Logger {
-	return hclog.New(&hclog.LoggerOptions{
+func newCLILogger(config *Config, levels *logLevels) hclog.Logger {
+	return levels.wrap(&hclog.LoggerOptions{
 		Name:       "polygon",
-		Level:      config.LogLevel,
 		JSONFormat: config.JSONLogFormat,
 	})
 }
@@ -124,9 +128,9 @@ func newCLILogger(config *Config) hclog.Logger {
 // newLoggerFromConfig creates a new logger which logs to a specified file.
 // If log file is not set it outputs to standard output ( console ).
 // If log file is specified, and it can't be created the server command will error out
-func newLoggerFromConfig(config *Config) (hclog.Logger, error) {
+func newLoggerFromConfig(config *Config, levels *logLevels) (hclog.Logger, error) {
 	if config.LogFilePath != "" {
-		fileLoggerInstance, err := newFileLogger(config)
+		fileLoggerInstance, err := newFileLogger(config, levels)
 		if err != nil {
 			return nil, err
 		}
@@ -134,18 +138,21 @@ func newLoggerFromConfig(config *Config) (hclog.Logger, error) {
 		return fileLoggerInstance, nil
 	}
 
-	return newCLILogger(config), nil
+	return newCLILogger(config, levels), nil
 }
 
 // NewServer creates a new Minimal server, using the passed in configuration
 func NewServer(config *Config) (*Server, error) {
-	logger, err := newLoggerFromConfig(config)
+	levels := newLogLevels(config.LogLevel, config.ModuleLogLevels)
+
+	logger, err := newLoggerFromConfig(config, levels)
 	if err != nil {
 		return nil, fmt.Errorf("could not setup new logger instance, %w", err)
 	}
 
 	m := &Server{
 		logger:             logger.Named("server"),
+		logLevels:          levels,
 		config:             config,
 		chain:              config.Chain,
 		grpcServer:         grpc.NewServer(grpc.UnaryInterceptor(unaryInterceptor)),
@@ -209,6 +216,7 @@ func NewServer(config *Config) (*Server, error) {
 	m.state = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
+	m.executor.ParallelExecution = m.config.ParallelExecution
 
 	// custom write genesis hook per consensus engine
 	engineName := m.config.Chain.Params.GetEngine()
@@ -252,6 +260,18 @@ func NewServer(config *Config) (*Server, error) {
 			m.config.Chain.Params.BridgeBlockList)
 	}
 
+	// apply call policy genesis data
+	if m.config.Chain.Params.CallPolicy != nil {
+		addresslist.ApplyCallPolicyGenesisAllocs(m.config.Chain.Genesis, contracts.CallPolicyAddr,
+			m.config.Chain.Params.CallPolicy)
+	}
+
+	// apply sponsor allow list genesis data
+	if m.config.Chain.Params.SponsorAllowList != nil {
+		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListSponsorsAddr,
+			m.config.Chain.Params.SponsorAllowList)
+	}
+
 	var initialStateRoot = types.ZeroHash
 
 	if ConsensusType(engineName) == PolyBFTConsensus {
@@ -288,13 +308,17 @@ func NewServer(config *Config) (*Server, error) {
 	// compute the genesis root state
 	config.Chain.Genesis.StateRoot = genesisRoot
 
-	// Use the london signer with eip-155 as a fallback one
-	var signer crypto.TxSigner = crypto.NewLondonSigner(
+	// Use the sponsor signer with london and eip-155 as the fallback ones
+	var signer crypto.TxSigner = crypto.NewSponsorSigner(
 		uint64(m.config.Chain.Params.ChainID),
 		config.Chain.Params.Forks.IsActive(chain.Homestead, 0),
-		crypto.NewEIP155Signer(
+		crypto.NewLondonSigner(
 			uint64(m.config.Chain.Params.ChainID),
 			config.Chain.Params.Forks.IsActive(chain.Homestead, 0),
+			crypto.NewEIP155Signer(
+				uint64(m.config.Chain.Params.ChainID),
+				config.Chain.Params.Forks.IsActive(chain.Homestead, 0),
+			),
 		),
 	)
 
@@ -330,12 +354,6 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
-	// here we can provide some other configuration
-	m.gasHelper, err = gasprice.NewGasHelper(gasprice.DefaultGasHelperConfig, m.blockchain)
-	if err != nil {
-		return nil, err
-	}
-
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
 	{
@@ -354,8 +372,10 @@ func NewServer(config *Config) (*Server, error) {
 			&txpool.Config{
 				MaxSlots:           m.config.MaxSlots,
 				PriceLimit:         m.config.PriceLimit,
+				PriceBump:          m.config.PriceBump,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
 				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
+				SponsorAllowList:   m.config.Chain.Params.SponsorAllowList != nil,
 			},
 		)
 		if err != nil {
@@ -365,6 +385,17 @@ func NewServer(config *Config) (*Server, error) {
 		m.txpool.SetSigner(signer)
 	}
 
+	{
+		// here we can provide some other configuration
+		gasHelperConfig := *gasprice.DefaultGasHelperConfig
+		gasHelperConfig.SuggestionMode = m.config.GasPriceSuggestion
+
+		m.gasHelper, err = gasprice.NewGasHelper(&gasHelperConfig, m.blockchain, m.txpool)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	{
 		// Setup consensus
 		if err := m.setupConsensus(); err != nil {
@@ -380,6 +411,10 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if !m.config.DisableFlatState {
+		st.EnableFlatState(m.blockchain.Header().StateRoot, logger)
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -496,6 +531,24 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 	return account.Balance, nil
 }
 
+func (t *txpoolHub) GetStorage(root types.Hash, addr types.Address, slot types.Hash) (types.Hash, error) {
+	account, err := getAccountImpl(t.state, root, addr)
+	if err != nil {
+		if errors.Is(err, jsonrpc.ErrStateNotFound) {
+			return types.ZeroHash, nil
+		}
+
+		return types.ZeroHash, err
+	}
+
+	snap, err := t.state.NewSnapshotAt(root)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return snap.GetStorage(addr, account.Root, slot), nil
+}
+
 // setupSecretsManager sets up the secrets manager
 func (s *Server) setupSecretsManager() error {
 	secretsManagerConfig := s.config.SecretsManager
@@ -660,8 +713,11 @@ func (s *Server) setupRelayer() error {
 }
 
 type jsonRPCHub struct {
+	logger             hclog.Logger
+	logLevels          *logLevels
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
+	exportDir          string
 
 	*blockchain.Blockchain
 	*txpool.TxPool
@@ -676,6 +732,114 @@ func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
 
+// GetLocalPeerInfo returns the networking information of the local node
+func (j *jsonRPCHub) GetLocalPeerInfo() (*jsonrpc.PeerInfo, error) {
+	addrInfo := j.Server.AddrInfo()
+
+	addr, err := networkCommon.AddrInfoToString(addrInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonrpc.PeerInfo{
+		ID:    addrInfo.ID.String(),
+		Addrs: []string{addr},
+	}, nil
+}
+
+// GetPeersInfo returns the networking information of all connected peers
+func (j *jsonRPCHub) GetPeersInfo() ([]*jsonrpc.PeerInfo, error) {
+	peers := j.Server.Peers()
+	peersInfo := make([]*jsonrpc.PeerInfo, 0, len(peers))
+
+	for _, p := range peers {
+		protocols, err := j.Server.GetProtocols(p.Info.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs := make([]string, 0, len(p.Info.Addrs))
+		for _, addr := range p.Info.Addrs {
+			addrs = append(addrs, addr.String())
+		}
+
+		peersInfo = append(peersInfo, &jsonrpc.PeerInfo{
+			ID:        p.Info.ID.String(),
+			Addrs:     addrs,
+			Protocols: protocols,
+		})
+	}
+
+	return peersInfo, nil
+}
+
+// DisconnectPeer disconnects from the peer with the given ID
+func (j *jsonRPCHub) DisconnectPeer(rawPeerID string) error {
+	peerID, err := peer.Decode(rawPeerID)
+	if err != nil {
+		return err
+	}
+
+	if !j.Server.IsConnected(peerID) {
+		return fmt.Errorf("peer %s is not connected", rawPeerID)
+	}
+
+	j.Server.DisconnectFromPeer(peerID, "disconnected by the operator")
+
+	return nil
+}
+
+// SetLogLevel changes the log level of the given subsystem, or the global one if the module is empty
+func (j *jsonRPCHub) SetLogLevel(module string, rawLevel string) error {
+	level, err := parseLogLevel(rawLevel)
+	if err != nil {
+		return err
+	}
+
+	if err := j.logLevels.SetLevel(module, level); err != nil {
+		return err
+	}
+
+	j.logger.Info("log level changed", "module", module, "level", level)
+
+	return nil
+}
+
+// ExportChain writes the blocks in the given range to a new backup file in the export directory.
+// The file path is relative to the export directory, and the existing files are never overwritten
+func (j *jsonRPCHub) ExportChain(filePath string, from uint64, to *uint64) (uint64, uint64, error) {
+	if j.exportDir == "" {
+		return 0, 0, errExportDisabled
+	}
+
+	if err := common.CreateDirSafe(j.exportDir, 0770); err != nil {
+		return 0, 0, err
+	}
+
+	return archive.ExportBackup(j.Blockchain, j.logger, from, to,
+		filepath.Join(j.exportDir, filePath), archive.BackupConfig{})
+}
+
+// GetValidatorUptime returns the validators uptime for the given epoch (or for the current epoch if nil)
+func (j *jsonRPCHub) GetValidatorUptime(epoch *uint64) (*types.EpochUptime, error) {
+	provider, ok := j.Consensus.(consensus.PolybftDataProvider)
+	if !ok {
+		return nil, errPolybftDataNotSupported
+	}
+
+	return provider.GetValidatorUptime(epoch)
+}
+
+// GetEpochProof returns the epoch ending block header of the given epoch
+func (j *jsonRPCHub) GetEpochProof(epoch uint64) (*types.EpochProof, error) {
+	provider, ok := j.Consensus.(consensus.PolybftDataProvider)
+	if !ok {
+		return nil, errPolybftDataNotSupported
+	}
+
+	return provider.GetEpochProof(epoch)
+}
+
 func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.Account, error) {
 	acct, err := getAccountImpl(j.state, root, addr)
 	if err != nil {
@@ -893,8 +1057,11 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
 func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
+		logger:             s.logger,
+		logLevels:          s.logLevels,
 		state:              s.state,
 		restoreProgression: s.restoreProgression,
+		exportDir:          s.config.JSONRPC.ExportDir,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
@@ -915,6 +1082,10 @@ func (s *Server) setupJSONRPC() error {
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
 		ConcurrentRequestsDebug:  s.config.JSONRPC.ConcurrentRequestsDebug,
 		WebSocketReadLimit:       s.config.JSONRPC.WebSocketReadLimit,
+		RateLimit:                s.config.JSONRPC.RateLimit,
+		Namespaces:               s.config.JSONRPC.Namespaces,
+		AdminAPIKeys:             s.config.JSONRPC.AdminAPIKeys,
+		DevAccounts:              s.config.JSONRPC.DevAccounts,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -960,6 +1131,8 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	headRoot := s.blockchain.Header().StateRoot
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -975,6 +1148,11 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Persist the flat state, so it does not need to be regenerated on restart
+	if st, ok := s.state.(*itrie.State); ok {
+		st.CloseFlatState(headRoot)
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
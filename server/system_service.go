@@ -176,6 +176,51 @@ func (s *systemService) BlockByNumber(
 	}, nil
 }
 
+// SetLogLevel implements the SetLogLevel operator service
+func (s *systemService) SetLogLevel(
+	ctx context.Context,
+	req *proto.SetLogLevelRequest,
+) (*proto.LogLevelsResponse, error) {
+	if req.ResetModule {
+		if req.Module == "" {
+			return nil, errors.New("the global log level can not be reset")
+		}
+
+		s.server.logLevels.ResetLevel(req.Module)
+	} else {
+		level, err := parseLogLevel(req.Level)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.server.logLevels.SetLevel(req.Module, level); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.logLevelsResponse(), nil
+}
+
+// GetLogLevels implements the GetLogLevels operator service
+func (s *systemService) GetLogLevels(context.Context, *empty.Empty) (*proto.LogLevelsResponse, error) {
+	return s.logLevelsResponse(), nil
+}
+
+func (s *systemService) logLevelsResponse() *proto.LogLevelsResponse {
+	modules := s.server.logLevels.Modules()
+
+	resp := &proto.LogLevelsResponse{
+		Global:  s.server.logLevels.Global().String(),
+		Modules: make(map[string]string, len(modules)),
+	}
+
+	for module, level := range modules {
+		resp.Modules[module] = level.String()
+	}
+
+	return resp
+}
+
 func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_ExportServer) error {
 	var (
 		from uint64 = 0
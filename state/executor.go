@@ -41,6 +41,11 @@ type Executor struct {
 
 	PostHook        func(txn *Transition)
 	GenesisPostHook func(*Transition) error
+
+	// ParallelExecution enables the optimistic parallel execution of the block transactions
+	// (see Transition.WriteParallel), which produces the same results as the sequential execution.
+	// It is used only for the blocks for which the ParallelExecution fork is enabled
+	ParallelExecution bool
 }
 
 // NewExecutor creates a new executor
@@ -138,6 +143,24 @@ func (e *Executor) ProcessBlock(
 		return nil, err
 	}
 
+	if e.ParallelExecution && e.GetForksInTime(block.Number()).ParallelExecution {
+		txs := make([]*types.Transaction, 0, len(block.Transactions))
+
+		for _, t := range block.Transactions {
+			if t.Gas <= block.Header.GasLimit {
+				txs = append(txs, t)
+			}
+		}
+
+		for _, err := range txn.WriteParallel(txs, ParallelExecutionWorkers()) {
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return txn, nil
+	}
+
 	for _, t := range block.Transactions {
 		if t.Gas > block.Header.GasLimit {
 			continue
@@ -171,13 +194,73 @@ func (e *Executor) BeginTxn(
 	header *types.Header,
 	coinbaseReceiver types.Address,
 ) (*Transition, error) {
-	forkConfig := e.config.Forks.At(header.Number)
-
 	auxSnap2, err := e.state.NewSnapshotAt(parentRoot)
 	if err != nil {
 		return nil, err
 	}
 
+	return e.beginTxn(auxSnap2, header, coinbaseReceiver)
+}
+
+// PrefetchBlock executes the block transactions on top of the parent state and discards the results,
+// so the state reads of the actual block execution are served by the state caches.
+// It does nothing if the state does not support prefetching, and stops as soon as abort returns true
+func (e *Executor) PrefetchBlock(
+	parentRoot types.Hash,
+	block *types.Block,
+	blockCreator types.Address,
+	abort func() bool,
+) (err error) {
+	prefetchState, ok := e.state.(PrefetchState)
+	if !ok {
+		return nil
+	}
+
+	snap, err := prefetchState.NewPrefetchSnapshotAt(parentRoot)
+	if err != nil {
+		return err
+	}
+
+	txn, err := e.beginTxn(snap, block.Header, blockCreator)
+	if err != nil {
+		return err
+	}
+
+	// the parent state may be outdated, so the failures are expected and not logged
+	txn.logger = hclog.NewNullLogger()
+	txn.PostHook = nil
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("prefetching panicked: %v", r)
+		}
+	}()
+
+	for _, t := range block.Transactions {
+		if abort() {
+			return nil
+		}
+
+		if t.Gas > block.Header.GasLimit {
+			continue
+		}
+
+		// the transaction is copied, as the execution sets its sender concurrently with the actual block execution
+		_ = txn.Write(t.Copy())
+	}
+
+	return nil
+}
+
+func (e *Executor) beginTxn(
+	auxSnap2 Snapshot,
+	header *types.Header,
+	coinbaseReceiver types.Address,
+) (*Transition, error) {
+	var err error
+
+	forkConfig := e.config.Forks.At(header.Number)
+
 	burnContract := types.ZeroAddress
 	if forkConfig.London {
 		burnContract, err = e.config.CalculateBurnContract(header.Number)
@@ -244,6 +327,16 @@ func (e *Executor) BeginTxn(
 		txn.bridgeBlockList = addresslist.NewAddressList(txn, contracts.BlockListBridgeAddr)
 	}
 
+	// enable call policy (if any)
+	if e.config.CallPolicy != nil {
+		txn.callPolicy = addresslist.NewCallPolicy(txn, contracts.CallPolicyAddr)
+	}
+
+	// enable sponsors allow list (if any)
+	if e.config.SponsorAllowList != nil {
+		txn.sponsorAllowList = addresslist.NewAddressList(txn, contracts.AllowListSponsorsAddr)
+	}
+
 	return txn, nil
 }
 
@@ -277,6 +370,17 @@ type Transition struct {
 	txnBlockList        *addresslist.AddressList
 	bridgeAllowList     *addresslist.AddressList
 	bridgeBlockList     *addresslist.AddressList
+
+	// call policy runtime
+	callPolicy *addresslist.CallPolicy
+
+	// sponsors allow list runtime
+	sponsorAllowList *addresslist.AddressList
+
+	// speculative is set for the transitions of the parallel executor,
+	// which defer the fees of the applied transaction (see WriteParallel)
+	speculative bool
+	fees        *txFees
 }
 
 func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -331,10 +435,29 @@ var emptyFrom = types.Address{}
 
 // Write writes another transaction to the executor
 func (t *Transition) Write(txn *types.Transaction) error {
+	if err := t.recoverSender(txn); err != nil {
+		return err
+	}
+
+	// Make a local copy and apply the transaction
+	msg := txn.Copy()
+
+	result, e := t.Apply(msg)
+	if e != nil {
+		t.logger.Error("failed to apply tx", "err", e)
+
+		return e
+	}
+
+	return t.writeReceipt(txn, msg, result, t.state.Logs())
+}
+
+// recoverSender sets the sender of the transaction if it is not set
+func (t *Transition) recoverSender(txn *types.Transaction) error {
 	var err error
 
 	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
+		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx || txn.Type == types.SponsoredTx) {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -344,20 +467,17 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
-	// Make a local copy and apply the transaction
-	msg := txn.Copy()
-
-	result, e := t.Apply(msg)
-	if e != nil {
-		t.logger.Error("failed to apply tx", "err", e)
-
-		return e
-	}
+	return nil
+}
 
+// writeReceipt creates the receipt of the applied transaction
+func (t *Transition) writeReceipt(
+	txn, msg *types.Transaction,
+	result *runtime.ExecutionResult,
+	logs []*types.Log,
+) error {
 	t.totalGas += result.GasUsed
 
-	logs := t.state.Logs()
-
 	receipt := &types.Receipt{
 		CumulativeGasUsed: t.totalGas,
 		TransactionType:   txn.Type,
@@ -401,6 +521,15 @@ func (t *Transition) Commit() (Snapshot, types.Hash, error) {
 	return s2, types.BytesToHash(root), nil
 }
 
+// creditFees pays the coinbase fee and burns the burn amount (if any)
+func (t *Transition) creditFees(coinbaseFee, burnAmount *big.Int) {
+	t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
+
+	if burnAmount != nil {
+		t.state.AddBalance(t.ctx.BurnContract, burnAmount)
+	}
+}
+
 func (t *Transition) subGasPool(amount uint64) error {
 	if t.gasPool < amount {
 		return ErrBlockLimitReached
@@ -446,7 +575,8 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	upfrontGasCost := GetLondonFixHandler(uint64(t.ctx.Number)).getUpfrontGasCost(msg, t.ctx.BaseFee)
 
-	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
+	// the sponsor pays the gas of the sponsored transaction
+	if err := t.state.SubBalance(msg.Payer(), upfrontGasCost); err != nil {
 		if errors.Is(err, runtime.ErrNotEnoughFunds) {
 			return ErrNotEnoughFundsForGas
 		}
@@ -457,6 +587,25 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	return nil
 }
 
+// sponsorCheck checks that the sponsored transactions are enabled, and that the sponsor
+// signed the transaction and is in the sponsors allow list (if any)
+func (t *Transition) sponsorCheck(msg *types.Transaction) error {
+	if !t.config.Sponsorship {
+		return ErrSponsorshipNotEnabled
+	}
+
+	signer := crypto.NewSponsorSigner(uint64(t.ctx.ChainID), t.config.Homestead, nil)
+	if _, err := signer.Sponsor(msg); err != nil {
+		return err
+	}
+
+	if t.sponsorAllowList != nil && !t.sponsorAllowList.GetRole(msg.Sponsor).Enabled() {
+		return ErrSponsorNotAllowed
+	}
+
+	return nil
+}
+
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
@@ -501,6 +650,14 @@ var (
 
 	// ErrNonceUintOverflow is returned if uint64 overflow happens
 	ErrNonceUintOverflow = errors.New("nonce uint64 overflow")
+
+	// ErrSponsorshipNotEnabled is returned if the sponsored transaction
+	// is applied before the sponsorship fork
+	ErrSponsorshipNotEnabled = errors.New("sponsored transactions are not enabled")
+
+	// ErrSponsorNotAllowed is returned if the sponsor of the transaction
+	// is not in the sponsors allow list
+	ErrSponsorNotAllowed = errors.New("sponsor is not in the sponsors allow list")
 )
 
 type TransitionApplicationError struct {
@@ -588,9 +745,9 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		t.ctx.Tracer.TxEnd(result.GasLeft)
 	}
 
-	// Refund the sender
+	// Refund the sender, or the sponsor of the sponsored transaction
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
-	t.state.AddBalance(msg.From, remaining)
+	t.state.AddBalance(msg.Payer(), remaining)
 
 	// Spec: https://eips.ethereum.org/EIPS/eip-1559#specification
 	// Define effective tip based on tx type.
@@ -602,13 +759,20 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 
 	// Pay the coinbase fee as a miner reward using the calculated effective tip.
 	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), effectiveTip)
-	t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
 
 	// Burn some amount if the london hardfork is applied.
 	// Basically, burn amount is just transferred to the current burn contract.
+	var burnAmount *big.Int
 	if t.config.London && msg.Type != types.StateTx {
-		burnAmount := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), t.ctx.BaseFee)
-		t.state.AddBalance(t.ctx.BurnContract, burnAmount)
+		burnAmount = new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), t.ctx.BaseFee)
+	}
+
+	if t.speculative {
+		// the fees are credited when the speculative execution is committed,
+		// otherwise all the transactions would conflict on the coinbase and burn contract balances
+		t.fees = &txFees{coinbase: coinbaseFee, burn: burnAmount}
+	} else {
+		t.creditFees(coinbaseFee, burnAmount)
 	}
 
 	// return gas to the pool
@@ -725,6 +889,20 @@ func (t *Transition) applyCall(
 		}
 	}
 
+	if !t.isCallPermitted(c) {
+		t.logger.Debug(
+			"Failing call. Caller is not permitted by the call policy",
+			"contract.Caller", c.Caller,
+			"contract.Address", c.Address,
+			"contract.CodeAddress", c.CodeAddress,
+		)
+
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrNotAuth,
+		}
+	}
+
 	snapshot := t.state.Snapshot()
 	t.state.TouchAccount(c.Address)
 
@@ -757,6 +935,30 @@ func (t *Transition) applyCall(
 	return result
 }
 
+// isCallPermitted checks the call against the call policy (if any), by the address
+// of the called account and the function selector of the call input.
+// The account whose code is executed is checked as well, so the restricted functions
+// can not be executed by DELEGATECALL or CALLCODE in the context of another account
+func (t *Transition) isCallPermitted(c *runtime.Contract) bool {
+	if t.callPolicy == nil || c.Caller == contracts.SystemCaller {
+		return true
+	}
+
+	selector := addresslist.CallSelector(c.Input)
+
+	for _, addr := range []types.Address{c.Address, c.CodeAddress} {
+		if addr == t.callPolicy.Addr() {
+			continue
+		}
+
+		if !t.callPolicy.CanCall(c.Caller, addr, selector, uint64(t.ctx.Timestamp)) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	if t.state.GetNonce(addr) != 0 {
 		return true
@@ -936,6 +1138,16 @@ func (t *Transition) handleAllowBlockListsUpdate(contract *runtime.Contract,
 		return t.txnBlockList.Run(contract, host, &t.config)
 	}
 
+	// check call policy (if any)
+	if t.callPolicy != nil && t.callPolicy.Addr() == contract.CodeAddress {
+		return t.callPolicy.Run(contract, host, &t.config)
+	}
+
+	// check sponsors allow list (if any)
+	if t.sponsorAllowList != nil && t.sponsorAllowList.Addr() == contract.CodeAddress {
+		return t.sponsorAllowList.Run(contract, host, &t.config)
+	}
+
 	return nil
 }
 
@@ -1121,10 +1333,16 @@ func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 		return NewTransitionApplicationError(err, true)
 	}
 
-	// 3. caller has enough balance to cover transaction
+	// 3. caller (or its sponsor) has enough balance to cover transaction
 	// Skip this check if the given flag is provided.
 	// It happens for eth_call and for other operations that do not change the state.
 	if !t.ctx.NonPayable {
+		if msg.Type == types.SponsoredTx {
+			if err := t.sponsorCheck(msg); err != nil {
+				return NewTransitionApplicationError(err, false)
+			}
+		}
+
 		if err := t.subGasLimitPrice(msg); err != nil {
 			return NewTransitionApplicationError(err, true)
 		}
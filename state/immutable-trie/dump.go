@@ -0,0 +1,220 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// stateLoaderFlushEntries is the number of the entries inserted into a trie by the state loader,
+// after which the trie nodes are written to the storage and released from memory
+const stateLoaderFlushEntries = 50_000
+
+var errStateKeysNotSorted = errors.New("state keys must be added in the ascending order")
+
+// DumpState walks the state with the given root in the order of the hashed keys. It calls onAccount
+// for every account, with the RLP encoded account and its code, followed by onSlot for every slot of its storage,
+// with the RLP encoded value as stored in the storage trie
+func DumpState(
+	storage Storage,
+	root types.Hash,
+	onAccount func(hash types.Hash, data []byte, code []byte) error,
+	onSlot func(hash types.Hash, value []byte) error,
+) error {
+	return walkTrie(root, storage, func(key, value []byte) error {
+		accountHash := types.BytesToHash(key)
+
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("failed to decode account %s: %w", accountHash, err)
+		}
+
+		var code []byte
+
+		if codeHash := types.BytesToHash(account.CodeHash); codeHash != types.ZeroHash && codeHash != types.EmptyCodeHash {
+			var ok bool
+
+			if code, ok = storage.GetCode(codeHash); !ok {
+				return fmt.Errorf("code %s of account %s not found", codeHash, accountHash)
+			}
+		}
+
+		if err := onAccount(accountHash, value, code); err != nil {
+			return err
+		}
+
+		return walkTrie(account.Root, storage, func(key, value []byte) error {
+			return onSlot(types.BytesToHash(key), value)
+		})
+	})
+}
+
+// StateLoader builds the state trie out of the accounts and their storage slots, as dumped by DumpState.
+// The accounts, and the slots of each account, must be added in the ascending order of their hashed keys
+type StateLoader struct {
+	state *State
+
+	accounts       *Txn
+	accountEntries int
+	lastAccount    *types.Hash
+
+	// account is the pending account, which is inserted once its storage is complete
+	account     *state.Account
+	accountHash types.Hash
+	accountData []byte
+
+	slots       *Txn
+	slotEntries int
+	lastSlot    *types.Hash
+}
+
+// NewStateLoader returns the state loader writing the state into the given storage
+func NewStateLoader(storage Storage) *StateLoader {
+	s := NewState(storage)
+
+	return &StateLoader{
+		state:    s,
+		accounts: s.newTrie().Txn(s.trieStorage),
+	}
+}
+
+// AddAccount adds the RLP encoded account with its code. The account storage root is checked
+// against its slots added afterwards
+func (l *StateLoader) AddAccount(hash types.Hash, data []byte, code []byte) error {
+	if l.lastAccount != nil && bytes.Compare(hash.Bytes(), l.lastAccount.Bytes()) <= 0 {
+		return fmt.Errorf("%w: account %s follows %s", errStateKeysNotSorted, hash, *l.lastAccount)
+	}
+
+	if err := l.finishAccount(); err != nil {
+		return err
+	}
+
+	account := &state.Account{}
+	if err := account.UnmarshalRlp(data); err != nil {
+		return fmt.Errorf("failed to decode account %s: %w", hash, err)
+	}
+
+	codeHash := types.BytesToHash(account.CodeHash)
+
+	switch {
+	case len(code) > 0:
+		if types.BytesToHash(crypto.Keccak256(code)) != codeHash {
+			return fmt.Errorf("code of account %s does not match its code hash %s", hash, codeHash)
+		}
+
+		l.state.SetCode(codeHash, code)
+	case codeHash != types.ZeroHash && codeHash != types.EmptyCodeHash:
+		// the code shared with the preceding accounts is added only once
+		if _, ok := l.state.GetCode(codeHash); !ok {
+			return fmt.Errorf("code %s of account %s not found", codeHash, hash)
+		}
+	}
+
+	l.lastAccount = &hash
+	l.account = account
+	l.accountHash = hash
+	l.accountData = data
+	l.slots = l.state.newTrie().Txn(l.state.trieStorage)
+	l.slotEntries = 0
+	l.lastSlot = nil
+
+	return nil
+}
+
+// AddSlot adds the storage slot of the last added account, with the RLP encoded value
+func (l *StateLoader) AddSlot(hash types.Hash, value []byte) error {
+	if l.account == nil {
+		return errors.New("storage slot added before any account")
+	}
+
+	if l.lastSlot != nil && bytes.Compare(hash.Bytes(), l.lastSlot.Bytes()) <= 0 {
+		return fmt.Errorf("%w: slot %s of account %s follows %s", errStateKeysNotSorted, hash, l.accountHash, *l.lastSlot)
+	}
+
+	l.lastSlot = &hash
+	l.slots.Insert(hash.Bytes(), value)
+
+	if l.slotEntries++; l.slotEntries%stateLoaderFlushEntries == 0 {
+		txn, _, err := l.flush(l.slots)
+		if err != nil {
+			return err
+		}
+
+		l.slots = txn
+	}
+
+	return nil
+}
+
+// Commit writes the remaining trie nodes to the storage and returns the state root
+func (l *StateLoader) Commit() (types.Hash, error) {
+	if err := l.finishAccount(); err != nil {
+		return types.Hash{}, err
+	}
+
+	batch := l.state.storage.Batch()
+	l.accounts.batch = batch
+
+	root, err := l.accounts.Hash()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	batch.Write()
+
+	return types.BytesToHash(root), nil
+}
+
+// finishAccount checks the storage root of the pending account and inserts it into the state trie
+func (l *StateLoader) finishAccount() error {
+	if l.account == nil {
+		return nil
+	}
+
+	_, root, err := l.flush(l.slots)
+	if err != nil {
+		return err
+	}
+
+	if root != l.account.Root {
+		return fmt.Errorf("storage root of account %s is %s, but its slots hash to %s", l.accountHash, l.account.Root, root)
+	}
+
+	l.accounts.Insert(l.accountHash.Bytes(), l.accountData)
+	l.account = nil
+
+	if l.accountEntries++; l.accountEntries%stateLoaderFlushEntries == 0 {
+		if l.accounts, _, err = l.flush(l.accounts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flush writes the trie nodes to the storage and returns the transaction on top of the trie
+// loaded back from the storage, so the written nodes are released from memory, along with the trie root
+func (l *StateLoader) flush(txn *Txn) (*Txn, types.Hash, error) {
+	batch := l.state.storage.Batch()
+	txn.batch = batch
+
+	hash, err := txn.Hash()
+	if err != nil {
+		return nil, types.Hash{}, err
+	}
+
+	batch.Write()
+
+	root := types.BytesToHash(hash)
+
+	trie, err := l.state.loadTrie(root)
+	if err != nil {
+		return nil, types.Hash{}, err
+	}
+
+	return trie.Txn(l.state.trieStorage), root, nil
+}
@@ -0,0 +1,153 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type dumpedAccount struct {
+	hash  types.Hash
+	data  []byte
+	code  []byte
+	slots map[types.Hash][]byte
+	order []types.Hash
+}
+
+func dumpTestState(t *testing.T, storage Storage, root types.Hash) []*dumpedAccount {
+	t.Helper()
+
+	accounts := []*dumpedAccount{}
+
+	require.NoError(t, DumpState(storage, root,
+		func(hash types.Hash, data []byte, code []byte) error {
+			accounts = append(accounts, &dumpedAccount{hash: hash, data: data, code: code, slots: map[types.Hash][]byte{}})
+
+			return nil
+		},
+		func(hash types.Hash, value []byte) error {
+			account := accounts[len(accounts)-1]
+			account.slots[hash] = value
+			account.order = append(account.order, hash)
+
+			return nil
+		},
+	))
+
+	return accounts
+}
+
+func TestStateLoader_DumpAndLoad(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+	snap, _ := commitAccounts(t, st.NewSnapshot(), 30, 1)
+
+	txn := state.NewTxn(snap)
+	txn.SetCode(testFlatAddress(1), []byte{0x60, 0x00})
+	txn.SetCode(testFlatAddress(2), []byte{0x60, 0x00})
+
+	for i := 0; i < 100; i++ {
+		txn.SetState(testFlatAddress(3), types.BytesToHash([]byte{byte(i), 1}), types.BytesToHash([]byte{byte(i + 1)}))
+	}
+
+	snap, root := commitTxn(t, snap, txn)
+
+	accounts := dumpTestState(t, st.storage, root)
+	require.Len(t, accounts, 30)
+
+	target := NewMemoryStorage()
+	loader := NewStateLoader(target)
+
+	for _, account := range accounts {
+		require.NoError(t, loader.AddAccount(account.hash, account.data, account.code))
+
+		for _, slot := range account.order {
+			require.NoError(t, loader.AddSlot(slot, account.slots[slot]))
+		}
+	}
+
+	loadedRoot, err := loader.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, root, loadedRoot)
+
+	loaded, err := NewState(target).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, addr := range []types.Address{testFlatAddress(1), testFlatAddress(3), testFlatAddress(30)} {
+		expected, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		account, err := loaded.GetAccount(addr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, account)
+	}
+
+	code, ok := loaded.GetCode(types.BytesToHash(mustAccount(t, loaded, testFlatAddress(2)).CodeHash))
+	require.True(t, ok)
+	assert.Equal(t, []byte{0x60, 0x00}, code)
+
+	account := mustAccount(t, loaded, testFlatAddress(3))
+	assert.Equal(t, types.BytesToHash([]byte{100}),
+		loaded.GetStorage(testFlatAddress(3), account.Root, types.BytesToHash([]byte{99, 1})))
+}
+
+func TestStateLoader_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+	_, root := commitAccounts(t, st.NewSnapshot(), 3, 1)
+
+	accounts := dumpTestState(t, st.storage, root)
+	require.Len(t, accounts, 3)
+
+	t.Run("accounts not sorted", func(t *testing.T) {
+		t.Parallel()
+
+		loader := NewStateLoader(NewMemoryStorage())
+
+		require.NoError(t, loader.AddAccount(accounts[1].hash, accounts[1].data, nil))
+		assert.ErrorIs(t, loader.AddAccount(accounts[0].hash, accounts[0].data, nil), errStateKeysNotSorted)
+	})
+
+	t.Run("missing storage slot", func(t *testing.T) {
+		t.Parallel()
+
+		loader := NewStateLoader(NewMemoryStorage())
+
+		require.NoError(t, loader.AddAccount(accounts[0].hash, accounts[0].data, nil))
+
+		_, err := loader.Commit()
+		assert.ErrorContains(t, err, "storage root")
+	})
+
+	t.Run("missing code", func(t *testing.T) {
+		t.Parallel()
+
+		account := &state.Account{
+			Balance:  big.NewInt(1),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.StringToHash("0x1").Bytes(),
+		}
+
+		loader := NewStateLoader(NewMemoryStorage())
+		err := loader.AddAccount(types.StringToHash("0x1"), account.MarshalWith(&fastrlp.Arena{}).MarshalTo(nil), nil)
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func mustAccount(t *testing.T, snap state.Snapshot, addr types.Address) *state.Account {
+	t.Helper()
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+	require.NotNil(t, account)
+
+	return account
+}
@@ -0,0 +1,396 @@
+package itrie
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// flatAccountPrefix is the prefix of the flat state accounts (generation + account hash)
+	flatAccountPrefix = []byte("fa")
+
+	// flatStoragePrefix is the prefix of the flat state storage slots
+	// (generation + account hash + incarnation + slot hash)
+	flatStoragePrefix = []byte("fs")
+
+	// flatStateMetaKey is the key of the flat state metadata (generation, root and generation status)
+	flatStateMetaKey = []byte("flatstate")
+
+	errFlatStateStale    = errors.New("flat state layer is stale")
+	errFlatStateNotReady = errors.New("flat state is being generated")
+)
+
+const (
+	// flatAccountCacheSize is the number of disk layer accounts kept in memory
+	flatAccountCacheSize = 8192
+
+	flatStateMetaSize = 8 + types.HashLength + 1
+)
+
+// flatLayer is a flat (key-value) view of the state at the given root, which serves
+// account and storage reads without walking the trie.
+// Any error means the layer can not serve the read, so the caller should fall back to the trie
+type flatLayer interface {
+	// stateRoot returns the state root of the layer
+	stateRoot() types.Hash
+
+	// accountData returns the RLP encoded account, or nil if the account does not exist
+	accountData(accountHash types.Hash) ([]byte, error)
+
+	// storageData returns the value (without leading zeros) of the storage slot,
+	// or nil if the slot is not set
+	storageData(accountHash, slotHash types.Hash) ([]byte, error)
+}
+
+// flatAccountEntry is the disk layer account, along with the incarnation of its storage.
+// Incarnation is increased when the account storage is wiped (e.g. account is self destructed),
+// which makes the slots of the previous incarnation unreachable without deleting them
+type flatAccountEntry struct {
+	incarnation uint64
+	data        []byte
+}
+
+func (e *flatAccountEntry) marshal() []byte {
+	buf := make([]byte, 8+len(e.data))
+	binary.BigEndian.PutUint64(buf, e.incarnation)
+	copy(buf[8:], e.data)
+
+	return buf
+}
+
+func (e *flatAccountEntry) unmarshal(buf []byte) error {
+	if len(buf) < 8 {
+		return fmt.Errorf("invalid flat account entry size %d", len(buf))
+	}
+
+	e.incarnation = binary.BigEndian.Uint64(buf)
+	e.data = nil
+
+	if len(buf) > 8 {
+		e.data = append([]byte{}, buf[8:]...)
+	}
+
+	return nil
+}
+
+// hasStorage returns true if the account exists and its storage is not empty
+func (e *flatAccountEntry) hasStorage() bool {
+	if len(e.data) == 0 {
+		return false
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(e.data); err != nil {
+		// be conservative and wipe the storage of an undecodable account
+		return true
+	}
+
+	return account.Root != types.EmptyRootHash
+}
+
+func flatAccountKey(generation uint64, accountHash types.Hash) []byte {
+	key := make([]byte, 0, len(flatAccountPrefix)+8+types.HashLength)
+	key = append(key, flatAccountPrefix...)
+	key = binary.BigEndian.AppendUint64(key, generation)
+
+	return append(key, accountHash.Bytes()...)
+}
+
+func flatStorageKey(generation uint64, accountHash types.Hash, incarnation uint64, slotHash types.Hash) []byte {
+	key := make([]byte, 0, len(flatStoragePrefix)+16+2*types.HashLength)
+	key = append(key, flatStoragePrefix...)
+	key = binary.BigEndian.AppendUint64(key, generation)
+	key = append(key, accountHash.Bytes()...)
+	key = binary.BigEndian.AppendUint64(key, incarnation)
+
+	return append(key, slotHash.Bytes()...)
+}
+
+// flatStateMeta is the persisted description of the disk layer
+type flatStateMeta struct {
+	generation uint64
+	root       types.Hash
+	complete   bool
+}
+
+func (m *flatStateMeta) marshal() []byte {
+	buf := make([]byte, 0, flatStateMetaSize)
+	buf = binary.BigEndian.AppendUint64(buf, m.generation)
+	buf = append(buf, m.root.Bytes()...)
+
+	if m.complete {
+		return append(buf, 1)
+	}
+
+	return append(buf, 0)
+}
+
+func (m *flatStateMeta) unmarshal(buf []byte) error {
+	if len(buf) != flatStateMetaSize {
+		return fmt.Errorf("invalid flat state metadata size %d", len(buf))
+	}
+
+	m.generation = binary.BigEndian.Uint64(buf)
+	m.root = types.BytesToHash(buf[8 : 8+types.HashLength])
+	m.complete = buf[flatStateMetaSize-1] == 1
+
+	return nil
+}
+
+var _ flatLayer = (*diskLayer)(nil)
+
+// diskLayer is the flat state persisted in the storage
+type diskLayer struct {
+	storage    Storage
+	root       types.Hash
+	generation uint64
+	cache      *lru.Cache
+
+	lock  sync.RWMutex
+	ready bool
+	stale bool
+}
+
+func newDiskLayer(storage Storage, root types.Hash, generation uint64, cache *lru.Cache, ready bool) *diskLayer {
+	return &diskLayer{
+		storage:    storage,
+		root:       root,
+		generation: generation,
+		cache:      cache,
+		ready:      ready,
+	}
+}
+
+func (d *diskLayer) stateRoot() types.Hash {
+	return d.root
+}
+
+func (d *diskLayer) isReady() bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.ready && !d.stale
+}
+
+func (d *diskLayer) setReady() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.ready = true
+}
+
+func (d *diskLayer) checkReadable() error {
+	if d.stale {
+		return errFlatStateStale
+	}
+
+	if !d.ready {
+		return errFlatStateNotReady
+	}
+
+	return nil
+}
+
+func (d *diskLayer) accountData(accountHash types.Hash) ([]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if err := d.checkReadable(); err != nil {
+		return nil, err
+	}
+
+	entry, err := d.entry(accountHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.data, nil
+}
+
+func (d *diskLayer) storageData(accountHash, slotHash types.Hash) ([]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if err := d.checkReadable(); err != nil {
+		return nil, err
+	}
+
+	entry, err := d.entry(accountHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entry.data) == 0 {
+		return nil, nil
+	}
+
+	val, ok := d.storage.Get(flatStorageKey(d.generation, accountHash, entry.incarnation, slotHash))
+	if !ok || len(val) == 0 {
+		return nil, nil
+	}
+
+	return val, nil
+}
+
+// entry returns the account entry (with nil data if the account does not exist), the caller must hold the lock
+func (d *diskLayer) entry(accountHash types.Hash) (*flatAccountEntry, error) {
+	if cached, ok := d.cache.Get(accountHash); ok {
+		entry, ok := cached.(*flatAccountEntry)
+		if !ok {
+			return nil, fmt.Errorf("invalid type assertion on flat account: %s", accountHash)
+		}
+
+		return entry, nil
+	}
+
+	entry := &flatAccountEntry{}
+
+	if buf, ok := d.storage.Get(flatAccountKey(d.generation, accountHash)); ok && len(buf) > 0 {
+		if err := entry.unmarshal(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	d.cache.Add(accountHash, entry)
+
+	return entry, nil
+}
+
+var _ flatLayer = (*diffLayer)(nil)
+
+// diffLayer holds the in-memory state changes of a single commit on top of its parent layer
+type diffLayer struct {
+	root types.Hash
+
+	// accounts holds the RLP encoded accounts changed by the commit (nil if the account is deleted)
+	accounts map[types.Hash][]byte
+
+	// storage holds the storage slots changed by the commit (empty value if the slot is deleted)
+	storage map[types.Hash]map[types.Hash][]byte
+
+	// destructs holds the accounts whose storage is wiped before the storage changes are applied
+	destructs map[types.Hash]struct{}
+
+	lock   sync.RWMutex
+	parent flatLayer
+	stale  bool
+}
+
+func newDiffLayer(parent flatLayer, root types.Hash) *diffLayer {
+	return &diffLayer{
+		root:      root,
+		parent:    parent,
+		accounts:  map[types.Hash][]byte{},
+		storage:   map[types.Hash]map[types.Hash][]byte{},
+		destructs: map[types.Hash]struct{}{},
+	}
+}
+
+func (dl *diffLayer) stateRoot() types.Hash {
+	return dl.root
+}
+
+// parentLayer returns the parent layer, or nil if the layer is stale
+func (dl *diffLayer) parentLayer() flatLayer {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return nil
+	}
+
+	return dl.parent
+}
+
+func (dl *diffLayer) setParent(parent flatLayer) {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	dl.parent = parent
+}
+
+func (dl *diffLayer) markStale() {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	dl.stale = true
+}
+
+func (dl *diffLayer) accountData(accountHash types.Hash) ([]byte, error) {
+	dl.lock.RLock()
+
+	if dl.stale {
+		dl.lock.RUnlock()
+
+		return nil, errFlatStateStale
+	}
+
+	data, ok := dl.accounts[accountHash]
+	parent := dl.parent
+
+	dl.lock.RUnlock()
+
+	if ok {
+		return data, nil
+	}
+
+	return parent.accountData(accountHash)
+}
+
+func (dl *diffLayer) storageData(accountHash, slotHash types.Hash) ([]byte, error) {
+	dl.lock.RLock()
+
+	if dl.stale {
+		dl.lock.RUnlock()
+
+		return nil, errFlatStateStale
+	}
+
+	val, ok := dl.storage[accountHash][slotHash]
+	_, destructed := dl.destructs[accountHash]
+	parent := dl.parent
+
+	dl.lock.RUnlock()
+
+	if ok {
+		if len(val) == 0 {
+			return nil, nil
+		}
+
+		return val, nil
+	}
+
+	if destructed {
+		return nil, nil
+	}
+
+	return parent.storageData(accountHash, slotHash)
+}
+
+// setAccount records the account change, data is nil if the account is deleted
+func (dl *diffLayer) setAccount(accountHash types.Hash, data []byte) {
+	dl.accounts[accountHash] = data
+}
+
+// destruct records that the account storage is wiped
+func (dl *diffLayer) destruct(accountHash types.Hash) {
+	dl.destructs[accountHash] = struct{}{}
+}
+
+// setStorage records the storage slot change, val is empty if the slot is deleted
+func (dl *diffLayer) setStorage(accountHash, slotHash types.Hash, val []byte) {
+	slots, ok := dl.storage[accountHash]
+	if !ok {
+		slots = map[types.Hash][]byte{}
+		dl.storage[accountHash] = slots
+	}
+
+	slots[slotHash] = val
+}
@@ -0,0 +1,719 @@
+package itrie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// flatStateDiffLayers is the number of in-memory diff layers kept on top of the disk layer,
+	// older layers are flattened into the disk layer
+	flatStateDiffLayers = 128
+
+	// flatStateGenerationBatch is the number of entries written at once while generating the flat state
+	flatStateGenerationBatch = 10_000
+)
+
+var (
+	errFlatStateGenerationAborted = errors.New("flat state generation aborted")
+	errFlatStateChunkGenerated    = errors.New("flat state chunk generated")
+)
+
+// prefixDeleter is implemented by the storages which are able to delete all the keys with the given prefix
+type prefixDeleter interface {
+	DeletePrefix(prefix []byte) error
+}
+
+// flatState manages the flat state layers: a single disk layer and the tree of diff layers on top of it
+type flatState struct {
+	storage Storage
+	logger  hclog.Logger
+
+	lock   sync.RWMutex
+	disk   *diskLayer
+	layers map[types.Hash]*diffLayer
+
+	quit          chan struct{}
+	quitOnce      sync.Once
+	generationWg  sync.WaitGroup
+	generationErr error
+
+	// generationMarker is the hash of the last account generated into the disk layer (nil if none yet).
+	// The diff layers flattened during the generation are written only for the accounts up to the marker,
+	// the following accounts are generated out of the root of the flattened layer
+	generationMarker *types.Hash
+}
+
+// newFlatState loads the disk layer from the storage. If the persisted disk layer is missing, incomplete
+// or it does not match the head root, a new disk layer is generated out of the trie in the background.
+// The flat state is disabled if the generation fails, so the reads are served by the trie
+func newFlatState(storage Storage, headRoot types.Hash, logger hclog.Logger) *flatState {
+	cache, _ := lru.New(flatAccountCacheSize)
+
+	f := &flatState{
+		storage: storage,
+		logger:  logger,
+		layers:  map[types.Hash]*diffLayer{},
+		quit:    make(chan struct{}),
+	}
+
+	meta := &flatStateMeta{}
+
+	if buf, ok := storage.Get(flatStateMetaKey); ok {
+		if err := meta.unmarshal(buf); err != nil {
+			logger.Warn("invalid flat state metadata", "err", err)
+
+			meta = &flatStateMeta{}
+		}
+	}
+
+	if meta.complete && meta.root == headRoot {
+		f.disk = newDiskLayer(storage, headRoot, meta.generation, cache, true)
+
+		logger.Info("flat state loaded", "root", headRoot, "generation", meta.generation)
+
+		return f
+	}
+
+	logger.Info("flat state is missing or inconsistent, generating it in the background",
+		"root", headRoot, "persisted root", meta.root, "complete", meta.complete)
+
+	f.disk = newDiskLayer(storage, headRoot, meta.generation+1, cache, false)
+
+	// persist the generation first, so the interrupted generation is not taken as a complete one
+	storage.Put(flatStateMetaKey, (&flatStateMeta{generation: f.disk.generation, root: headRoot}).marshal())
+
+	f.generationWg.Add(1)
+
+	go f.generate()
+
+	return f
+}
+
+// layer returns the flat layer of the given state root, or nil if there is no such layer
+func (f *flatState) layer(root types.Hash) flatLayer {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if f.generationErr != nil {
+		return nil
+	}
+
+	if f.disk.root == root {
+		return f.disk
+	}
+
+	if dl, ok := f.layers[root]; ok {
+		return dl
+	}
+
+	return nil
+}
+
+// update adds the diff layer of the given root on top of the parent root layer.
+// The diff is dropped if the parent layer is unknown or the flat state is disabled
+func (f *flatState) update(parentRoot types.Hash, dl *diffLayer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if parentRoot == dl.root || f.generationErr != nil {
+		return
+	}
+
+	if _, ok := f.layers[dl.root]; ok || f.disk.root == dl.root {
+		return
+	}
+
+	var parent flatLayer
+
+	if f.disk.root == parentRoot {
+		parent = f.disk
+	} else if parentDiff, ok := f.layers[parentRoot]; ok {
+		parent = parentDiff
+	} else {
+		return
+	}
+
+	dl.setParent(parent)
+	f.layers[dl.root] = dl
+
+	// the layers are flattened during the generation as well, so they don't pile up in memory
+	f.capLocked(dl, flatStateDiffLayers)
+}
+
+// capLocked flattens the diff layers below the given layer into the disk layer,
+// until there are at most the given number of diff layers left. The caller must hold the lock
+func (f *flatState) capLocked(top *diffLayer, layers int) {
+	var chain []*diffLayer
+
+	for layer := flatLayer(top); ; {
+		dl, ok := layer.(*diffLayer)
+		if !ok {
+			break
+		}
+
+		chain = append(chain, dl)
+		layer = dl.parentLayer()
+	}
+
+	if len(chain) <= layers {
+		return
+	}
+
+	for len(chain) > layers {
+		f.flattenLocked(chain[len(chain)-1])
+		chain = chain[:len(chain)-1]
+	}
+
+	// drop the layers which are not built on top of the new disk layer (e.g. abandoned forks)
+	for root, dl := range f.layers {
+		if !f.reachesDiskLocked(dl) {
+			dl.markStale()
+			delete(f.layers, root)
+		}
+	}
+}
+
+// flattenLocked writes the bottom diff layer into the storage and makes it the new disk layer.
+// While the disk layer is being generated, only the accounts up to the generation marker are written.
+// The caller must hold the lock
+func (f *flatState) flattenLocked(bottom *diffLayer) {
+	disk := f.disk
+
+	disk.lock.Lock()
+
+	generating := !disk.ready
+
+	// generated returns true if the account is already generated into the disk layer
+	generated := func(accountHash types.Hash) bool {
+		return !generating ||
+			(f.generationMarker != nil && bytes.Compare(accountHash[:], f.generationMarker[:]) <= 0)
+	}
+
+	batch := f.storage.Batch()
+	entries := make(map[types.Hash]*flatAccountEntry, len(bottom.accounts))
+
+	accountEntry := func(accountHash types.Hash) *flatAccountEntry {
+		if entry, ok := entries[accountHash]; ok {
+			return entry
+		}
+
+		entry, err := disk.entry(accountHash)
+		if err != nil {
+			f.logger.Warn("failed to read flat account, resetting it", "account", accountHash, "err", err)
+
+			entry = &flatAccountEntry{}
+		}
+
+		updated := &flatAccountEntry{incarnation: entry.incarnation, data: entry.data}
+
+		if _, ok := bottom.destructs[accountHash]; ok && entry.hasStorage() {
+			updated.incarnation++
+		}
+
+		entries[accountHash] = updated
+
+		return updated
+	}
+
+	for accountHash := range bottom.destructs {
+		if generated(accountHash) {
+			accountEntry(accountHash)
+		}
+	}
+
+	for accountHash, data := range bottom.accounts {
+		if generated(accountHash) {
+			accountEntry(accountHash).data = data
+		}
+	}
+
+	for accountHash, slots := range bottom.storage {
+		if !generated(accountHash) {
+			continue
+		}
+
+		entry := accountEntry(accountHash)
+
+		for slotHash, val := range slots {
+			batch.Put(flatStorageKey(disk.generation, accountHash, entry.incarnation, slotHash), val)
+		}
+	}
+
+	for accountHash, entry := range entries {
+		batch.Put(flatAccountKey(disk.generation, accountHash), entry.marshal())
+	}
+
+	meta := &flatStateMeta{generation: disk.generation, root: bottom.root, complete: !generating}
+	batch.Put(flatStateMetaKey, meta.marshal())
+	batch.Write()
+
+	for accountHash, entry := range entries {
+		disk.cache.Add(accountHash, entry)
+	}
+
+	disk.stale = true
+	disk.lock.Unlock()
+
+	f.disk = newDiskLayer(f.storage, bottom.root, disk.generation, disk.cache, !generating)
+
+	bottom.markStale()
+	delete(f.layers, bottom.root)
+
+	for _, dl := range f.layers {
+		if dl.parentLayer() == flatLayer(bottom) {
+			dl.setParent(f.disk)
+		}
+	}
+}
+
+// reachesDiskLocked returns true if the diff layer is built on top of the current disk layer
+func (f *flatState) reachesDiskLocked(dl *diffLayer) bool {
+	for layer := flatLayer(dl); layer != nil; {
+		switch l := layer.(type) {
+		case *diskLayer:
+			return l == f.disk
+		case *diffLayer:
+			layer = l.parentLayer()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// generate writes the accounts and storage of the disk layer root trie into the storage.
+// The accounts are generated in chunks, each one out of the root of the current disk layer,
+// since the diff layers are flattened into the disk layer during the generation
+func (f *flatState) generate() {
+	defer f.generationWg.Done()
+
+	var (
+		start    = time.Now()
+		entries  = 0
+		accounts = 0
+	)
+
+	for {
+		select {
+		case <-f.quit:
+			f.disable(errFlatStateGenerationAborted)
+
+			return
+		default:
+		}
+
+		done, chunkAccounts, chunkEntries, err := f.generateChunk()
+		if err != nil {
+			f.disable(err)
+
+			f.logger.Error("flat state generation failed", "err", err)
+
+			return
+		}
+
+		accounts += chunkAccounts
+		entries += chunkEntries
+
+		if done {
+			break
+		}
+	}
+
+	f.lock.RLock()
+	disk := f.disk
+	f.lock.RUnlock()
+
+	f.logger.Info("flat state generated", "root", disk.root, "accounts", accounts,
+		"entries", entries, "elapsed", time.Since(start))
+
+	f.pruneGenerations(disk.generation)
+}
+
+// generateChunk writes the accounts following the generation marker, until at least flatStateGenerationBatch
+// entries are written. The disk layer is set ready once all the accounts are generated.
+// The lock is held meanwhile, so the diff layers are not flattened in the middle of the chunk
+func (f *flatState) generateChunk() (bool, int, int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var (
+		disk     = f.disk
+		batch    = f.storage.Batch()
+		entries  = 0
+		accounts = 0
+		marker   *types.Hash
+	)
+
+	err := walkTrieAfter(disk.root, f.storage, f.generationMarker, func(key, value []byte) error {
+		if entries >= flatStateGenerationBatch {
+			return errFlatStateChunkGenerated
+		}
+
+		accountHash := types.BytesToHash(key)
+
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("failed to decode account %s: %w", accountHash, err)
+		}
+
+		batch.Put(flatAccountKey(disk.generation, accountHash), (&flatAccountEntry{data: value}).marshal())
+
+		if err := walkTrie(account.Root, f.storage, func(key, value []byte) error {
+			val, err := decodeStorageValue(value)
+			if err != nil {
+				return fmt.Errorf("failed to decode storage slot of account %s: %w", accountHash, err)
+			}
+
+			batch.Put(flatStorageKey(disk.generation, accountHash, 0, types.BytesToHash(key)), val)
+			entries++
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		entries++
+		accounts++
+		marker = &accountHash
+
+		return nil
+	})
+
+	done := err == nil
+	if !done && !errors.Is(err, errFlatStateChunkGenerated) {
+		return false, 0, 0, err
+	}
+
+	if done {
+		batch.Put(flatStateMetaKey,
+			(&flatStateMeta{generation: disk.generation, root: disk.root, complete: true}).marshal())
+	}
+
+	batch.Write()
+
+	if marker != nil {
+		f.generationMarker = marker
+	}
+
+	if done {
+		disk.setReady()
+
+		f.generationMarker = nil
+	}
+
+	return done, accounts, entries, nil
+}
+
+// disable disables the flat state after the failed generation and drops its diff layers,
+// so the reads are served by the trie
+func (f *flatState) disable(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.generationErr = err
+
+	for root, dl := range f.layers {
+		dl.markStale()
+		delete(f.layers, root)
+	}
+}
+
+// pruneGenerations deletes the entries of the previous flat state generations, if the storage supports it
+func (f *flatState) pruneGenerations(generation uint64) {
+	deleter, ok := f.storage.(prefixDeleter)
+	if !ok {
+		return
+	}
+
+	for gen := uint64(1); gen < generation; gen++ {
+		for _, prefix := range [][]byte{flatAccountPrefix, flatStoragePrefix} {
+			key := binary.BigEndian.AppendUint64(append([]byte{}, prefix...), gen)
+
+			if err := deleter.DeletePrefix(key); err != nil {
+				f.logger.Warn("failed to prune flat state generation", "generation", gen, "err", err)
+
+				return
+			}
+		}
+	}
+}
+
+// verify compares the accounts and the storage of the trie with the given root against the flat state,
+// once the generation (if any) is finished
+func (f *flatState) verify(root types.Hash) error {
+	f.generationWg.Wait()
+
+	f.lock.RLock()
+	generationErr := f.generationErr
+	f.lock.RUnlock()
+
+	if generationErr != nil {
+		return fmt.Errorf("flat state generation failed: %w", generationErr)
+	}
+
+	layer := f.layer(root)
+	if layer == nil {
+		return fmt.Errorf("flat state layer not found for root %s", root)
+	}
+
+	return walkTrie(root, f.storage, func(key, value []byte) error {
+		accountHash := types.BytesToHash(key)
+
+		data, err := layer.accountData(accountHash)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(data, value) {
+			return fmt.Errorf("account %s mismatch: expected %x, but got %x", accountHash, value, data)
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("failed to decode account %s: %w", accountHash, err)
+		}
+
+		return walkTrie(account.Root, f.storage, func(key, value []byte) error {
+			slotHash := types.BytesToHash(key)
+
+			expected, err := decodeStorageValue(value)
+			if err != nil {
+				return err
+			}
+
+			val, err := layer.storageData(accountHash, slotHash)
+			if err != nil {
+				return err
+			}
+
+			if !bytes.Equal(val, expected) {
+				return fmt.Errorf("account %s storage slot %s mismatch: expected %x, but got %x",
+					accountHash, slotHash, expected, val)
+			}
+
+			return nil
+		})
+	})
+}
+
+// close stops the generation and flattens the diff layers up to the head root into the disk layer,
+// so the flat state does not need to be generated again on restart
+func (f *flatState) close(headRoot types.Hash) {
+	f.quitOnce.Do(func() { close(f.quit) })
+	f.generationWg.Wait()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if !f.disk.isReady() {
+		return
+	}
+
+	if dl, ok := f.layers[headRoot]; ok {
+		f.capLocked(dl, 0)
+	}
+}
+
+// decodeStorageValue decodes the RLP encoded storage trie value
+func decodeStorageValue(value []byte) ([]byte, error) {
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Type() != fastrlp.TypeBytes {
+		return nil, fmt.Errorf("storage value expected to be bytes")
+	}
+
+	return v.GetBytes(nil)
+}
+
+// walkTrie calls fn for every key and value stored in the trie with the given root
+func walkTrie(root types.Hash, storage Storage, fn func(key, value []byte) error) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return fmt.Errorf("failed to get trie node %s: %w", root, err)
+	}
+
+	if !ok {
+		return fmt.Errorf("state not found at hash %s", root)
+	}
+
+	return walkNode(node, storage, nil, fn)
+}
+
+// walkTrieAfter calls fn for every key greater than the given one (every key if nil)
+// and its value stored in the trie with the given root
+func walkTrieAfter(root types.Hash, storage Storage, after *types.Hash,
+	fn func(key, value []byte) error) error {
+	if after == nil {
+		return walkTrie(root, storage, fn)
+	}
+
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return fmt.Errorf("failed to get trie node %s: %w", root, err)
+	}
+
+	if !ok {
+		return fmt.Errorf("state not found at hash %s", root)
+	}
+
+	nibbles := bytesToHexNibbles(after.Bytes())
+
+	return walkNodeAfter(node, storage, nil, nibbles[:len(nibbles)-1], fn)
+}
+
+// walkNodeAfter walks the node like walkNode, skipping the keys up to the given one (as nibbles)
+func walkNodeAfter(node Node, storage Storage, path, after []byte, fn func(key, value []byte) error) error {
+	prefix := path
+	if hasTerminator(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+
+	size := len(prefix)
+	if len(after) < size {
+		size = len(after)
+	}
+
+	if cmp := bytes.Compare(prefix[:size], after[:size]); cmp < 0 {
+		return nil
+	} else if cmp > 0 {
+		// all the keys of the node are greater
+		return walkNode(node, storage, path, fn)
+	} else if len(prefix) >= len(after) {
+		// the key itself
+		return nil
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("trie node %x not found", n.buf)
+			}
+
+			return walkNodeAfter(nc, storage, path, after, fn)
+		}
+
+		// the key is the prefix of the given one, so it is lower
+		return nil
+
+	case *ShortNode:
+		return walkNodeAfter(n.child, storage, concat(path, n.key), after, fn)
+
+	case *FullNode:
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := walkNodeAfter(child, storage, concat(path, []byte{byte(i)}), after, fn); err != nil {
+				return err
+			}
+		}
+
+		// the value of the full node is the prefix of the given key, so it is lower
+		return nil
+
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+}
+
+func walkNode(node Node, storage Storage, path []byte, fn func(key, value []byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("trie node %x not found", n.buf)
+			}
+
+			return walkNode(nc, storage, path, fn)
+		}
+
+		key, err := hexNibblesToBytes(path)
+		if err != nil {
+			return err
+		}
+
+		return fn(key, n.buf)
+
+	case *ShortNode:
+		return walkNode(n.child, storage, concat(path, n.key), fn)
+
+	case *FullNode:
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := walkNode(child, storage, concat(path, []byte{byte(i)}), fn); err != nil {
+				return err
+			}
+		}
+
+		return walkNode(n.value, storage, path, fn)
+
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+}
+
+// hexNibblesToBytes joins the nibbles (with optional terminator flag) into bytes
+func hexNibblesToBytes(nibbles []byte) ([]byte, error) {
+	if hasTerminator(nibbles) {
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+
+	if len(nibbles)%2 != 0 {
+		return nil, fmt.Errorf("odd number of key nibbles %d", len(nibbles))
+	}
+
+	key := make([]byte, len(nibbles)/2)
+	for i := range key {
+		key[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return key, nil
+}
@@ -0,0 +1,329 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestFlatState_State(t *testing.T) {
+	state.TestState(t, func(pre state.PreStates) state.Snapshot {
+		st := NewState(NewMemoryStorage())
+		st.EnableFlatState(types.EmptyRootHash, hclog.NewNullLogger())
+		waitFlatStateReady(t, st)
+
+		snap, err := st.NewSnapshotAt(types.EmptyRootHash)
+		require.NoError(t, err)
+
+		return snap
+	})
+}
+
+func TestFlatState_GenerateAndVerify(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+	snap, root := commitAccounts(t, st.NewSnapshot(), 50, 1)
+
+	st.EnableFlatState(root, hclog.NewNullLogger())
+	waitFlatStateReady(t, st)
+
+	require.NoError(t, st.VerifyFlatState(root))
+
+	flatSnap, err := st.NewSnapshotAt(root)
+	require.NoError(t, err)
+	require.NotNil(t, flatSnap.(*Snapshot).flat) //nolint:forcetypeassert
+
+	for i := 0; i < 50; i++ {
+		addr := testFlatAddress(i + 1)
+
+		expected, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		account, err := flatSnap.GetAccount(addr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, account)
+
+		assert.Equal(t, types.BytesToHash([]byte{1}),
+			flatSnap.GetStorage(addr, account.Root, types.BytesToHash([]byte{byte(i)})))
+	}
+
+	account, err := flatSnap.GetAccount(types.StringToAddress("0xdead"))
+	require.NoError(t, err)
+	assert.Nil(t, account)
+}
+
+func TestFlatState_CapAndReload(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+	st.EnableFlatState(types.EmptyRootHash, hclog.NewNullLogger())
+	waitFlatStateReady(t, st)
+
+	snap, err := st.NewSnapshotAt(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	var root types.Hash
+
+	for i := 0; i < flatStateDiffLayers+10; i++ {
+		snap, root = commitAccounts(t, snap, 3, byte(i+1))
+	}
+
+	assert.Len(t, st.flat.layers, flatStateDiffLayers)
+	assert.NotEqual(t, types.EmptyRootHash, st.flat.disk.root)
+	require.NoError(t, st.VerifyFlatState(root))
+
+	st.CloseFlatState(root)
+	assert.Equal(t, root, st.flat.disk.root)
+	assert.Len(t, st.flat.layers, 0)
+
+	// the persisted flat state matches the head, so it is loaded without the generation
+	reloaded := NewState(storage)
+	reloaded.EnableFlatState(root, hclog.NewNullLogger())
+
+	assert.True(t, reloaded.flat.disk.isReady())
+	assert.Equal(t, uint64(1), reloaded.flat.disk.generation)
+	require.NoError(t, reloaded.VerifyFlatState(root))
+}
+
+func TestFlatState_CapDuringGeneration(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+
+	// the accounts don't fit into a single generation chunk
+	_, root := commitAccounts(t, st.NewSnapshot(), flatStateGenerationBatch/2+1000, 1)
+
+	cache, _ := lru.New(flatAccountCacheSize)
+
+	// the generation is driven by the test, instead of the background goroutine
+	st.flat = &flatState{
+		storage: storage,
+		logger:  hclog.NewNullLogger(),
+		layers:  map[types.Hash]*diffLayer{},
+		quit:    make(chan struct{}),
+		disk:    newDiskLayer(storage, root, 1, cache, false),
+	}
+
+	done, _, _, err := st.flat.generateChunk()
+	require.NoError(t, err)
+	require.False(t, done)
+	require.NotNil(t, st.flat.generationMarker)
+
+	snap, err := st.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for i := 0; i < flatStateDiffLayers+10; i++ {
+		txn := state.NewTxn(snap)
+
+		// update the generated and the not yet generated accounts, and create the new ones
+		for j := 1; j <= 20; j++ {
+			txn.SetNonce(testFlatAddress(j), uint64(i+2))
+			txn.SetState(testFlatAddress(j), types.BytesToHash([]byte{1}), types.BytesToHash([]byte{byte(i + 2)}))
+		}
+
+		txn.SetBalance(testFlatAddress(100_000+i), big.NewInt(1))
+
+		snap, root = commitTxn(t, snap, txn)
+
+		require.LessOrEqual(t, len(st.flat.layers), flatStateDiffLayers)
+	}
+
+	assert.False(t, st.flat.disk.isReady())
+
+	for !done {
+		done, _, _, err = st.flat.generateChunk()
+		require.NoError(t, err)
+	}
+
+	assert.True(t, st.flat.disk.isReady())
+	require.NoError(t, st.VerifyFlatState(root))
+}
+
+func TestFlatState_GenerationFailureDisables(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	// the trie of the head root is missing
+	root := types.StringToHash("0x1234")
+
+	st.EnableFlatState(root, hclog.NewNullLogger())
+	st.flat.generationWg.Wait()
+
+	require.Error(t, st.flat.generationErr)
+	assert.Nil(t, st.flat.layer(root))
+
+	st.flat.update(root, newDiffLayer(nil, types.StringToHash("0x5678")))
+	assert.Len(t, st.flat.layers, 0)
+
+	assert.Error(t, st.VerifyFlatState(root))
+}
+
+func TestFlatState_SuicideWipesStorage(t *testing.T) {
+	t.Parallel()
+
+	addr := testFlatAddress(1)
+	slot0, slot1, slot2 := types.BytesToHash([]byte{0}), types.BytesToHash([]byte{1}), types.BytesToHash([]byte{2})
+
+	st := NewState(NewMemoryStorage())
+	st.EnableFlatState(types.EmptyRootHash, hclog.NewNullLogger())
+	waitFlatStateReady(t, st)
+
+	snap, err := st.NewSnapshotAt(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	txn := state.NewTxn(snap)
+	txn.SetNonce(addr, 1)
+	txn.SetState(addr, slot0, slot1)
+	txn.SetState(addr, slot1, slot1)
+
+	snap, root := commitTxn(t, snap, txn)
+	st.CloseFlatState(root)
+
+	// destruct the account and recreate it with the single slot
+	txn = state.NewTxn(snap)
+	txn.Suicide(addr)
+	require.NoError(t, txn.CleanDeleteObjects(true))
+	txn.CreateAccount(addr)
+	txn.SetNonce(addr, 1)
+	txn.SetState(addr, slot1, slot2)
+
+	snap, root = commitTxn(t, snap, txn)
+
+	assertSlots := func(snap state.Snapshot) {
+		t.Helper()
+
+		txn := state.NewTxn(snap)
+		assert.Equal(t, types.Hash{}, txn.GetState(addr, slot0))
+		assert.Equal(t, slot2, txn.GetState(addr, slot1))
+	}
+
+	// served by the diff layer
+	assertSlots(snap)
+
+	// served by the disk layer
+	st.CloseFlatState(root)
+
+	snap, err = st.NewSnapshotAt(root)
+	require.NoError(t, err)
+	require.Equal(t, flatLayer(st.flat.disk), snap.(*Snapshot).flat) //nolint:forcetypeassert
+
+	assertSlots(snap)
+	require.NoError(t, st.VerifyFlatState(root))
+
+	entry, err := st.flat.disk.entry(types.BytesToHash(hashit(addr.Bytes())))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), entry.incarnation)
+}
+
+func TestFlatState_RegenerateInconsistent(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+	st.EnableFlatState(types.EmptyRootHash, hclog.NewNullLogger())
+	waitFlatStateReady(t, st)
+
+	snap, err := st.NewSnapshotAt(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	snap, _ = commitAccounts(t, snap, 10, 1)
+	_, root := commitAccounts(t, snap, 10, 2)
+
+	st.CloseFlatState(root)
+
+	// the head moved without the flat state (e.g. node crashed before the flat state was persisted)
+	snap, err = st.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	snap.(*Snapshot).flat = nil //nolint:forcetypeassert
+	_, newRoot := commitAccounts(t, snap, 10, 3)
+
+	reloaded := NewState(storage)
+	reloaded.EnableFlatState(newRoot, hclog.NewNullLogger())
+	waitFlatStateReady(t, reloaded)
+
+	assert.Equal(t, uint64(2), reloaded.flat.disk.generation)
+	require.NoError(t, reloaded.VerifyFlatState(newRoot))
+
+	// previous generation is pruned
+	_, ok := storage.Get(flatAccountKey(1, types.BytesToHash(hashit(testFlatAddress(1).Bytes()))))
+	assert.False(t, ok)
+}
+
+func TestFlatState_StaleLayerFallback(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+	st.EnableFlatState(types.EmptyRootHash, hclog.NewNullLogger())
+	waitFlatStateReady(t, st)
+
+	snap, err := st.NewSnapshotAt(types.EmptyRootHash)
+	require.NoError(t, err)
+
+	oldSnap, oldRoot := commitAccounts(t, snap, 5, 1)
+	_, root := commitAccounts(t, oldSnap, 5, 2)
+
+	st.CloseFlatState(root)
+
+	// the diff layer of the old snapshot is flattened and stale, so the reads fall back to the trie
+	_, err = oldSnap.(*Snapshot).flat.accountData(types.Hash{}) //nolint:forcetypeassert
+	require.ErrorIs(t, err, errFlatStateStale)
+
+	addr := testFlatAddress(1)
+
+	account, err := oldSnap.GetAccount(addr)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), account.Nonce)
+	assert.Equal(t, types.BytesToHash([]byte{1}), oldSnap.GetStorage(addr, account.Root, types.BytesToHash([]byte{0})))
+
+	assert.Error(t, st.VerifyFlatState(oldRoot))
+}
+
+// commitAccounts sets the nonce and a storage slot of the given number of accounts and commits them
+func commitAccounts(t *testing.T, snap state.Snapshot, accounts int, nonce byte) (state.Snapshot, types.Hash) {
+	t.Helper()
+
+	txn := state.NewTxn(snap)
+
+	for i := 0; i < accounts; i++ {
+		addr := testFlatAddress(i + 1)
+
+		txn.SetNonce(addr, uint64(nonce))
+		txn.SetState(addr, types.BytesToHash([]byte{byte(i)}), types.BytesToHash([]byte{nonce}))
+	}
+
+	return commitTxn(t, snap, txn)
+}
+
+func commitTxn(t *testing.T, snap state.Snapshot, txn *state.Txn) (state.Snapshot, types.Hash) {
+	t.Helper()
+
+	objs, err := txn.Commit(false)
+	require.NoError(t, err)
+
+	snap, root := snap.Commit(objs)
+
+	return snap, types.BytesToHash(root)
+}
+
+func testFlatAddress(i int) types.Address {
+	return types.BytesToAddress(big.NewInt(int64(i)).Bytes())
+}
+
+func waitFlatStateReady(t *testing.T, st *State) {
+	t.Helper()
+
+	st.flat.generationWg.Wait()
+	require.True(t, st.flat.disk.isReady())
+}
@@ -0,0 +1,53 @@
+package itrie
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// nodeCacheSize is the number of the recently read trie nodes kept in memory
+const nodeCacheSize = 100_000
+
+// nodeCacheStorage serves the trie node reads from the cache of the recently read nodes,
+// which is warmed up by the prefetch snapshots. The nodes are stored by their hash, so the cached ones never go stale
+type nodeCacheStorage struct {
+	Storage
+
+	nodes *lru.Cache
+}
+
+func newNodeCacheStorage(storage Storage) *nodeCacheStorage {
+	nodes, _ := lru.New(nodeCacheSize)
+
+	return &nodeCacheStorage{
+		Storage: storage,
+		nodes:   nodes,
+	}
+}
+
+func (s *nodeCacheStorage) Get(k []byte) ([]byte, bool) {
+	if data, ok := s.nodes.Get(string(k)); ok {
+		return data.([]byte), true //nolint:forcetypeassert
+	}
+
+	data, ok := s.Storage.Get(k)
+	if ok {
+		s.nodes.Add(string(k), data)
+	}
+
+	return data, ok
+}
+
+// NewPrefetchSnapshotAt returns the snapshot at the given root which warms up the trie node cache.
+// The trie nodes resolved by the lookups are attached to the trie, so the prefetch snapshot does not share
+// the tries with the other snapshots, nor it uses the flat state, so all its reads walk the trie
+func (s *State) NewPrefetchSnapshotAt(root types.Hash) (state.Snapshot, error) {
+	t, err := s.loadTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{state: s, trie: t, prefetch: true}, nil
+}
@@ -0,0 +1,71 @@
+package itrie
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// countingStorage counts the reads of the underlying storage
+type countingStorage struct {
+	Storage
+
+	reads atomic.Int64
+}
+
+func (s *countingStorage) Get(k []byte) ([]byte, bool) {
+	s.reads.Add(1)
+
+	return s.Storage.Get(k)
+}
+
+func TestPrefetch_WarmsNodeCache(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	expected, root := commitAccounts(t, NewState(storage).NewSnapshot(), 20, 1)
+
+	counting := &countingStorage{Storage: storage}
+	st := NewState(counting)
+
+	prefetchSnap, err := st.NewPrefetchSnapshotAt(root)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		addr := testFlatAddress(i + 1)
+
+		account, err := prefetchSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		prefetchSnap.GetStorage(addr, account.Root, types.BytesToHash([]byte{byte(i)}))
+	}
+
+	reads := counting.reads.Load()
+	require.NotZero(t, reads)
+
+	// the prefetched trie is not shared with the regular snapshots
+	snap, err := st.NewSnapshotAt(root)
+	require.NoError(t, err)
+	require.NotSame(t, prefetchSnap.(*Snapshot).trie, snap.(*Snapshot).trie) //nolint:forcetypeassert
+
+	for i := 0; i < 20; i++ {
+		addr := testFlatAddress(i + 1)
+
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		expectedAccount, err := expected.GetAccount(addr)
+		require.NoError(t, err)
+		assert.Equal(t, expectedAccount, account)
+
+		assert.Equal(t, types.BytesToHash([]byte{1}),
+			snap.GetStorage(addr, account.Root, types.BytesToHash([]byte{byte(i)})))
+	}
+
+	// all the trie nodes are served by the cache
+	assert.Equal(t, reads, counting.reads.Load())
+}
@@ -12,6 +12,12 @@ import (
 type Snapshot struct {
 	state *State
 	trie  *Trie
+
+	// flat is the flat state layer of the snapshot root, nil if there is none
+	flat flatLayer
+
+	// prefetch is set for the snapshots which warm up the trie node cache (see NewPrefetchSnapshotAt)
+	prefetch bool
 }
 
 var emptyStateHash = types.StringToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
@@ -22,8 +28,17 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 		trie *Trie
 	)
 
+	if val, ok := s.getFlatStorage(addr, root, rawkey); ok {
+		return val
+	}
+
 	if root == emptyStateHash {
 		trie = s.state.newTrie()
+	} else if s.prefetch {
+		trie, err = s.state.loadTrie(root)
+		if err != nil {
+			return types.Hash{}
+		}
 	} else {
 		trie, err = s.state.newTrieAt(root)
 		if err != nil {
@@ -33,7 +48,7 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 
 	key := crypto.Keccak256(rawkey.Bytes())
 
-	val, ok := trie.Get(key, s.state.storage)
+	val, ok := trie.Get(key, s.state.trieStorage)
 	if !ok {
 		return types.Hash{}
 	}
@@ -53,10 +68,52 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 	return types.BytesToHash(res)
 }
 
+// getFlatStorage reads the storage slot from the flat state. It returns false if the flat state
+// can not serve the read (e.g. it is being generated or the account root does not match)
+func (s *Snapshot) getFlatStorage(addr types.Address, root types.Hash, rawkey types.Hash) (types.Hash, bool) {
+	if s.flat == nil {
+		return types.Hash{}, false
+	}
+
+	accountHash := types.BytesToHash(crypto.Keccak256(addr.Bytes()))
+
+	data, err := s.flat.accountData(accountHash)
+	if err != nil || len(data) == 0 {
+		return types.Hash{}, false
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil || account.Root != root {
+		return types.Hash{}, false
+	}
+
+	val, err := s.flat.storageData(accountHash, types.BytesToHash(crypto.Keccak256(rawkey.Bytes())))
+	if err != nil {
+		return types.Hash{}, false
+	}
+
+	return types.BytesToHash(val), true
+}
+
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	key := crypto.Keccak256(addr.Bytes())
 
-	data, ok := s.trie.Get(key, s.state.storage)
+	var (
+		data []byte
+		ok   bool
+		err  error = errFlatStateNotReady
+	)
+
+	if s.flat != nil {
+		data, err = s.flat.accountData(types.BytesToHash(key))
+		ok = len(data) > 0
+	}
+
+	if err != nil {
+		// flat state can not serve the read, fall back to the trie
+		data, ok = s.trie.Get(key, s.state.trieStorage)
+	}
+
 	if !ok {
 		return nil, nil
 	}
@@ -76,15 +133,31 @@ func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 	batch := s.state.storage.Batch()
 
-	tt := s.trie.Txn(s.state.storage)
+	tt := s.trie.Txn(s.state.trieStorage)
 	tt.batch = batch
 
 	arena := stateArenaPool.Get()
 	defer stateArenaPool.Put(arena)
 
+	var diff *diffLayer
+	if s.flat != nil {
+		diff = newDiffLayer(nil, types.Hash{})
+	}
+
 	for _, obj := range objs {
+		accountHash := types.BytesToHash(hashit(obj.Address.Bytes()))
+
+		if diff != nil && (obj.Deleted || obj.Root == types.EmptyRootHash) {
+			// the account storage is wiped (either deleted or recreated)
+			diff.destruct(accountHash)
+		}
+
 		if obj.Deleted {
-			tt.Delete(hashit(obj.Address.Bytes()))
+			tt.Delete(accountHash.Bytes())
+
+			if diff != nil {
+				diff.setAccount(accountHash, nil)
+			}
 		} else {
 			account := state.Account{
 				Balance:  obj.Balance,
@@ -99,16 +172,25 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 					panic(err) //nolint:gocritic
 				}
 
-				localTxn := trie.Txn(s.state.storage)
+				localTxn := trie.Txn(s.state.trieStorage)
 				localTxn.batch = batch
 
 				for _, entry := range obj.Storage {
 					k := hashit(entry.Key)
 					if entry.Deleted {
 						localTxn.Delete(k)
+
+						if diff != nil {
+							diff.setStorage(accountHash, types.BytesToHash(k), nil)
+						}
 					} else {
-						vv := arena.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
+						val := bytes.TrimLeft(entry.Val, "\x00")
+						vv := arena.NewBytes(val)
 						localTxn.Insert(k, vv.MarshalTo(nil))
+
+						if diff != nil {
+							diff.setStorage(accountHash, types.BytesToHash(k), append([]byte{}, val...))
+						}
 					}
 				}
 
@@ -128,8 +210,12 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			tt.Insert(accountHash.Bytes(), data)
 			arena.Reset()
+
+			if diff != nil {
+				diff.setAccount(accountHash, data)
+			}
 		}
 	}
 
@@ -142,5 +228,13 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 
 	s.state.AddState(types.BytesToHash(root), nTrie)
 
-	return &Snapshot{trie: nTrie, state: s.state}, root
+	snap := &Snapshot{trie: nTrie, state: s.state}
+
+	if diff != nil {
+		diff.root = types.BytesToHash(root)
+		s.state.flat.update(s.flat.stateRoot(), diff)
+		snap.flat = s.state.flat.layer(diff.root)
+	}
+
+	return snap, root
 }
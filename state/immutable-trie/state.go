@@ -3,6 +3,7 @@ package itrie
 import (
 	"fmt"
 
+	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/0xPolygon/polygon-edge/state"
@@ -12,14 +13,21 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// trieStorage serves the trie nodes, caching the recently read ones
+	trieStorage *nodeCacheStorage
+
+	// flat is the flat state used to serve the account and storage reads, nil if disabled
+	flat *flatState
 }
 
 func NewState(storage Storage) *State {
 	cache, _ := lru.New(128)
 
 	s := &State{
-		storage: storage,
-		cache:   cache,
+		storage:     storage,
+		cache:       cache,
+		trieStorage: newNodeCacheStorage(storage),
 	}
 
 	return s
@@ -35,7 +43,36 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 		return nil, err
 	}
 
-	return &Snapshot{state: s, trie: t}, nil
+	snap := &Snapshot{state: s, trie: t}
+
+	if s.flat != nil {
+		snap.flat = s.flat.layer(root)
+	}
+
+	return snap, nil
+}
+
+// EnableFlatState enables the flat state reads on top of the given head root.
+// If the persisted flat state does not match the head root, it is regenerated in the background
+// and the reads are served by the trie in the meantime
+func (s *State) EnableFlatState(headRoot types.Hash, logger hclog.Logger) {
+	s.flat = newFlatState(s.storage, headRoot, logger.Named("flat_state"))
+}
+
+// VerifyFlatState checks the flat state at the given root against the trie
+func (s *State) VerifyFlatState(root types.Hash) error {
+	if s.flat == nil {
+		return fmt.Errorf("flat state is not enabled")
+	}
+
+	return s.flat.verify(root)
+}
+
+// CloseFlatState stops the flat state generation and persists the diff layers up to the head root
+func (s *State) CloseFlatState(headRoot types.Hash) {
+	if s.flat != nil {
+		s.flat.close(headRoot)
+	}
 }
 
 func (s *State) newTrie() *Trie {
@@ -71,7 +108,16 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 		return t, nil
 	}
 
-	n, ok, err := GetNode(root.Bytes(), s.storage)
+	return s.loadTrie(root)
+}
+
+// loadTrie loads the trie with the given root from the storage, bypassing the cache of the tries
+func (s *State) loadTrie(root types.Hash) (*Trie, error) {
+	if root == types.EmptyRootHash {
+		return s.newTrie(), nil
+	}
+
+	n, ok, err := GetNode(root.Bytes(), s.trieStorage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get storage root %s: %w", root, err)
 	}
@@ -16,3 +16,9 @@ func buildPreState(pre state.PreStates) state.Snapshot {
 
 	return st.NewSnapshot()
 }
+
+func TestParallelExecution(t *testing.T) {
+	state.TestParallelExecution(t, func() state.State {
+		return NewState(NewMemoryStorage())
+	})
+}
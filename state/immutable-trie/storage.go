@@ -2,12 +2,14 @@ package itrie
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -82,6 +84,32 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+// DeletePrefix deletes all the keys with the given prefix
+func (kv *KVStorage) DeletePrefix(prefix []byte) error {
+	iter := kv.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	batch := &leveldb.Batch{}
+
+	for iter.Next() {
+		batch.Delete(iter.Key())
+
+		if batch.Len() >= 10_000 {
+			if err := kv.db.Write(batch, nil); err != nil {
+				return err
+			}
+
+			batch.Reset()
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -149,7 +177,23 @@ func (m *memStorage) GetCode(hash types.Hash) ([]byte, bool) {
 }
 
 func (m *memStorage) Batch() Batch {
-	return &memBatch{db: &m.db, l: new(sync.Mutex)}
+	return &memBatch{db: &m.db, l: m.l}
+}
+
+// DeletePrefix deletes all the keys with the given prefix
+func (m *memStorage) DeletePrefix(prefix []byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	encodedPrefix := hex.EncodeToHex(prefix)
+
+	for k := range m.db {
+		if strings.HasPrefix(k, encodedPrefix) {
+			delete(m.db, k)
+		}
+	}
+
+	return nil
 }
 
 func (m *memStorage) Close() error {
@@ -24,7 +24,7 @@ type LondonFixForkV1 struct{}
 // Basically, makes sure gas tip cap and gas fee cap are good for dynamic and legacy transactions
 // and that GasFeeCap/GasPrice cap is not lower than base fee when London fork is active.
 func (l *LondonFixForkV1) checkDynamicFees(msg *types.Transaction, t *Transition) error {
-	if msg.Type != types.DynamicFeeTx {
+	if !msg.IsDynamicFee() {
 		return nil
 	}
 
@@ -74,7 +74,7 @@ func (l *LondonFixForkV1) getUpfrontGasCost(msg *types.Transaction, baseFee *big
 
 func (l *LondonFixForkV1) getEffectiveTip(msg *types.Transaction, gasPrice *big.Int,
 	baseFee *big.Int, isLondonForkEnabled bool) *big.Int {
-	if isLondonForkEnabled && msg.Type == types.DynamicFeeTx {
+	if isLondonForkEnabled && msg.IsDynamicFee() {
 		return common.BigMin(
 			new(big.Int).Sub(msg.GasFeeCap, baseFee),
 			new(big.Int).Set(msg.GasTipCap),
@@ -91,7 +91,7 @@ func (l *LondonFixForkV2) checkDynamicFees(msg *types.Transaction, t *Transition
 		return nil
 	}
 
-	if msg.Type == types.DynamicFeeTx {
+	if msg.IsDynamicFee() {
 		if msg.GasFeeCap.BitLen() == 0 && msg.GasTipCap.BitLen() == 0 {
 			return nil
 		}
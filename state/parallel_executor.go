@@ -0,0 +1,452 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	goruntime "runtime"
+	"sync"
+
+	iradix "github.com/hashicorp/go-immutable-radix"
+
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ParallelExecutionWorkers returns the number of the workers executing the transactions in parallel
+func ParallelExecutionWorkers() int {
+	return goruntime.NumCPU()
+}
+
+// txFees are the fees of the speculatively executed transaction, credited when the transaction is committed
+type txFees struct {
+	coinbase *big.Int
+	burn     *big.Int
+}
+
+// speculativeResult is the result of the transaction executed on top of the state
+// at the beginning of the parallel execution
+type speculativeResult struct {
+	msg    *types.Transaction
+	txn    *Txn
+	result *runtime.ExecutionResult
+	fees   *txFees
+	err    error
+}
+
+// WriteParallel writes the transactions to the executor with the same outcome
+// (state, receipts and errors) as if they were written one by one with Write.
+//
+// The transactions are executed speculatively in parallel on top of the current state, tracking
+// the accounts and storage slots each of them reads and writes. The speculative results are then committed
+// in the transactions order, as long as the values the transaction read are not changed
+// by the previous transactions. Otherwise (or if the speculative execution failed)
+// the transaction is executed again on top of the committed state.
+// It returns the error of each transaction (nil if the transaction is written)
+func (t *Transition) WriteParallel(txs []*types.Transaction, workers int) []error {
+	errs := make([]error, len(txs))
+
+	// tracers and post hooks observe the execution of each transaction in order
+	if workers < 2 || len(txs) < 2 || t.ctx.Tracer != nil || t.PostHook != nil {
+		for i, tx := range txs {
+			errs[i] = t.Write(tx)
+		}
+
+		return errs
+	}
+
+	results := t.speculate(txs, workers)
+	reexecuted := 0
+
+	for i, tx := range txs {
+		committed, err := t.commitSpeculative(tx, results[i])
+		if err != nil {
+			errs[i] = err
+
+			continue
+		}
+
+		if !committed {
+			reexecuted++
+			errs[i] = t.Write(tx)
+		}
+	}
+
+	if t.logger != nil {
+		t.logger.Debug("parallel execution finished", "txs", len(txs), "reexecuted", reexecuted)
+	}
+
+	return errs
+}
+
+// speculate executes the transactions in parallel on top of the current state
+func (t *Transition) speculate(txs []*types.Transaction, workers int) []*speculativeResult {
+	var (
+		results = make([]*speculativeResult, len(txs))
+		jobs    = make(chan int)
+		wg      sync.WaitGroup
+		reader  = &speculativeReader{
+			base:     t.state.txn.CommitOnly(),
+			snapshot: t.state.snapshot,
+		}
+	)
+
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results[i] = t.speculateTx(txs[i], reader)
+			}
+		}()
+	}
+
+	for i := range txs {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// speculateTx executes the single transaction on top of the state at the beginning of the parallel execution
+func (t *Transition) speculateTx(tx *types.Transaction, reader *speculativeReader) (res *speculativeResult) {
+	res = &speculativeResult{}
+
+	defer func() {
+		// the transaction executed on top of the outdated state is executed again, so it must not crash the node
+		if r := recover(); r != nil {
+			res.err = fmt.Errorf("speculative execution panicked: %v", r)
+		}
+	}()
+
+	if res.err = t.recoverSender(tx); res.err != nil {
+		return res
+	}
+
+	st := t.newSpeculativeTransition(reader)
+
+	res.msg = tx.Copy()
+	res.txn = st.state
+	res.result, res.err = st.Apply(res.msg)
+	res.fees = st.fees
+
+	return res
+}
+
+// newSpeculativeTransition creates the transition which executes the transaction
+// on top of the state provided by the reader
+func (t *Transition) newSpeculativeTransition(reader *speculativeReader) *Transition {
+	txn := newTxn(reader)
+	txn.spec = newSpeculation(reader)
+
+	st := &Transition{
+		logger:      t.logger,
+		auxState:    t.auxState,
+		snap:        t.snap,
+		config:      t.config,
+		state:       txn,
+		getHash:     t.getHash,
+		ctx:         t.ctx,
+		gasPool:     uint64(t.ctx.GasLimit),
+		evm:         evm.NewEVM(),
+		precompiles: precompiled.NewPrecompiled(),
+		speculative: true,
+	}
+
+	if t.deploymentAllowList != nil {
+		st.deploymentAllowList = addresslist.NewAddressList(st, contracts.AllowListContractsAddr)
+	}
+
+	if t.deploymentBlockList != nil {
+		st.deploymentBlockList = addresslist.NewAddressList(st, contracts.BlockListContractsAddr)
+	}
+
+	if t.txnAllowList != nil {
+		st.txnAllowList = addresslist.NewAddressList(st, contracts.AllowListTransactionsAddr)
+	}
+
+	if t.txnBlockList != nil {
+		st.txnBlockList = addresslist.NewAddressList(st, contracts.BlockListTransactionsAddr)
+	}
+
+	if t.bridgeAllowList != nil {
+		st.bridgeAllowList = addresslist.NewAddressList(st, contracts.AllowListBridgeAddr)
+	}
+
+	if t.bridgeBlockList != nil {
+		st.bridgeBlockList = addresslist.NewAddressList(st, contracts.BlockListBridgeAddr)
+	}
+
+	if t.callPolicy != nil {
+		st.callPolicy = addresslist.NewCallPolicy(st, contracts.CallPolicyAddr)
+	}
+
+	if t.sponsorAllowList != nil {
+		st.sponsorAllowList = addresslist.NewAddressList(st, contracts.AllowListSponsorsAddr)
+	}
+
+	return st
+}
+
+// commitSpeculative writes the speculative result to the state, if it is still valid.
+// It returns false if the transaction has to be executed again
+func (t *Transition) commitSpeculative(tx *types.Transaction, res *speculativeResult) (bool, error) {
+	if res.err != nil {
+		return false, nil
+	}
+
+	// let the sequential execution report the gas limit error
+	if t.gasPool < res.msg.Gas {
+		return false, nil
+	}
+
+	if !res.txn.spec.validate(t.state) {
+		return false, nil
+	}
+
+	t.gasPool -= res.msg.Gas
+	t.gasPool += res.result.GasLeft
+
+	res.txn.spec.apply(res.txn, t.state)
+	t.creditFees(res.fees.coinbase, res.fees.burn)
+
+	if err := t.writeReceipt(tx, res.msg, res.result, res.txn.Logs()); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// speculativeReader provides the state at the beginning of the parallel execution
+// to the concurrently executed transactions
+type speculativeReader struct {
+	// base holds the state objects written by the previous transactions of the block
+	base     *iradix.Tree
+	snapshot readSnapshot
+
+	// lock serializes the reads, as neither copying the state objects
+	// nor reading the snapshot is safe for concurrent use
+	lock sync.Mutex
+}
+
+// baseStateObject returns the copy of the state object written by the previous transactions of the block.
+// It returns false if the object is not written (so it has to be read from the snapshot)
+// and nil object if the account is deleted
+func (r *speculativeReader) baseStateObject(addr types.Address) (*StateObject, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	val, ok := r.base.Get(addr.Bytes())
+	if !ok {
+		return nil, false
+	}
+
+	obj := val.(*StateObject) //nolint:forcetypeassert
+	if obj.Deleted {
+		return nil, true
+	}
+
+	return obj.Copy(), true
+}
+
+func (r *speculativeReader) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.snapshot.GetStorage(addr, root, key)
+}
+
+func (r *speculativeReader) GetAccount(addr types.Address) (*Account, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.snapshot.GetAccount(addr)
+}
+
+func (r *speculativeReader) GetCode(hash types.Hash) ([]byte, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.snapshot.GetCode(hash)
+}
+
+// accountRead is the account as it was read by the speculatively executed transaction
+type accountRead struct {
+	exists   bool
+	nonce    uint64
+	balance  *big.Int
+	root     types.Hash
+	codeHash []byte
+	suicide  bool
+}
+
+func (a *accountRead) matches(obj *StateObject, exists bool) bool {
+	if a.exists != exists {
+		return false
+	}
+
+	if !exists {
+		return true
+	}
+
+	return a.nonce == obj.Account.Nonce &&
+		a.balance.Cmp(obj.Account.Balance) == 0 &&
+		a.root == obj.Account.Root &&
+		bytes.Equal(a.codeHash, obj.Account.CodeHash) &&
+		a.suicide == obj.Suicide
+}
+
+// speculation tracks the state accesses of the speculatively executed transaction.
+// Only the first read of each account or storage slot is tracked, as the following reads
+// return either the same value or the value written by the transaction itself
+type speculation struct {
+	*speculativeReader
+
+	accountReads  map[types.Address]*accountRead
+	storageReads  map[types.Address]map[types.Hash]types.Hash
+	accountWrites map[types.Address]struct{}
+	storageWrites map[types.Address]map[types.Hash]struct{}
+
+	// created holds the accounts (re)created by the transaction, which discards their previous storage
+	created map[types.Address]struct{}
+}
+
+func newSpeculation(reader *speculativeReader) *speculation {
+	return &speculation{
+		speculativeReader: reader,
+		accountReads:      map[types.Address]*accountRead{},
+		storageReads:      map[types.Address]map[types.Hash]types.Hash{},
+		accountWrites:     map[types.Address]struct{}{},
+		storageWrites:     map[types.Address]map[types.Hash]struct{}{},
+		created:           map[types.Address]struct{}{},
+	}
+}
+
+func (s *speculation) readAccount(addr types.Address, obj *StateObject, exists bool) {
+	if _, ok := s.accountReads[addr]; ok {
+		return
+	}
+
+	read := &accountRead{exists: exists}
+
+	if exists {
+		read.nonce = obj.Account.Nonce
+		read.balance = new(big.Int).Set(obj.Account.Balance)
+		read.root = obj.Account.Root
+		read.codeHash = obj.Account.CodeHash
+		read.suicide = obj.Suicide
+	}
+
+	s.accountReads[addr] = read
+}
+
+func (s *speculation) readStorage(addr types.Address, key types.Hash, val types.Hash) {
+	if _, ok := s.created[addr]; ok {
+		return
+	}
+
+	if _, ok := s.storageWrites[addr][key]; ok {
+		return
+	}
+
+	slots, ok := s.storageReads[addr]
+	if !ok {
+		slots = map[types.Hash]types.Hash{}
+		s.storageReads[addr] = slots
+	}
+
+	if _, ok := slots[key]; !ok {
+		slots[key] = val
+	}
+}
+
+func (s *speculation) writeAccount(addr types.Address) {
+	s.accountWrites[addr] = struct{}{}
+}
+
+func (s *speculation) writeStorage(addr types.Address, key types.Hash) {
+	slots, ok := s.storageWrites[addr]
+	if !ok {
+		slots = map[types.Hash]struct{}{}
+		s.storageWrites[addr] = slots
+	}
+
+	slots[key] = struct{}{}
+}
+
+func (s *speculation) createAccount(addr types.Address) {
+	s.accountWrites[addr] = struct{}{}
+	s.created[addr] = struct{}{}
+}
+
+// validate returns true if the accounts and storage slots read by the transaction
+// have the same values in the given state
+func (s *speculation) validate(state *Txn) bool {
+	for addr, read := range s.accountReads {
+		obj, exists := state.getStateObject(addr)
+		if !read.matches(obj, exists) {
+			return false
+		}
+	}
+
+	for addr, slots := range s.storageReads {
+		for key, val := range slots {
+			if state.GetState(addr, key) != val {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// apply writes the accounts and storage slots written by the transaction to the given state.
+// The written accounts were read by the transaction (and validated), so the account fields are
+// taken as they are, while the written storage slots are merged into the current storage of the account
+func (s *speculation) apply(spec *Txn, state *Txn) {
+	for addr := range s.accountWrites {
+		val, ok := spec.txn.Get(addr.Bytes())
+		if !ok {
+			continue
+		}
+
+		specObj := val.(*StateObject) //nolint:forcetypeassert
+		obj := specObj.Copy()
+
+		if _, created := s.created[addr]; !created {
+			if current, exists := state.getStateObject(addr); exists {
+				obj.Txn = current.Txn
+
+				for key := range s.storageWrites[addr] {
+					if specObj.Txn == nil {
+						break
+					}
+
+					if obj.Txn == nil {
+						obj.Txn = iradix.New().Txn()
+					}
+
+					// the slot is missing if its write is reverted
+					if slot, ok := specObj.Txn.Get(key.Bytes()); ok {
+						obj.Txn.Insert(key.Bytes(), slot)
+					}
+				}
+			}
+		}
+
+		state.txn.Insert(addr.Bytes(), obj)
+	}
+}
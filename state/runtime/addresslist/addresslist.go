@@ -152,6 +152,7 @@ var (
 	NoRole      Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000000"))
 	EnabledRole Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000001"))
 	AdminRole   Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000002"))
+	ManagerRole Role = Role(types.StringToHash("0x0000000000000000000000000000000000000000000000000000000000000003"))
 )
 
 func (r Role) Uint64() uint64 {
@@ -160,6 +161,8 @@ func (r Role) Uint64() uint64 {
 		return 1
 	case AdminRole:
 		return 2
+	case ManagerRole:
+		return 3
 	default:
 		return 0
 	}
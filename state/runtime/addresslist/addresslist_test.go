@@ -158,6 +158,7 @@ func TestRole_ToUint(t *testing.T) {
 		role Role
 		num  uint64
 	}{
+		{ManagerRole, uint64(3)},
 		{AdminRole, uint64(2)},
 		{EnabledRole, uint64(1)},
 		{NoRole, uint64(0)},
@@ -175,6 +176,7 @@ func TestRole_Enabled(t *testing.T) {
 	}{
 		{AdminRole, true},
 		{EnabledRole, true},
+		{ManagerRole, false},
 		{NoRole, false},
 	}
 
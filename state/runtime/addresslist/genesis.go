@@ -24,6 +24,32 @@ func ApplyGenesisAllocs(chain *chain.Genesis, addressListAddr types.Address, con
 	}
 }
 
+func ApplyCallPolicyGenesisAllocs(chain *chain.Genesis, policyAddr types.Address, config *chain.CallPolicyConfig) {
+	policy := NewCallPolicy(&genesisState{chain}, policyAddr)
+
+	// manager addr
+	for _, addr := range config.ManagerAddresses {
+		policy.SetRole(addr, ManagerRole)
+	}
+
+	// admin addr
+	for _, addr := range config.AdminAddresses {
+		policy.SetRole(addr, AdminRole)
+	}
+
+	for _, r := range config.Restrictions {
+		policy.SetRestricted(r.Target, r.Selector, true)
+	}
+
+	for _, perm := range config.Permissions {
+		policy.SetPermission(perm.Caller, perm.Target, perm.Selector, Permission{
+			Granted:    true,
+			ValidFrom:  perm.ValidFrom,
+			ValidUntil: perm.ValidUntil,
+		})
+	}
+}
+
 type genesisState struct {
 	chain *chain.Genesis
 }
@@ -47,7 +73,7 @@ func (g *genesisState) SetState(addr types.Address, key, value types.Hash) {
 }
 
 func (g *genesisState) GetStorage(addr types.Address, key types.Hash) types.Hash {
-	// since `genesisState` is used only as part of `ApplyGenesisAllocs` to set the initial
-	// roles in the contract. It never calls this `GetStorage` function.
+	// since `genesisState` is used only as part of `ApplyGenesisAllocs` and `ApplyCallPolicyGenesisAllocs`
+	// to set the initial roles in the contract. It never calls this `GetStorage` function.
 	return types.Hash{}
 }
@@ -43,3 +43,38 @@ func TestGenesis(t *testing.T) {
 
 	require.Equal(t, expect, gen.Alloc[types.Address{}])
 }
+
+func TestGenesis_CallPolicy(t *testing.T) {
+	admin := types.Address{0x1}
+	manager := types.Address{0x2}
+	caller := types.Address{0x3}
+	target := types.Address{0x4}
+	selector := chain.FunctionSelector{0x1, 0x2, 0x3, 0x4}
+
+	gen := &chain.Genesis{
+		Alloc: map[types.Address]*chain.GenesisAccount{},
+	}
+
+	config := &chain.CallPolicyConfig{
+		AdminAddresses:   []types.Address{admin},
+		ManagerAddresses: []types.Address{manager},
+		Restrictions: []*chain.CallRestrictionConfig{
+			{Target: target, Selector: selector},
+		},
+		Permissions: []*chain.CallPermissionConfig{
+			{Caller: caller, Target: target, Selector: selector, ValidFrom: 10, ValidUntil: 20},
+		},
+	}
+
+	ApplyCallPolicyGenesisAllocs(gen, types.Address{}, config)
+
+	// read the genesis storage back through the call policy
+	state := &mockState{state: gen.Alloc[types.Address{}].Storage}
+	policy := NewCallPolicy(state, types.Address{})
+
+	require.Equal(t, AdminRole, policy.GetRole(admin))
+	require.Equal(t, ManagerRole, policy.GetRole(manager))
+	require.True(t, policy.IsRestricted(target, selector))
+	require.Equal(t, Permission{Granted: true, ValidFrom: 10, ValidUntil: 20},
+		policy.GetPermission(caller, target, selector))
+}
@@ -0,0 +1,421 @@
+package addresslist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/abi"
+)
+
+// list of function methods for the call policy functionality, on top of the
+// setAdmin, setNone and readAddressList methods of the address list
+var (
+	SetManagerFunc       = abi.MustNewMethod("function setManager(address)")
+	SetRestrictedFunc    = abi.MustNewMethod("function setRestricted(address,bytes4,bool)")
+	IsRestrictedFunc     = abi.MustNewMethod("function isRestricted(address,bytes4) returns (bool)")
+	GrantPermissionFunc  = abi.MustNewMethod("function grantPermission(address,address,bytes4,uint64,uint64)")
+	RevokePermissionFunc = abi.MustNewMethod("function revokePermission(address,address,bytes4)")
+	ReadPermissionFunc   = abi.MustNewMethod(
+		"function readPermission(address,address,bytes4) returns (bool,uint64,uint64)")
+	CanCallFunc = abi.MustNewMethod("function canCall(address,address,bytes4) returns (bool)")
+)
+
+// list of events emitted by the call policy
+var (
+	RoleChangedEvent = abi.MustNewEvent(
+		"event RoleChanged(address indexed account, uint256 role, address indexed sender)")
+	RestrictionChangedEvent = abi.MustNewEvent(
+		"event RestrictionChanged(address indexed target, bytes4 indexed selector, bool restricted)")
+	PermissionGrantedEvent = abi.MustNewEvent("event PermissionGranted(address indexed caller, " +
+		"address indexed target, bytes4 indexed selector, uint64 validFrom, uint64 validUntil)")
+	PermissionRevokedEvent = abi.MustNewEvent(
+		"event PermissionRevoked(address indexed caller, address indexed target, bytes4 indexed selector)")
+)
+
+var (
+	errWrongInputSize  = fmt.Errorf("wrong input size")
+	errInvalidValidity = fmt.Errorf("permission valid until is before valid from")
+)
+
+// AnySelector is the selector which restricts and permits all the calls to the target contract,
+// including the ones with the input too short to hold a function selector
+var AnySelector = [types.SignatureSize]byte{}
+
+// storage key prefixes of the restrictions and the permissions. The roles are stored
+// by the address list under the left padded addresses
+const (
+	restrictionKeyPrefix byte = 0x1
+	permissionKeyPrefix  byte = 0x2
+)
+
+// Permission is the permission of the caller to call the function of the target contract
+type Permission struct {
+	Granted bool
+	// ValidFrom is the block timestamp from which the permission is valid
+	ValidFrom uint64
+	// ValidUntil is the last block timestamp at which the permission is valid, zero if it doesn't expire
+	ValidUntil uint64
+}
+
+// ValidAt returns true if the permission is granted and valid at the given block timestamp
+func (p Permission) ValidAt(timestamp uint64) bool {
+	return p.Granted && timestamp >= p.ValidFrom && (p.ValidUntil == 0 || timestamp <= p.ValidUntil)
+}
+
+type logEmitter interface {
+	EmitLog(addr types.Address, topics []types.Hash, data []byte)
+}
+
+// CallPolicy restricts the calls to the contract functions, identified by the target address
+// and the function selector, to the callers holding a valid permission for them.
+// Admins manage the roles and the restrictions, while admins and managers manage the permissions
+type CallPolicy struct {
+	state stateRef
+	addr  types.Address
+	roles *AddressList
+}
+
+func NewCallPolicy(state stateRef, addr types.Address) *CallPolicy {
+	return &CallPolicy{state: state, addr: addr, roles: NewAddressList(state, addr)}
+}
+
+func (p *CallPolicy) Addr() types.Address {
+	return p.addr
+}
+
+func (p *CallPolicy) Run(c *runtime.Contract, host runtime.Host, _ *chain.ForksInTime) *runtime.ExecutionResult {
+	timestamp := uint64(host.GetTxContext().Timestamp)
+	ret, gasUsed, err := p.runInputCall(c.Caller, c.Input, c.Gas, c.Static, timestamp, host)
+
+	res := &runtime.ExecutionResult{
+		ReturnValue: ret,
+		GasUsed:     gasUsed,
+		GasLeft:     c.Gas - gasUsed,
+		Err:         err,
+	}
+
+	return res
+}
+
+// policyFunc describes the call policy function by the number of its 32 bytes arguments,
+// whether it writes to the state and the role required to call it
+type policyFunc struct {
+	method *abi.Method
+	args   int
+	write  bool
+	roles  []Role
+}
+
+var policyFuncs = []*policyFunc{
+	{method: ReadAddressListFunc, args: 1},
+	{method: IsRestrictedFunc, args: 2},
+	{method: ReadPermissionFunc, args: 3},
+	{method: CanCallFunc, args: 3},
+	{method: SetAdminFunc, args: 1, write: true, roles: []Role{AdminRole}},
+	{method: SetManagerFunc, args: 1, write: true, roles: []Role{AdminRole}},
+	{method: SetNoneFunc, args: 1, write: true, roles: []Role{AdminRole}},
+	{method: SetRestrictedFunc, args: 3, write: true, roles: []Role{AdminRole}},
+	{method: GrantPermissionFunc, args: 5, write: true, roles: []Role{AdminRole, ManagerRole}},
+	{method: RevokePermissionFunc, args: 3, write: true, roles: []Role{AdminRole, ManagerRole}},
+}
+
+func (p *CallPolicy) runInputCall(caller types.Address, input []byte,
+	gas uint64, isStatic bool, timestamp uint64, emitter logEmitter) ([]byte, uint64, error) {
+	// decode the function signature from the input
+	if len(input) < types.SignatureSize {
+		return nil, 0, errNoFunctionSignature
+	}
+
+	sig, inputBytes := input[:4], input[4:]
+
+	var fn *policyFunc
+
+	for _, f := range policyFuncs {
+		if bytes.Equal(sig, f.method.ID()) {
+			fn = f
+
+			break
+		}
+	}
+
+	if fn == nil {
+		return nil, 0, errFunctionNotFound
+	}
+
+	// all the arguments are static types, each of them codified in abi as a 32 bytes word
+	if len(inputBytes) != fn.args*32 {
+		return nil, 0, errWrongInputSize
+	}
+
+	args := make([][]byte, fn.args)
+	for i := range args {
+		args[i] = inputBytes[i*32 : (i+1)*32]
+	}
+
+	gasUsed := readAddressListCost
+	if fn.write {
+		gasUsed = writeAddressListCost
+	}
+
+	if gas < gasUsed {
+		return nil, 0, runtime.ErrOutOfGas
+	}
+
+	if !fn.write {
+		return p.runRead(fn.method, args, timestamp), gasUsed, nil
+	}
+
+	// we cannot perform any write operation if the call is static
+	if isStatic {
+		return nil, gasUsed, errWriteProtection
+	}
+
+	if !p.hasRole(caller, fn.roles) {
+		return nil, gasUsed, runtime.ErrNotAuth
+	}
+
+	if err := p.runWrite(caller, fn.method, args, emitter); err != nil {
+		return nil, gasUsed, err
+	}
+
+	return nil, gasUsed, nil
+}
+
+func (p *CallPolicy) runRead(method *abi.Method, args [][]byte, timestamp uint64) []byte {
+	switch method {
+	case ReadAddressListFunc:
+		return p.GetRole(types.BytesToAddress(args[0])).Bytes()
+	case IsRestrictedFunc:
+		return encodeBool(p.IsRestricted(types.BytesToAddress(args[0]), decodeSelector(args[1])))
+	case ReadPermissionFunc:
+		perm := p.GetPermission(types.BytesToAddress(args[0]), types.BytesToAddress(args[1]),
+			decodeSelector(args[2]))
+
+		ret := encodeBool(perm.Granted)
+		ret = append(ret, encodeUint64(perm.ValidFrom)...)
+
+		return append(ret, encodeUint64(perm.ValidUntil)...)
+	default:
+		return encodeBool(p.CanCall(types.BytesToAddress(args[0]), types.BytesToAddress(args[1]),
+			decodeSelector(args[2]), timestamp))
+	}
+}
+
+func (p *CallPolicy) runWrite(caller types.Address, method *abi.Method, args [][]byte, emitter logEmitter) error {
+	switch method {
+	case SetAdminFunc, SetManagerFunc, SetNoneFunc:
+		account := types.BytesToAddress(args[0])
+
+		// An admin can not remove himself from the list
+		if caller == account {
+			return errAdminSelfRemove
+		}
+
+		role := NoRole
+		if method == SetAdminFunc {
+			role = AdminRole
+		} else if method == SetManagerFunc {
+			role = ManagerRole
+		}
+
+		p.SetRole(account, role)
+		p.emit(emitter, RoleChangedEvent, [][]byte{account.Bytes(), caller.Bytes()}, role.Bytes())
+	case SetRestrictedFunc:
+		target, selector, restricted := types.BytesToAddress(args[0]), decodeSelector(args[1]), decodeBool(args[2])
+
+		p.SetRestricted(target, selector, restricted)
+		p.emit(emitter, RestrictionChangedEvent, [][]byte{target.Bytes(), selector[:]}, encodeBool(restricted))
+	case GrantPermissionFunc:
+		perm := Permission{
+			Granted:    true,
+			ValidFrom:  decodeUint64(args[3]),
+			ValidUntil: decodeUint64(args[4]),
+		}
+
+		if perm.ValidUntil != 0 && perm.ValidUntil < perm.ValidFrom {
+			return errInvalidValidity
+		}
+
+		callerArg, target, selector := types.BytesToAddress(args[0]), types.BytesToAddress(args[1]),
+			decodeSelector(args[2])
+
+		p.SetPermission(callerArg, target, selector, perm)
+		p.emit(emitter, PermissionGrantedEvent, [][]byte{callerArg.Bytes(), target.Bytes(), selector[:]},
+			append(encodeUint64(perm.ValidFrom), encodeUint64(perm.ValidUntil)...))
+	case RevokePermissionFunc:
+		callerArg, target, selector := types.BytesToAddress(args[0]), types.BytesToAddress(args[1]),
+			decodeSelector(args[2])
+
+		p.SetPermission(callerArg, target, selector, Permission{})
+		p.emit(emitter, PermissionRevokedEvent, [][]byte{callerArg.Bytes(), target.Bytes(), selector[:]}, nil)
+	}
+
+	return nil
+}
+
+// emit emits the event with the given indexed values, each of them left padded to the topic
+// except for the function selector which is right padded as the abi does for the fixed bytes
+func (p *CallPolicy) emit(emitter logEmitter, event *abi.Event, indexed [][]byte, data []byte) {
+	if emitter == nil {
+		return
+	}
+
+	topics := make([]types.Hash, 0, len(indexed)+1)
+	topics = append(topics, types.Hash(event.ID()))
+
+	for _, value := range indexed {
+		if len(value) == types.SignatureSize {
+			var topic types.Hash
+
+			copy(topic[:], value)
+			topics = append(topics, topic)
+		} else {
+			topics = append(topics, types.BytesToHash(value))
+		}
+	}
+
+	emitter.EmitLog(p.addr, topics, data)
+}
+
+func (p *CallPolicy) hasRole(addr types.Address, roles []Role) bool {
+	addrRole := p.GetRole(addr)
+
+	for _, role := range roles {
+		if addrRole == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *CallPolicy) SetRole(addr types.Address, role Role) {
+	p.roles.SetRole(addr, role)
+}
+
+func (p *CallPolicy) GetRole(addr types.Address) Role {
+	return p.roles.GetRole(addr)
+}
+
+// SetRestricted restricts or releases the calls to the function of the target contract
+func (p *CallPolicy) SetRestricted(target types.Address, selector [types.SignatureSize]byte, restricted bool) {
+	var value types.Hash
+	if restricted {
+		value[types.HashLength-1] = 1
+	}
+
+	p.state.SetState(p.addr, restrictionKey(target, selector), value)
+}
+
+// IsRestricted returns true if the calls to the function of the target contract are restricted
+func (p *CallPolicy) IsRestricted(target types.Address, selector [types.SignatureSize]byte) bool {
+	return p.state.GetStorage(p.addr, restrictionKey(target, selector)) != types.ZeroHash
+}
+
+// SetPermission sets the permission of the caller to call the function of the target contract.
+// The permission is stored as the granted flag in the first byte, followed by the valid from
+// and valid until timestamps in the last 16 bytes
+func (p *CallPolicy) SetPermission(caller, target types.Address, selector [types.SignatureSize]byte,
+	perm Permission) {
+	var value types.Hash
+
+	if perm.Granted {
+		value[0] = 1
+		binary.BigEndian.PutUint64(value[16:24], perm.ValidFrom)
+		binary.BigEndian.PutUint64(value[24:32], perm.ValidUntil)
+	}
+
+	p.state.SetState(p.addr, permissionKey(caller, target, selector), value)
+}
+
+// GetPermission returns the permission of the caller to call the function of the target contract
+func (p *CallPolicy) GetPermission(caller, target types.Address, selector [types.SignatureSize]byte) Permission {
+	value := p.state.GetStorage(p.addr, permissionKey(caller, target, selector))
+
+	return Permission{
+		Granted:    value[0] == 1,
+		ValidFrom:  binary.BigEndian.Uint64(value[16:24]),
+		ValidUntil: binary.BigEndian.Uint64(value[24:32]),
+	}
+}
+
+// CanCall returns true if the caller is allowed to call the function of the target contract
+// at the given block timestamp. The call is allowed if neither the function nor the whole contract
+// is restricted, or if the caller holds a valid permission for either of them
+func (p *CallPolicy) CanCall(caller, target types.Address, selector [types.SignatureSize]byte,
+	timestamp uint64) bool {
+	if !p.IsRestricted(target, selector) && !p.IsRestricted(target, AnySelector) {
+		return true
+	}
+
+	return p.GetPermission(caller, target, selector).ValidAt(timestamp) ||
+		p.GetPermission(caller, target, AnySelector).ValidAt(timestamp)
+}
+
+// CallSelector returns the function selector of the call input, or AnySelector
+// if the input is too short to hold one
+func CallSelector(input []byte) [types.SignatureSize]byte {
+	var selector [types.SignatureSize]byte
+
+	if len(input) >= types.SignatureSize {
+		copy(selector[:], input[:types.SignatureSize])
+	}
+
+	return selector
+}
+
+func restrictionKey(target types.Address, selector [types.SignatureSize]byte) types.Hash {
+	buf := make([]byte, 0, 1+types.AddressLength+types.SignatureSize)
+	buf = append(buf, restrictionKeyPrefix)
+	buf = append(buf, target.Bytes()...)
+	buf = append(buf, selector[:]...)
+
+	return types.BytesToHash(keccak.Keccak256(nil, buf))
+}
+
+func permissionKey(caller, target types.Address, selector [types.SignatureSize]byte) types.Hash {
+	buf := make([]byte, 0, 1+2*types.AddressLength+types.SignatureSize)
+	buf = append(buf, permissionKeyPrefix)
+	buf = append(buf, caller.Bytes()...)
+	buf = append(buf, target.Bytes()...)
+	buf = append(buf, selector[:]...)
+
+	return types.BytesToHash(keccak.Keccak256(nil, buf))
+}
+
+func decodeSelector(word []byte) [types.SignatureSize]byte {
+	var selector [types.SignatureSize]byte
+
+	copy(selector[:], word[:types.SignatureSize])
+
+	return selector
+}
+
+func decodeBool(word []byte) bool {
+	return word[31] != 0
+}
+
+func decodeUint64(word []byte) uint64 {
+	return binary.BigEndian.Uint64(word[24:32])
+}
+
+func encodeBool(value bool) []byte {
+	word := make([]byte, 32)
+	if value {
+		word[31] = 1
+	}
+
+	return word
+}
+
+func encodeUint64(value uint64) []byte {
+	word := make([]byte, 32)
+	binary.BigEndian.PutUint64(word[24:], value)
+
+	return word
+}
@@ -0,0 +1,225 @@
+package addresslist
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEmitter struct {
+	logs []*types.Log
+}
+
+func (m *mockEmitter) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
+	m.logs = append(m.logs, &types.Log{Address: addr, Topics: topics, Data: data})
+}
+
+var (
+	policyAddr    = types.Address{0xff}
+	policyAdmin   = types.Address{0x1}
+	policyManager = types.Address{0x2}
+	policyCaller  = types.Address{0x3}
+	policyTarget  = types.Address{0x4}
+	testSelector  = [types.SignatureSize]byte{0x1, 0x2, 0x3, 0x4}
+)
+
+func newMockCallPolicy() *CallPolicy {
+	state := &mockState{
+		state: map[types.Hash]types.Hash{},
+	}
+
+	p := NewCallPolicy(state, policyAddr)
+	p.SetRole(policyAdmin, AdminRole)
+	p.SetRole(policyManager, ManagerRole)
+
+	return p
+}
+
+func TestCallPolicy_WrongInput(t *testing.T) {
+	p := newMockCallPolicy()
+
+	// no function signature
+	_, _, err := p.runInputCall(policyAdmin, []byte{}, writeAddressListCost, false, 0, nil)
+	require.Equal(t, errNoFunctionSignature, err)
+
+	// wrong signature
+	_, _, err = p.runInputCall(policyAdmin, []byte{0x1, 0x2, 0x3, 0x4}, writeAddressListCost, false, 0, nil)
+	require.Equal(t, errFunctionNotFound, err)
+
+	// wrong number of arguments
+	input, _ := SetAdminFunc.Encode([]interface{}{types.Address{}})
+	input = append(SetRestrictedFunc.ID(), input[types.SignatureSize:]...)
+
+	_, _, err = p.runInputCall(policyAdmin, input, writeAddressListCost, false, 0, nil)
+	require.Equal(t, errWrongInputSize, err)
+}
+
+func TestCallPolicy_WriteOp_Auth(t *testing.T) {
+	p := newMockCallPolicy()
+
+	restrictInput, _ := SetRestrictedFunc.Encode([]interface{}{policyTarget, testSelector, true})
+	grantInput, _ := GrantPermissionFunc.Encode(
+		[]interface{}{policyCaller, policyTarget, testSelector, uint64(0), uint64(0)})
+
+	cases := []struct {
+		name   string
+		caller types.Address
+		input  []byte
+		err    error
+	}{
+		{"admin restricts", policyAdmin, restrictInput, nil},
+		{"manager cannot restrict", policyManager, restrictInput, runtime.ErrNotAuth},
+		{"caller cannot restrict", policyCaller, restrictInput, runtime.ErrNotAuth},
+		{"admin grants", policyAdmin, grantInput, nil},
+		{"manager grants", policyManager, grantInput, nil},
+		{"caller cannot grant", policyCaller, grantInput, runtime.ErrNotAuth},
+	}
+
+	for _, c := range cases {
+		_, gasCost, err := p.runInputCall(c.caller, c.input, writeAddressListCost, false, 0, nil)
+		require.Equal(t, writeAddressListCost, gasCost, c.name)
+		require.Equal(t, c.err, err, c.name)
+	}
+
+	// the write operations are not allowed in a static call
+	_, _, err := p.runInputCall(policyAdmin, grantInput, writeAddressListCost, true, 0, nil)
+	require.Equal(t, errWriteProtection, err)
+
+	// the write operations cost more gas than the read ones
+	_, _, err = p.runInputCall(policyAdmin, grantInput, readAddressListCost, false, 0, nil)
+	require.Equal(t, runtime.ErrOutOfGas, err)
+}
+
+func TestCallPolicy_Roles(t *testing.T) {
+	p := newMockCallPolicy()
+	emitter := &mockEmitter{}
+
+	// the manager cannot change the roles
+	input, _ := SetManagerFunc.Encode([]interface{}{policyCaller})
+	_, _, err := p.runInputCall(policyManager, input, writeAddressListCost, false, 0, emitter)
+	require.Equal(t, runtime.ErrNotAuth, err)
+
+	_, _, err = p.runInputCall(policyAdmin, input, writeAddressListCost, false, 0, emitter)
+	require.NoError(t, err)
+	require.Equal(t, ManagerRole, p.GetRole(policyCaller))
+
+	// the admin cannot remove itself
+	input, _ = SetNoneFunc.Encode([]interface{}{policyAdmin})
+	_, _, err = p.runInputCall(policyAdmin, input, writeAddressListCost, false, 0, emitter)
+	require.Equal(t, errAdminSelfRemove, err)
+
+	input, _ = ReadAddressListFunc.Encode([]interface{}{policyCaller})
+	ret, _, err := p.runInputCall(policyCaller, input, readAddressListCost, false, 0, emitter)
+	require.NoError(t, err)
+	require.Equal(t, ManagerRole.Bytes(), ret)
+
+	require.Len(t, emitter.logs, 1)
+	require.Equal(t, policyAddr, emitter.logs[0].Address)
+	require.Equal(t, []types.Hash{
+		types.Hash(RoleChangedEvent.ID()),
+		types.BytesToHash(policyCaller.Bytes()),
+		types.BytesToHash(policyAdmin.Bytes()),
+	}, emitter.logs[0].Topics)
+	require.Equal(t, ManagerRole.Bytes(), emitter.logs[0].Data)
+}
+
+func TestCallPolicy_CanCall(t *testing.T) {
+	p := newMockCallPolicy()
+	otherSelector := [types.SignatureSize]byte{0x5}
+
+	// nothing is restricted
+	require.True(t, p.CanCall(policyCaller, policyTarget, testSelector, 0))
+
+	// restrict a single function of the target
+	p.SetRestricted(policyTarget, testSelector, true)
+	require.False(t, p.CanCall(policyCaller, policyTarget, testSelector, 0))
+	require.True(t, p.CanCall(policyCaller, policyTarget, otherSelector, 0))
+
+	// permit the function within a time range
+	p.SetPermission(policyCaller, policyTarget, testSelector, Permission{Granted: true, ValidFrom: 10, ValidUntil: 20})
+	require.False(t, p.CanCall(policyCaller, policyTarget, testSelector, 9))
+	require.True(t, p.CanCall(policyCaller, policyTarget, testSelector, 10))
+	require.True(t, p.CanCall(policyCaller, policyTarget, testSelector, 20))
+	require.False(t, p.CanCall(policyCaller, policyTarget, testSelector, 21))
+	require.False(t, p.CanCall(policyAdmin, policyTarget, testSelector, 15))
+
+	// restrict the whole target, the function permission still applies to its function
+	p.SetRestricted(policyTarget, AnySelector, true)
+	require.False(t, p.CanCall(policyCaller, policyTarget, otherSelector, 15))
+	require.True(t, p.CanCall(policyCaller, policyTarget, testSelector, 15))
+
+	// permit the whole target without expiry
+	p.SetPermission(policyCaller, policyTarget, AnySelector, Permission{Granted: true})
+	require.True(t, p.CanCall(policyCaller, policyTarget, otherSelector, 100))
+	require.True(t, p.CanCall(policyCaller, policyTarget, CallSelector(nil), 100))
+}
+
+func TestCallPolicy_GrantAndRevoke(t *testing.T) {
+	p := newMockCallPolicy()
+	emitter := &mockEmitter{}
+
+	input, _ := SetRestrictedFunc.Encode([]interface{}{policyTarget, testSelector, true})
+	_, _, err := p.runInputCall(policyAdmin, input, writeAddressListCost, false, 0, emitter)
+	require.NoError(t, err)
+
+	input, _ = IsRestrictedFunc.Encode([]interface{}{policyTarget, testSelector})
+	ret, gasCost, err := p.runInputCall(policyCaller, input, readAddressListCost, false, 0, emitter)
+	require.NoError(t, err)
+	require.Equal(t, readAddressListCost, gasCost)
+	require.Equal(t, encodeBool(true), ret)
+
+	// the permission cannot expire before it is valid
+	input, _ = GrantPermissionFunc.Encode(
+		[]interface{}{policyCaller, policyTarget, testSelector, uint64(20), uint64(10)})
+	_, _, err = p.runInputCall(policyManager, input, writeAddressListCost, false, 0, emitter)
+	require.Equal(t, errInvalidValidity, err)
+
+	input, _ = GrantPermissionFunc.Encode(
+		[]interface{}{policyCaller, policyTarget, testSelector, uint64(10), uint64(20)})
+	_, _, err = p.runInputCall(policyManager, input, writeAddressListCost, false, 0, emitter)
+	require.NoError(t, err)
+
+	input, _ = ReadPermissionFunc.Encode([]interface{}{policyCaller, policyTarget, testSelector})
+	ret, _, err = p.runInputCall(policyCaller, input, readAddressListCost, false, 0, emitter)
+	require.NoError(t, err)
+
+	expected := append(encodeBool(true), encodeUint64(10)...)
+	require.Equal(t, append(expected, encodeUint64(20)...), ret)
+
+	canCallInput, _ := CanCallFunc.Encode([]interface{}{policyCaller, policyTarget, testSelector})
+	ret, _, err = p.runInputCall(policyCaller, canCallInput, readAddressListCost, false, 15, emitter)
+	require.NoError(t, err)
+	require.Equal(t, encodeBool(true), ret)
+
+	input, _ = RevokePermissionFunc.Encode([]interface{}{policyCaller, policyTarget, testSelector})
+	_, _, err = p.runInputCall(policyManager, input, writeAddressListCost, false, 0, emitter)
+	require.NoError(t, err)
+
+	ret, _, err = p.runInputCall(policyCaller, canCallInput, readAddressListCost, false, 15, emitter)
+	require.NoError(t, err)
+	require.Equal(t, encodeBool(false), ret)
+
+	// the events index the selector right padded, as the abi does for the fixed bytes
+	var selectorTopic types.Hash
+
+	copy(selectorTopic[:], testSelector[:])
+
+	require.Len(t, emitter.logs, 3)
+	require.Equal(t, []types.Hash{
+		types.Hash(RestrictionChangedEvent.ID()),
+		types.BytesToHash(policyTarget.Bytes()),
+		selectorTopic,
+	}, emitter.logs[0].Topics)
+	require.Equal(t, encodeBool(true), emitter.logs[0].Data)
+	require.Equal(t, []types.Hash{
+		types.Hash(PermissionGrantedEvent.ID()),
+		types.BytesToHash(policyCaller.Bytes()),
+		types.BytesToHash(policyTarget.Bytes()),
+		selectorTopic,
+	}, emitter.logs[1].Topics)
+	require.Equal(t, append(encodeUint64(10), encodeUint64(20)...), emitter.logs[1].Data)
+	require.Equal(t, types.Hash(PermissionRevokedEvent.ID()), emitter.logs[2].Topics[0])
+	require.Empty(t, emitter.logs[2].Data)
+}
@@ -0,0 +1,139 @@
+package evm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+const benchmarkIterations = 1024
+
+var (
+	benchmarkWordA = bytes.Repeat([]byte{0xfe}, 32)
+	benchmarkWordB = append([]byte{0x7f}, bytes.Repeat([]byte{0x13}, 31)...)
+	benchmarkWordC = append(make([]byte, 16), bytes.Repeat([]byte{0x0d}, 16)...)
+)
+
+// benchmarkLoop builds the code which executes the given loop body benchmarkIterations times.
+// The body must leave the stack as it found it
+func benchmarkLoop(body []byte) []byte {
+	code := []byte{
+		PUSH1 + 1, byte(benchmarkIterations >> 8), byte(benchmarkIterations & 0xff), // counter
+		JUMPDEST, // loop start (pc 3)
+	}
+
+	code = append(code, body...)
+
+	return append(code,
+		PUSH1, 1, SWAP1, SUB, // counter - 1
+		DUP1, PUSH1, 3, JUMPI, // jump to the loop start if counter is not zero
+		POP, byte(STOP),
+	)
+}
+
+func push32(word []byte) []byte {
+	return append([]byte{PUSH32}, word...)
+}
+
+func benchmarkCode(b *testing.B, code []byte) {
+	b.Helper()
+
+	evm := NewEVM()
+	host := &mockHost{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result := evm.Run(newMockContract(big.NewInt(0), 1<<40, code), host, &allEnabledForks)
+		if result.Err != nil {
+			b.Fatal(result.Err)
+		}
+	}
+}
+
+func BenchmarkEVM_Arithmetic(b *testing.B) {
+	var body []byte
+
+	body = append(body, push32(benchmarkWordA)...)
+	body = append(body, push32(benchmarkWordB)...)
+	body = append(body, ADD)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, MUL)
+	body = append(body, push32(benchmarkWordB)...)
+	body = append(body, SUB)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, SWAP1, DIV)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, SWAP1, MOD, POP)
+
+	benchmarkCode(b, benchmarkLoop(body))
+}
+
+func BenchmarkEVM_SignedAndModular(b *testing.B) {
+	var body []byte
+
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, push32(benchmarkWordA)...)
+	body = append(body, SDIV)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, SWAP1, SMOD)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, push32(benchmarkWordB)...)
+	body = append(body, ADDMOD)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, push32(benchmarkWordA)...)
+	body = append(body, MULMOD)
+	body = append(body, push32(benchmarkWordB)...)
+	body = append(body, EXP, POP)
+
+	benchmarkCode(b, benchmarkLoop(body))
+}
+
+func BenchmarkEVM_BitwiseAndComparison(b *testing.B) {
+	var body []byte
+
+	body = append(body, push32(benchmarkWordA)...)
+	body = append(body, push32(benchmarkWordB)...)
+	body = append(body, AND)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, XOR, NOT)
+	body = append(body, PUSH1, 7, SHL)
+	body = append(body, PUSH1, 3, SAR)
+	body = append(body, push32(benchmarkWordB)...)
+	body = append(body, SLT)
+	body = append(body, push32(benchmarkWordC)...)
+	body = append(body, GT, ISZERO, POP)
+
+	benchmarkCode(b, benchmarkLoop(body))
+}
+
+func BenchmarkEVM_MemoryAndHashing(b *testing.B) {
+	var body []byte
+
+	body = append(body, push32(benchmarkWordA)...)
+	body = append(body, PUSH1, 0, MSTORE)
+	body = append(body, push32(benchmarkWordB)...)
+	body = append(body, PUSH1, 32, MSTORE)
+	body = append(body, PUSH1, 64, PUSH1, 0, SHA3)
+	body = append(body, PUSH1, 16, MLOAD)
+	body = append(body, XOR, POP)
+
+	benchmarkCode(b, benchmarkLoop(body))
+}
+
+func BenchmarkEVM_StackOperations(b *testing.B) {
+	var body []byte
+
+	for i := 0; i < 8; i++ {
+		body = append(body, push32(benchmarkWordA)...)
+	}
+
+	body = append(body, DUP1+7, DUP1+3, SWAP1+1, SWAP1+6, DUP1, SWAP1)
+
+	for i := 0; i < 11; i++ {
+		body = append(body, POP)
+	}
+
+	benchmarkCode(b, benchmarkLoop(body))
+}
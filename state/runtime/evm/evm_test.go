@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -211,7 +212,7 @@ type mockTracer struct {
 
 func (m *mockTracer) CaptureState(
 	memory []byte,
-	stack []*big.Int,
+	stack []uint256.Int,
 	opCode int,
 	contractAddress types.Address,
 	sp int,
@@ -222,7 +223,7 @@ func (m *mockTracer) CaptureState(
 		name: "CaptureState",
 		args: map[string]interface{}{
 			"memory":          memory,
-			"stack":           stack,
+			"stack":           append([]uint256.Int{}, stack...), // stack is only valid during the call
 			"opCode":          opCode,
 			"contractAddress": contractAddress,
 			"sp":              sp,
@@ -282,7 +283,7 @@ func TestRunWithTracer(t *testing.T) {
 					name: "CaptureState",
 					args: map[string]interface{}{
 						"memory":          []byte{},
-						"stack":           []*big.Int{},
+						"stack":           []uint256.Int{},
 						"opCode":          int(PUSH1),
 						"contractAddress": contractAddress,
 						"sp":              0,
@@ -305,8 +306,8 @@ func TestRunWithTracer(t *testing.T) {
 					name: "CaptureState",
 					args: map[string]interface{}{
 						"memory": []byte{},
-						"stack": []*big.Int{
-							big.NewInt(1),
+						"stack": []uint256.Int{
+							*uint256.NewInt(1),
 						},
 						"opCode":          int(0),
 						"contractAddress": contractAddress,
@@ -327,7 +328,7 @@ func TestRunWithTracer(t *testing.T) {
 					name: "CaptureState",
 					args: map[string]interface{}{
 						"memory":          []byte{},
-						"stack":           []*big.Int{},
+						"stack":           []uint256.Int{},
 						"opCode":          int(POP),
 						"contractAddress": contractAddress,
 						"sp":              0,
@@ -376,7 +377,7 @@ func TestRunWithTracer(t *testing.T) {
 			state.config = config
 
 			// make sure stack, memory, and returnData are empty
-			state.stack = make([]*big.Int, 0)
+			state.sp = 0
 			state.memory = make([]byte, 0)
 			state.returnData = make([]byte, 0)
 
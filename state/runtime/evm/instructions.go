@@ -4,22 +4,23 @@ package evm
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
-	"math/bits"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 type instruction func(c *state)
 
 var (
-	zero     = big.NewInt(0)
-	one      = big.NewInt(1)
-	wordSize = big.NewInt(32)
+	zero     = uint256.NewInt(0)
+	one      = uint256.NewInt(1)
+	wordSize = uint256.NewInt(32)
 )
 
 func opAdd(c *state) {
@@ -27,7 +28,6 @@ func opAdd(c *state) {
 	b := c.top()
 
 	b.Add(a, b)
-	toU256(b)
 }
 
 func opMul(c *state) {
@@ -35,7 +35,6 @@ func opMul(c *state) {
 	b := c.top()
 
 	b.Mul(a, b)
-	toU256(b)
 }
 
 func opSub(c *state) {
@@ -43,81 +42,38 @@ func opSub(c *state) {
 	b := c.top()
 
 	b.Sub(a, b)
-	toU256(b)
 }
 
 func opDiv(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		b.Div(a, b)
-		toU256(b)
-	}
+	// division by zero results in zero
+	b.Div(a, b)
 }
 
 func opSDiv(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
+	a := c.pop()
+	b := c.top()
 
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		neg := a.Sign() != b.Sign()
-		b.Div(a.Abs(a), b.Abs(b))
-		if neg {
-			b.Neg(b)
-		}
-		toU256(b)
-	}
+	// division by zero results in zero
+	b.SDiv(a, b)
 }
 
 func opMod(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		b.Mod(a, b)
-		toU256(b)
-	}
+	// division by zero results in zero
+	b.Mod(a, b)
 }
 
 func opSMod(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
-
-	if b.Sign() == 0 {
-		// division by zero
-		b.Set(zero)
-	} else {
-		neg := a.Sign() < 0
-		b.Mod(a.Abs(a), b.Abs(b))
-		if neg {
-			b.Neg(b)
-		}
-		toU256(b)
-	}
-}
-
-var bigPool = sync.Pool{
-	New: func() interface{} {
-		return new(big.Int)
-	},
-}
-
-func acquireBig() *big.Int {
-	return bigPool.Get().(*big.Int)
-}
+	a := c.pop()
+	b := c.top()
 
-func releaseBig(b *big.Int) {
-	bigPool.Put(b)
+	// division by zero results in zero
+	b.SMod(a, b)
 }
 
 func opExp(c *state) {
@@ -131,28 +87,12 @@ func opExp(c *state) {
 		gas = 10
 	}
 
-	gasCost := uint64((y.BitLen()+7)/8) * gas
+	gasCost := uint64(y.ByteLen()) * gas
 	if !c.consumeGas(gasCost) {
 		return
 	}
 
-	z := acquireBig().Set(one)
-
-	// https://www.programminglogic.com/fast-exponentiation-algorithms/
-	for _, d := range y.Bits() {
-		for i := 0; i < _W; i++ {
-			if d&1 == 1 {
-				toU256(z.Mul(z, x))
-			}
-
-			d >>= 1
-
-			toU256(x.Mul(x, x))
-		}
-	}
-
-	y.Set(z)
-	releaseBig(z)
+	y.Exp(x, y)
 }
 
 func opAddMod(c *state) {
@@ -160,14 +100,8 @@ func opAddMod(c *state) {
 	b := c.pop()
 	z := c.top()
 
-	if z.Sign() == 0 {
-		// division by zero
-		z.Set(zero)
-	} else {
-		a = a.Add(a, b)
-		z = z.Mod(a, z)
-		toU256(z)
-	}
+	// division by zero results in zero
+	z.AddMod(a, b, z)
 }
 
 func opMulMod(c *state) {
@@ -175,14 +109,8 @@ func opMulMod(c *state) {
 	b := c.pop()
 	z := c.top()
 
-	if z.Sign() == 0 {
-		// division by zero
-		z.Set(zero)
-	} else {
-		a = a.Mul(a, b)
-		z = z.Mod(a, z)
-		toU256(z)
-	}
+	// division by zero results in zero
+	z.MulMod(a, b, z)
 }
 
 func opAnd(c *state) {
@@ -206,33 +134,23 @@ func opXor(c *state) {
 	b.Xor(a, b)
 }
 
-var opByteMask = big.NewInt(255)
-
 func opByte(c *state) {
 	x := c.pop()
 	y := c.top()
 
-	indx := x.Int64()
-	if indx > 31 {
-		y.Set(zero)
-	} else {
-		sh := (31 - indx) * 8
-		y.Rsh(y, uint(sh))
-		y.And(y, opByteMask)
-	}
+	y.Byte(x)
 }
 
 func opNot(c *state) {
 	a := c.top()
 
 	a.Not(a)
-	toU256(a)
 }
 
 func opIsZero(c *state) {
 	a := c.top()
 
-	if a.Sign() == 0 {
+	if a.IsZero() {
 		a.Set(one)
 	} else {
 		a.Set(zero)
@@ -243,7 +161,7 @@ func opEq(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if a.Cmp(b) == 0 {
+	if a.Eq(b) {
 		b.Set(one)
 	} else {
 		b.Set(zero)
@@ -254,7 +172,7 @@ func opLt(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if a.Cmp(b) < 0 {
+	if a.Lt(b) {
 		b.Set(one)
 	} else {
 		b.Set(zero)
@@ -265,7 +183,7 @@ func opGt(c *state) {
 	a := c.pop()
 	b := c.top()
 
-	if a.Cmp(b) > 0 {
+	if a.Gt(b) {
 		b.Set(one)
 	} else {
 		b.Set(zero)
@@ -273,10 +191,10 @@ func opGt(c *state) {
 }
 
 func opSlt(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
+	a := c.pop()
+	b := c.top()
 
-	if a.Cmp(b) < 0 {
+	if a.Slt(b) {
 		b.Set(one)
 	} else {
 		b.Set(zero)
@@ -284,10 +202,10 @@ func opSlt(c *state) {
 }
 
 func opSgt(c *state) {
-	a := to256(c.pop())
-	b := to256(c.top())
+	a := c.pop()
+	b := c.top()
 
-	if a.Cmp(b) > 0 {
+	if a.Sgt(b) {
 		b.Set(one)
 	} else {
 		b.Set(zero)
@@ -298,33 +216,17 @@ func opSignExtension(c *state) {
 	ext := c.pop()
 	x := c.top()
 
-	if ext.Cmp(wordSize) > 0 {
-		return
-	}
-
 	if x == nil {
 		return
 	}
 
-	bit := uint(ext.Uint64()*8 + 7)
-
-	mask := acquireBig().Set(one)
-	mask.Lsh(mask, bit)
-	mask.Sub(mask, one)
-
-	if x.Bit(int(bit)) > 0 {
-		mask.Not(mask)
-		x.Or(x, mask)
-	} else {
-		x.And(x, mask)
-	}
-
-	toU256(x)
-	releaseBig(mask)
+	// sign extension is a no-op for the extension of 31 bytes and more
+	x.ExtendSign(x, ext)
 }
 
-func equalOrOverflowsUint256(b *big.Int) bool {
-	return b.BitLen() > 8
+// shiftOverflows returns true if the shift clears the whole word
+func shiftOverflows(shift *uint256.Int) bool {
+	return !shift.LtUint64(256)
 }
 
 func opShl(c *state) {
@@ -337,11 +239,10 @@ func opShl(c *state) {
 	shift := c.pop()
 	value := c.top()
 
-	if equalOrOverflowsUint256(shift) {
-		value.Set(zero)
+	if shiftOverflows(shift) {
+		value.Clear()
 	} else {
 		value.Lsh(value, uint(shift.Uint64()))
-		toU256(value)
 	}
 }
 
@@ -355,11 +256,10 @@ func opShr(c *state) {
 	shift := c.pop()
 	value := c.top()
 
-	if equalOrOverflowsUint256(shift) {
-		value.Set(zero)
+	if shiftOverflows(shift) {
+		value.Clear()
 	} else {
 		value.Rsh(value, uint(shift.Uint64()))
-		toU256(value)
 	}
 }
 
@@ -371,17 +271,16 @@ func opSar(c *state) {
 	}
 
 	shift := c.pop()
-	value := to256(c.top())
+	value := c.top()
 
-	if equalOrOverflowsUint256(shift) {
+	if shiftOverflows(shift) {
 		if value.Sign() >= 0 {
-			value.Set(zero)
+			value.Clear()
 		} else {
-			value.Set(tt256m1)
+			value.SetAllOne()
 		}
 	} else {
-		value.Rsh(value, uint(shift.Uint64()))
-		toU256(value)
+		value.SRsh(value, uint(shift.Uint64()))
 	}
 }
 
@@ -410,11 +309,6 @@ func opMload(c *state) {
 	c.push1().SetBytes(c.tmp)
 }
 
-var (
-	_W = bits.UintSize
-	_S = _W / 8
-)
-
 func opMStore(c *state) {
 	offset := c.pop()
 	val := c.pop()
@@ -424,28 +318,7 @@ func opMStore(c *state) {
 	}
 
 	o := offset.Uint64()
-	buf := c.memory[o : o+32]
-
-	i := 32
-
-	// convert big.int to bytes
-	// https://golang.org/src/math/big/nat.go#L1284
-	for _, d := range val.Bits() {
-		for j := 0; j < _S; j++ {
-			i--
-
-			buf[i] = byte(d)
-
-			d >>= 8
-		}
-	}
-
-	// fill the rest of the slot with zeros
-	for i > 0 {
-		i--
-
-		buf[i] = 0
-	}
+	val.WriteToSlice(c.memory[o : o+32])
 }
 
 func opMStore8(c *state) {
@@ -478,8 +351,8 @@ func opSload(c *state) {
 		return
 	}
 
-	val := c.host.GetStorage(c.msg.Address, bigToHash(loc))
-	loc.SetBytes(val.Bytes())
+	val := c.host.GetStorage(c.msg.Address, wordToHash(loc))
+	loc.SetBytes32(val.Bytes())
 }
 
 func opSStore(c *state) {
@@ -557,8 +430,7 @@ func opSha3(c *state) {
 
 	c.tmp = keccak.Keccak256(c.tmp[:0], c.tmp)
 
-	v := c.push1()
-	v.SetBytes(c.tmp)
+	c.push1().SetBytes32(c.tmp)
 }
 
 func opPop(c *state) {
@@ -588,7 +460,7 @@ func opBalance(c *state) {
 		return
 	}
 
-	c.push1().Set(c.host.GetBalance(addr))
+	c.push1().SetFromBig(c.host.GetBalance(addr))
 }
 
 func opSelfBalance(c *state) {
@@ -598,7 +470,7 @@ func opSelfBalance(c *state) {
 		return
 	}
 
-	c.push1().Set(c.host.GetBalance(c.msg.Address))
+	c.push1().SetFromBig(c.host.GetBalance(c.msg.Address))
 }
 
 func opChainID(c *state) {
@@ -622,9 +494,9 @@ func opCaller(c *state) {
 func opCallValue(c *state) {
 	v := c.push1()
 	if value := c.msg.Value; value != nil {
-		v.Set(value)
+		v.SetFromBig(value)
 	} else {
-		v.Set(zero)
+		v.Clear()
 	}
 }
 
@@ -642,7 +514,7 @@ func opCallDataLoad(c *state) {
 	bufPtr := bufPool.Get().(*[]byte)
 	buf := *bufPtr
 	c.setBytes(buf[:32], c.msg.Input, 32, offset)
-	offset.SetBytes(buf[:32])
+	offset.SetBytes32(buf[:32])
 	bufPool.Put(bufPtr)
 }
 
@@ -705,9 +577,9 @@ func opExtCodeHash(c *state) {
 
 	v := c.push1()
 	if c.host.Empty(address) {
-		v.Set(zero)
+		v.Clear()
 	} else {
-		v.SetBytes(c.host.GetCodeHash(address).Bytes())
+		v.SetBytes32(c.host.GetCodeHash(address).Bytes())
 	}
 }
 
@@ -723,7 +595,7 @@ func opGas(c *state) {
 	c.push1().SetUint64(c.gas)
 }
 
-func (c *state) setBytes(dst, input []byte, size uint64, dataOffset *big.Int) {
+func (c *state) setBytes(dst, input []byte, size uint64, dataOffset *uint256.Int) {
 	if !dataOffset.IsUint64() {
 		// overflow, copy 'size' 0 bytes to dst
 		for i := uint64(0); i < size; i++ {
@@ -820,7 +692,7 @@ func opReturnDataCopy(c *state) {
 	}
 
 	// if length is 0, return immediately since no need for the data copying nor memory allocation
-	if length.Sign() == 0 || !c.allocateMemory(memOffset, length) {
+	if length.IsZero() || !c.allocateMemory(memOffset, length) {
 		return
 	}
 
@@ -829,8 +701,8 @@ func opReturnDataCopy(c *state) {
 		return
 	}
 
-	dataEnd := length.Add(dataOffset, length)
-	if !dataEnd.IsUint64() {
+	dataEnd, overflow := length.AddOverflow(dataOffset, length)
+	if overflow || !dataEnd.IsUint64() {
 		c.exit(errReturnDataOutOfBounds)
 
 		return
@@ -871,19 +743,19 @@ func opCodeCopy(c *state) {
 func opBlockHash(c *state) {
 	num := c.top()
 
-	if !num.IsInt64() {
-		num.Set(zero)
+	if !num.IsUint64() || num.Uint64() > math.MaxInt64 {
+		num.Clear()
 
 		return
 	}
 
-	n := num.Int64()
+	n := int64(num.Uint64())
 	lastBlock := c.host.GetTxContext().Number
 
 	if lastBlock-257 < n && n < lastBlock {
-		num.SetBytes(c.host.GetBlockHash(n).Bytes())
+		num.SetBytes32(c.host.GetBlockHash(n).Bytes())
 	} else {
-		num.Set(zero)
+		num.Clear()
 	}
 }
 
@@ -892,11 +764,11 @@ func opCoinbase(c *state) {
 }
 
 func opTimestamp(c *state) {
-	c.push1().SetInt64(c.host.GetTxContext().Timestamp)
+	c.push1().SetUint64(uint64(c.host.GetTxContext().Timestamp))
 }
 
 func opNumber(c *state) {
-	c.push1().SetInt64(c.host.GetTxContext().Number)
+	c.push1().SetUint64(uint64(c.host.GetTxContext().Number))
 }
 
 func opDifficulty(c *state) {
@@ -904,7 +776,7 @@ func opDifficulty(c *state) {
 }
 
 func opGasLimit(c *state) {
-	c.push1().SetInt64(c.host.GetTxContext().GasLimit)
+	c.push1().SetUint64(uint64(c.host.GetTxContext().GasLimit))
 }
 
 func opBaseFee(c *state) {
@@ -914,7 +786,7 @@ func opBaseFee(c *state) {
 		return
 	}
 
-	c.push(c.host.GetTxContext().BaseFee)
+	c.push1().SetFromBig(c.host.GetTxContext().BaseFee)
 }
 
 func opSelfDestruct(c *state) {
@@ -963,7 +835,7 @@ func opJumpi(c *state) {
 	dest := c.pop()
 	cond := c.pop()
 
-	if cond.Sign() != 0 {
+	if !cond.IsZero() {
 		if c.validJumpdest(dest) {
 			c.ip = int(dest.Uint64() - 1)
 		} else {
@@ -982,7 +854,11 @@ func opPush(n int) instruction {
 
 		v := c.push1()
 		if ip+1+n > len(ins) {
-			v.SetBytes(append(ins[ip+1:], make([]byte, n)...))
+			// missing code bytes are zeros
+			var buf [32]byte
+
+			copy(buf[:n], ins[ip+1:])
+			v.SetBytes(buf[:n])
 		} else {
 			v.SetBytes(ins[ip+1 : ip+1+n])
 		}
@@ -1033,7 +909,7 @@ func opLog(size int) instruction {
 
 		topics := make([]types.Hash, size)
 		for i := 0; i < size; i++ {
-			topics[i] = bigToHash(c.pop())
+			topics[i] = wordToHash(c.pop())
 		}
 
 		var ok bool
@@ -1080,7 +956,7 @@ func opCreate(op OpCode) instruction {
 
 		contract, err := c.buildCreateContract(op)
 		if err != nil {
-			c.push1().Set(zero)
+			c.push1().Clear()
 
 			if contract != nil {
 				c.gas += contract.Gas
@@ -1100,13 +976,13 @@ func opCreate(op OpCode) instruction {
 
 		v := c.push1()
 		if op == CREATE && c.config.Homestead && errors.Is(result.Err, runtime.ErrCodeStoreOutOfGas) {
-			v.Set(zero)
+			v.Clear()
 		} else if op == CREATE && result.Failed() && !errors.Is(result.Err, runtime.ErrCodeStoreOutOfGas) {
-			v.Set(zero)
+			v.Clear()
 		} else if op == CREATE2 && result.Failed() {
-			v.Set(zero)
+			v.Clear()
 		} else {
-			v.SetBytes(contract.Address.Bytes())
+			v.SetBytes20(contract.Address.Bytes())
 		}
 
 		c.gas += result.GasLeft
@@ -1122,7 +998,7 @@ func opCall(op OpCode) instruction {
 		c.resetReturnData()
 
 		if op == CALL && c.inStaticCall() {
-			if val := c.peekAt(3); val != nil && val.BitLen() > 0 {
+			if val := c.peekAt(3); !val.IsZero() {
 				c.exit(errWriteProtection)
 
 				return
@@ -1162,7 +1038,7 @@ func opCall(op OpCode) instruction {
 
 		contract, offset, size, err := c.buildCallContract(op)
 		if err != nil {
-			c.push1().Set(zero)
+			c.push1().Clear()
 
 			if contract != nil {
 				c.gas += contract.Gas
@@ -1181,9 +1057,9 @@ func opCall(op OpCode) instruction {
 
 		v := c.push1()
 		if result.Succeeded() {
-			v.Set(one)
+			v.SetOne()
 		} else {
-			v.Set(zero)
+			v.Clear()
 		}
 
 		if result.Succeeded() || result.Reverted() {
@@ -1202,9 +1078,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	initialGas := c.pop()
 	addr, _ := c.popAddr()
 
+	// value is converted, because the call contract outlives the stack word
 	var value *big.Int
 	if op == CALL || op == CALLCODE {
-		value = c.pop()
+		value = c.pop().ToBig()
 	}
 
 	// input range
@@ -1318,17 +1195,17 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 
 func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 	// Pop input arguments
-	value := c.pop()
+	value := c.pop().ToBig()
 	offset := c.pop()
 	length := c.pop()
 
-	var salt *big.Int
+	var salt *uint256.Int
 	if op == CREATE2 {
 		salt = c.pop()
 	}
 
 	// check if the value can be transferred
-	hasTransfer := value != nil && value.Sign() != 0
+	hasTransfer := value.Sign() != 0
 
 	// Calculate and consume gas cost
 
@@ -1381,7 +1258,7 @@ func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 	if op == CREATE {
 		address = crypto.CreateAddress(c.msg.Address, c.host.GetNonce(c.msg.Address))
 	} else {
-		address = crypto.CreateAddress2(c.msg.Address, bigToHash(salt), input)
+		address = crypto.CreateAddress2(c.msg.Address, wordToHash(salt), input)
 	}
 
 	contract := runtime.NewContractCreation(c.msg.Depth+1, c.msg.Origin, c.msg.Address, address, value, gas, input)
@@ -1414,24 +1291,3 @@ func opHalt(op OpCode) instruction {
 		}
 	}
 }
-
-var (
-	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)   // 2 ** 256
-	tt256m1 = new(big.Int).Sub(tt256, big.NewInt(1)) // 2 ** 256 - 1
-)
-
-func toU256(x *big.Int) *big.Int {
-	if x.Sign() < 0 || x.BitLen() > 256 {
-		x.And(x, tt256m1)
-	}
-
-	return x
-}
-
-func to256(x *big.Int) *big.Int {
-	if x.BitLen() > 255 {
-		x.Sub(x, tt256)
-	}
-
-	return x
-}
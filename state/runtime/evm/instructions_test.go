@@ -1,26 +1,26 @@
 package evm
 
 import (
-	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	two = big.NewInt(2)
+	two = uint256.NewInt(2)
 
 	allEnabledForks = chain.AllForksEnabled.At(0)
 )
 
 type cases2To1 []struct {
-	a *big.Int
-	b *big.Int
-	c *big.Int
+	a *uint256.Int
+	b *uint256.Int
+	c *uint256.Int
 }
 
 func test2to1(t *testing.T, f instruction, tests cases2To1) {
@@ -40,8 +40,8 @@ func test2to1(t *testing.T, f instruction, tests cases2To1) {
 }
 
 type cases2ToBool []struct {
-	a *big.Int
-	b *big.Int
+	a *uint256.Int
+	b *uint256.Int
 	c bool
 }
 
@@ -65,6 +65,15 @@ func test2toBool(t *testing.T, f instruction, tests cases2ToBool) {
 	}
 }
 
+// newTestStack returns the stack which holds the given words, the last word being on top of the stack
+func newTestStack(words ...*uint256.Int) (stack [stackSize + 1]uint256.Int) {
+	for i, word := range words {
+		stack[i].Set(word)
+	}
+
+	return stack
+}
+
 func TestAdd(t *testing.T) {
 	test2to1(t, opAdd, cases2To1{
 		{one, one, two},
@@ -88,12 +97,48 @@ func TestIsZero(t *testing.T) {
 	})
 }
 
+func TestSignedArithmetic(t *testing.T) {
+	var (
+		minusTwo   = new(uint256.Int).Neg(two)
+		minusEight = new(uint256.Int).Neg(uint256.NewInt(8))
+		three      = uint256.NewInt(3)
+	)
+
+	// the second operand is on top of the stack, so it is the dividend
+	test2to1(t, opSDiv, cases2To1{
+		{three, minusEight, minusTwo},
+		{zero, minusEight, zero},
+	})
+
+	test2to1(t, opSMod, cases2To1{
+		{three, minusEight, minusTwo},
+		{zero, minusEight, zero},
+	})
+
+	test2toBool(t, opSlt, cases2ToBool{
+		{one, minusTwo, true},
+		{minusTwo, one, false},
+	})
+}
+
+func TestPushTruncatedCode(t *testing.T) {
+	s, closeFn := getState()
+	defer closeFn()
+
+	// PUSH2 with a single byte left in the code, missing byte is zero
+	s.code = []byte{PUSH1 + 1, 0xab}
+
+	opPush(2)(s)
+
+	assert.Equal(t, uint256.NewInt(0xab00), s.pop())
+}
+
 func TestMStore(t *testing.T) {
 	s, closeFn := getState()
 	defer closeFn()
 
-	s.push(big.NewInt(10))   // value
-	s.push(big.NewInt(1024)) // offset
+	s.push(uint256.NewInt(10))   // value
+	s.push(uint256.NewInt(1024)) // offset
 
 	s.gas = 1000
 	opMStore(s)
@@ -128,14 +173,14 @@ func TestCreate(t *testing.T) {
 	type state struct {
 		gas    uint64
 		sp     int
-		stack  []*big.Int
+		stack  [stackSize + 1]uint256.Int
 		memory []byte
 		stop   bool
 		err    error
 	}
 
-	addressToBigInt := func(addr types.Address) *big.Int {
-		return new(big.Int).SetBytes(addr[:])
+	addressToWord := func(addr types.Address) *uint256.Int {
+		return new(uint256.Int).SetBytes(addr[:])
 	}
 
 	tests := []struct {
@@ -158,11 +203,11 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -170,11 +215,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 500,
 				sp:  1,
-				stack: []*big.Int{
-					addressToBigInt(crypto.CreateAddress(addr1, 0)), // contract address
-					big.NewInt(0x00),
-					big.NewInt(0x00),
-				},
+				stack: newTestStack(
+					addressToWord(crypto.CreateAddress(addr1, 0)), // contract address
+					uint256.NewInt(0x00),
+					uint256.NewInt(0x00),
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -197,11 +242,11 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -212,11 +257,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -235,11 +280,11 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -250,11 +295,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -276,11 +321,11 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -291,12 +336,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  1,
-				stack: []*big.Int{
-					// need to init with 0x01 to add abs field in big.Int
-					big.NewInt(0x01).SetInt64(0x00),
-					big.NewInt(0x00),
-					big.NewInt(0x00),
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x00),
+					uint256.NewInt(0x00),
+					uint256.NewInt(0x00),
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -324,11 +368,11 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  3,
-				stack: []*big.Int{
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -339,12 +383,11 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 1000,
 				sp:  1,
-				stack: []*big.Int{
-					// need to init with 0x01 to add abs field in big.Int
-					big.NewInt(0x01).SetInt64(0x00),
-					big.NewInt(0x00),
-					big.NewInt(0x00),
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x00),
+					uint256.NewInt(0x00),
+					uint256.NewInt(0x00),
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -373,12 +416,12 @@ func TestCreate(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  4,
-				stack: []*big.Int{
-					big.NewInt(0x01), // salt
-					big.NewInt(0x01), // length
-					big.NewInt(0x00), // offset
-					big.NewInt(0x00), // value
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x01), // salt
+					uint256.NewInt(0x01), // length
+					uint256.NewInt(0x00), // offset
+					uint256.NewInt(0x00), // value
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -389,12 +432,12 @@ func TestCreate(t *testing.T) {
 			resultState: &state{
 				gas: 15,
 				sp:  1,
-				stack: []*big.Int{
-					big.NewInt(0x01).SetInt64(0x00),
-					big.NewInt(0x01),
-					big.NewInt(0x00),
-					big.NewInt(0x00),
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x00),
+					uint256.NewInt(0x01),
+					uint256.NewInt(0x00),
+					uint256.NewInt(0x00),
+				),
 				memory: []byte{
 					byte(REVERT),
 				},
@@ -464,20 +507,20 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if memOffset is negative",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1),  // length
-					big.NewInt(0),  // dataOffset
-					big.NewInt(-1), // memOffset
-				},
+				stack: newTestStack(
+					uint256.NewInt(1),            // length
+					uint256.NewInt(0),            // dataOffset
+					new(uint256.Int).SetAllOne(), // memOffset
+				),
 				sp: 3,
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					big.NewInt(0),
-					big.NewInt(-1),
-				},
+				stack: newTestStack(
+					uint256.NewInt(1),
+					uint256.NewInt(0),
+					new(uint256.Int).SetAllOne(),
+				),
 				sp:   0,
 				stop: true,
 				err:  errReturnDataOutOfBounds,
@@ -487,21 +530,21 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if dataOffset is negative",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1),  // length
-					big.NewInt(-1), // dataOffset
-					big.NewInt(0),  // memOffset
-				},
+				stack: newTestStack(
+					uint256.NewInt(1),            // length
+					new(uint256.Int).SetAllOne(), // dataOffset
+					uint256.NewInt(0),            // memOffset
+				),
 				sp:     3,
 				memory: make([]byte, 1),
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					big.NewInt(-1),
-					big.NewInt(0),
-				},
+				stack: newTestStack(
+					uint256.NewInt(1),
+					new(uint256.Int).SetAllOne(),
+					uint256.NewInt(0),
+				),
 				sp:     0,
 				memory: make([]byte, 1),
 				stop:   true,
@@ -512,20 +555,20 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should return error if length is negative",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(-1), // length
-					big.NewInt(0),  // dataOffset
-					big.NewInt(0),  // memOffset
-				},
+				stack: newTestStack(
+					new(uint256.Int).SetAllOne(), // length
+					uint256.NewInt(0),            // dataOffset
+					uint256.NewInt(0),            // memOffset
+				),
 				sp: 3,
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(-1),
-					big.NewInt(0),
-					big.NewInt(0),
-				},
+				stack: newTestStack(
+					new(uint256.Int).SetAllOne(),
+					uint256.NewInt(0),
+					uint256.NewInt(0),
+				),
 				sp:   0,
 				stop: true,
 				err:  errReturnDataOutOfBounds,
@@ -535,11 +578,11 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should copy data from returnData to memory",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(1), // length
-					big.NewInt(0), // dataOffset
-					big.NewInt(0), // memOffset
-				},
+				stack: newTestStack(
+					uint256.NewInt(1), // length
+					uint256.NewInt(0), // dataOffset
+					uint256.NewInt(0), // memOffset
+				),
 				sp:         3,
 				returnData: []byte{0xff},
 				memory:     []byte{0x0},
@@ -547,11 +590,11 @@ func Test_opReturnDataCopy(t *testing.T) {
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(1),
-					big.NewInt(0),
-					big.NewInt(0),
-				},
+				stack: newTestStack(
+					uint256.NewInt(1),
+					uint256.NewInt(0),
+					uint256.NewInt(0),
+				),
 				sp:                 0,
 				returnData:         []byte{0xff},
 				memory:             []byte{0xff},
@@ -566,11 +609,11 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should expand memory and copy data returnData",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(5), // length
-					big.NewInt(1), // dataOffset
-					big.NewInt(2), // memOffset
-				},
+				stack: newTestStack(
+					uint256.NewInt(5), // length
+					uint256.NewInt(1), // dataOffset
+					uint256.NewInt(2), // memOffset
+				),
 				sp:         3,
 				returnData: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
 				memory:     []byte{0x11, 0x22},
@@ -578,11 +621,11 @@ func Test_opReturnDataCopy(t *testing.T) {
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(6), // updated for end index
-					big.NewInt(1),
-					big.NewInt(2),
-				},
+				stack: newTestStack(
+					uint256.NewInt(6), // updated for end index
+					uint256.NewInt(1),
+					uint256.NewInt(2),
+				),
 				sp:         0,
 				returnData: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
 				memory: append(
@@ -602,22 +645,22 @@ func Test_opReturnDataCopy(t *testing.T) {
 			name:   "should not copy data if length is zero",
 			config: &allEnabledForks,
 			initState: &state{
-				stack: []*big.Int{
-					big.NewInt(0), // length
-					big.NewInt(0), // dataOffset
-					big.NewInt(4), // memOffset
-				},
+				stack: newTestStack(
+					uint256.NewInt(0), // length
+					uint256.NewInt(0), // dataOffset
+					uint256.NewInt(4), // memOffset
+				),
 				sp:         3,
 				returnData: []byte{0x01},
 				memory:     []byte{0x02},
 			},
 			resultState: &state{
 				config: &allEnabledForks,
-				stack: []*big.Int{
-					big.NewInt(0),
-					big.NewInt(0),
-					big.NewInt(4),
-				},
+				stack: newTestStack(
+					uint256.NewInt(0),
+					uint256.NewInt(0),
+					uint256.NewInt(4),
+				),
 				sp:         0,
 				returnData: []byte{0x01},
 				memory:     []byte{0x02},
@@ -682,14 +725,14 @@ func Test_opCall(t *testing.T) {
 			initState: &state{
 				gas: 1000,
 				sp:  6,
-				stack: []*big.Int{
-					big.NewInt(0x00), // outSize
-					big.NewInt(0x02), // outOffset
-					big.NewInt(0x00), // inSize
-					big.NewInt(0x00), // inOffset
-					big.NewInt(0x00), // address
-					big.NewInt(0x00), // initialGas
-				},
+				stack: newTestStack(
+					uint256.NewInt(0x00), // outSize
+					uint256.NewInt(0x02), // outOffset
+					uint256.NewInt(0x00), // inSize
+					uint256.NewInt(0x00), // inOffset
+					uint256.NewInt(0x00), // address
+					uint256.NewInt(0x00), // initialGas
+				),
 				memory: []byte{0x01},
 			},
 			resultState: &state{
@@ -2,7 +2,6 @@ package evm
 
 import (
 	"errors"
-	"math/big"
 	"strings"
 
 	"sync"
@@ -12,11 +11,14 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 var statePool = sync.Pool{
 	New: func() interface{} {
-		return new(state)
+		return &state{
+			memory: make([]byte, 0, initialMemorySize),
+		}
 	},
 }
 
@@ -34,7 +36,12 @@ func releaseState(s *state) {
 	statePool.Put(s)
 }
 
-const stackSize = 1024
+const (
+	stackSize = 1024
+
+	// initialMemorySize is the memory capacity preallocated for each state
+	initialMemorySize = 4 * 1024
+)
 
 var (
 	errOutOfGas              = runtime.ErrOutOfGas
@@ -61,8 +68,9 @@ type state struct {
 	memory      []byte
 	lastGasCost uint64
 
-	// stack
-	stack []*big.Int
+	// stack is preallocated, it can exceed the stack size by a single word,
+	// because the stack overflow is checked after the instruction is executed
+	stack [stackSize + 1]uint256.Int
 	sp    int
 
 	// remove later
@@ -98,7 +106,6 @@ func (c *state) reset() {
 		c.memory[i] = 0
 	}
 
-	c.stack = c.stack[:0]
 	c.tmp = c.tmp[:0]
 	c.ret = c.ret[:0]
 	c.code = c.code[:0]
@@ -106,13 +113,12 @@ func (c *state) reset() {
 	c.memory = c.memory[:0]
 }
 
-func (c *state) validJumpdest(dest *big.Int) bool {
-	udest := dest.Uint64()
-	if dest.BitLen() >= 63 || udest >= uint64(len(c.code)) {
+func (c *state) validJumpdest(dest *uint256.Int) bool {
+	if !dest.IsUint64() || dest.Uint64() >= uint64(len(c.code)) {
 		return false
 	}
 
-	return c.bitmap.isSet(udest)
+	return c.bitmap.isSet(dest.Uint64())
 }
 
 func (c *state) Halt() {
@@ -128,22 +134,15 @@ func (c *state) exit(err error) {
 	c.err = err
 }
 
-func (c *state) push(val *big.Int) {
+func (c *state) push(val *uint256.Int) {
 	c.push1().Set(val)
 }
 
-func (c *state) push1() *big.Int {
-	if len(c.stack) > c.sp {
-		c.sp++
-
-		return c.stack[c.sp-1]
-	}
-
-	v := big.NewInt(0)
-	c.stack = append(c.stack, v)
+// push1 pushes a new word on the stack and returns it, so the caller can set its value
+func (c *state) push1() *uint256.Int {
 	c.sp++
 
-	return v
+	return &c.stack[c.sp-1]
 }
 
 func (c *state) stackAtLeast(n int) bool {
@@ -151,7 +150,7 @@ func (c *state) stackAtLeast(n int) bool {
 }
 
 func (c *state) popHash() types.Hash {
-	return types.BytesToHash(c.pop().Bytes())
+	return c.pop().Bytes32()
 }
 
 func (c *state) popAddr() (types.Address, bool) {
@@ -160,34 +159,36 @@ func (c *state) popAddr() (types.Address, bool) {
 		return types.Address{}, false
 	}
 
-	return types.BytesToAddress(b.Bytes()), true
+	return b.Bytes20(), true
 }
 
 func (c *state) stackSize() int {
 	return c.sp
 }
 
-func (c *state) top() *big.Int {
+func (c *state) top() *uint256.Int {
 	if c.sp == 0 {
 		return nil
 	}
 
-	return c.stack[c.sp-1]
+	return &c.stack[c.sp-1]
 }
 
-func (c *state) pop() *big.Int {
+// pop removes the top word from the stack and returns it.
+// The returned word is valid until the next push
+func (c *state) pop() *uint256.Int {
 	if c.sp == 0 {
 		return nil
 	}
 
-	o := c.stack[c.sp-1]
+	o := &c.stack[c.sp-1]
 	c.sp--
 
 	return o
 }
 
-func (c *state) peekAt(n int) *big.Int {
-	return c.stack[c.sp-n]
+func (c *state) peekAt(n int) *uint256.Int {
+	return &c.stack[c.sp-n]
 }
 
 func (c *state) swap(n int) {
@@ -283,8 +284,8 @@ func (c *state) inStaticCall() bool {
 	return c.msg.Static
 }
 
-func bigToHash(b *big.Int) types.Hash {
-	return types.BytesToHash(b.Bytes())
+func wordToHash(w *uint256.Int) types.Hash {
+	return w.Bytes32()
 }
 
 func (c *state) Len() int {
@@ -294,14 +295,14 @@ func (c *state) Len() int {
 // allocateMemory allocates memory to enable accessing in the range of [offset, offset+size]
 // throws error if the given offset and size are negative
 // consumes gas if memory needs to be expanded
-func (c *state) allocateMemory(offset, size *big.Int) bool {
+func (c *state) allocateMemory(offset, size *uint256.Int) bool {
 	if !offset.IsUint64() || !size.IsUint64() {
 		c.exit(errReturnDataOutOfBounds)
 
 		return false
 	}
 
-	if size.Sign() == 0 {
+	if size.IsZero() {
 		return true
 	}
 
@@ -333,8 +334,8 @@ func (c *state) allocateMemory(offset, size *big.Int) bool {
 	return true
 }
 
-func (c *state) get2(dst []byte, offset, length *big.Int) ([]byte, bool) {
-	if length.Sign() == 0 {
+func (c *state) get2(dst []byte, offset, length *uint256.Int) ([]byte, bool) {
+	if length.IsZero() {
 		return nil, true
 	}
 
@@ -381,7 +382,7 @@ func (c *state) captureState(opCode int) {
 
 	tracer.CaptureState(
 		c.memory,
-		c.stack,
+		c.stack[:c.sp],
 		opCode,
 		c.msg.Address,
 		c.sp,
@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 )
 
 type Config struct {
@@ -125,7 +126,7 @@ func (t *StructTracer) CallEnd(
 
 func (t *StructTracer) CaptureState(
 	memory []byte,
-	stack []*big.Int,
+	stack []uint256.Int,
 	opCode int,
 	contractAddress types.Address,
 	sp int,
@@ -169,7 +170,7 @@ func (t *StructTracer) captureMemory(
 }
 
 func (t *StructTracer) captureStack(
-	stack []*big.Int,
+	stack []uint256.Int,
 	sp int,
 	opCode int,
 ) {
@@ -179,8 +180,8 @@ func (t *StructTracer) captureStack(
 
 	currentStack := make([]*big.Int, sp)
 
-	for i, v := range stack[:sp] {
-		currentStack[i] = new(big.Int).Set(v)
+	for i := range stack[:sp] {
+		currentStack[i] = stack[i].ToBig()
 	}
 
 	t.currentStack[len(t.currentStack)-1] = currentStack
@@ -191,7 +192,7 @@ func (t *StructTracer) captureStack(
 }
 
 func (t *StructTracer) captureStorage(
-	stack []*big.Int,
+	stack []uint256.Int,
 	opCode int,
 	contractAddress types.Address,
 	sp int,
@@ -214,7 +215,7 @@ func (t *StructTracer) captureStorage(
 	switch opCode {
 	case evm.SLOAD:
 		if sp >= 1 {
-			slot := types.BytesToHash(stack[sp-1].Bytes())
+			slot := types.Hash(stack[sp-1].Bytes32())
 			value := host.GetStorage(contractAddress, slot)
 
 			addToStorage(slot, value)
@@ -222,8 +223,8 @@ func (t *StructTracer) captureStorage(
 
 	case evm.SSTORE:
 		if sp >= 2 {
-			slot := types.BytesToHash(stack[sp-1].Bytes())
-			value := types.BytesToHash(stack[sp-2].Bytes())
+			slot := types.Hash(stack[sp-1].Bytes32())
+			value := types.Hash(stack[sp-2].Bytes32())
 
 			addToStorage(slot, value)
 		}
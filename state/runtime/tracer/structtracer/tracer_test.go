@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -321,9 +322,9 @@ func TestStructTracerCaptureState(t *testing.T) {
 
 	var (
 		memory = [][]byte{[]byte("memory")}
-		stack  = []([]*big.Int){[]*big.Int{
-			big.NewInt(1), /* value */
-			big.NewInt(2), /* key */
+		stack  = []([]uint256.Int){[]uint256.Int{
+			*uint256.NewInt(1), /* value */
+			*uint256.NewInt(2), /* key */
 		}}
 		contractAddress = types.StringToAddress("3")
 		storageValue    = types.StringToHash("4")
@@ -337,7 +338,7 @@ func TestStructTracerCaptureState(t *testing.T) {
 
 		// input
 		memory          [][]byte
-		stack           [][]*big.Int
+		stack           [][]uint256.Int
 		opCode          int
 		contractAddress types.Address
 		sp              int
@@ -394,7 +395,10 @@ func TestStructTracerCaptureState(t *testing.T) {
 				Config: Config{
 					EnableStack: true,
 				},
-				currentStack: stack,
+				currentStack: []([]*big.Int){[]*big.Int{
+					big.NewInt(1),
+					big.NewInt(2),
+				}},
 			},
 			expectedVMState: &mockState{},
 		},
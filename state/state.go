@@ -17,6 +17,13 @@ type State interface {
 	GetCode(hash types.Hash) ([]byte, bool)
 }
 
+// PrefetchState is the state whose caches can be warmed up ahead of the block execution
+type PrefetchState interface {
+	// NewPrefetchSnapshotAt returns the snapshot at the given root whose reads warm up the state caches.
+	// Unlike the snapshots returned by NewSnapshotAt, it is safe to read it concurrently with them
+	NewPrefetchSnapshotAt(types.Hash) (Snapshot, error)
+}
+
 type Snapshot interface {
 	readSnapshot
 
@@ -1,12 +1,18 @@
 package state
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -454,3 +460,410 @@ func testSetAndGetCode(t *testing.T, buildPreState buildPreState) {
 	assert.True(t, ok)
 	assert.Equal(t, testCode, code)
 }
+
+var (
+	// parallelCounterCode increments the counter in the slot 0
+	parallelCounterCode = []byte{0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00}
+	// parallelSenderCounterCode increments the counter in the slot of the caller and emits the log
+	parallelSenderCounterCode = []byte{0x33, 0x54, 0x60, 0x01, 0x01, 0x33, 0x55, 0x60, 0x00, 0x60, 0x00, 0xa0, 0x00}
+	// parallelCoinbaseBalanceCode stores the balance of the coinbase in the slot of the caller
+	parallelCoinbaseBalanceCode = []byte{0x41, 0x31, 0x33, 0x55, 0x00}
+	// parallelRevertCode writes the slot 0 and reverts
+	parallelRevertCode = []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x60, 0x00, 0x60, 0x00, 0xfd}
+	// parallelSelfdestructCode sends its balance to the caller and selfdestructs
+	parallelSelfdestructCode = []byte{0x33, 0xff}
+	// parallelInitCode writes the slot 0 of the created contract
+	parallelInitCode = []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x00}
+
+	parallelCounterAddr       = types.StringToAddress("0x1000")
+	parallelSenderCounterAddr = types.StringToAddress("0x1001")
+	parallelCoinbaseAddr      = types.StringToAddress("0x1002")
+	parallelRevertAddr        = types.StringToAddress("0x1003")
+	parallelBurnAddr          = types.StringToAddress("0x1004")
+	parallelCoinbase          = types.StringToAddress("0x1005")
+)
+
+const (
+	parallelSenders   = 16
+	parallelGasLimit  = 10_000_000
+	parallelTxGas     = 100_000
+	parallelWorkers   = 4
+	parallelBaseFee   = 1
+	parallelSelfDests = 4
+)
+
+// TestParallelExecution checks that the transactions executed in parallel (see Transition.WriteParallel)
+// produce the same errors, receipts and state as the transactions executed sequentially
+func TestParallelExecution(t *testing.T, buildState func() State) {
+	t.Helper()
+	t.Parallel()
+
+	allowedSponsorKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	otherSponsorKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	allowedSponsor := crypto.PubKeyToAddress(&allowedSponsorKey.PublicKey)
+	otherSponsor := crypto.PubKeyToAddress(&otherSponsorKey.PublicKey)
+
+	tests := []struct {
+		name      string
+		gasLimit  uint64
+		configure func(params *chain.Params, genesis *chain.Genesis)
+		txs       func() []*types.Transaction
+	}{
+		{
+			name: "independent transfers",
+			txs: func() []*types.Transaction {
+				txs := make([]*types.Transaction, parallelSenders)
+				for i := range txs {
+					txs[i] = parallelTx(i, 0, parallelAddress(100+i), 10)
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "dependent transfers",
+			txs: func() []*types.Transaction {
+				txs := []*types.Transaction{}
+				for nonce := uint64(0); nonce < 3; nonce++ {
+					for i := 0; i < parallelSenders-1; i++ {
+						// every sender pays the next one, so the following transactions read the updated balances
+						txs = append(txs, parallelTx(i, nonce, parallelAddress(i+1), 10))
+					}
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "shared counter",
+			txs: func() []*types.Transaction {
+				txs := make([]*types.Transaction, parallelSenders)
+				for i := range txs {
+					txs[i] = parallelTx(i, 0, parallelCounterAddr, 0)
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "sender counters",
+			txs: func() []*types.Transaction {
+				txs := []*types.Transaction{}
+				for nonce := uint64(0); nonce < 2; nonce++ {
+					for i := 0; i < parallelSenders; i++ {
+						txs = append(txs, parallelTx(i, nonce, parallelSenderCounterAddr, 0))
+					}
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "coinbase balance and reverts",
+			txs: func() []*types.Transaction {
+				txs := make([]*types.Transaction, parallelSenders)
+				for i := range txs {
+					to := parallelCoinbaseAddr
+					if i%2 == 0 {
+						to = parallelRevertAddr
+					}
+
+					txs[i] = parallelTx(i, 0, to, 10)
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "contract creation and selfdestruct",
+			txs: func() []*types.Transaction {
+				txs := make([]*types.Transaction, 0, parallelSenders)
+				for i := 0; i < parallelSelfDests; i++ {
+					txs = append(txs, parallelTx(i, 0, parallelAddress(200+i), 0))
+				}
+
+				for i := parallelSelfDests; i < parallelSenders; i++ {
+					txs = append(txs, parallelTx(i, 0, types.ZeroAddress, 0))
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "invalid nonce",
+			txs: func() []*types.Transaction {
+				return []*types.Transaction{
+					parallelTx(0, 0, parallelAddress(1), 10),
+					parallelTx(1, 1, parallelAddress(2), 10),
+					parallelTx(2, 0, parallelAddress(3), 10),
+				}
+			},
+		},
+		{
+			name:     "block gas limit",
+			gasLimit: 5 * parallelTxGas,
+			txs: func() []*types.Transaction {
+				txs := make([]*types.Transaction, parallelSenders)
+				for i := range txs {
+					txs[i] = parallelTx(i, 0, parallelCounterAddr, 0)
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "call policy",
+			configure: func(params *chain.Params, _ *chain.Genesis) {
+				params.CallPolicy = &chain.CallPolicyConfig{
+					Restrictions: []*chain.CallRestrictionConfig{
+						{Target: parallelSenderCounterAddr, Selector: addresslist.AnySelector},
+					},
+					Permissions: []*chain.CallPermissionConfig{
+						{Caller: parallelAddress(0), Target: parallelSenderCounterAddr, Selector: addresslist.AnySelector},
+					},
+				}
+			},
+			txs: func() []*types.Transaction {
+				// only the first sender is permitted to call the restricted sender counter
+				txs := make([]*types.Transaction, parallelSenders)
+				for i := range txs {
+					txs[i] = parallelTx(i, 0, parallelSenderCounterAddr, 0)
+				}
+
+				return txs
+			},
+		},
+		{
+			name: "sponsor allow list",
+			configure: func(params *chain.Params, genesis *chain.Genesis) {
+				params.SponsorAllowList = &chain.AddressListConfig{
+					EnabledAddresses: []types.Address{allowedSponsor},
+				}
+
+				genesis.Alloc[allowedSponsor] = &chain.GenesisAccount{Balance: big.NewInt(1_000_000_000)}
+				genesis.Alloc[otherSponsor] = &chain.GenesisAccount{Balance: big.NewInt(1_000_000_000)}
+			},
+			txs: func() []*types.Transaction {
+				// the sponsor which is not in the allow list can't pay the gas of the transaction
+				txs := []*types.Transaction{
+					parallelSponsoredTx(t, 0, parallelSenderCounterAddr, allowedSponsorKey),
+					parallelSponsoredTx(t, 1, parallelSenderCounterAddr, otherSponsorKey),
+				}
+
+				for i := 2; i < parallelSenders; i++ {
+					txs = append(txs, parallelTx(i, 0, parallelSenderCounterAddr, 0))
+				}
+
+				return txs
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gasLimit := tc.gasLimit
+			if gasLimit == 0 {
+				gasLimit = parallelGasLimit
+			}
+
+			sequential := newParallelTestTransition(t, buildState, gasLimit, tc.configure)
+			sequentialErrs := make([]error, 0)
+
+			for _, tx := range tc.txs() {
+				sequentialErrs = append(sequentialErrs, sequential.Write(tx))
+			}
+
+			parallel := newParallelTestTransition(t, buildState, gasLimit, tc.configure)
+			parallelErrs := parallel.WriteParallel(tc.txs(), parallelWorkers)
+
+			require.Equal(t, sequentialErrs, parallelErrs)
+			require.Equal(t, sequential.TotalGas(), parallel.TotalGas())
+			require.Equal(t, sequential.Receipts(), parallel.Receipts())
+
+			_, sequentialRoot, err := sequential.Commit()
+			require.NoError(t, err)
+
+			_, parallelRoot, err := parallel.Commit()
+			require.NoError(t, err)
+
+			require.Equal(t, sequentialRoot, parallelRoot)
+		})
+	}
+
+	t.Run("process block", func(t *testing.T) {
+		t.Parallel()
+
+		txs := make([]*types.Transaction, 0, 2*parallelSenders)
+		for i := 0; i < parallelSenders; i++ {
+			txs = append(txs,
+				parallelTx(i, 0, parallelSenderCounterAddr, 0),
+				parallelTx(i, 1, parallelCounterAddr, 0))
+		}
+
+		results := make([]*Transition, 2)
+
+		for i, parallelExecution := range []bool{false, true} {
+			executor, root := newParallelTestExecutor(t, buildState, nil)
+			executor.ParallelExecution = parallelExecution
+
+			block := &types.Block{
+				Header:       parallelTestHeader(parallelGasLimit),
+				Transactions: txs,
+			}
+
+			txn, err := executor.ProcessBlock(root, block, parallelCoinbase)
+			require.NoError(t, err)
+
+			results[i] = txn
+		}
+
+		require.Equal(t, results[0].Receipts(), results[1].Receipts())
+
+		_, sequentialRoot, err := results[0].Commit()
+		require.NoError(t, err)
+
+		_, parallelRoot, err := results[1].Commit()
+		require.NoError(t, err)
+
+		require.Equal(t, sequentialRoot, parallelRoot)
+	})
+}
+
+func newParallelTestExecutor(t *testing.T, buildState func() State,
+	configure func(params *chain.Params, genesis *chain.Genesis)) (*Executor, types.Hash) {
+	t.Helper()
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		parallelCounterAddr:       {Code: parallelCounterCode},
+		parallelSenderCounterAddr: {Code: parallelSenderCounterCode},
+		parallelCoinbaseAddr:      {Code: parallelCoinbaseBalanceCode},
+		parallelRevertAddr:        {Code: parallelRevertCode},
+		parallelCoinbase:          {Balance: big.NewInt(1)},
+	}
+
+	for i := 0; i < parallelSenders; i++ {
+		alloc[parallelAddress(i)] = &chain.GenesisAccount{Balance: big.NewInt(1_000_000_000)}
+	}
+
+	for i := 0; i < parallelSelfDests; i++ {
+		alloc[parallelAddress(200+i)] = &chain.GenesisAccount{
+			Code:    parallelSelfdestructCode,
+			Balance: big.NewInt(1000),
+			Storage: map[types.Hash]types.Hash{hash1: hash1},
+		}
+	}
+
+	params := &chain.Params{
+		Forks:        chain.AllForksEnabled,
+		ChainID:      100,
+		BurnContract: map[uint64]types.Address{0: parallelBurnAddr},
+	}
+
+	genesis := &chain.Genesis{Alloc: alloc}
+
+	if configure != nil {
+		configure(params, genesis)
+	}
+
+	if params.CallPolicy != nil {
+		addresslist.ApplyCallPolicyGenesisAllocs(genesis, contracts.CallPolicyAddr, params.CallPolicy)
+	}
+
+	if params.SponsorAllowList != nil {
+		addresslist.ApplyGenesisAllocs(genesis, contracts.AllowListSponsorsAddr, params.SponsorAllowList)
+	}
+
+	executor := NewExecutor(params, buildState(), hclog.NewNullLogger())
+
+	executor.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.Hash{}
+		}
+	}
+
+	root, err := executor.WriteGenesis(alloc, types.ZeroHash)
+	require.NoError(t, err)
+
+	return executor, root
+}
+
+func newParallelTestTransition(t *testing.T, buildState func() State, gasLimit uint64,
+	configure func(params *chain.Params, genesis *chain.Genesis)) *Transition {
+	t.Helper()
+
+	executor, root := newParallelTestExecutor(t, buildState, configure)
+
+	transition, err := executor.BeginTxn(root, parallelTestHeader(gasLimit), parallelCoinbase)
+	require.NoError(t, err)
+
+	return transition
+}
+
+func parallelTestHeader(gasLimit uint64) *types.Header {
+	return &types.Header{
+		Number:   1,
+		GasLimit: gasLimit,
+		BaseFee:  parallelBaseFee,
+	}
+}
+
+// parallelTx creates the transaction of the given sender (see parallelAddress)
+// which transfers the value to the recipient or creates the contract if the recipient is not set
+func parallelTx(sender int, nonce uint64, to types.Address, value int64) *types.Transaction {
+	tx := &types.Transaction{
+		Nonce:    nonce,
+		From:     parallelAddress(sender),
+		Value:    big.NewInt(value),
+		Gas:      parallelTxGas,
+		GasPrice: big.NewInt(parallelBaseFee + 1),
+	}
+
+	if to != types.ZeroAddress {
+		tx.To = &to
+	} else {
+		tx.Input = parallelInitCode
+	}
+
+	return tx.ComputeHash(1)
+}
+
+// parallelSponsoredTx creates the transaction of the given sender (see parallelAddress)
+// which calls the recipient, with the gas paid by the sponsor
+func parallelSponsoredTx(t *testing.T, sender int, to types.Address, sponsorKey *ecdsa.PrivateKey) *types.Transaction {
+	t.Helper()
+
+	tx := &types.Transaction{
+		Type:      types.SponsoredTx,
+		ChainID:   big.NewInt(100),
+		From:      parallelAddress(sender),
+		To:        &to,
+		Value:     big.NewInt(0),
+		Gas:       parallelTxGas,
+		GasFeeCap: big.NewInt(parallelBaseFee + 1),
+		GasTipCap: big.NewInt(1),
+		Sponsor:   crypto.PubKeyToAddress(&sponsorKey.PublicKey),
+	}
+
+	hash := crypto.NewSponsorSigner(100, true, nil).SponsorHash(tx)
+
+	sig, err := crypto.Sign(sponsorKey, hash[:])
+	require.NoError(t, err)
+
+	tx.SponsorR = new(big.Int).SetBytes(sig[:32])
+	tx.SponsorS = new(big.Int).SetBytes(sig[32:64])
+	tx.SponsorV = new(big.Int).SetBytes([]byte{sig[64]})
+
+	return tx.ComputeHash(1)
+}
+
+func parallelAddress(i int) types.Address {
+	return types.BytesToAddress(big.NewInt(int64(0x10000 + i)).Bytes())
+}
@@ -4,10 +4,17 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func newTestTransition(preState map[types.Address]*PreState) *Transition {
@@ -160,3 +167,177 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransition_IsCallPermitted(t *testing.T) {
+	t.Parallel()
+
+	caller := types.Address{0x1}
+	target := types.Address{0x2}
+	input := []byte{0x1, 0x2, 0x3, 0x4}
+
+	transition := newTestTransition(nil)
+	transition.ctx.Timestamp = 100
+
+	newCall := func(caller, to types.Address, input []byte) *runtime.Contract {
+		return runtime.NewContractCall(1, caller, caller, to, big.NewInt(0), 0, nil, input)
+	}
+
+	// the calls are not checked without the call policy
+	assert.True(t, transition.isCallPermitted(newCall(caller, target, input)))
+
+	transition.callPolicy = addresslist.NewCallPolicy(transition, contracts.CallPolicyAddr)
+	transition.callPolicy.SetRestricted(target, addresslist.CallSelector(input), true)
+
+	assert.False(t, transition.isCallPermitted(newCall(caller, target, input)))
+	assert.True(t, transition.isCallPermitted(newCall(caller, target, nil)))
+	assert.True(t, transition.isCallPermitted(newCall(contracts.SystemCaller, target, input)))
+	assert.True(t, transition.isCallPermitted(newCall(caller, contracts.CallPolicyAddr, input)))
+
+	// the permission is checked against the block timestamp
+	transition.callPolicy.SetPermission(caller, target, addresslist.CallSelector(input),
+		addresslist.Permission{Granted: true, ValidFrom: 50, ValidUntil: 99})
+	assert.False(t, transition.isCallPermitted(newCall(caller, target, input)))
+
+	transition.callPolicy.SetPermission(caller, target, addresslist.CallSelector(input),
+		addresslist.Permission{Granted: true, ValidFrom: 50})
+	assert.True(t, transition.isCallPermitted(newCall(caller, target, input)))
+}
+
+func TestTransition_IsCallPermitted_DelegatedCalls(t *testing.T) {
+	t.Parallel()
+
+	caller := types.Address{0x1}
+	proxy := types.Address{0x2}
+	target := types.Address{0x3}
+	selector := [types.SignatureSize]byte{0x1, 0x2, 0x3, 0x4}
+
+	// proxyCode returns the code which calls the target with the selector (by the given opcode)
+	// and returns the success flag of the call
+	proxyCode := func(op byte) []byte {
+		code := []byte{0x63} // PUSH4 selector
+		code = append(code, selector[:]...)
+		code = append(code,
+			0x60, 0xe0, 0x1b, // PUSH1 0xe0, SHL
+			0x60, 0x00, 0x52, // PUSH1 0, MSTORE
+			0x60, 0x00, 0x60, 0x00, // retLen, retOffset
+			0x60, 0x04, 0x60, 0x00, // argsLen, argsOffset
+		)
+
+		if op == 0xf2 {
+			code = append(code, 0x60, 0x00) // CALLCODE value
+		}
+
+		code = append(code, 0x73) // PUSH20 target
+		code = append(code, target.Bytes()...)
+
+		return append(code,
+			0x5a, op, // GAS, (DELEGATECALL | CALLCODE)
+			0x60, 0x00, 0x52, // PUSH1 0, MSTORE
+			0x60, 0x20, 0x60, 0x00, 0xf3, // PUSH1 0x20, PUSH1 0, RETURN
+		)
+	}
+
+	cases := []struct {
+		name string
+		op   byte
+	}{
+		{"delegatecall", 0xf4},
+		{"callcode", 0xf2},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestTransition(map[types.Address]*PreState{
+				caller: {Balance: 1},
+				proxy:  {Balance: 1},
+				target: {Balance: 1},
+			})
+			transition.evm = evm.NewEVM()
+			transition.precompiles = precompiled.NewPrecompiled()
+			transition.config = chain.AllForksEnabled.At(0)
+
+			require.NoError(t, transition.SetCodeDirectly(proxy, proxyCode(c.op)))
+			require.NoError(t, transition.SetCodeDirectly(target, []byte{0x00}))
+
+			transition.callPolicy = addresslist.NewCallPolicy(transition, contracts.CallPolicyAddr)
+			transition.callPolicy.SetRestricted(target, selector, true)
+
+			// the restricted function of the target can not be executed in the context of the proxy
+			result := transition.Call2(caller, proxy, nil, big.NewInt(0), 1_000_000)
+			require.NoError(t, result.Err)
+			assert.Equal(t, uint64(0), new(big.Int).SetBytes(result.ReturnValue).Uint64())
+
+			permitted := proxy
+			if c.op == 0xf4 {
+				// the caller of the delegated call is the caller of the proxy
+				permitted = caller
+			}
+
+			transition.callPolicy.SetPermission(permitted, target, selector, addresslist.Permission{Granted: true})
+
+			result = transition.Call2(caller, proxy, nil, big.NewInt(0), 1_000_000)
+			require.NoError(t, result.Err)
+			assert.Equal(t, uint64(1), new(big.Int).SetBytes(result.ReturnValue).Uint64())
+		})
+	}
+}
+
+func TestTransition_Sponsorship(t *testing.T) {
+	t.Parallel()
+
+	senderKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sponsorKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&senderKey.PublicKey)
+	sponsor := crypto.PubKeyToAddress(&sponsorKey.PublicKey)
+	to := types.Address{0x3}
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		sender:  {Balance: 0},
+		sponsor: {Balance: 1000},
+	})
+	transition.ctx.ChainID = 100
+	transition.ctx.BaseFee = big.NewInt(1)
+
+	signer := crypto.NewSponsorSigner(100, true, nil)
+
+	msg, err := signer.SignTx(&types.Transaction{
+		Type:      types.SponsoredTx,
+		ChainID:   big.NewInt(100),
+		To:        &to,
+		Value:     big.NewInt(0),
+		Gas:       10,
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(10),
+		Sponsor:   sponsor,
+	}, senderKey)
+	require.NoError(t, err)
+
+	msg, err = signer.SignSponsorTx(msg, sponsorKey)
+	require.NoError(t, err)
+
+	// the sponsored transactions are rejected until the fork is enabled
+	assert.ErrorIs(t, transition.sponsorCheck(msg), ErrSponsorshipNotEnabled)
+
+	transition.config = chain.AllForksEnabled.At(0)
+	assert.NoError(t, transition.sponsorCheck(msg))
+
+	// the sponsor must be enabled in the sponsors allow list, if any
+	transition.sponsorAllowList = addresslist.NewAddressList(transition, contracts.AllowListSponsorsAddr)
+	assert.ErrorIs(t, transition.sponsorCheck(msg), ErrSponsorNotAllowed)
+
+	transition.sponsorAllowList.SetRole(sponsor, addresslist.EnabledRole)
+	assert.NoError(t, transition.sponsorCheck(msg))
+
+	// the gas is charged to the sponsor instead of the sender
+	require.NoError(t, transition.subGasLimitPrice(msg))
+	assert.Zero(t, transition.GetBalance(sender).Sign())
+	assert.Equal(t, uint64(900), transition.GetBalance(sponsor).Uint64())
+}
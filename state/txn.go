@@ -36,6 +36,9 @@ type Txn struct {
 	snapshots []*iradix.Tree
 	txn       *iradix.Txn
 	codeCache *lru.Cache
+
+	// spec tracks the state accesses of the speculative (parallel) execution, nil otherwise
+	spec *speculation
 }
 
 func NewTxn(snapshot Snapshot) *Txn {
@@ -92,6 +95,16 @@ func (txn *Txn) GetAccount(addr types.Address) (*Account, bool) {
 }
 
 func (txn *Txn) getStateObject(addr types.Address) (*StateObject, bool) {
+	obj, exists := txn.lookupStateObject(addr)
+
+	if txn.spec != nil {
+		txn.spec.readAccount(addr, obj, exists)
+	}
+
+	return obj, exists
+}
+
+func (txn *Txn) lookupStateObject(addr types.Address) (*StateObject, bool) {
 	// Try to get state from radix tree which holds transient states during block processing first
 	val, exists := txn.txn.Get(addr.Bytes())
 	if exists {
@@ -103,6 +116,13 @@ func (txn *Txn) getStateObject(addr types.Address) (*StateObject, bool) {
 		return obj.Copy(), true
 	}
 
+	// speculative execution reads the state written by the previous transactions of the block
+	if txn.spec != nil {
+		if obj, found := txn.spec.baseStateObject(addr); found {
+			return obj, obj != nil
+		}
+	}
+
 	account, err := txn.snapshot.GetAccount(addr)
 	if err != nil {
 		return nil, false
@@ -136,6 +156,10 @@ func (txn *Txn) upsertAccount(addr types.Address, create bool, f func(object *St
 
 	if object != nil {
 		txn.txn.Insert(addr.Bytes(), object)
+
+		if txn.spec != nil {
+			txn.spec.writeAccount(addr)
+		}
 	}
 }
 
@@ -306,10 +330,24 @@ func (txn *Txn) SetState(
 			object.Txn.Insert(key.Bytes(), value.Bytes())
 		}
 	})
+
+	if txn.spec != nil {
+		txn.spec.writeStorage(addr, key)
+	}
 }
 
 // GetState returns the state of the address at a given key
 func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
+	val := txn.getState(addr, key)
+
+	if txn.spec != nil {
+		txn.spec.readStorage(addr, key, val)
+	}
+
+	return val
+}
+
+func (txn *Txn) getState(addr types.Address, key types.Hash) types.Hash {
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return types.Hash{}
@@ -545,6 +583,10 @@ func (txn *Txn) CreateAccount(addr types.Address) {
 	}
 
 	txn.txn.Insert(addr.Bytes(), obj)
+
+	if txn.spec != nil {
+		txn.spec.createAccount(addr)
+	}
 }
 
 func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
@@ -16,6 +16,9 @@ import (
 const (
 	syncerName  = "syncer"
 	syncerProto = "/syncer/0.2"
+
+	// prefetchBlocksAhead is the number of the received blocks prefetched ahead of the verified one
+	prefetchBlocksAhead = 16
 )
 
 var (
@@ -218,13 +221,19 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.F
 		return 0, false, err
 	}
 
+	doneCh := make(chan struct{})
+
 	defer func() {
+		close(doneCh)
+
 		err := s.syncPeerClient.CloseStream(peerID)
 		if err != nil {
 			s.logger.Error("Failed to close stream: ", err)
 		}
 	}()
 
+	blockCh = s.prefetchBlocks(blockCh, doneCh)
+
 	var lastReceivedNumber uint64
 
 	for {
@@ -262,6 +271,32 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.F
 	}
 }
 
+// prefetchBlocks forwards the received blocks, prefetching their state
+// while the previously received blocks are being verified
+func (s *syncer) prefetchBlocks(blockCh <-chan *types.Block, doneCh <-chan struct{}) <-chan *types.Block {
+	prefetchedCh := make(chan *types.Block, prefetchBlocksAhead)
+
+	go func() {
+		defer close(prefetchedCh)
+
+		for block := range blockCh {
+			s.blockchain.PrefetchBlock(block)
+
+			select {
+			case prefetchedCh <- block:
+			case <-doneCh:
+				// drain the blocks, so the stream is not blocked until it is closed
+				for range blockCh {
+				}
+
+				return
+			}
+		}
+	}()
+
+	return prefetchedCh
+}
+
 func updateMetrics(fullBlock *types.FullBlock) {
 	metrics.SetGauge([]string{syncerMetrics, "tx_num"}, float32(len(fullBlock.Block.Transactions)))
 	metrics.SetGauge([]string{syncerMetrics, "receipts_num"}, float32(len(fullBlock.Receipts)))
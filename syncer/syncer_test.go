@@ -60,6 +60,8 @@ func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock,
 	return m.verifyFinalizedBlockHandler(b)
 }
 
+func (m *mockBlockchain) PrefetchBlock(b *types.Block) {}
+
 func (m *mockBlockchain) WriteBlock(b *types.Block, s string) error {
 	return m.writeBlockHandler(b)
 }
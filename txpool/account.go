@@ -203,6 +203,20 @@ func (a *account) getNonce() uint64 {
 	return atomic.LoadUint64(&a.nextNonce)
 }
 
+// getPendingNonce returns the next nonce for this account, including the enqueued transactions
+// which follow the next expected nonce, since those are promoted asynchronously
+func (a *account) getPendingNonce() uint64 {
+	a.nonceToTx.lock()
+	defer a.nonceToTx.unlock()
+
+	nonce := a.getNonce()
+	for a.nonceToTx.get(nonce) != nil {
+		nonce++
+	}
+
+	return nonce
+}
+
 // setNonce sets the next expected nonce for this account.
 func (a *account) setNonce(nonce uint64) {
 	atomic.StoreUint64(&a.nextNonce, nonce)
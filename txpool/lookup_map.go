@@ -1,7 +1,9 @@
 package txpool
 
 import (
+	"math/big"
 	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -10,6 +12,22 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+	// arrivals holds the time when the transactions were added to the pool
+	arrivals map[types.Hash]time.Time
+	// sponsored holds the pending sponsored transactions, whose gas cost is accounted in sponsoredCosts
+	sponsored map[types.Hash]*types.Transaction
+	// sponsoredCosts holds the total gas cost of the pending sponsored transactions per sponsor
+	sponsoredCosts map[types.Address]*big.Int
+}
+
+// newLookupMap creates an empty lookup map
+func newLookupMap() lookupMap {
+	return lookupMap{
+		all:            make(map[types.Hash]*types.Transaction),
+		arrivals:       make(map[types.Hash]time.Time),
+		sponsored:      make(map[types.Hash]*types.Transaction),
+		sponsoredCosts: make(map[types.Address]*big.Int),
+	}
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -23,6 +41,19 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 	}
 
 	m.all[tx.Hash] = tx
+	m.arrivals[tx.Hash] = time.Now()
+
+	if tx.Type == types.SponsoredTx {
+		m.sponsored[tx.Hash] = tx
+
+		cost, ok := m.sponsoredCosts[tx.Sponsor]
+		if !ok {
+			cost = new(big.Int)
+			m.sponsoredCosts[tx.Sponsor] = cost
+		}
+
+		cost.Add(cost, tx.GasCost())
+	}
 
 	return true
 }
@@ -34,7 +65,45 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.arrivals, tx.Hash)
+		m.releaseSponsoredLocked(tx.Hash)
+	}
+}
+
+// releaseSponsored stops accounting the gas cost of the given (e.g. demoted) transaction
+// against its sponsor, while the transaction itself remains in the map. [thread-safe]
+func (m *lookupMap) releaseSponsored(tx *types.Transaction) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.releaseSponsoredLocked(tx.Hash)
+}
+
+func (m *lookupMap) releaseSponsoredLocked(hash types.Hash) {
+	tx, ok := m.sponsored[hash]
+	if !ok {
+		return
 	}
+
+	delete(m.sponsored, hash)
+
+	cost := m.sponsoredCosts[tx.Sponsor]
+	if cost.Sub(cost, tx.GasCost()).Sign() <= 0 {
+		delete(m.sponsoredCosts, tx.Sponsor)
+	}
+}
+
+// sponsoredGasCost returns the total gas cost of the pending transactions sponsored by the given sponsor.
+// [thread-safe]
+func (m *lookupMap) sponsoredGasCost(sponsor types.Address) *big.Int {
+	m.RLock()
+	defer m.RUnlock()
+
+	if cost, ok := m.sponsoredCosts[sponsor]; ok {
+		return new(big.Int).Set(cost)
+	}
+
+	return new(big.Int)
 }
 
 // get returns the transaction associated with the given hash. [thread-safe]
@@ -49,3 +118,13 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// getArrivalTime returns the time when the transaction with the given hash was added to the pool. [thread-safe]
+func (m *lookupMap) getArrivalTime(hash types.Hash) (time.Time, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	arrival, ok := m.arrivals[hash]
+
+	return arrival, ok
+}
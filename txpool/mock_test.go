@@ -18,6 +18,7 @@ type defaultMockStore struct {
 
 	getBlockByHashFn   func(types.Hash, bool) (*types.Block, bool)
 	calculateBaseFeeFn func(*types.Header) uint64
+	getStorageFn       func() (types.Hash, error)
 	nonce              uint64
 }
 
@@ -52,6 +53,14 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+func (m defaultMockStore) GetStorage(types.Hash, types.Address, types.Hash) (types.Hash, error) {
+	if m.getStorageFn != nil {
+		return m.getStorageFn()
+	}
+
+	return types.ZeroHash, nil
+}
+
 func (m defaultMockStore) CalculateBaseFee(header *types.Header) uint64 {
 	if m.calculateBaseFeeFn != nil {
 		return m.calculateBaseFeeFn(header)
@@ -79,6 +88,10 @@ func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big
 	return nil, fmt.Errorf("unable to fetch account state")
 }
 
+func (fms faultyMockStore) GetStorage(types.Hash, types.Address, types.Hash) (types.Hash, error) {
+	return types.ZeroHash, fmt.Errorf("unable to fetch account state")
+}
+
 func (fms faultyMockStore) CalculateBaseFee(*types.Header) uint64 {
 	return 0
 }
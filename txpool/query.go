@@ -11,7 +11,8 @@ import (
 
 // GetNonce returns the next nonce for the account
 //
-// -> Returns the value from the TxPool if the account is initialized in-memory
+// -> Returns the value from the TxPool if the account is initialized in-memory,
+// including the enqueued transactions which are about to be promoted
 //
 // -> Returns the value from the world state otherwise
 func (p *TxPool) GetNonce(addr types.Address) uint64 {
@@ -23,7 +24,7 @@ func (p *TxPool) GetNonce(addr types.Address) uint64 {
 		return stateNonce
 	}
 
-	return account.getNonce()
+	return account.getPendingNonce()
 }
 
 // GetCapacity returns the current number of slots
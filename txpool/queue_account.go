@@ -60,6 +60,25 @@ func (q *accountQueue) prune(nonce uint64) (
 	return
 }
 
+// removeFrom removes all transactions from the queue
+// with nonce greater than or equal to the given one.
+func (q *accountQueue) removeFrom(nonce uint64) (removed []*types.Transaction) {
+	kept := make(minNonceQueue, 0, q.queue.Len())
+
+	for _, tx := range q.queue {
+		if tx.Nonce >= nonce {
+			removed = append(removed, tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+
+	q.queue = kept
+	heap.Init(&q.queue)
+
+	return
+}
+
 // clear removes all transactions from the queue.
 func (q *accountQueue) clear() (removed []*types.Transaction) {
 	// store txs
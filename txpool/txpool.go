@@ -15,10 +15,12 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -39,6 +41,10 @@ const (
 
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
+
+	// DefaultPriceBump is the minimum percentage by which both the tip and the fee cap
+	// of a transaction have to be increased to replace a transaction with the same nonce
+	DefaultPriceBump uint64 = 10
 )
 
 // errors
@@ -65,6 +71,11 @@ var (
 	ErrNonceExistsInPool       = errors.New("tx with the same nonce is already present")
 	ErrReplacementUnderpriced  = errors.New("replacement tx underpriced")
 	ErrDynamicTxNotAllowed     = errors.New("dynamic tx not allowed currently")
+	ErrTxNotFound              = errors.New("tx not found in the pool")
+	ErrAccountNotFound         = errors.New("account not found in the pool")
+	ErrInvalidSponsor          = errors.New("invalid sponsor")
+	ErrSponsorNotAllowed       = errors.New("sponsor not allowed")
+	ErrSponsorFundsTooLow      = errors.New("insufficient sponsor funds for gas * price")
 )
 
 // indicates origin of a transaction
@@ -91,6 +102,7 @@ type store interface {
 	Header() *types.Header
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
+	GetStorage(root types.Hash, addr types.Address, slot types.Hash) (types.Hash, error)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 	CalculateBaseFee(parent *types.Header) uint64
 }
@@ -99,11 +111,19 @@ type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
+// sponsorSigner is the signer able to verify the sponsor of the sponsored transactions
+type sponsorSigner interface {
+	Sponsor(tx *types.Transaction) (types.Address, error)
+}
+
 type Config struct {
 	PriceLimit         uint64
+	PriceBump          uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
 	ChainID            *big.Int
+	// SponsorAllowList enables the check of the sponsors against the sponsors allow list
+	SponsorAllowList bool
 }
 
 /* All requests are passed to the main loop
@@ -168,6 +188,14 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// sponsorAllowList is set if the sponsors are checked against the sponsors allow list
+	sponsorAllowList bool
+
+	// priceBump is the minimum percentage by which a replacement transaction
+	// has to increase both the tip and the fee cap of the replaced one.
+	// Zero only requires them to be higher
+	priceBump uint64
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	promoteReqCh chan promoteRequest
@@ -208,15 +236,17 @@ func NewTxPool(
 	config *Config,
 ) (*TxPool, error) {
 	pool := &TxPool{
-		logger:      logger.Named("txpool"),
-		forks:       forks,
-		store:       store,
-		executables: newPricesQueue(0, nil),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
-		chainID:     config.ChainID,
+		logger:           logger.Named("txpool"),
+		forks:            forks,
+		store:            store,
+		executables:      newPricesQueue(0, nil),
+		accounts:         accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
+		index:            newLookupMap(),
+		gauge:            slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit:       config.PriceLimit,
+		priceBump:        config.PriceBump,
+		sponsorAllowList: config.SponsorAllowList,
+		chainID:          config.ChainID,
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -354,6 +384,12 @@ func (p *TxPool) Peek() *types.Transaction {
 	return p.executables.pop()
 }
 
+// ArrivalTime returns the time when the transaction with the given hash
+// was added to the pool (false if the transaction is not in the pool)
+func (p *TxPool) ArrivalTime(hash types.Hash) (time.Time, bool) {
+	return p.index.getArrivalTime(hash)
+}
+
 // Pop removes the given transaction from the
 // associated promoted queue (account).
 // Will update executables with the next primary
@@ -398,6 +434,85 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	p.dropAccount(account, tx.Nonce, tx)
 }
 
+// DropTransaction evicts the transaction with the given hash from the pool,
+// together with the transactions of the same account with higher nonces,
+// as they can not be executed anymore. It returns the evicted transactions.
+func (p *TxPool) DropTransaction(txHash types.Hash) ([]*types.Transaction, error) {
+	tx, ok := p.index.get(txHash)
+	if !ok {
+		return nil, ErrTxNotFound
+	}
+
+	account := p.accounts.get(tx.From)
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	return p.evictAccountTxs(account, tx.From, tx.Nonce), nil
+}
+
+// DropAccount evicts all the promoted and enqueued transactions
+// of the given account from the pool. It returns the evicted transactions.
+func (p *TxPool) DropAccount(addr types.Address) ([]*types.Transaction, error) {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	return p.evictAccountTxs(account, addr, 0), nil
+}
+
+// evictAccountTxs removes the account transactions with nonce greater than or equal to the given one,
+// rolls back the account's next nonce and signals EventType_EVICTED for all removed transactions
+func (p *TxPool) evictAccountTxs(account *account, addr types.Address, nonce uint64) []*types.Transaction {
+	account.promoted.lock(true)
+	account.enqueued.lock(true)
+	account.nonceToTx.lock()
+
+	defer func() {
+		account.nonceToTx.unlock()
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	promoted := account.promoted.removeFrom(nonce)
+	enqueued := account.enqueued.removeFrom(nonce)
+
+	evicted := make([]*types.Transaction, 0, len(promoted)+len(enqueued))
+	evicted = append(evicted, promoted...)
+	evicted = append(evicted, enqueued...)
+
+	if len(evicted) == 0 {
+		return evicted
+	}
+
+	// the next nonce is rolled back to the lowest evicted promoted tx,
+	// so the account accepts the transactions with evicted nonces again
+	for _, tx := range promoted {
+		if tx.Nonce < account.getNonce() {
+			account.setNonce(tx.Nonce)
+		}
+	}
+
+	account.nonceToTx.remove(evicted...)
+	p.index.remove(evicted...)
+	p.gauge.decrease(slotsRequired(evicted...))
+
+	// update metrics
+	p.updatePending(-1 * int64(len(promoted)))
+	metrics.IncrCounter([]string{txPoolMetrics, "evicted_tx"}, float32(len(evicted)))
+
+	p.eventManager.signalEvent(proto.EventType_EVICTED, toHash(evicted...)...)
+
+	p.logger.Info("evicted account txs",
+		"address", addr.String(),
+		"num", len(evicted),
+		"next_nonce", account.getNonce(),
+	)
+
+	return evicted
+}
+
 // dropAccount clears all promoted and enqueued tx from the account
 // signals EventType_DROPPED for provided hash, clears all the slots and metrics
 // and sets nonce to provided nonce
@@ -456,6 +571,9 @@ func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Trans
 // due to a recoverable error. If an account has been demoted too many times (maxAccountDemotions),
 // it is Dropped instead.
 func (p *TxPool) Demote(tx *types.Transaction) {
+	// demoted transaction is not pending anymore, so its gas is not reserved from the sponsor funds
+	p.index.releaseSponsored(tx)
+
 	account := p.accounts.get(tx.From)
 	if account.Demotions() >= maxAccountDemotions {
 		if p.logger.IsDebug() {
@@ -618,7 +736,13 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	latestBlockGasLimit := currentHeader.GasLimit
 	baseFee := p.GetBaseFee() // base fee is calculated for the next block
 
-	if tx.Type == types.DynamicFeeTx {
+	if tx.Type == types.SponsoredTx {
+		if err := p.validateSponsor(tx, forks, stateRoot); err != nil {
+			return err
+		}
+	}
+
+	if tx.IsDynamicFee() {
 		// Reject dynamic fee tx if london hardfork is not enabled
 		if !forks.London {
 			metrics.IncrCounter([]string{txPoolMetrics, "tx_type"}, 1)
@@ -695,8 +819,35 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidAccountState
 	}
 
-	// Check if the sender has enough funds to execute the transaction
-	if accountBalance.Cmp(tx.Cost()) < 0 {
+	// Check if the sender has enough funds to execute the transaction,
+	// while the gas of the sponsored transaction is paid by the sponsor
+	if tx.Type == types.SponsoredTx {
+		sponsorBalance, balanceErr := p.store.GetBalance(stateRoot, tx.Sponsor)
+		if balanceErr != nil {
+			metrics.IncrCounter([]string{txPoolMetrics, "invalid_account_state_tx"}, 1)
+
+			return ErrInvalidAccountState
+		}
+
+		// the sponsor has to cover the gas of its pending transactions as well
+		sponsoredGasCost := p.index.sponsoredGasCost(tx.Sponsor)
+		if replaced := p.pendingTxWithNonce(tx.From, tx.Nonce); replaced != nil &&
+			replaced.Type == types.SponsoredTx && replaced.Sponsor == tx.Sponsor {
+			sponsoredGasCost.Sub(sponsoredGasCost, replaced.GasCost())
+		}
+
+		if sponsorBalance.Cmp(sponsoredGasCost.Add(sponsoredGasCost, tx.GasCost())) < 0 {
+			metrics.IncrCounter([]string{txPoolMetrics, "insufficient_sponsor_funds_tx"}, 1)
+
+			return ErrSponsorFundsTooLow
+		}
+
+		if accountBalance.Cmp(tx.Value) < 0 {
+			metrics.IncrCounter([]string{txPoolMetrics, "insufficient_funds_tx"}, 1)
+
+			return ErrInsufficientFunds
+		}
+	} else if accountBalance.Cmp(tx.Cost()) < 0 {
 		metrics.IncrCounter([]string{txPoolMetrics, "insufficient_funds_tx"}, 1)
 
 		return ErrInsufficientFunds
@@ -725,6 +876,54 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	return nil
 }
 
+// pendingTxWithNonce returns the pending transaction of the given account with the given nonce (if any)
+func (p *TxPool) pendingTxWithNonce(addr types.Address, nonce uint64) *types.Transaction {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil
+	}
+
+	account.nonceToTx.lock()
+	defer account.nonceToTx.unlock()
+
+	return account.nonceToTx.get(nonce)
+}
+
+// validateSponsor ensures the sponsored transactions are enabled, and that the sponsor
+// signed the transaction and is in the sponsors allow list (if any)
+func (p *TxPool) validateSponsor(tx *types.Transaction, forks chain.ForksInTime, stateRoot types.Hash) error {
+	sponsorSigner, ok := p.signer.(sponsorSigner)
+	if !forks.Sponsorship || !ok {
+		metrics.IncrCounter([]string{txPoolMetrics, "tx_type"}, 1)
+
+		return fmt.Errorf("%w: type %d rejected, sponsorship is not enabled", ErrTxTypeNotSupported, tx.Type)
+	}
+
+	if _, err := sponsorSigner.Sponsor(tx); err != nil {
+		metrics.IncrCounter([]string{txPoolMetrics, "invalid_sponsor_txs"}, 1)
+
+		return fmt.Errorf("%w: %w", ErrInvalidSponsor, err)
+	}
+
+	if p.sponsorAllowList {
+		role, err := p.store.GetStorage(stateRoot, contracts.AllowListSponsorsAddr,
+			types.BytesToHash(tx.Sponsor.Bytes()))
+		if err != nil {
+			metrics.IncrCounter([]string{txPoolMetrics, "invalid_account_state_tx"}, 1)
+
+			return ErrInvalidAccountState
+		}
+
+		if !addresslist.Role(role).Enabled() {
+			metrics.IncrCounter([]string{txPoolMetrics, "sponsor_not_allowed_txs"}, 1)
+
+			return ErrSponsorNotAllowed
+		}
+	}
+
+	return nil
+}
+
 func (p *TxPool) signalPruning() {
 	select {
 	case p.pruneCh <- struct{}{}:
@@ -779,7 +978,7 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	}
 
 	// add chainID to the tx - only dynamic fee tx
-	if tx.Type == types.DynamicFeeTx {
+	if tx.IsDynamicFee() {
 		tx.ChainID = p.chainID
 	}
 
@@ -819,9 +1018,8 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 			metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
 
 			return ErrAlreadyKnown
-		} else if oldTxWithSameNonce.GetGasPrice(p.baseFee).Cmp(
-			tx.GetGasPrice(p.baseFee)) >= 0 {
-			// if tx with same nonce does exist and has same or better gas price -> return error
+		} else if !isPriceBumped(oldTxWithSameNonce, tx, p.priceBump) {
+			// if tx with same nonce does exist and the new one does not bump its price enough -> return error
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 			return ErrReplacementUnderpriced
@@ -871,6 +1069,9 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 
 	if oldTxWithSameNonce != nil {
 		p.index.remove(oldTxWithSameNonce)
+		p.eventManager.signalEvent(proto.EventType_REPLACED, oldTxWithSameNonce.Hash)
+
+		metrics.IncrCounter([]string{txPoolMetrics, "replaced_tx"}, 1)
 	} else {
 		metrics.SetGauge([]string{txPoolMetrics, "added_tx"}, 1)
 	}
@@ -882,6 +1083,34 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	return nil
 }
 
+// isPriceBumped checks if the replacement transaction increases both the tip and the fee cap
+// of the transaction with the same nonce by at least the given percentage.
+// Legacy transactions use their gas price as both the tip and the fee cap
+func isPriceBumped(oldTx, newTx *types.Transaction, priceBump uint64) bool {
+	return isBumped(oldTx.GetGasTipCap(), newTx.GetGasTipCap(), priceBump) &&
+		isBumped(oldTx.GetGasFeeCap(), newTx.GetGasFeeCap(), priceBump)
+}
+
+// isBumped checks if the new price is higher than the old one by at least the given percentage
+func isBumped(oldPrice, newPrice *big.Int, priceBump uint64) bool {
+	if newPrice == nil {
+		return false
+	}
+
+	if oldPrice == nil {
+		return newPrice.Sign() > 0
+	}
+
+	if newPrice.Cmp(oldPrice) <= 0 {
+		return false
+	}
+
+	// newPrice * 100 >= oldPrice * (100 + priceBump)
+	threshold := new(big.Int).Mul(oldPrice, new(big.Int).SetUint64(100+priceBump))
+
+	return new(big.Int).Mul(newPrice, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
 func (p *TxPool) invokePromotion(tx *types.Transaction, callPromote bool) {
 	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
 
@@ -22,6 +22,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -1197,6 +1198,30 @@ func TestAddTx(t *testing.T) {
 	)
 }
 
+func TestGetNonce(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// unknown account -> nonce from the world state
+	require.Equal(t, uint64(0), pool.GetNonce(addr1))
+
+	// enqueued transactions following the expected nonce are accounted before the promotion
+	require.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+	require.NoError(t, pool.addTx(local, newTx(addr1, 1, 1)))
+	require.NoError(t, pool.addTx(local, newTx(addr1, 3, 1)))
+
+	require.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	require.Equal(t, uint64(2), pool.GetNonce(addr1))
+
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	require.Equal(t, uint64(2), pool.accounts.get(addr1).getNonce())
+	require.Equal(t, uint64(2), pool.GetNonce(addr1))
+}
+
 func TestPromoteHandler(t *testing.T) {
 	t.Parallel()
 
@@ -1847,6 +1872,39 @@ func TestPop(t *testing.T) {
 	assert.Equal(t, (*types.Transaction)(nil), acc.nonceToTx.get(tx1.Nonce))
 }
 
+func TestArrivalTime(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	before := time.Now()
+
+	tx1 := newTx(addr1, 0, 1)
+	assert.NoError(t, pool.addTx(local, tx1))
+
+	tx2 := newTx(addr1, 1, 1)
+	assert.NoError(t, pool.addTx(local, tx2))
+
+	arrival1, ok := pool.ArrivalTime(tx1.Hash)
+	assert.True(t, ok)
+	assert.False(t, arrival1.Before(before))
+
+	arrival2, ok := pool.ArrivalTime(tx2.Hash)
+	assert.True(t, ok)
+	assert.False(t, arrival2.Before(arrival1))
+
+	_, ok = pool.ArrivalTime(types.StringToHash("0x1"))
+	assert.False(t, ok)
+
+	// arrival time is forgotten once the transaction leaves the pool
+	pool.index.remove(tx1)
+
+	_, ok = pool.ArrivalTime(tx1.Hash)
+	assert.False(t, ok)
+}
+
 func TestDrop(t *testing.T) {
 	t.Parallel()
 
@@ -1881,6 +1939,87 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, (*types.Transaction)(nil), acc.nonceToTx.get(tx1.Nonce))
 }
 
+func TestDropTransaction(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_EVICTED})
+	defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+	// promote nonces 0 and 1 and enqueue nonce 3
+	tx0, tx1, tx3 := newTx(addr1, 0, 1), newTx(addr1, 1, 1), newTx(addr1, 3, 1)
+
+	require.NoError(t, pool.addTx(local, tx0))
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+	require.NoError(t, pool.addTx(local, tx1))
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+	require.NoError(t, pool.addTx(local, tx3))
+
+	acc := pool.accounts.get(addr1)
+	require.Equal(t, uint64(2), acc.getNonce())
+
+	_, err = pool.DropTransaction(types.StringToHash("0x1"))
+	require.ErrorIs(t, err, ErrTxNotFound)
+
+	evicted, err := pool.DropTransaction(tx1.Hash)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*types.Transaction{tx1, tx3}, evicted)
+
+	assert.Equal(t, uint64(1), pool.gauge.read())
+	assert.Equal(t, uint64(1), acc.getNonce())
+	assert.Equal(t, uint64(1), acc.promoted.length())
+	assert.Equal(t, uint64(0), acc.enqueued.length())
+	assert.Equal(t, int64(1), pool.pending)
+	assert.Len(t, acc.nonceToTx.mapping, 1)
+
+	_, exists := pool.index.get(tx1.Hash)
+	assert.False(t, exists)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := waitForEvents(ctx, subscription, 2)
+	require.Len(t, events, 2)
+
+	for _, event := range events {
+		assert.Equal(t, proto.EventType_EVICTED, event.Type)
+	}
+
+	// the evicted nonce is accepted again
+	require.NoError(t, pool.addTx(local, newTx(addr1, 1, 2)))
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+	assert.Equal(t, uint64(2), acc.getNonce())
+}
+
+func TestDropAccount(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	_, err = pool.DropAccount(addr1)
+	require.ErrorIs(t, err, ErrAccountNotFound)
+
+	require.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+	require.NoError(t, pool.addTx(local, newTx(addr1, 2, 1)))
+
+	evicted, err := pool.DropAccount(addr1)
+	require.NoError(t, err)
+	assert.Len(t, evicted, 2)
+
+	acc := pool.accounts.get(addr1)
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), acc.getNonce())
+	assert.Equal(t, uint64(0), acc.promoted.length())
+	assert.Equal(t, uint64(0), acc.enqueued.length())
+	assert.Len(t, pool.index.all, 0)
+}
+
 func TestDemote(t *testing.T) {
 	t.Parallel()
 
@@ -2320,6 +2459,158 @@ func Test_TxPool_validateTx(t *testing.T) {
 	})
 }
 
+func Test_TxPool_validateSponsoredTx(t *testing.T) {
+	t.Parallel()
+
+	signer := crypto.NewSponsorSigner(100, true, crypto.NewLondonSigner(100, true, crypto.NewEIP155Signer(100, true)))
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	sponsorKey, sponsorAddr := tests.GenerateKeyAndAddr(t)
+	otherKey, _ := tests.GenerateKeyAndAddr(t)
+
+	setupPool := func(mockStore defaultMockStore, sponsorship bool) *TxPool {
+		pool, err := newTestPool(mockStore)
+		require.NoError(t, err)
+
+		pool.forks = &chain.Forks{
+			chain.Homestead: chain.NewFork(0),
+			chain.Istanbul:  chain.NewFork(0),
+			chain.London:    chain.NewFork(0),
+		}
+
+		if sponsorship {
+			pool.forks.SetFork(chain.Sponsorship, chain.NewFork(0))
+		}
+
+		pool.SetBaseFee(mockStore.DefaultHeader)
+		pool.SetSigner(signer)
+
+		return pool
+	}
+
+	newSponsoredTx := func(nonce uint64, gasFeeCap int64, sponsor *ecdsa.PrivateKey) *types.Transaction {
+		tx := newTx(senderAddr, nonce, 1)
+		tx.Type = types.SponsoredTx
+		tx.GasPrice = nil
+		tx.GasFeeCap = big.NewInt(gasFeeCap)
+		tx.GasTipCap = big.NewInt(10)
+		tx.Sponsor = sponsorAddr
+
+		tx, err := signer.SignTx(tx, senderKey)
+		require.NoError(t, err)
+
+		if sponsor == otherKey {
+			// sign with the key of another account, claiming to be the sponsor
+			otherTx := tx.Copy()
+			otherTx.Sponsor = crypto.PubKeyToAddress(&otherKey.PublicKey)
+
+			otherTx, err = signer.SignSponsorTx(otherTx, otherKey)
+			require.NoError(t, err)
+
+			tx.SponsorV, tx.SponsorR, tx.SponsorS = otherTx.SponsorV, otherTx.SponsorR, otherTx.SponsorS
+
+			return tx
+		}
+
+		tx, err = signer.SignSponsorTx(tx, sponsor)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	header := mockHeader.Copy()
+	header.BaseFee = 1000
+
+	t.Run("sponsored tx can pass", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(NewDefaultMockStore(header), true)
+
+		assert.NoError(t, pool.validateTx(newSponsoredTx(0, 1100, sponsorKey)))
+	})
+
+	t.Run("sponsored tx placed without sponsorship fork enabled", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(NewDefaultMockStore(header), false)
+
+		assert.ErrorIs(t,
+			pool.validateTx(newSponsoredTx(0, 1100, sponsorKey)),
+			ErrTxTypeNotSupported,
+		)
+	})
+
+	t.Run("sponsored tx not signed by the sponsor", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(NewDefaultMockStore(header), true)
+
+		assert.ErrorIs(t,
+			pool.validateTx(newSponsoredTx(0, 1100, otherKey)),
+			ErrInvalidSponsor,
+		)
+	})
+
+	t.Run("sponsor without enough funds for the gas", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(NewDefaultMockStore(header), true)
+
+		assert.ErrorIs(t,
+			pool.validateTx(newSponsoredTx(0, 100000000, sponsorKey)),
+			ErrSponsorFundsTooLow,
+		)
+	})
+
+	t.Run("sponsor without enough funds for the gas of the pending txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(NewDefaultMockStore(header), true)
+
+		// the sponsor can pay the gas of either transaction, but not both
+		pendingTx := newSponsoredTx(0, 15000000, sponsorKey)
+		require.NoError(t, pool.addTx(local, pendingTx))
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newSponsoredTx(1, 15000000, sponsorKey)),
+			ErrSponsorFundsTooLow,
+		)
+
+		// the replaced transaction is not accounted
+		assert.NoError(t, pool.validateTx(newSponsoredTx(0, 16500000, sponsorKey)))
+
+		// the demoted transaction is not accounted, even if it is dropped afterwards
+		pool.Demote(pendingTx)
+		assert.NoError(t, pool.validateTx(newSponsoredTx(1, 15000000, sponsorKey)))
+
+		pool.index.remove(pendingTx)
+		assert.Equal(t, big.NewInt(0), pool.index.sponsoredGasCost(sponsorAddr))
+	})
+
+	t.Run("sponsor not in the sponsors allow list", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore := NewDefaultMockStore(header)
+		mockStore.getStorageFn = func() (types.Hash, error) {
+			return types.Hash(addresslist.NoRole), nil
+		}
+
+		pool := setupPool(mockStore, true)
+		pool.sponsorAllowList = true
+
+		assert.ErrorIs(t,
+			pool.validateTx(newSponsoredTx(0, 1100, sponsorKey)),
+			ErrSponsorNotAllowed,
+		)
+
+		mockStore.getStorageFn = func() (types.Hash, error) {
+			return types.Hash(addresslist.EnabledRole), nil
+		}
+		pool.store = mockStore
+
+		assert.NoError(t, pool.validateTx(newSponsoredTx(0, 1100, sponsorKey)))
+	})
+}
+
 /* "Integrated" tests */
 
 // The following tests ensure that the pool's inner event loop
@@ -3724,6 +4015,61 @@ func TestAddTx_TxReplacement(t *testing.T) {
 	assert.Equal(t, ac2.enqueued.queue[0], tx1)
 }
 
+func TestAddTx_PriceBump(t *testing.T) {
+	t.Parallel()
+
+	newDynamicTx := func(nonce, gasFeeCap, gasTipCap uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.GasPrice = nil
+		tx.GasFeeCap = new(big.Int).SetUint64(gasFeeCap)
+		tx.GasTipCap = new(big.Int).SetUint64(gasTipCap)
+
+		return tx
+	}
+
+	newLegacyTx := func(nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.baseFee = 10
+	pool.priceBump = DefaultPriceBump
+
+	subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_REPLACED})
+	defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+	// legacy replacement has to bump the gas price
+	legacyTx := newLegacyTx(1, 100)
+	require.NoError(t, pool.addTx(local, legacyTx))
+	assert.ErrorIs(t, pool.addTx(local, newLegacyTx(1, 109)), ErrReplacementUnderpriced)
+
+	replacement := newLegacyTx(1, 110)
+	require.NoError(t, pool.addTx(local, replacement))
+
+	// dynamic fee replacement has to bump both the tip and the fee cap
+	assert.ErrorIs(t, pool.addTx(local, newDynamicTx(1, 200, 115)), ErrReplacementUnderpriced)
+	assert.ErrorIs(t, pool.addTx(local, newDynamicTx(1, 120, 120)), ErrReplacementUnderpriced)
+	require.NoError(t, pool.addTx(local, newDynamicTx(1, 121, 121)))
+
+	assert.Len(t, pool.index.all, 1)
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := waitForEvents(ctx, subscription, 2)
+	require.Len(t, events, 2)
+	assert.Equal(t, legacyTx.Hash.String(), events[0].TxHash)
+	assert.Equal(t, replacement.Hash.String(), events[1].TxHash)
+}
+
 func BenchmarkAddTxTime(b *testing.B) {
 	b.Run("benchmark add one tx", func(b *testing.B) {
 		signer := crypto.NewEIP155Signer(100, true)
@@ -22,12 +22,109 @@ const (
 	numRetries                 = 1000
 	gasLimitIncreasePercentage = 100
 	feeIncreasePercentage      = 100
+
+	// DefaultFeeBumpPercentage is the default percentage by which the fees
+	// of the resubmitted transaction are increased
+	DefaultFeeBumpPercentage = 20
+	// DefaultResubmitInterval is the default time to wait for a receipt before the transaction is resubmitted
+	DefaultResubmitInterval = 15 * time.Second
+	// DefaultFeeCapMultiplier limits the fees of the resubmitted transaction to the given multiple
+	// of its initial fees, unless the fee cap is set explicitly
+	DefaultFeeCapMultiplier = 10
 )
 
 var (
-	errNoAccounts = errors.New("no accounts registered")
+	errNoAccounts    = errors.New("no accounts registered")
+	errFeeCapReached = errors.New("transaction fees have reached the fee cap")
 )
 
+// ResubmitPolicy defines how the transactions which are not mined in time are resubmitted.
+// Transaction is re-signed with the same nonce and bumped fees, so it replaces the stuck one.
+type ResubmitPolicy struct {
+	// Interval is the time to wait for a receipt before the transaction is resubmitted
+	Interval time.Duration
+	// FeeBumpPercentage is the percentage by which the fees are increased on each resubmission
+	// (DefaultFeeBumpPercentage is used if it is not set)
+	FeeBumpPercentage uint64
+	// FeeCap is the upper limit of the (max) fee per gas or gas price of the resubmitted transactions
+	// (initial fees multiplied by DefaultFeeCapMultiplier are used if it is nil)
+	FeeCap *big.Int
+}
+
+// feeCap returns the upper limit of the fees of the resubmitted transaction. It is either the configured fee cap
+// or (if it is not set) the initial max fee per gas or gas price multiplied by DefaultFeeCapMultiplier
+func (p *ResubmitPolicy) feeCap(txn *ethgo.Transaction) *big.Int {
+	if p.FeeCap != nil {
+		return p.FeeCap
+	}
+
+	fee := new(big.Int).SetUint64(txn.GasPrice)
+	if txn.Type == ethgo.TransactionDynamicFee && txn.MaxFeePerGas != nil {
+		fee = new(big.Int).Set(txn.MaxFeePerGas)
+	}
+
+	return fee.Mul(fee, big.NewInt(DefaultFeeCapMultiplier))
+}
+
+// bumpFees returns a copy of the given transaction with the fees increased by the fee bump percentage,
+// without exceeding the given fee cap. The given transaction is not modified.
+// It returns false if the fees can not be increased anymore
+func (p *ResubmitPolicy) bumpFees(txn *ethgo.Transaction, feeCap *big.Int) (*ethgo.Transaction, bool) {
+	bumped := txn.Copy()
+
+	if txn.Type == ethgo.TransactionDynamicFee {
+		maxFeePerGas := p.bump(txn.MaxFeePerGas, feeCap)
+		if txn.MaxFeePerGas != nil && maxFeePerGas.Cmp(txn.MaxFeePerGas) <= 0 {
+			return nil, false
+		}
+
+		// priority fee must not exceed the max fee per gas
+		maxPriorityFeePerGas := p.bump(txn.MaxPriorityFeePerGas, feeCap)
+		if maxPriorityFeePerGas.Cmp(maxFeePerGas) > 0 {
+			maxPriorityFeePerGas = maxFeePerGas
+		}
+
+		bumped.MaxFeePerGas = maxFeePerGas
+		bumped.MaxPriorityFeePerGas = maxPriorityFeePerGas
+
+		return bumped, true
+	}
+
+	gasPrice := p.bump(new(big.Int).SetUint64(txn.GasPrice), feeCap)
+	if !gasPrice.IsUint64() || gasPrice.Uint64() <= txn.GasPrice {
+		return nil, false
+	}
+
+	bumped.GasPrice = gasPrice.Uint64()
+
+	return bumped, true
+}
+
+// bump returns the given fee increased by the fee bump percentage (at least by one), capped by the given fee cap
+func (p *ResubmitPolicy) bump(fee *big.Int, feeCap *big.Int) *big.Int {
+	if fee == nil {
+		fee = big.NewInt(0)
+	}
+
+	feeBumpPercentage := p.FeeBumpPercentage
+	if feeBumpPercentage == 0 {
+		feeBumpPercentage = DefaultFeeBumpPercentage
+	}
+
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+feeBumpPercentage))
+	bumped = bumped.Div(bumped, big.NewInt(100))
+
+	if bumped.Cmp(fee) <= 0 {
+		bumped = bumped.Add(fee, big.NewInt(1))
+	}
+
+	if feeCap != nil && bumped.Cmp(feeCap) > 0 {
+		bumped = new(big.Int).Set(feeCap)
+	}
+
+	return bumped
+}
+
 type TxRelayer interface {
 	// Call executes a message call immediately without creating a transaction on the blockchain
 	Call(from ethgo.Address, to ethgo.Address, input []byte) (string, error)
@@ -46,6 +143,7 @@ type TxRelayerImpl struct {
 	ipAddress      string
 	client         *jsonrpc.Client
 	receiptTimeout time.Duration
+	resubmitPolicy *ResubmitPolicy
 
 	lock sync.Mutex
 
@@ -100,7 +198,7 @@ func (t *TxRelayerImpl) SendTransaction(txn *ethgo.Transaction, key ethgo.Key) (
 		return nil, err
 	}
 
-	return t.waitForReceipt(txnHash)
+	return t.waitForReceipt(txnHash, txn, key)
 }
 
 // Client returns jsonrpc client
@@ -176,8 +274,40 @@ func (t *TxRelayerImpl) sendTransactionLocked(txn *ethgo.Transaction, key ethgo.
 		txn.Gas = gasLimit + (gasLimit * gasLimitIncreasePercentage / 100)
 	}
 
-	signer := wallet.NewEIP155Signer(chainID.Uint64())
-	if txn, err = signer.SignTx(txn, key); err != nil {
+	return t.signAndSend(txn, key)
+}
+
+// resubmitTransaction bumps the fees of already sent transaction and sends it again with the same nonce,
+// so it replaces the previous one in the transaction pool. The bumped fees are kept in the given transaction
+// only if the replacement is sent successfully, so the failed resubmission is retried with the same fees
+func (t *TxRelayerImpl) resubmitTransaction(txn *ethgo.Transaction, key ethgo.Key,
+	feeCap *big.Int) (ethgo.Hash, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	bumped, ok := t.resubmitPolicy.bumpFees(txn, feeCap)
+	if !ok {
+		return ethgo.ZeroHash, errFeeCapReached
+	}
+
+	hash, err := t.signAndSend(bumped, key)
+	if err != nil {
+		return ethgo.ZeroHash, err
+	}
+
+	txn.GasPrice = bumped.GasPrice
+	txn.MaxFeePerGas = bumped.MaxFeePerGas
+	txn.MaxPriorityFeePerGas = bumped.MaxPriorityFeePerGas
+
+	return hash, nil
+}
+
+// signAndSend signs given transaction by provided key and sends it to the blockchain
+func (t *TxRelayerImpl) signAndSend(txn *ethgo.Transaction, key ethgo.Key) (ethgo.Hash, error) {
+	signer := wallet.NewEIP155Signer(txn.ChainID.Uint64())
+
+	txn, err := signer.SignTx(txn, key)
+	if err != nil {
 		return ethgo.ZeroHash, err
 	}
 
@@ -231,26 +361,57 @@ func (t *TxRelayerImpl) SendTransactionLocal(txn *ethgo.Transaction) (*ethgo.Rec
 		return nil, err
 	}
 
-	return t.waitForReceipt(txnHash)
+	return t.waitForReceipt(txnHash, nil, nil)
 }
 
-func (t *TxRelayerImpl) waitForReceipt(hash ethgo.Hash) (*ethgo.Receipt, error) {
-	count := uint(0)
+// waitForReceipt polls the receipt of the transaction with given hash until it gets mined.
+// If resubmit policy is set and the signed transaction is provided, the transaction is resubmitted
+// with bumped fees whenever it is not mined within the resubmit interval.
+// Receipts of all the replacement transactions are polled and the first mined one is returned.
+func (t *TxRelayerImpl) waitForReceipt(hash ethgo.Hash,
+	txn *ethgo.Transaction, key ethgo.Key) (*ethgo.Receipt, error) {
+	var (
+		hashes         = []ethgo.Hash{hash}
+		count          = uint(0)
+		lastSubmission = time.Now()
+		resubmit       = t.resubmitPolicy != nil && t.resubmitPolicy.Interval > 0 && txn != nil
+		feeCap         *big.Int
+	)
+
+	if resubmit {
+		feeCap = t.resubmitPolicy.feeCap(txn)
+	}
 
 	for {
-		receipt, err := t.client.Eth().GetTransactionReceipt(hash)
-		if err != nil {
-			if err.Error() != "not found" {
-				return nil, err
+		for _, hash := range hashes {
+			receipt, err := t.client.Eth().GetTransactionReceipt(hash)
+			if err != nil {
+				if err.Error() != "not found" {
+					return nil, err
+				}
 			}
-		}
 
-		if receipt != nil {
-			return receipt, nil
+			if receipt != nil {
+				return receipt, nil
+			}
 		}
 
 		if count > numRetries {
-			return nil, fmt.Errorf("timeout while waiting for transaction %s to be processed", hash)
+			return nil, fmt.Errorf("timeout while waiting for transaction %s to be processed", formatHashes(hashes))
+		}
+
+		if resubmit && time.Since(lastSubmission) >= t.resubmitPolicy.Interval {
+			lastSubmission = time.Now()
+
+			newHash, err := t.resubmitTransaction(txn, key, feeCap)
+			if err != nil {
+				// keep waiting for already sent transactions, one of them might still get mined
+				t.writeResubmitError(hashes[len(hashes)-1], err)
+
+				resubmit = !errors.Is(err, errFeeCapReached)
+			} else {
+				hashes = append(hashes, newHash)
+			}
 		}
 
 		time.Sleep(t.receiptTimeout)
@@ -258,6 +419,26 @@ func (t *TxRelayerImpl) waitForReceipt(hash ethgo.Hash) (*ethgo.Receipt, error)
 	}
 }
 
+// writeResubmitError writes the error of the failed resubmission to the writer (if any)
+func (t *TxRelayerImpl) writeResubmitError(hash ethgo.Hash, err error) {
+	if t.writer == nil {
+		return
+	}
+
+	_, _ = t.writer.Write([]byte(
+		fmt.Sprintf("[TxRelayer.SendTransaction]\nFailed to resubmit transaction %s: %v\n", hash, err)))
+}
+
+// formatHashes returns comma separated transaction hashes
+func formatHashes(hashes []ethgo.Hash) string {
+	strs := make([]string, len(hashes))
+	for i, hash := range hashes {
+		strs[i] = hash.String()
+	}
+
+	return strings.Join(strs, ", ")
+}
+
 // ConvertTxnToCallMsg converts txn instance to call message
 func ConvertTxnToCallMsg(txn *ethgo.Transaction) *ethgo.CallMsg {
 	return &ethgo.CallMsg{
@@ -295,3 +476,9 @@ func WithWriter(writer io.Writer) TxRelayerOption {
 		t.writer = writer
 	}
 }
+
+func WithResubmitPolicy(policy *ResubmitPolicy) TxRelayerOption {
+	return func(t *TxRelayerImpl) {
+		t.resubmitPolicy = policy
+	}
+}
@@ -0,0 +1,248 @@
+package txrelayer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+// rootchainMock is a minimal JSON-RPC server which mines the transaction
+// only after it gets resubmitted given number of times
+type rootchainMock struct {
+	lock sync.Mutex
+
+	minedAfter int
+	// failedSends are the indexes of the send attempts which are rejected
+	failedSends map[int]bool
+	attempts    int
+	sent        []*ethgo.Transaction
+	hashes      []ethgo.Hash
+}
+
+func (m *rootchainMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var result interface{}
+
+	switch req.Method {
+	case "eth_getTransactionCount":
+		result = "0x5"
+	case "eth_chainId":
+		result = "0x64"
+	case "eth_sendRawTransaction":
+		m.attempts++
+		if m.failedSends[m.attempts-1] {
+			http.Error(w, "transaction rejected", http.StatusInternalServerError)
+
+			return
+		}
+
+		var raw string
+		_ = json.Unmarshal(req.Params[0], &raw)
+
+		data, _ := hex.DecodeString(strings.TrimPrefix(raw, "0x"))
+
+		txn := &ethgo.Transaction{}
+		if err := txn.UnmarshalRLP(data); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		hash, _ := txn.GetHash()
+
+		m.sent = append(m.sent, txn)
+		m.hashes = append(m.hashes, hash)
+		result = hash.String()
+	case "eth_getTransactionReceipt":
+		var hash ethgo.Hash
+		_ = json.Unmarshal(req.Params[0], &hash)
+
+		if len(m.hashes) > m.minedAfter && hash == m.hashes[m.minedAfter] {
+			result = map[string]interface{}{
+				"from":              ethgo.ZeroAddress.String(),
+				"transactionHash":   hash.String(),
+				"blockHash":         ethgo.ZeroHash.String(),
+				"transactionIndex":  "0x0",
+				"blockNumber":       "0x1",
+				"gasUsed":           "0x5208",
+				"cumulativeGasUsed": "0x5208",
+				"logsBloom":         "0x" + strings.Repeat("00", 256),
+				"status":            "0x1",
+				"logs":              []interface{}{},
+			}
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unexpected method %s", req.Method), http.StatusBadRequest)
+
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+		"result":  result,
+	})
+}
+
+func TestTxRelayer_SendTransaction_Resubmit(t *testing.T) {
+	t.Parallel()
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	to := ethgo.Address{0x1}
+
+	cases := []struct {
+		name   string
+		txn    *ethgo.Transaction
+		policy *ResubmitPolicy
+		// indexes of the rejected send attempts
+		failedSends map[int]bool
+		// expected fees of the sent transactions
+		fees []uint64
+	}{
+		{
+			name:   "legacy",
+			txn:    &ethgo.Transaction{To: &to, Gas: 21000, GasPrice: 100},
+			policy: &ResubmitPolicy{Interval: time.Millisecond, FeeBumpPercentage: 10},
+			fees:   []uint64{100, 110, 121},
+		},
+		{
+			name: "dynamic fee",
+			txn: &ethgo.Transaction{
+				Type:                 ethgo.TransactionDynamicFee,
+				To:                   &to,
+				Gas:                  21000,
+				MaxFeePerGas:         big.NewInt(100),
+				MaxPriorityFeePerGas: big.NewInt(10),
+			},
+			policy: &ResubmitPolicy{Interval: time.Millisecond, FeeCap: big.NewInt(115)},
+			fees:   []uint64{100, 115},
+		},
+		{
+			name:        "failed resubmission does not keep bumped fees",
+			txn:         &ethgo.Transaction{To: &to, Gas: 21000, GasPrice: 100},
+			policy:      &ResubmitPolicy{Interval: time.Millisecond, FeeBumpPercentage: 10},
+			failedSends: map[int]bool{1: true, 2: true},
+			fees:        []uint64{100, 110},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			// transaction gets mined only after it reaches the last expected fee
+			rootchain := &rootchainMock{minedAfter: len(c.fees) - 1, failedSends: c.failedSends}
+			server := httptest.NewServer(rootchain)
+
+			defer server.Close()
+
+			relayer, err := NewTxRelayer(
+				WithIPAddress(server.URL),
+				WithReceiptTimeout(time.Millisecond),
+				WithResubmitPolicy(c.policy))
+			require.NoError(t, err)
+
+			receipt, err := relayer.SendTransaction(c.txn, key)
+			require.NoError(t, err)
+
+			rootchain.lock.Lock()
+			defer rootchain.lock.Unlock()
+
+			require.Len(t, rootchain.sent, len(c.fees))
+			require.Equal(t, rootchain.hashes[len(c.fees)-1], receipt.TransactionHash)
+
+			for i, txn := range rootchain.sent {
+				require.Equal(t, uint64(5), txn.Nonce)
+
+				if txn.Type == ethgo.TransactionDynamicFee {
+					require.Equal(t, c.fees[i], txn.MaxFeePerGas.Uint64())
+					require.LessOrEqual(t, txn.MaxPriorityFeePerGas.Cmp(txn.MaxFeePerGas), 0)
+				} else {
+					require.Equal(t, c.fees[i], txn.GasPrice)
+				}
+			}
+		})
+	}
+}
+
+func TestResubmitPolicy_BumpFees(t *testing.T) {
+	t.Parallel()
+
+	policy := &ResubmitPolicy{FeeCap: big.NewInt(130)}
+
+	txn := &ethgo.Transaction{GasPrice: 100}
+	feeCap := policy.feeCap(txn)
+
+	bumped, ok := policy.bumpFees(txn, feeCap)
+	require.True(t, ok)
+	require.Equal(t, uint64(120), bumped.GasPrice)
+
+	// given transaction is not modified
+	require.Equal(t, uint64(100), txn.GasPrice)
+
+	// bumped gas price is capped
+	bumped, ok = policy.bumpFees(bumped, feeCap)
+	require.True(t, ok)
+	require.Equal(t, uint64(130), bumped.GasPrice)
+
+	// fee cap is reached
+	_, ok = policy.bumpFees(bumped, feeCap)
+	require.False(t, ok)
+
+	// fees are always increased by at least one
+	policy = &ResubmitPolicy{FeeBumpPercentage: 1}
+	txn = &ethgo.Transaction{
+		Type:                 ethgo.TransactionDynamicFee,
+		MaxFeePerGas:         big.NewInt(10),
+		MaxPriorityFeePerGas: big.NewInt(10),
+	}
+
+	bumped, ok = policy.bumpFees(txn, policy.feeCap(txn))
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(11), bumped.MaxFeePerGas)
+	require.Equal(t, big.NewInt(11), bumped.MaxPriorityFeePerGas)
+	require.Equal(t, big.NewInt(10), txn.MaxFeePerGas)
+
+	// fees are capped by the multiple of the initial fees if the fee cap is not set
+	policy = &ResubmitPolicy{FeeBumpPercentage: 1000}
+	txn = &ethgo.Transaction{GasPrice: 100}
+	feeCap = policy.feeCap(txn)
+
+	require.Equal(t, big.NewInt(100*DefaultFeeCapMultiplier), feeCap)
+
+	bumped, ok = policy.bumpFees(txn, feeCap)
+	require.True(t, ok)
+	require.Equal(t, uint64(100*DefaultFeeCapMultiplier), bumped.GasPrice)
+
+	_, ok = policy.bumpFees(bumped, feeCap)
+	require.False(t, ok)
+}
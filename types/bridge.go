@@ -0,0 +1,288 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+// BridgeTransferStatus is the stage which a bridge transfer (deposit or exit) has reached
+type BridgeTransferStatus string
+
+const (
+	// BridgeTransferUnknown means that the transfer is not (yet) observed by the node
+	BridgeTransferUnknown BridgeTransferStatus = "unknown"
+	// BridgeTransferPending means that the transfer is observed,
+	// but it is neither committed (deposit) nor checkpointed (exit) yet
+	BridgeTransferPending BridgeTransferStatus = "pending"
+	// BridgeTransferCommitted means that the deposit is included in a commitment submitted to the child chain
+	BridgeTransferCommitted BridgeTransferStatus = "committed"
+	// BridgeTransferExecuted means that the deposit is executed on the child chain
+	BridgeTransferExecuted BridgeTransferStatus = "executed"
+	// BridgeTransferCheckpointed means that the exit is included in a checkpoint submitted to the root chain,
+	// so it is ready to be exited
+	BridgeTransferCheckpointed BridgeTransferStatus = "checkpointed"
+	// BridgeTransferExited means that the exit is processed by the ExitHelper contract on the root chain
+	BridgeTransferExited BridgeTransferStatus = "exited"
+)
+
+// DepositStatus describes the progress of a deposit (state sync) from the root chain to the child chain
+type DepositStatus struct {
+	StateSyncID uint64               `json:"stateSyncID"`
+	Status      BridgeTransferStatus `json:"status"`
+	Sender      *Address             `json:"sender,omitempty"`
+	Receiver    *Address             `json:"receiver,omitempty"`
+	// CommitmentStartID and CommitmentEndID are the state sync id range
+	// of the commitment which includes the deposit (set once the deposit is committed)
+	CommitmentStartID *uint64 `json:"commitmentStartID,omitempty"`
+	CommitmentEndID   *uint64 `json:"commitmentEndID,omitempty"`
+}
+
+// ExitStatus describes the progress of an exit from the child chain to the root chain
+type ExitStatus struct {
+	ExitID   uint64               `json:"exitID"`
+	Status   BridgeTransferStatus `json:"status"`
+	Sender   *Address             `json:"sender,omitempty"`
+	Receiver *Address             `json:"receiver,omitempty"`
+	// EpochNumber and BlockNumber are the child chain epoch and block whose exit tree includes the exit
+	EpochNumber uint64 `json:"epochNumber,omitempty"`
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	// CheckpointBlock is the latest child chain block checkpointed on the root chain
+	CheckpointBlock uint64 `json:"checkpointBlock,omitempty"`
+}
+
+// CheckpointSubmission describes the checkpoint which the node successfully submitted to the root chain
+type CheckpointSubmission struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	EpochNumber uint64 `json:"epochNumber"`
+	TxHash      Hash   `json:"txHash"`
+	// Timestamp is the unix time (in seconds) when the checkpoint submission got mined
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// CheckpointStatus describes how far the checkpoints on the root chain lag behind the child chain
+type CheckpointStatus struct {
+	// ChildChainHead is the latest child chain block
+	ChildChainHead uint64 `json:"childChainHead"`
+	// LastCheckpointedBlock is the latest child chain block checkpointed on the root chain
+	LastCheckpointedBlock uint64 `json:"lastCheckpointedBlock"`
+	// Lag is the number of child chain blocks which are not checkpointed yet
+	Lag uint64 `json:"lag"`
+	// CatchingUp indicates whether the node is submitting the missed (pending) checkpoints
+	CatchingUp bool `json:"catchingUp"`
+	// PendingCheckpoints is the number of checkpoints which the node still has to submit
+	PendingCheckpoints uint64 `json:"pendingCheckpoints"`
+	// LastSubmission is the last checkpoint submitted by the node (if any)
+	LastSubmission *CheckpointSubmission `json:"lastSubmission,omitempty"`
+}
+
+// hexUint64 is the JSON representation of the uint64 fields of the bridge types, which are hex encoded
+// the same way as the numbers of the other JSON-RPC responses. Plain JSON numbers and decimal strings
+// are decoded as well, so the values encoded by the previous versions are still readable
+type hexUint64 uint64
+
+func hexUint64Ptr(n *uint64) *hexUint64 {
+	if n == nil {
+		return nil
+	}
+
+	v := hexUint64(*n)
+
+	return &v
+}
+
+func (u *hexUint64) uint64Ptr() *uint64 {
+	if u == nil {
+		return nil
+	}
+
+	v := uint64(*u)
+
+	return &v
+}
+
+func (u hexUint64) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeUint64(uint64(u))), nil
+}
+
+func (u *hexUint64) UnmarshalJSON(input []byte) error {
+	str := strings.Trim(string(input), "\"")
+
+	var (
+		num uint64
+		err error
+	)
+
+	if strings.HasPrefix(str, "0x") {
+		num, err = hex.DecodeUint64(str)
+	} else {
+		num, err = strconv.ParseUint(str, 10, 64)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*u = hexUint64(num)
+
+	return nil
+}
+
+type depositStatusJSON struct {
+	StateSyncID       hexUint64            `json:"stateSyncID"`
+	Status            BridgeTransferStatus `json:"status"`
+	Sender            *Address             `json:"sender,omitempty"`
+	Receiver          *Address             `json:"receiver,omitempty"`
+	CommitmentStartID *hexUint64           `json:"commitmentStartID,omitempty"`
+	CommitmentEndID   *hexUint64           `json:"commitmentEndID,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (d *DepositStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&depositStatusJSON{
+		StateSyncID:       hexUint64(d.StateSyncID),
+		Status:            d.Status,
+		Sender:            d.Sender,
+		Receiver:          d.Receiver,
+		CommitmentStartID: hexUint64Ptr(d.CommitmentStartID),
+		CommitmentEndID:   hexUint64Ptr(d.CommitmentEndID),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (d *DepositStatus) UnmarshalJSON(input []byte) error {
+	var raw depositStatusJSON
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return err
+	}
+
+	*d = DepositStatus{
+		StateSyncID:       uint64(raw.StateSyncID),
+		Status:            raw.Status,
+		Sender:            raw.Sender,
+		Receiver:          raw.Receiver,
+		CommitmentStartID: raw.CommitmentStartID.uint64Ptr(),
+		CommitmentEndID:   raw.CommitmentEndID.uint64Ptr(),
+	}
+
+	return nil
+}
+
+type exitStatusJSON struct {
+	ExitID          hexUint64            `json:"exitID"`
+	Status          BridgeTransferStatus `json:"status"`
+	Sender          *Address             `json:"sender,omitempty"`
+	Receiver        *Address             `json:"receiver,omitempty"`
+	EpochNumber     hexUint64            `json:"epochNumber,omitempty"`
+	BlockNumber     hexUint64            `json:"blockNumber,omitempty"`
+	CheckpointBlock hexUint64            `json:"checkpointBlock,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (e *ExitStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&exitStatusJSON{
+		ExitID:          hexUint64(e.ExitID),
+		Status:          e.Status,
+		Sender:          e.Sender,
+		Receiver:        e.Receiver,
+		EpochNumber:     hexUint64(e.EpochNumber),
+		BlockNumber:     hexUint64(e.BlockNumber),
+		CheckpointBlock: hexUint64(e.CheckpointBlock),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (e *ExitStatus) UnmarshalJSON(input []byte) error {
+	var raw exitStatusJSON
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return err
+	}
+
+	*e = ExitStatus{
+		ExitID:          uint64(raw.ExitID),
+		Status:          raw.Status,
+		Sender:          raw.Sender,
+		Receiver:        raw.Receiver,
+		EpochNumber:     uint64(raw.EpochNumber),
+		BlockNumber:     uint64(raw.BlockNumber),
+		CheckpointBlock: uint64(raw.CheckpointBlock),
+	}
+
+	return nil
+}
+
+type checkpointSubmissionJSON struct {
+	BlockNumber hexUint64 `json:"blockNumber"`
+	EpochNumber hexUint64 `json:"epochNumber"`
+	TxHash      Hash      `json:"txHash"`
+	Timestamp   hexUint64 `json:"timestamp"`
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (c *CheckpointSubmission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&checkpointSubmissionJSON{
+		BlockNumber: hexUint64(c.BlockNumber),
+		EpochNumber: hexUint64(c.EpochNumber),
+		TxHash:      c.TxHash,
+		Timestamp:   hexUint64(c.Timestamp),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (c *CheckpointSubmission) UnmarshalJSON(input []byte) error {
+	var raw checkpointSubmissionJSON
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return err
+	}
+
+	*c = CheckpointSubmission{
+		BlockNumber: uint64(raw.BlockNumber),
+		EpochNumber: uint64(raw.EpochNumber),
+		TxHash:      raw.TxHash,
+		Timestamp:   uint64(raw.Timestamp),
+	}
+
+	return nil
+}
+
+type checkpointStatusJSON struct {
+	ChildChainHead        hexUint64             `json:"childChainHead"`
+	LastCheckpointedBlock hexUint64             `json:"lastCheckpointedBlock"`
+	Lag                   hexUint64             `json:"lag"`
+	CatchingUp            bool                  `json:"catchingUp"`
+	PendingCheckpoints    hexUint64             `json:"pendingCheckpoints"`
+	LastSubmission        *CheckpointSubmission `json:"lastSubmission,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (c *CheckpointStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&checkpointStatusJSON{
+		ChildChainHead:        hexUint64(c.ChildChainHead),
+		LastCheckpointedBlock: hexUint64(c.LastCheckpointedBlock),
+		Lag:                   hexUint64(c.Lag),
+		CatchingUp:            c.CatchingUp,
+		PendingCheckpoints:    hexUint64(c.PendingCheckpoints),
+		LastSubmission:        c.LastSubmission,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (c *CheckpointStatus) UnmarshalJSON(input []byte) error {
+	var raw checkpointStatusJSON
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return err
+	}
+
+	*c = CheckpointStatus{
+		ChildChainHead:        uint64(raw.ChildChainHead),
+		LastCheckpointedBlock: uint64(raw.LastCheckpointedBlock),
+		Lag:                   uint64(raw.Lag),
+		CatchingUp:            raw.CatchingUp,
+		PendingCheckpoints:    uint64(raw.PendingCheckpoints),
+		LastSubmission:        raw.LastSubmission,
+	}
+
+	return nil
+}
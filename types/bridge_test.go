@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgeStatus_JSON(t *testing.T) {
+	t.Parallel()
+
+	startID, endID := uint64(10), uint64(20)
+	sender, receiver := StringToAddress("0x1"), StringToAddress("0x2")
+
+	deposit := &DepositStatus{
+		StateSyncID:       15,
+		Status:            BridgeTransferCommitted,
+		Sender:            &sender,
+		Receiver:          &receiver,
+		CommitmentStartID: &startID,
+		CommitmentEndID:   &endID,
+	}
+
+	raw, err := json.Marshal(deposit)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"stateSyncID":"0xf"`)
+	require.Contains(t, string(raw), `"commitmentStartID":"0xa","commitmentEndID":"0x14"`)
+
+	var decodedDeposit *DepositStatus
+
+	require.NoError(t, json.Unmarshal(raw, &decodedDeposit))
+	require.Equal(t, deposit, decodedDeposit)
+
+	exit := &ExitStatus{
+		ExitID:          255,
+		Status:          BridgeTransferCheckpointed,
+		EpochNumber:     2,
+		BlockNumber:     16,
+		CheckpointBlock: 20,
+	}
+
+	raw, err = json.Marshal([]*ExitStatus{exit})
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"exitID":"0xff"`)
+	require.Contains(t, string(raw), `"epochNumber":"0x2","blockNumber":"0x10","checkpointBlock":"0x14"`)
+
+	var decodedExits []*ExitStatus
+
+	require.NoError(t, json.Unmarshal(raw, &decodedExits))
+	require.Equal(t, []*ExitStatus{exit}, decodedExits)
+
+	checkpoint := &CheckpointStatus{
+		ChildChainHead:        100,
+		LastCheckpointedBlock: 90,
+		Lag:                   10,
+		CatchingUp:            true,
+		PendingCheckpoints:    1,
+		LastSubmission:        &CheckpointSubmission{BlockNumber: 90, EpochNumber: 9, Timestamp: 1000},
+	}
+
+	raw, err = json.Marshal(checkpoint)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"childChainHead":"0x64","lastCheckpointedBlock":"0x5a","lag":"0xa"`)
+	require.Contains(t, string(raw), `"timestamp":"0x3e8"`)
+
+	var decodedCheckpoint *CheckpointStatus
+
+	require.NoError(t, json.Unmarshal(raw, &decodedCheckpoint))
+	require.Equal(t, checkpoint, decodedCheckpoint)
+
+	// checkpoint submissions stored with the plain JSON numbers are still decoded
+	var submission *CheckpointSubmission
+
+	require.NoError(t, json.Unmarshal([]byte(`{"blockNumber":90,"epochNumber":9,"timestamp":1000}`), &submission))
+	require.Equal(t, checkpoint.LastSubmission, submission)
+}
@@ -0,0 +1,12 @@
+package types
+
+// EpochProof contains the epoch ending block header, which a light client needs
+// in order to move its trusted validator set from the given epoch to the next one
+type EpochProof struct {
+	Epoch uint64 `json:"epoch"`
+	// EpochEndingBlock is the number of the last block of the epoch
+	EpochEndingBlock uint64 `json:"epochEndingBlock"`
+	// Header is the hex encoded RLP of the epoch ending block header, whose extra data carries
+	// the next epoch validator set delta and the committed seals of the epoch validators
+	Header string `json:"header"`
+}
@@ -175,6 +175,49 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	}
 }
 
+func TestRLPMarshall_And_Unmarshall_SponsoredTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	originalTx := &Transaction{
+		Type:      SponsoredTx,
+		ChainID:   big.NewInt(100),
+		Nonce:     1,
+		GasFeeCap: big.NewInt(12),
+		GasTipCap: big.NewInt(13),
+		Gas:       11,
+		To:        &addrTo,
+		Value:     big.NewInt(1),
+		Input:     []byte{1, 2},
+		V:         big.NewInt(1),
+		R:         big.NewInt(27),
+		S:         big.NewInt(26),
+		Sponsor:   StringToAddress("33"),
+		SponsorV:  big.NewInt(0),
+		SponsorR:  big.NewInt(28),
+		SponsorS:  big.NewInt(29),
+	}
+	originalTx.ComputeHash(1)
+
+	txRLP := originalTx.MarshalRLP()
+	require.Equal(t, byte(SponsoredTx), txRLP[0])
+
+	unmarshalledTx := new(Transaction)
+	require.NoError(t, unmarshalledTx.UnmarshalRLP(txRLP))
+
+	unmarshalledTx.ComputeHash(1)
+	assert.Equal(t, originalTx.Hash, unmarshalledTx.Hash)
+	assert.Equal(t, originalTx.Sponsor, unmarshalledTx.Sponsor)
+	assert.Equal(t, originalTx.SponsorR, unmarshalledTx.SponsorR)
+	assert.Equal(t, originalTx.SponsorS, unmarshalledTx.SponsorS)
+	assert.Equal(t, originalTx.GasFeeCap, unmarshalledTx.GetGasFeeCap())
+	assert.Equal(t, originalTx.GasTipCap, unmarshalledTx.GetGasTipCap())
+
+	// the sponsor is kept in the store format as well
+	storedTx := new(Transaction)
+	require.NoError(t, storedTx.UnmarshalStoreRLP(originalTx.MarshalStoreRLPTo(nil)))
+	assert.Equal(t, SponsoredTx, storedTx.Type)
+	assert.Equal(t, originalTx.Sponsor, storedTx.Sponsor)
+}
+
 func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	t.Parallel()
 
@@ -274,6 +317,10 @@ func TestRLPMarshall_And_Unmarshall_TxType(t *testing.T) {
 			name:   "DynamicFeeTx",
 			txType: DynamicFeeTx,
 		},
+		{
+			name:   "SponsoredTx",
+			txType: SponsoredTx,
+		},
 		{
 			name:        "undefined type",
 			txType:      TxType(0x09),
@@ -193,13 +193,13 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv := arena.NewArray()
 
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
+	if t.IsDynamicFee() {
 		vv.Set(arena.NewBigInt(t.ChainID))
 	}
 
 	vv.Set(arena.NewUint(t.Nonce))
 
-	if t.Type == DynamicFeeTx {
+	if t.IsDynamicFee() {
 		// Add EIP-1559 related fields.
 		// For non-dynamic-fee-tx gas price is used.
 		vv.Set(arena.NewBigInt(t.GasTipCap))
@@ -224,10 +224,19 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	// This is needed to have the same format as other EVM chains do.
 	// There is no access list feature here, so it is always empty just to be compatible.
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
+	if t.IsDynamicFee() {
 		vv.Set(arena.NewArray())
 	}
 
+	// the sponsored transaction carries the sponsor and its signature
+	// before the signature values of the sender
+	if t.Type == SponsoredTx {
+		vv.Set(arena.NewCopyBytes(t.Sponsor.Bytes()))
+		vv.Set(arena.NewBigInt(t.SponsorV))
+		vv.Set(arena.NewBigInt(t.SponsorR))
+		vv.Set(arena.NewBigInt(t.SponsorS))
+	}
+
 	// signature values
 	vv.Set(arena.NewBigInt(t.V))
 	vv.Set(arena.NewBigInt(t.R))
@@ -409,6 +409,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		num = 10
 	case DynamicFeeTx:
 		num = 12
+	case SponsoredTx:
+		num = 16
 	default:
 		return fmt.Errorf("transaction type %d not found", t.Type)
 	}
@@ -418,7 +420,7 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	}
 
 	// Load Chain ID for dynamic transactions
-	if t.Type == DynamicFeeTx {
+	if t.IsDynamicFee() {
 		t.ChainID = new(big.Int)
 		if err = getElem().GetBigInt(t.ChainID); err != nil {
 			return err
@@ -430,7 +432,7 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	if t.Type == DynamicFeeTx {
+	if t.IsDynamicFee() {
 		// gasTipCap
 		t.GasTipCap = new(big.Int)
 		if err = getElem().GetBigInt(t.GasTipCap); err != nil {
@@ -479,10 +481,33 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	// Skipping Access List field since we don't support it.
 	// This is needed to be compatible with other EVM chains and have the same format.
 	// Since we don't have access list, just skip it here.
-	if t.Type == DynamicFeeTx {
+	if t.IsDynamicFee() {
 		_ = getElem()
 	}
 
+	if t.Type == SponsoredTx {
+		// sponsor
+		if err = getElem().GetAddr(t.Sponsor[:]); err != nil {
+			return err
+		}
+
+		// sponsor signature values
+		t.SponsorV = new(big.Int)
+		if err = getElem().GetBigInt(t.SponsorV); err != nil {
+			return err
+		}
+
+		t.SponsorR = new(big.Int)
+		if err = getElem().GetBigInt(t.SponsorR); err != nil {
+			return err
+		}
+
+		t.SponsorS = new(big.Int)
+		if err = getElem().GetBigInt(t.SponsorS); err != nil {
+			return err
+		}
+	}
+
 	// V
 	t.V = new(big.Int)
 	if err = getElem().GetBigInt(t.V); err != nil {
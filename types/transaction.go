@@ -21,13 +21,14 @@ const (
 	LegacyTx     TxType = 0x0
 	StateTx      TxType = 0x7f
 	DynamicFeeTx TxType = 0x02
+	SponsoredTx  TxType = 0x7d
 )
 
 func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx, DynamicFeeTx:
+	case LegacyTx, StateTx, DynamicFeeTx, SponsoredTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -43,6 +44,8 @@ func (t TxType) String() (s string) {
 		return "StateTx"
 	case DynamicFeeTx:
 		return "DynamicFeeTx"
+	case SponsoredTx:
+		return "SponsoredTx"
 	}
 
 	return
@@ -65,6 +68,11 @@ type Transaction struct {
 
 	ChainID *big.Int
 
+	// Sponsor is the account paying the gas of the sponsored transaction,
+	// along with its signature of the transaction
+	Sponsor                      Address
+	SponsorV, SponsorR, SponsorS *big.Int
+
 	// Cache
 	size atomic.Pointer[uint64]
 }
@@ -117,6 +125,18 @@ func (t *Transaction) Copy() *Transaction {
 		tt.S = new(big.Int).Set(t.S)
 	}
 
+	if t.SponsorV != nil {
+		tt.SponsorV = new(big.Int).Set(t.SponsorV)
+	}
+
+	if t.SponsorR != nil {
+		tt.SponsorR = new(big.Int).Set(t.SponsorR)
+	}
+
+	if t.SponsorS != nil {
+		tt.SponsorS = new(big.Int).Set(t.SponsorS)
+	}
+
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
@@ -125,6 +145,11 @@ func (t *Transaction) Copy() *Transaction {
 
 // Cost returns gas * gasPrice + value
 func (t *Transaction) Cost() *big.Int {
+	return new(big.Int).Add(t.GasCost(), t.Value)
+}
+
+// GasCost returns gas * gasPrice, which is the most the transaction payer is charged for the gas
+func (t *Transaction) GasCost() *big.Int {
 	var factor *big.Int
 
 	if t.GasFeeCap != nil && t.GasFeeCap.BitLen() > 0 {
@@ -133,10 +158,22 @@ func (t *Transaction) Cost() *big.Int {
 		factor = new(big.Int).Set(t.GasPrice)
 	}
 
-	total := new(big.Int).Mul(factor, new(big.Int).SetUint64(t.Gas))
-	total = total.Add(total, t.Value)
+	return factor.Mul(factor, new(big.Int).SetUint64(t.Gas))
+}
+
+// IsDynamicFee checks if the transaction sets the EIP-1559 fee fields instead of the gas price
+func (t *Transaction) IsDynamicFee() bool {
+	return t.Type == DynamicFeeTx || t.Type == SponsoredTx
+}
+
+// Payer returns the account paying the gas of the transaction,
+// which is the sponsor of the sponsored transaction and the sender otherwise
+func (t *Transaction) Payer() Address {
+	if t.Type == SponsoredTx {
+		return t.Sponsor
+	}
 
-	return total
+	return t.From
 }
 
 // GetGasPrice returns gas price if not empty, or calculates one based on
@@ -204,7 +241,7 @@ func (t *Transaction) EffectiveGasTip(baseFee *big.Int) *big.Int {
 // Spec: https://eips.ethereum.org/EIPS/eip-1559#specification
 func (t *Transaction) GetGasTipCap() *big.Int {
 	switch t.Type {
-	case DynamicFeeTx:
+	case DynamicFeeTx, SponsoredTx:
 		return t.GasTipCap
 	default:
 		return t.GasPrice
@@ -215,7 +252,7 @@ func (t *Transaction) GetGasTipCap() *big.Int {
 // Spec: https://eips.ethereum.org/EIPS/eip-1559#specification
 func (t *Transaction) GetGasFeeCap() *big.Int {
 	switch t.Type {
-	case DynamicFeeTx:
+	case DynamicFeeTx, SponsoredTx:
 		return t.GasFeeCap
 	default:
 		return t.GasPrice
@@ -0,0 +1,22 @@
+package types
+
+// ValidatorUptime represents the liveness of the validator during an epoch
+type ValidatorUptime struct {
+	Address Address `json:"address"`
+	// SignedBlocks is the number of epoch blocks committed (sealed) by the validator
+	SignedBlocks uint64 `json:"signedBlocks"`
+	// MissedBlocks is the number of epoch blocks the validator was expected to, but did not seal
+	MissedBlocks uint64 `json:"missedBlocks"`
+	// Uptime is the percentage of the expected epoch blocks sealed by the validator
+	Uptime float64 `json:"uptime"`
+}
+
+// EpochUptime represents the liveness of the validators during an epoch
+type EpochUptime struct {
+	Epoch uint64 `json:"epoch"`
+	// TotalBlocks is the number of epoch blocks whose committed seals are accounted
+	TotalBlocks uint64 `json:"totalBlocks"`
+	// LastBlock is the last accounted epoch block
+	LastBlock  uint64             `json:"lastBlock"`
+	Validators []*ValidatorUptime `json:"validators"`
+}